@@ -60,6 +60,31 @@ func main() {
 		os.Exit(1)
 	}
 
+	if validationErr := cfg.Stop.Validate(); validationErr != nil {
+		fmt.Fprintln(os.Stderr, validationErr)
+		os.Exit(1)
+	}
+
+	if validationErr := cfg.Logs.Retention.Validate(); validationErr != nil {
+		fmt.Fprintln(os.Stderr, validationErr)
+		os.Exit(1)
+	}
+
+	if validationErr := cfg.Stats.Validate(); validationErr != nil {
+		fmt.Fprintln(os.Stderr, validationErr)
+		os.Exit(1)
+	}
+
+	if validationErr := cfg.Alerts.Validate(); validationErr != nil {
+		fmt.Fprintln(os.Stderr, validationErr)
+		os.Exit(1)
+	}
+
+	if validationErr := cfg.Tables.Validate(); validationErr != nil {
+		fmt.Fprintln(os.Stderr, validationErr)
+		os.Exit(1)
+	}
+
 	if *debug {
 		cfg.Debug.Enabled = true
 	}
@@ -13,7 +13,11 @@ type Client interface {
 	Volumes() VolumeService
 	Networks() NetworkService
 	Compose() ComposeProjectService
+	BuildCache() BuildCacheService
 	Info(ctx context.Context) (SystemInfo, error)
+	// DiskUsage breaks down the disk space used by images, containers, volumes
+	// and the build cache.
+	DiskUsage(ctx context.Context) (DiskUsageReport, error)
 	Ping(ctx context.Context) error
 	Close() error
 }
@@ -41,6 +45,7 @@ type DiskUsage struct {
 	ImagesSize     int64
 	VolumesSize    int64
 	ContainersSize int64
+	BuildCacheSize int64
 }
 
 // ComposeUpOptions configures how a Compose project is brought up.
@@ -71,6 +76,15 @@ type ComposeRestartOptions struct {
 	NoDeps  bool
 }
 
+// ContainerStopOptions configures how a container is stopped or restarted.
+type ContainerStopOptions struct {
+	// Timeout is the grace period before the container is killed. Nil uses the
+	// container's default.
+	Timeout *time.Duration
+	// Signal is sent to stop the container. Empty uses the container's default.
+	Signal string
+}
+
 // ComposeProjectService manages Docker Compose projects detected from running containers.
 type ComposeProjectService interface {
 	List(ctx context.Context) ([]ComposeProject, error)
@@ -94,18 +108,32 @@ type ContainerService interface {
 	Run(ctx context.Context, image Image, opts RunOptions) (string, error)
 	Get(ctx context.Context, id string) (Container, error)
 	Start(ctx context.Context, id string) error
-	Stop(ctx context.Context, id string) error
-	Restart(ctx context.Context, id string) error
+	Stop(ctx context.Context, id string, opts ContainerStopOptions) error
+	Restart(ctx context.Context, id string, opts ContainerStopOptions) error
 	Remove(ctx context.Context, id string, force bool) error
 	Kill(ctx context.Context, id string, signal string) error
 	FileTree(ctx context.Context, id string) (*FileNode, error)
 	Logs(ctx context.Context, id string, opts LogOptions) (*LogsSession, error)
 	Exec(ctx context.Context, id string) (*ExecSession, error)
 	Stats(ctx context.Context, is string) (*StatsSession, error)
+	// StatsOnce takes a single stats sample of a running container without
+	// waiting for the daemon to sample the CPU usage twice.
+	StatsOnce(ctx context.Context, id string) (ContainerStats, error)
 	Prune(ctx context.Context, opts PruneOptions) (PruneReport, error)
+	// PrunePreview lists what Prune would remove with opts, without removing
+	// anything.
+	PrunePreview(ctx context.Context, opts PruneOptions) ([]PruneCandidate, error)
 	Pause(ctx context.Context, id string) error
 	Unpause(ctx context.Context, id string) error
 	CopyFromContainer(ctx context.Context, containerID, srcPath string) (io.ReadCloser, error)
+	// Top lists the processes running inside the container.
+	Top(ctx context.Context, id string) ([]Process, error)
+	// SignalProcess sends signal to a single process inside the container by
+	// running kill through exec. pid is the PID reported by Top.
+	SignalProcess(ctx context.Context, id string, pid int, signal string) error
+	// Events streams the container events of the daemon until ctx is done.
+	// The error channel receives the error that ended the stream, if any.
+	Events(ctx context.Context) (<-chan ContainerEvent, <-chan error)
 }
 
 // ImageService manages Docker images.
@@ -115,6 +143,7 @@ type ImageService interface {
 	FetchLayers(ctx context.Context, id string) []Layer
 	Remove(ctx context.Context, id string, force bool) error
 	Prune(ctx context.Context, opts PruneOptions) (PruneReport, error)
+	PrunePreview(ctx context.Context, opts PruneOptions) ([]PruneCandidate, error)
 	CheckUpdate(ctx context.Context, image Image) (bool, error)
 }
 
@@ -123,6 +152,14 @@ type VolumeService interface {
 	List(ctx context.Context) ([]Volume, error)
 	Remove(ctx context.Context, name string, force bool) error
 	Prune(ctx context.Context, opts PruneOptions) (PruneReport, error)
+	PrunePreview(ctx context.Context, opts PruneOptions) ([]PruneCandidate, error)
+}
+
+// BuildCacheService manages the build cache of the daemon.
+type BuildCacheService interface {
+	Prune(ctx context.Context, opts BuildCachePruneOptions) (PruneReport, error)
+	// Remove removes a single build cache record that is not in use.
+	Remove(ctx context.Context, id string) error
 }
 
 // NetworkService manages Docker networks.
@@ -130,4 +167,5 @@ type NetworkService interface {
 	List(ctx context.Context) ([]Network, error)
 	Remove(ctx context.Context, id string) error
 	Prune(ctx context.Context, opts PruneOptions) (PruneReport, error)
+	PrunePreview(ctx context.Context, opts PruneOptions) ([]PruneCandidate, error)
 }
@@ -10,6 +10,8 @@ import (
 
 	"github.com/docker/cli/cli/connhelper"
 	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/image"
 	"github.com/docker/docker/client"
 
 	"github.com/GustavoCaso/docker-dash/internal/config"
@@ -27,6 +29,7 @@ type dockerClient struct {
 	volumes    *volumeService
 	networks   *networkService
 	compose    *composeProjectService
+	buildCache *buildCacheService
 }
 
 // NewDockerClientFromConfig creates a dockerClient using settings from cfg.
@@ -77,6 +80,7 @@ func NewDockerClientFromConfig(cfg config.DockerConfig) (Client, error) {
 	c.images = &imageService{cli: cli}
 	c.volumes = &volumeService{cli: cli}
 	c.networks = &networkService{cli: cli}
+	c.buildCache = &buildCacheService{cli: cli}
 	c.compose, err = newComposeProjectService(cfg, cli)
 	if err != nil {
 		_ = cli.Close()
@@ -95,6 +99,7 @@ func (c *dockerClient) Images() ImageService           { return c.images }
 func (c *dockerClient) Volumes() VolumeService         { return c.volumes }
 func (c *dockerClient) Networks() NetworkService       { return c.networks }
 func (c *dockerClient) Compose() ComposeProjectService { return c.compose }
+func (c *dockerClient) BuildCache() BuildCacheService  { return c.buildCache }
 func (c *dockerClient) Info(ctx context.Context) (SystemInfo, error) {
 	systemInfo, err := c.cli.Info(ctx)
 	if err != nil {
@@ -106,6 +111,7 @@ func (c *dockerClient) Info(ctx context.Context) (SystemInfo, error) {
 			types.ContainerObject,
 			types.ImageObject,
 			types.VolumeObject,
+			types.BuildCacheObject,
 		},
 	})
 	if err != nil {
@@ -116,6 +122,7 @@ func (c *dockerClient) Info(ctx context.Context) (SystemInfo, error) {
 		imagesSize     int64
 		volumesSize    int64
 		containersSize int64
+		buildCacheSize int64
 	)
 
 	for _, usage := range diskUsage.Images {
@@ -136,6 +143,12 @@ func (c *dockerClient) Info(ctx context.Context) (SystemInfo, error) {
 		}
 	}
 
+	for _, record := range diskUsage.BuildCache {
+		if record != nil && !record.Shared {
+			buildCacheSize += record.Size
+		}
+	}
+
 	return SystemInfo{
 		DockerVersion:    systemInfo.ServerVersion,
 		APIVersion:       c.cli.ClientVersion(),
@@ -153,11 +166,135 @@ func (c *dockerClient) Info(ctx context.Context) (SystemInfo, error) {
 			ImagesSize:     imagesSize,
 			VolumesSize:    volumesSize,
 			ContainersSize: containersSize,
+			BuildCacheSize: buildCacheSize,
 		},
 		Warnings: systemInfo.Warnings,
 	}, nil
 }
 
+func (c *dockerClient) DiskUsage(ctx context.Context) (DiskUsageReport, error) {
+	log.Printf("[docker] DiskUsage")
+	du, err := c.cli.DiskUsage(ctx, types.DiskUsageOptions{
+		Types: []types.DiskUsageObject{
+			types.ContainerObject,
+			types.ImageObject,
+			types.VolumeObject,
+			types.BuildCacheObject,
+		},
+	})
+	if err != nil {
+		return DiskUsageReport{}, err
+	}
+	report := diskUsageReport(du)
+	log.Printf("[docker] DiskUsage: returned count=%d", len(report.Entries))
+	return report, nil
+}
+
+// summaryImageName is the first tag of img, or its ID when untagged.
+func summaryImageName(img *image.Summary) string {
+	if summaryImageDangling(img) {
+		return img.ID
+	}
+	return img.RepoTags[0]
+}
+
+func summaryImageDangling(img *image.Summary) bool {
+	return len(img.RepoTags) == 0 || img.RepoTags[0] == "<none>:<none>"
+}
+
+// summaryImageReclaimable is the space removing img frees: the layers it does
+// not share with other images.
+func summaryImageReclaimable(img *image.Summary) int64 {
+	// SharedSize is -1 when the daemon did not compute it.
+	return img.Size - max(img.SharedSize, 0)
+}
+
+func summaryContainerName(c *container.Summary) string {
+	if len(c.Names) > 0 {
+		return strings.TrimPrefix(c.Names[0], "/")
+	}
+	return c.ID
+}
+
+// summaryContainerInUse reports whether a container prune would keep c.
+func summaryContainerInUse(c *container.Summary) bool {
+	return c.State == "running" || c.State == "paused" || c.State == "restarting"
+}
+
+// diskUsageReport lists the images, containers, volumes and build cache
+// records of du with the space each one uses and would free.
+func diskUsageReport(du types.DiskUsage) DiskUsageReport {
+	report := DiskUsageReport{LayersSize: du.LayersSize}
+	for _, img := range du.Images {
+		if img == nil {
+			continue
+		}
+		entry := DiskUsageEntry{
+			Kind:  DiskUsageImage,
+			ID:    img.ID,
+			Name:  summaryImageName(img),
+			Size:  img.Size,
+			InUse: img.Containers > 0,
+		}
+		if !entry.InUse {
+			entry.Reclaimable = summaryImageReclaimable(img)
+		}
+		report.Entries = append(report.Entries, entry)
+	}
+	for _, c := range du.Containers {
+		if c == nil {
+			continue
+		}
+		entry := DiskUsageEntry{
+			Kind:  DiskUsageContainer,
+			ID:    c.ID,
+			Name:  summaryContainerName(c),
+			Size:  c.SizeRw,
+			InUse: summaryContainerInUse(c),
+		}
+		if !entry.InUse {
+			entry.Reclaimable = c.SizeRw
+		}
+		report.Entries = append(report.Entries, entry)
+	}
+	for _, v := range du.Volumes {
+		if v == nil {
+			continue
+		}
+		entry := DiskUsageEntry{Kind: DiskUsageVolume, ID: v.Name, Name: v.Name}
+		if v.UsageData != nil {
+			// Size and RefCount are -1 when the daemon did not compute them.
+			entry.Size = max(v.UsageData.Size, 0)
+			entry.InUse = v.UsageData.RefCount > 0
+		}
+		if !entry.InUse {
+			entry.Reclaimable = entry.Size
+		}
+		report.Entries = append(report.Entries, entry)
+	}
+	for _, record := range du.BuildCache {
+		if record == nil {
+			continue
+		}
+		name := record.Description
+		if name == "" {
+			name = record.Type
+		}
+		entry := DiskUsageEntry{
+			Kind:  DiskUsageBuildCache,
+			ID:    record.ID,
+			Name:  name,
+			Size:  record.Size,
+			InUse: record.InUse,
+		}
+		if !entry.InUse {
+			entry.Reclaimable = record.Size
+		}
+		report.Entries = append(report.Entries, entry)
+	}
+	return report
+}
+
 func (c *dockerClient) Ping(ctx context.Context) error {
 	log.Printf("[docker] Ping")
 	_, err := c.cli.Ping(ctx)
@@ -0,0 +1,46 @@
+package client
+
+import (
+	"context"
+	"log"
+
+	"github.com/docker/docker/api/types/build"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/client"
+)
+
+// Local Build Cache Service.
+type buildCacheService struct {
+	cli *client.Client
+}
+
+func (s *buildCacheService) Prune(ctx context.Context, opts BuildCachePruneOptions) (PruneReport, error) {
+	log.Printf("[docker] BuildCachePrune: all=%t until=%q keepStorage=%d", opts.All, opts.Until, opts.KeepStorage)
+	args := filters.NewArgs()
+	if opts.Until != "" {
+		args.Add("until", opts.Until)
+	}
+	return s.prune(ctx, build.CachePruneOptions{
+		All:     opts.All,
+		Filters: args,
+		// Daemons before API 1.48 only know keep-storage, later ones call it
+		// reserved-space.
+		KeepStorage:   opts.KeepStorage,
+		ReservedSpace: opts.KeepStorage,
+	})
+}
+
+func (s *buildCacheService) Remove(ctx context.Context, id string) error {
+	log.Printf("[docker] BuildCachePrune: id=%q", id)
+	_, err := s.prune(ctx, build.CachePruneOptions{All: true, Filters: filters.NewArgs(filters.Arg("id", id))})
+	return err
+}
+
+func (s *buildCacheService) prune(ctx context.Context, opts build.CachePruneOptions) (PruneReport, error) {
+	r, err := s.cli.BuildCachePrune(ctx, opts)
+	if err != nil {
+		return PruneReport{}, err
+	}
+	log.Printf("[docker] BuildCachePrune: deleted=%d spaceReclaimed=%d", len(r.CachesDeleted), r.SpaceReclaimed)
+	return PruneReport{ItemsDeleted: len(r.CachesDeleted), SpaceReclaimed: r.SpaceReclaimed}, nil
+}
@@ -132,9 +132,10 @@ func (s *composeProjectService) List(ctx context.Context) ([]ComposeProject, err
 		}
 
 		proj.Services = append(proj.Services, ComposeServiceInfo{
-			Name:  serviceName,
-			State: c.State,
-			Image: c.Image,
+			Name:        serviceName,
+			State:       c.State,
+			Image:       c.Image,
+			ContainerID: c.ID,
 		})
 	}
 
@@ -3,9 +3,15 @@ package client
 import (
 	"archive/tar"
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log"
+	"math"
+	"os"
+	"path/filepath"
+	"slices"
 	"strconv"
 	"strings"
 	"sync"
@@ -13,6 +19,7 @@ import (
 
 	dockertypes "github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/events"
 	"github.com/docker/docker/api/types/filters"
 	"github.com/docker/docker/client"
 	"github.com/docker/docker/pkg/stdcopy"
@@ -51,6 +58,7 @@ func (s *containerService) List(ctx context.Context) ([]Container, error) {
 			if containerErr != nil {
 				return containerErr
 			}
+			container.Size = c.SizeRw
 
 			resultMap.Store(idx, container)
 
@@ -218,6 +226,7 @@ func (s *containerService) Get(ctx context.Context, id string) (Container, error
 	}
 
 	health := buildContainerHealth(c.State.Health)
+	health.Config = buildHealthcheckConfig(c.Config.Healthcheck)
 
 	restartPolicy := string(c.HostConfig.RestartPolicy.Name)
 	if c.HostConfig.RestartPolicy.MaximumRetryCount > 0 {
@@ -245,6 +254,13 @@ func (s *containerService) Get(ctx context.Context, id string) (Container, error
 		Env:        c.Config.Env,
 		Labels:     c.Config.Labels,
 		Health:     health,
+		// Lifecycle
+		ExitCode:     c.State.ExitCode,
+		OOMKilled:    c.State.OOMKilled,
+		RestartCount: c.RestartCount,
+		StartedAt:    parseStateTime(c.State.StartedAt),
+		FinishedAt:   parseStateTime(c.State.FinishedAt),
+		Error:        c.State.Error,
 		// Resource limits
 		MemoryLimit:   c.HostConfig.Memory,
 		CPUShares:     c.HostConfig.CPUShares,
@@ -253,6 +269,17 @@ func (s *containerService) Get(ctx context.Context, id string) (Container, error
 	}, nil
 }
 
+// parseStateTime parses a container state timestamp. The daemon reports
+// "0001-01-01T00:00:00Z" for events that never happened, which parses to the
+// zero time; malformed values are treated the same way.
+func parseStateTime(value string) time.Time {
+	t, err := time.Parse(time.RFC3339Nano, value)
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
+
 func (s *containerService) Start(ctx context.Context, id string) error {
 	log.Printf("[docker] ContainerStart: id=%q", id)
 	err := s.cli.ContainerStart(ctx, id, container.StartOptions{})
@@ -260,20 +287,48 @@ func (s *containerService) Start(ctx context.Context, id string) error {
 	return err
 }
 
-func (s *containerService) Stop(ctx context.Context, id string) error {
-	log.Printf("[docker] ContainerStop: id=%q", id)
-	err := s.cli.ContainerStop(ctx, id, container.StopOptions{})
+func (s *containerService) Stop(ctx context.Context, id string, opts ContainerStopOptions) error {
+	log.Printf(
+		"[docker] ContainerStop: id=%q timeout=%s signal=%q",
+		id,
+		formatStopTimeout(opts.Timeout),
+		opts.Signal,
+	)
+	err := s.cli.ContainerStop(ctx, id, buildStopOptions(opts))
 	log.Printf("[docker] ContainerStop: done err=%v", err)
 	return err
 }
 
-func (s *containerService) Restart(ctx context.Context, id string) error {
-	log.Printf("[docker] ContainerRestart: id=%q", id)
-	err := s.cli.ContainerRestart(ctx, id, container.StopOptions{})
+func (s *containerService) Restart(ctx context.Context, id string, opts ContainerStopOptions) error {
+	log.Printf(
+		"[docker] ContainerRestart: id=%q timeout=%s signal=%q",
+		id,
+		formatStopTimeout(opts.Timeout),
+		opts.Signal,
+	)
+	err := s.cli.ContainerRestart(ctx, id, buildStopOptions(opts))
 	log.Printf("[docker] ContainerRestart: done err=%v", err)
 	return err
 }
 
+// buildStopOptions converts opts to the daemon's stop options. The daemon
+// counts the timeout in whole seconds, so partial seconds are rounded up.
+func buildStopOptions(opts ContainerStopOptions) container.StopOptions {
+	stopOpts := container.StopOptions{Signal: opts.Signal}
+	if opts.Timeout != nil {
+		seconds := int(math.Ceil(opts.Timeout.Seconds()))
+		stopOpts.Timeout = &seconds
+	}
+	return stopOpts
+}
+
+func formatStopTimeout(timeout *time.Duration) string {
+	if timeout == nil {
+		return "default"
+	}
+	return timeout.String()
+}
+
 func (s *containerService) Remove(ctx context.Context, id string, force bool) error {
 	log.Printf("[docker] ContainerRemove: id=%q force=%v", id, force)
 	err := s.cli.ContainerRemove(ctx, id, container.RemoveOptions{Force: force})
@@ -309,21 +364,201 @@ func (s *containerService) CopyFromContainer(ctx context.Context, containerID, s
 	return rc, err
 }
 
-// sinceUnix converts a duration string (e.g. "2h", "10m") to a Unix timestamp
-// string as required by the Docker API. Returns empty string if since is empty.
+// topPsArgs are the ps arguments passed to ContainerTop. The daemon runs ps on
+// the host, so the PID column holds host PIDs rather than container PIDs.
+var topPsArgs = []string{"-eo", "pid,user,%cpu,%mem,args"}
+
+func (s *containerService) Top(ctx context.Context, id string) ([]Process, error) {
+	log.Printf("[docker] ContainerTop: id=%q", id)
+	top, err := s.cli.ContainerTop(ctx, id, topPsArgs)
+	if err != nil {
+		return nil, err
+	}
+	processes := buildContainerProcesses(top)
+	log.Printf("[docker] ContainerTop: returned count=%d", len(processes))
+	return processes, nil
+}
+
+// buildContainerProcesses maps a top response onto Process values. Columns are
+// looked up by title so engines that ignore ps_args (e.g. Windows) still
+// produce PIDs and commands.
+func buildContainerProcesses(top container.TopResponse) []Process {
+	column := func(titles ...string) int {
+		for i, title := range top.Titles {
+			if slices.Contains(titles, title) {
+				return i
+			}
+		}
+		return -1
+	}
+	field := func(row []string, idx int) string {
+		if idx < 0 || idx >= len(row) {
+			return ""
+		}
+		return row[idx]
+	}
+
+	pidIdx := column("PID")
+	userIdx := column("USER", "UID")
+	cpuIdx := column("%CPU", "CPU")
+	memIdx := column("%MEM")
+	cmdIdx := column("COMMAND", "CMD", "ARGS", "Name")
+
+	processes := make([]Process, 0, len(top.Processes))
+	for _, row := range top.Processes {
+		pid, err := strconv.Atoi(field(row, pidIdx))
+		if err != nil {
+			continue
+		}
+		cpu, _ := strconv.ParseFloat(field(row, cpuIdx), 64)
+		mem, _ := strconv.ParseFloat(field(row, memIdx), 64)
+		processes = append(processes, Process{
+			PID:     pid,
+			User:    field(row, userIdx),
+			CPU:     cpu,
+			Memory:  mem,
+			Command: field(row, cmdIdx),
+		})
+	}
+	return processes
+}
+
+func (s *containerService) SignalProcess(ctx context.Context, id string, pid int, signal string) error {
+	log.Printf("[docker] ContainerExec (kill): id=%q pid=%d signal=%q", id, pid, signal)
+	nsPID, err := s.namespacePID(ctx, id, pid)
+	if err == nil {
+		_, err = s.execOutput(ctx, id, killArgs(signal, nsPID))
+	}
+	log.Printf("[docker] ContainerExec (kill): done err=%v", err)
+	return err
+}
+
+// killArgs builds the kill command line for signal, which is either a name
+// such as "SIGHUP" or a signal number.
+func killArgs(signal string, pid int) []string {
+	if _, err := strconv.Atoi(signal); err == nil {
+		return []string{"kill", "-" + signal, strconv.Itoa(pid)}
+	}
+	// POSIX kill expects the signal name without the SIG prefix.
+	return []string{"kill", "-s", strings.TrimPrefix(signal, "SIG"), strconv.Itoa(pid)}
+}
+
+// procRoot is where the host's proc filesystem is mounted.
+const procRoot = "/proc"
+
+// namespacePID translates a host PID, as reported by Top, into the PID seen
+// inside the container, which is what kill needs when it runs through exec.
+// It reads the NSpid line of the process status, so it only works when the
+// daemon runs on this host; otherwise it fails rather than guess.
+func (s *containerService) namespacePID(ctx context.Context, id string, hostPID int) (int, error) {
+	c, err := s.cli.ContainerInspect(ctx, id)
+	if err != nil {
+		return 0, err
+	}
+	if c.HostConfig != nil && c.HostConfig.PidMode.IsHost() {
+		return hostPID, nil
+	}
+	if c.State == nil || c.State.Pid == 0 {
+		return 0, fmt.Errorf("container %s is not running", id)
+	}
+
+	// The process must share the PID namespace of the container's init
+	// process, or the host PID was reused by a process of another container.
+	ns, err := os.Readlink(filepath.Join(procRoot, strconv.Itoa(hostPID), "ns", "pid"))
+	if err != nil {
+		return 0, fmt.Errorf("cannot map process %d into the container: %w", hostPID, err)
+	}
+	initNS, err := os.Readlink(filepath.Join(procRoot, strconv.Itoa(c.State.Pid), "ns", "pid"))
+	if err != nil {
+		return 0, fmt.Errorf("cannot map process %d into the container: %w", hostPID, err)
+	}
+	if ns != initNS {
+		return 0, fmt.Errorf("process %d is not in container %s", hostPID, id)
+	}
+
+	status, err := os.ReadFile(filepath.Join(procRoot, strconv.Itoa(hostPID), "status"))
+	if err != nil {
+		return 0, fmt.Errorf("cannot map process %d into the container: %w", hostPID, err)
+	}
+	return parseNSpid(string(status))
+}
+
+// parseNSpid returns the PID in the innermost PID namespace from the NSpid
+// line of a /proc/<pid>/status file. The line lists the PID in every nested
+// namespace, from the outermost to the innermost.
+func parseNSpid(status string) (int, error) {
+	for line := range strings.Lines(status) {
+		value, ok := strings.CutPrefix(line, "NSpid:")
+		if !ok {
+			continue
+		}
+		fields := strings.Fields(value)
+		if len(fields) == 0 {
+			break
+		}
+		return strconv.Atoi(fields[len(fields)-1])
+	}
+	return 0, errors.New("process status has no NSpid line (Linux 4.1 or later is needed)")
+}
+
+// execOutput runs cmd inside the container and returns its stdout. A non-zero
+// exit code is reported as an error carrying stderr.
+func (s *containerService) execOutput(ctx context.Context, id string, cmd []string) (string, error) {
+	execResp, err := s.cli.ContainerExecCreate(ctx, id, container.ExecOptions{
+		AttachStdout: true,
+		AttachStderr: true,
+		Cmd:          cmd,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	attachResp, err := s.cli.ContainerExecAttach(ctx, execResp.ID, container.ExecStartOptions{})
+	if err != nil {
+		return "", err
+	}
+	defer attachResp.Close()
+
+	var stdout, stderr strings.Builder
+	if _, err = stdcopy.StdCopy(&stdout, &stderr, attachResp.Reader); err != nil {
+		return "", err
+	}
+
+	inspect, err := s.cli.ContainerExecInspect(ctx, execResp.ID)
+	if err != nil {
+		return "", err
+	}
+	if inspect.ExitCode != 0 {
+		return "", fmt.Errorf(
+			"%s exited with code %d: %s",
+			cmd[0],
+			inspect.ExitCode,
+			strings.TrimSpace(stderr.String()),
+		)
+	}
+	return stdout.String(), nil
+}
+
+// sinceUnix converts a Since or Until value of LogOptions, as accepted by
+// ParseLogTime, to a Unix timestamp string as required by the Docker API.
+// Returns empty string if since is empty or invalid.
 func sinceUnix(since string) string {
 	if since == "" {
 		return ""
 	}
-	d, err := time.ParseDuration(since)
+	if d, err := time.ParseDuration(since); err == nil {
+		return strconv.FormatInt(time.Now().Add(-d).Unix(), 10)
+	}
+	t, err := ParseLogTime(since, time.Now())
 	if err != nil {
 		return ""
 	}
-	return strconv.FormatInt(time.Now().Add(-d).Unix(), 10)
+	return fmt.Sprintf("%d.%09d", t.Unix(), t.Nanosecond())
 }
 
 func (s *containerService) Logs(ctx context.Context, id string, opts LogOptions) (*LogsSession, error) {
-	log.Printf("[docker] ContainerLogs: id=%q follow=%v tail=%q since=%q", id, opts.Follow, opts.Tail, opts.Since)
+	log.Printf("[docker] ContainerLogs: id=%q follow=%v tail=%q since=%q until=%q",
+		id, opts.Follow, opts.Tail, opts.Since, opts.Until)
 	reader, err := s.cli.ContainerLogs(ctx, id, container.LogsOptions{
 		ShowStdout: true,
 		ShowStderr: true,
@@ -331,21 +566,18 @@ func (s *containerService) Logs(ctx context.Context, id string, opts LogOptions)
 		Tail:       opts.Tail,
 		Timestamps: opts.Timestamps,
 		Since:      sinceUnix(opts.Since),
+		Until:      sinceUnix(opts.Until),
 	})
 
 	if err != nil {
 		return nil, err
 	}
 
-	pr, pw := io.Pipe()
-	go func() {
-		pw.CloseWithError(copyStream(pw, reader))
-	}()
-
 	log.Printf("[docker] ContainerLogs: session created")
-	return NewLogsSession(
-		io.NopCloser(pr),
+	return newLogsSession(
 		func() { _ = reader.Close() },
+		opts.Timestamps,
+		func(stdout, stderr io.Writer) error { return copyStream(stdout, stderr, reader) },
 	), nil
 }
 
@@ -355,9 +587,11 @@ func (s *containerService) Logs(ctx context.Context, id string, opts LogOptions)
 const maxMuxStreamType = 0x02
 
 // copyStream detects whether r is a Docker multiplexed stream or a raw stream,
-// then copies accordingly. This handles engines like OrbStack and Colima that
-// may not use Docker's multiplexed format.
-func copyStream(w io.Writer, r io.Reader) error {
+// then copies accordingly. Multiplexed frames are split between stdout and
+// stderr; a raw stream carries no stream information and goes to stdout. This
+// handles engines like OrbStack and Colima that may not use Docker's
+// multiplexed format.
+func copyStream(stdout, stderr io.Writer, r io.Reader) error {
 	var streamType [1]byte
 	_, err := io.ReadFull(r, streamType[:])
 	if err != nil {
@@ -367,10 +601,10 @@ func copyStream(w io.Writer, r io.Reader) error {
 	full := io.MultiReader(strings.NewReader(string(streamType[:])), r)
 
 	if streamType[0] <= maxMuxStreamType {
-		_, err = stdcopy.StdCopy(w, w, full)
+		_, err = stdcopy.StdCopy(stdout, stderr, full)
 	} else {
 		log.Printf("[docker] ContainerLogs: raw stream detected (first byte=0x%02x), using io.Copy", streamType[0])
-		_, err = io.Copy(w, full)
+		_, err = io.Copy(stdout, full)
 	}
 	return err
 }
@@ -430,9 +664,77 @@ func (s *containerService) Stats(ctx context.Context, id string) (*StatsSession,
 	), nil
 }
 
-func (s *containerService) Prune(ctx context.Context, _ PruneOptions) (PruneReport, error) {
-	log.Printf("[docker] ContainersPrune")
-	r, err := s.cli.ContainersPrune(ctx, filters.Args{})
+func (s *containerService) StatsOnce(ctx context.Context, id string) (ContainerStats, error) {
+	log.Printf("[docker] ContainerStats: id=%q one-shot", id)
+	reader, err := s.cli.ContainerStatsOneShot(ctx, id)
+	if err != nil {
+		return ContainerStats{}, err
+	}
+	defer reader.Body.Close()
+
+	var frame container.StatsResponse
+	if err = json.NewDecoder(reader.Body).Decode(&frame); err != nil {
+		return ContainerStats{}, err
+	}
+	return statsFromResponse(frame), nil
+}
+
+// containerEventActions are the container events that change what the
+// dashboard shows. The daemon matches "health_status" against every
+// "health_status: <status>" event.
+var containerEventActions = []events.Action{
+	events.ActionCreate, events.ActionStart, events.ActionRestart, events.ActionStop,
+	events.ActionPause, events.ActionUnPause, events.ActionDie, events.ActionOOM,
+	events.ActionDestroy, events.ActionRename, events.ActionHealthStatus,
+}
+
+func (s *containerService) Events(ctx context.Context) (<-chan ContainerEvent, <-chan error) {
+	log.Printf("[docker] Events: type=container")
+	args := filters.NewArgs(filters.Arg("type", string(events.ContainerEventType)))
+	for _, action := range containerEventActions {
+		args.Add("event", string(action))
+	}
+	messages, errs := s.cli.Events(ctx, events.ListOptions{Filters: args})
+
+	out := make(chan ContainerEvent)
+	outErrs := make(chan error, 1)
+	go func() {
+		defer close(out)
+		for {
+			select {
+			case msg := <-messages:
+				select {
+				case out <- containerEventFromMessage(msg):
+				case <-ctx.Done():
+					return
+				}
+			case err := <-errs:
+				log.Printf("[docker] Events: err=%v", err)
+				outErrs <- err
+				return
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return out, outErrs
+}
+
+func containerEventFromMessage(msg events.Message) ContainerEvent {
+	exitCode, _ := strconv.Atoi(msg.Actor.Attributes["exitCode"])
+	return ContainerEvent{
+		ID:       msg.Actor.ID,
+		Name:     msg.Actor.Attributes["name"],
+		Action:   string(msg.Action),
+		Time:     time.Unix(0, msg.TimeNano),
+		ExitCode: exitCode,
+	}
+}
+
+func (s *containerService) Prune(ctx context.Context, opts PruneOptions) (PruneReport, error) {
+	log.Printf("[docker] ContainersPrune: until=%q labels=%v excludeLabels=%v",
+		opts.Until, opts.Labels, opts.ExcludeLabels)
+	r, err := s.cli.ContainersPrune(ctx, opts.filterArgs())
 	if err != nil {
 		return PruneReport{}, err
 	}
@@ -440,6 +742,31 @@ func (s *containerService) Prune(ctx context.Context, _ PruneOptions) (PruneRepo
 	return PruneReport{ItemsDeleted: len(r.ContainersDeleted), SpaceReclaimed: r.SpaceReclaimed}, nil
 }
 
+func (s *containerService) PrunePreview(ctx context.Context, opts PruneOptions) ([]PruneCandidate, error) {
+	log.Printf("[docker] ContainersPrune preview: until=%q labels=%v excludeLabels=%v",
+		opts.Until, opts.Labels, opts.ExcludeLabels)
+	du, err := s.cli.DiskUsage(ctx, dockertypes.DiskUsageOptions{
+		Types: []dockertypes.DiskUsageObject{dockertypes.ContainerObject},
+	})
+	if err != nil {
+		return nil, err
+	}
+	var objects []pruneObject
+	for _, c := range du.Containers {
+		if c == nil || summaryContainerInUse(c) {
+			continue
+		}
+		objects = append(objects, pruneObject{
+			ID:      c.ID,
+			Name:    summaryContainerName(c),
+			Size:    c.SizeRw,
+			Created: time.Unix(c.Created, 0),
+			Labels:  c.Labels,
+		})
+	}
+	return pruneCandidates(objects, opts, time.Now())
+}
+
 func (s *containerService) FileTree(ctx context.Context, id string) (*FileNode, error) {
 	log.Printf("[docker] ContainerExport (file tree): id=%q", id)
 	reader, err := s.cli.ContainerExport(ctx, id)
@@ -546,10 +873,39 @@ func buildContainerHealth(h *container.Health) *HealthInfo {
 		lastOutput = last.Output
 	}
 
+	probes := make([]HealthProbe, 0, len(h.Log))
+	for _, result := range h.Log {
+		if result == nil {
+			continue
+		}
+		probes = append(probes, HealthProbe{
+			Start:    result.Start,
+			End:      result.End,
+			ExitCode: result.ExitCode,
+			Output:   result.Output,
+		})
+	}
+
 	return &HealthInfo{
 		Status:        healthStatus,
 		FailingStreak: h.FailingStreak,
 		LastCheck:     lastCheckTime,
 		Output:        lastOutput,
+		Log:           probes,
+	}
+}
+
+// buildHealthcheckConfig converts the container healthcheck definition. It
+// returns nil when no healthcheck is configured or it was disabled with NONE.
+func buildHealthcheckConfig(hc *container.HealthConfig) *HealthcheckConfig {
+	if hc == nil || len(hc.Test) == 0 || hc.Test[0] == "NONE" {
+		return nil
+	}
+	return &HealthcheckConfig{
+		Test:        hc.Test,
+		Interval:    hc.Interval,
+		Timeout:     hc.Timeout,
+		StartPeriod: hc.StartPeriod,
+		Retries:     hc.Retries,
 	}
 }
@@ -10,7 +10,6 @@ import (
 	"time"
 
 	dockertypes "github.com/docker/docker/api/types"
-	"github.com/docker/docker/api/types/filters"
 	"github.com/docker/docker/api/types/image"
 	"github.com/docker/docker/client"
 	"golang.org/x/sync/errgroup"
@@ -160,10 +159,11 @@ func (s *imageService) Remove(ctx context.Context, id string, force bool) error
 }
 
 func (s *imageService) Prune(ctx context.Context, opts PruneOptions) (PruneReport, error) {
-	log.Printf("[docker] ImagesPrune: all=%v", opts.All)
-	f := filters.Args{}
+	log.Printf("[docker] ImagesPrune: all=%v until=%q labels=%v excludeLabels=%v",
+		opts.All, opts.Until, opts.Labels, opts.ExcludeLabels)
+	f := opts.filterArgs()
 	if opts.All {
-		f = filters.NewArgs(filters.Arg("dangling", "false"))
+		f.Add("dangling", "false")
 	}
 	r, err := s.cli.ImagesPrune(ctx, f)
 	if err != nil {
@@ -173,6 +173,31 @@ func (s *imageService) Prune(ctx context.Context, opts PruneOptions) (PruneRepor
 	return PruneReport{ItemsDeleted: len(r.ImagesDeleted), SpaceReclaimed: r.SpaceReclaimed}, nil
 }
 
+func (s *imageService) PrunePreview(ctx context.Context, opts PruneOptions) ([]PruneCandidate, error) {
+	log.Printf("[docker] ImagesPrune preview: all=%v until=%q labels=%v excludeLabels=%v",
+		opts.All, opts.Until, opts.Labels, opts.ExcludeLabels)
+	du, err := s.cli.DiskUsage(ctx, dockertypes.DiskUsageOptions{
+		Types: []dockertypes.DiskUsageObject{dockertypes.ImageObject},
+	})
+	if err != nil {
+		return nil, err
+	}
+	var objects []pruneObject
+	for _, img := range du.Images {
+		if img == nil || img.Containers > 0 || (!opts.All && !summaryImageDangling(img)) {
+			continue
+		}
+		objects = append(objects, pruneObject{
+			ID:      img.ID,
+			Name:    summaryImageName(img),
+			Size:    summaryImageReclaimable(img),
+			Created: time.Unix(img.Created, 0),
+			Labels:  img.Labels,
+		})
+	}
+	return pruneCandidates(objects, opts, time.Now())
+}
+
 func (s *imageService) Pull(ctx context.Context, imageRef, platform string) error {
 	body, err := s.cli.ImagePull(ctx, imageRef, image.PullOptions{
 		Platform: platform,
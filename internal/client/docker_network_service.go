@@ -3,9 +3,10 @@ package client
 import (
 	"context"
 	"log"
+	"slices"
 	"sync"
+	"time"
 
-	"github.com/docker/docker/api/types/filters"
 	"github.com/docker/docker/api/types/network"
 	"github.com/docker/docker/client"
 	"golang.org/x/sync/errgroup"
@@ -60,6 +61,7 @@ func (s *networkService) List(ctx context.Context) ([]Network, error) {
 				Created:             n.Created,
 				ConnectedContainers: connected,
 				IPAM:                NetworkIPAM{Subnet: subnet, Gateway: gateway},
+				Labels:              n.Labels,
 			})
 			return nil
 		})
@@ -88,12 +90,33 @@ func (s *networkService) Remove(ctx context.Context, id string) error {
 	return err
 }
 
-func (s *networkService) Prune(ctx context.Context, _ PruneOptions) (PruneReport, error) {
-	log.Printf("[docker] NetworksPrune")
-	r, err := s.cli.NetworksPrune(ctx, filters.Args{})
+func (s *networkService) Prune(ctx context.Context, opts PruneOptions) (PruneReport, error) {
+	log.Printf("[docker] NetworksPrune: until=%q labels=%v excludeLabels=%v",
+		opts.Until, opts.Labels, opts.ExcludeLabels)
+	r, err := s.cli.NetworksPrune(ctx, opts.filterArgs())
 	if err != nil {
 		return PruneReport{}, err
 	}
 	log.Printf("[docker] NetworksPrune: deleted=%d", len(r.NetworksDeleted))
 	return PruneReport{ItemsDeleted: len(r.NetworksDeleted), SpaceReclaimed: 0}, nil
 }
+
+// predefinedNetworks are created by the daemon and never pruned.
+var predefinedNetworks = []string{"bridge", "host", "none", "ingress", "docker_gwbridge"}
+
+func (s *networkService) PrunePreview(ctx context.Context, opts PruneOptions) ([]PruneCandidate, error) {
+	log.Printf("[docker] NetworksPrune preview: until=%q labels=%v excludeLabels=%v",
+		opts.Until, opts.Labels, opts.ExcludeLabels)
+	networks, err := s.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var objects []pruneObject
+	for _, n := range networks {
+		if len(n.ConnectedContainers) > 0 || slices.Contains(predefinedNetworks, n.Name) {
+			continue
+		}
+		objects = append(objects, pruneObject{ID: n.ID, Name: n.Name, Created: n.Created, Labels: n.Labels})
+	}
+	return pruneCandidates(objects, opts, time.Now())
+}
@@ -6,11 +6,16 @@ import (
 	"encoding/binary"
 	"errors"
 	"io"
+	"slices"
 	"strconv"
 	"testing"
 	"time"
 
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/build"
 	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/image"
+	"github.com/docker/docker/api/types/volume"
 
 	"github.com/GustavoCaso/docker-dash/internal/config"
 )
@@ -416,8 +421,8 @@ func buildMuxFrame(streamType byte, payload []byte) []byte {
 func TestCopyStreamRaw(t *testing.T) {
 	// First byte > 0x02 → raw stream, io.Copy is used.
 	data := []byte{0x03, 'h', 'e', 'l', 'l', 'o'}
-	var buf bytes.Buffer
-	err := copyStream(&buf, bytes.NewReader(data))
+	var buf, stderr bytes.Buffer
+	err := copyStream(&buf, &stderr, bytes.NewReader(data))
 	if err != nil {
 		t.Fatalf("copyStream() raw error: %v", err)
 	}
@@ -430,8 +435,8 @@ func TestCopyStreamMuxStdout(t *testing.T) {
 	// First byte == 0x01 (stdout) → Docker multiplexed format.
 	payload := []byte("hello logs")
 	frame := buildMuxFrame(0x01, payload)
-	var buf bytes.Buffer
-	err := copyStream(&buf, bytes.NewReader(frame))
+	var buf, stderr bytes.Buffer
+	err := copyStream(&buf, &stderr, bytes.NewReader(frame))
 	if err != nil {
 		t.Fatalf("copyStream() mux error: %v", err)
 	}
@@ -441,41 +446,47 @@ func TestCopyStreamMuxStdout(t *testing.T) {
 }
 
 func TestCopyStreamMuxStderr(t *testing.T) {
-	// First byte == 0x02 (stderr) → Docker multiplexed format, written to same writer.
+	// First byte == 0x02 (stderr) → Docker multiplexed format, written to the stderr writer.
 	payload := []byte("error output")
 	frame := buildMuxFrame(0x02, payload)
-	var buf bytes.Buffer
-	err := copyStream(&buf, bytes.NewReader(frame))
+	var stdout, buf bytes.Buffer
+	err := copyStream(&stdout, &buf, bytes.NewReader(frame))
 	if err != nil {
 		t.Fatalf("copyStream() mux stderr error: %v", err)
 	}
 	if !bytes.Equal(buf.Bytes(), payload) {
 		t.Errorf("copyStream() mux stderr output = %q, want %q", buf.Bytes(), payload)
 	}
+	if stdout.Len() != 0 {
+		t.Errorf("copyStream() mux stderr wrote %q to stdout", stdout.Bytes())
+	}
 }
 
 func TestCopyStreamEmptyReader(t *testing.T) {
 	// Empty reader → io.ReadFull returns io.ErrUnexpectedEOF (or io.EOF for 0 bytes).
 	var buf bytes.Buffer
-	err := copyStream(&buf, bytes.NewReader(nil))
+	err := copyStream(&buf, &buf, bytes.NewReader(nil))
 	if err == nil {
 		t.Fatal("copyStream() on empty reader should return an error")
 	}
 }
 
 func TestCopyStreamMuxMultipleFrames(t *testing.T) {
-	// Multiple frames: stdout then stderr, both written to the same writer.
+	// Multiple frames: stdout then stderr, each written to its own writer.
 	var input bytes.Buffer
 	input.Write(buildMuxFrame(0x01, []byte("line1\n")))
 	input.Write(buildMuxFrame(0x02, []byte("line2\n")))
-	var buf bytes.Buffer
-	err := copyStream(&buf, &input)
+	input.Write(buildMuxFrame(0x01, []byte("line3\n")))
+	var stdout, stderr bytes.Buffer
+	err := copyStream(&stdout, &stderr, &input)
 	if err != nil {
 		t.Fatalf("copyStream() multiple frames error: %v", err)
 	}
-	got := buf.String()
-	if got != "line1\nline2\n" {
-		t.Errorf("copyStream() multiple frames = %q, want %q", got, "line1\nline2\n")
+	if got := stdout.String(); got != "line1\nline3\n" {
+		t.Errorf("copyStream() multiple frames stdout = %q, want %q", got, "line1\nline3\n")
+	}
+	if got := stderr.String(); got != "line2\n" {
+		t.Errorf("copyStream() multiple frames stderr = %q, want %q", got, "line2\n")
 	}
 }
 
@@ -544,4 +555,254 @@ func TestSinceUnix(t *testing.T) {
 			t.Errorf("sinceUnix(%q) = %d, want between %d and %d", "10m", ts, before, after)
 		}
 	})
+
+	t.Run("day and time returns its unix timestamp", func(t *testing.T) {
+		want := time.Date(time.Now().Year(), time.Now().Month(), time.Now().Day(), 0, 0, 0, 0, time.Local).
+			AddDate(0, 0, -1).Add(14 * time.Hour)
+		if got := sinceUnix("yesterday 14:00"); got != strconv.FormatInt(want.Unix(), 10)+".000000000" {
+			t.Errorf("sinceUnix(%q) = %q, want %d", "yesterday 14:00", got, want.Unix())
+		}
+	})
+
+	t.Run("RFC 3339 time returns its unix timestamp", func(t *testing.T) {
+		at := "2024-01-15T10:00:00.5Z"
+		if got, want := sinceUnix(at), "1705312800.500000000"; got != want {
+			t.Errorf("sinceUnix(%q) = %q, want %q", at, got, want)
+		}
+	})
+}
+
+func TestBuildContainerProcesses(t *testing.T) {
+	top := container.TopResponse{
+		Titles: []string{"PID", "USER", "%CPU", "%MEM", "COMMAND"},
+		Processes: [][]string{
+			{"1201", "root", "0.0", "0.1", "nginx: master process nginx -g daemon off;"},
+			{"1254", "nginx", "1.5", "0.3", "nginx: worker process"},
+			{"not-a-pid", "nginx", "0.0", "0.0", "broken"},
+		},
+	}
+
+	got := buildContainerProcesses(top)
+	if len(got) != 2 {
+		t.Fatalf("buildContainerProcesses() returned %d processes, want 2", len(got))
+	}
+	want := Process{PID: 1254, User: "nginx", CPU: 1.5, Memory: 0.3, Command: "nginx: worker process"}
+	if got[1] != want {
+		t.Errorf("buildContainerProcesses()[1] = %+v, want %+v", got[1], want)
+	}
+}
+
+func TestBuildContainerProcesses_MissingColumns(t *testing.T) {
+	top := container.TopResponse{
+		Titles:    []string{"Name", "PID", "CPU", "Private Working Set"},
+		Processes: [][]string{{"smss.exe", "412", "00:00:00.015", "221.2kB"}},
+	}
+
+	got := buildContainerProcesses(top)
+	if len(got) != 1 {
+		t.Fatalf("buildContainerProcesses() returned %d processes, want 1", len(got))
+	}
+	if got[0].PID != 412 || got[0].Command != "smss.exe" {
+		t.Errorf("buildContainerProcesses()[0] = %+v, want PID 412 and command smss.exe", got[0])
+	}
+}
+
+func TestParseNSpid(t *testing.T) {
+	tests := map[string]struct {
+		status  string
+		want    int
+		wantErr bool
+	}{
+		"container process": {status: "Name:\tnginx\nPid:\t5013\nNSpid:\t5013\t8\nPPid:\t5001\n", want: 8},
+		"nested namespaces": {status: "NSpid:\t5013\t812\t3\n", want: 3},
+		"host process":      {status: "NSpid:\t5013\n", want: 5013},
+		"no NSpid line":     {status: "Name:\tnginx\nPid:\t5013\n", wantErr: true},
+		"empty NSpid line":  {status: "NSpid:\n", wantErr: true},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := parseNSpid(test.status)
+			if test.wantErr {
+				if err == nil {
+					t.Errorf("parseNSpid() = %d, want error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseNSpid() error = %v", err)
+			}
+			if got != test.want {
+				t.Errorf("parseNSpid() = %d, want %d", got, test.want)
+			}
+		})
+	}
+}
+
+func TestKillArgs(t *testing.T) {
+	if got := killArgs("SIGHUP", 7); !slices.Equal(got, []string{"kill", "-s", "HUP", "7"}) {
+		t.Errorf("killArgs(SIGHUP) = %v", got)
+	}
+	if got := killArgs("28", 7); !slices.Equal(got, []string{"kill", "-28", "7"}) {
+		t.Errorf("killArgs(28) = %v", got)
+	}
+}
+
+func TestBuildStopOptions(t *testing.T) {
+	opts := buildStopOptions(ContainerStopOptions{})
+	if opts.Timeout != nil || opts.Signal != "" {
+		t.Errorf("buildStopOptions(zero) = %+v, want daemon defaults", opts)
+	}
+
+	timeout := 1500 * time.Millisecond
+	opts = buildStopOptions(ContainerStopOptions{Timeout: &timeout, Signal: "SIGINT"})
+	if opts.Timeout == nil || *opts.Timeout != 2 {
+		t.Errorf("buildStopOptions(1.5s) timeout = %v, want 2 seconds", opts.Timeout)
+	}
+	if opts.Signal != "SIGINT" {
+		t.Errorf("buildStopOptions() signal = %q, want SIGINT", opts.Signal)
+	}
+
+	timeout = 0
+	opts = buildStopOptions(ContainerStopOptions{Timeout: &timeout})
+	if opts.Timeout == nil || *opts.Timeout != 0 {
+		t.Errorf("buildStopOptions(0s) timeout = %v, want 0 seconds", opts.Timeout)
+	}
+}
+
+func TestParseStateTime(t *testing.T) {
+	if got := parseStateTime("0001-01-01T00:00:00Z"); !got.IsZero() {
+		t.Errorf("parseStateTime(never) = %v, want zero time", got)
+	}
+	if got := parseStateTime("garbage"); !got.IsZero() {
+		t.Errorf("parseStateTime(garbage) = %v, want zero time", got)
+	}
+	want := time.Date(2026, 3, 4, 5, 6, 7, 890000000, time.UTC)
+	if got := parseStateTime("2026-03-04T05:06:07.89Z"); !got.Equal(want) {
+		t.Errorf("parseStateTime() = %v, want %v", got, want)
+	}
+}
+
+func TestContainerCrashLooping(t *testing.T) {
+	tests := []struct {
+		name      string
+		container Container
+		want      bool
+	}{
+		{name: "restarting", container: Container{State: StateRestarting, RestartCount: 4}, want: true},
+		{
+			name:      "gave up after failures",
+			container: Container{State: StateStopped, ExitCode: 1, RestartCount: 5},
+			want:      true,
+		},
+		{name: "few restarts", container: Container{State: StateRestarting, RestartCount: 1}, want: false},
+		{name: "clean exit", container: Container{State: StateStopped, RestartCount: 5}, want: false},
+		{name: "running again", container: Container{State: StateRunning, RestartCount: 5}, want: false},
+	}
+	for _, tt := range tests {
+		if got := tt.container.CrashLooping(); got != tt.want {
+			t.Errorf("%s: CrashLooping() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestBuildContainerHealthKeepsFullLog(t *testing.T) {
+	start := time.Date(2026, 3, 4, 5, 6, 0, 0, time.UTC)
+	h := buildContainerHealth(&container.Health{
+		Status: container.Unhealthy,
+		Log: []*container.HealthcheckResult{
+			{Start: start, End: start.Add(time.Second), ExitCode: 0, Output: "ok"},
+			nil,
+			{Start: start.Add(time.Minute), End: start.Add(time.Minute + time.Second), ExitCode: 1, Output: "refused"},
+		},
+	})
+
+	want := []HealthProbe{
+		{Start: start, End: start.Add(time.Second), ExitCode: 0, Output: "ok"},
+		{Start: start.Add(time.Minute), End: start.Add(time.Minute + time.Second), ExitCode: 1, Output: "refused"},
+	}
+	if !slices.Equal(h.Log, want) {
+		t.Errorf("Log = %+v, want %+v", h.Log, want)
+	}
+}
+
+func TestBuildHealthcheckConfig(t *testing.T) {
+	if got := buildHealthcheckConfig(nil); got != nil {
+		t.Errorf("buildHealthcheckConfig(nil) = %+v, want nil", got)
+	}
+	if got := buildHealthcheckConfig(&container.HealthConfig{Test: []string{"NONE"}}); got != nil {
+		t.Errorf("buildHealthcheckConfig(NONE) = %+v, want nil", got)
+	}
+
+	got := buildHealthcheckConfig(&container.HealthConfig{
+		Test:     []string{"CMD-SHELL", "curl -f localhost || exit 1"},
+		Interval: 10 * time.Second,
+		Timeout:  2 * time.Second,
+		Retries:  4,
+	})
+	if got == nil {
+		t.Fatal("buildHealthcheckConfig() = nil, want config")
+	}
+	if got.Interval != 10*time.Second || got.Timeout != 2*time.Second || got.Retries != 4 {
+		t.Errorf("buildHealthcheckConfig() = %+v, want interval 10s, timeout 2s, retries 4", got)
+	}
+	if cmd := got.Command(); cmd != "curl -f localhost || exit 1" {
+		t.Errorf("Command() = %q, want shell command", cmd)
+	}
+}
+
+func TestHealthcheckConfigCommand(t *testing.T) {
+	tests := []struct {
+		test []string
+		want string
+	}{
+		{test: []string{"CMD", "pg_isready", "-U", "admin"}, want: "pg_isready -U admin"},
+		{test: []string{"CMD-SHELL", "redis-cli ping"}, want: "redis-cli ping"},
+		{test: nil, want: ""},
+	}
+	for _, tt := range tests {
+		if got := (HealthcheckConfig{Test: tt.test}).Command(); got != tt.want {
+			t.Errorf("Command(%q) = %q, want %q", tt.test, got, tt.want)
+		}
+	}
+}
+
+func TestDiskUsageReport(t *testing.T) {
+	du := types.DiskUsage{
+		LayersSize: 300,
+		Images: []*image.Summary{
+			{ID: "sha256:a", RepoTags: []string{"nginx:latest"}, Size: 100, SharedSize: 40, Containers: 0},
+			{ID: "sha256:b", RepoTags: []string{"<none>:<none>"}, Size: 200, SharedSize: -1, Containers: 1},
+		},
+		Containers: []*container.Summary{
+			{ID: "c1", Names: []string{"/web"}, SizeRw: 10, State: "running"},
+			{ID: "c2", Names: []string{"/old"}, SizeRw: 20, State: "exited"},
+		},
+		Volumes: []*volume.Volume{
+			{Name: "data", UsageData: &volume.UsageData{Size: 50, RefCount: 0}},
+			{Name: "db", UsageData: &volume.UsageData{Size: -1, RefCount: 1}},
+		},
+		BuildCache: []*build.CacheRecord{
+			{ID: "r1", Description: "RUN make", Size: 70},
+			{ID: "r2", Type: "regular", Size: 30, InUse: true},
+		},
+	}
+
+	got := diskUsageReport(du)
+	want := []DiskUsageEntry{
+		{Kind: DiskUsageImage, ID: "sha256:a", Name: "nginx:latest", Size: 100, Reclaimable: 60},
+		{Kind: DiskUsageImage, ID: "sha256:b", Name: "sha256:b", Size: 200, InUse: true},
+		{Kind: DiskUsageContainer, ID: "c1", Name: "web", Size: 10, InUse: true},
+		{Kind: DiskUsageContainer, ID: "c2", Name: "old", Size: 20, Reclaimable: 20},
+		{Kind: DiskUsageVolume, ID: "data", Name: "data", Size: 50, Reclaimable: 50},
+		{Kind: DiskUsageVolume, ID: "db", Name: "db", InUse: true},
+		{Kind: DiskUsageBuildCache, ID: "r1", Name: "RUN make", Size: 70, Reclaimable: 70},
+		{Kind: DiskUsageBuildCache, ID: "r2", Name: "regular", Size: 30, InUse: true},
+	}
+	if got.LayersSize != 300 {
+		t.Errorf("LayersSize = %d, want 300", got.LayersSize)
+	}
+	if !slices.Equal(got.Entries, want) {
+		t.Errorf("Entries = %+v\nwant %+v", got.Entries, want)
+	}
 }
@@ -3,9 +3,9 @@ package client
 import (
 	"context"
 	"log"
+	"time"
 
 	dockertypes "github.com/docker/docker/api/types"
-	"github.com/docker/docker/api/types/filters"
 	"github.com/docker/docker/client"
 )
 
@@ -39,6 +39,7 @@ func (s *volumeService) List(ctx context.Context) ([]Volume, error) {
 			MountPath: v.Mountpoint,
 			Size:      size,
 			UsedCount: usedCount,
+			Labels:    v.Labels,
 		}
 	}
 
@@ -53,11 +54,18 @@ func (s *volumeService) Remove(ctx context.Context, name string, force bool) err
 	return err
 }
 
+// anonymousVolumeLabel marks the volumes the daemon created without a name.
+const anonymousVolumeLabel = "com.docker.volume.anonymous"
+
 func (s *volumeService) Prune(ctx context.Context, opts PruneOptions) (PruneReport, error) {
-	log.Printf("[docker] VolumesPrune: all=%v", opts.All)
-	f := filters.Args{}
+	log.Printf("[docker] VolumesPrune: all=%v until=%q labels=%v excludeLabels=%v",
+		opts.All, opts.Until, opts.Labels, opts.ExcludeLabels)
+	if opts.Until != "" {
+		return s.pruneUntil(ctx, opts)
+	}
+	f := opts.filterArgs()
 	if opts.All {
-		f = filters.NewArgs(filters.Arg("all", "true"))
+		f.Add("all", "true")
 	}
 	r, err := s.cli.VolumesPrune(ctx, f)
 	if err != nil {
@@ -66,3 +74,58 @@ func (s *volumeService) Prune(ctx context.Context, opts PruneOptions) (PruneRepo
 	log.Printf("[docker] VolumesPrune: deleted=%d spaceReclaimed=%d", len(r.VolumesDeleted), r.SpaceReclaimed)
 	return PruneReport{ItemsDeleted: len(r.VolumesDeleted), SpaceReclaimed: r.SpaceReclaimed}, nil
 }
+
+// pruneUntil removes the prune candidates one by one, as the daemon has no
+// until filter for volumes.
+func (s *volumeService) pruneUntil(ctx context.Context, opts PruneOptions) (PruneReport, error) {
+	candidates, err := s.PrunePreview(ctx, opts)
+	if err != nil {
+		return PruneReport{}, err
+	}
+	var report PruneReport
+	for _, c := range candidates {
+		if c.Skipped != "" {
+			continue
+		}
+		if err := s.Remove(ctx, c.ID, false); err != nil {
+			return report, err
+		}
+		report.ItemsDeleted++
+		report.SpaceReclaimed += uint64(max(c.Size, 0))
+	}
+	return report, nil
+}
+
+func (s *volumeService) PrunePreview(ctx context.Context, opts PruneOptions) ([]PruneCandidate, error) {
+	log.Printf("[docker] VolumesPrune preview: all=%v until=%q labels=%v excludeLabels=%v",
+		opts.All, opts.Until, opts.Labels, opts.ExcludeLabels)
+	du, err := s.cli.DiskUsage(ctx, dockertypes.DiskUsageOptions{
+		Types: []dockertypes.DiskUsageObject{dockertypes.VolumeObject},
+	})
+	if err != nil {
+		return nil, err
+	}
+	var objects []pruneObject
+	for _, v := range du.Volumes {
+		if v == nil || v.UsageData == nil || v.UsageData.RefCount != 0 {
+			continue
+		}
+		if _, anonymous := v.Labels[anonymousVolumeLabel]; !opts.All && !anonymous {
+			continue
+		}
+		// A volume whose creation time cannot be parsed is left with a zero
+		// Created, which an until filter skips.
+		created, err := time.Parse(time.RFC3339, v.CreatedAt)
+		if err != nil {
+			log.Printf("[docker] VolumesPrune preview: volume %q created at %q: %v", v.Name, v.CreatedAt, err)
+		}
+		objects = append(objects, pruneObject{
+			ID:      v.Name,
+			Name:    v.Name,
+			Size:    max(v.UsageData.Size, 0),
+			Created: created,
+			Labels:  v.Labels,
+		})
+	}
+	return pruneCandidates(objects, opts, time.Now())
+}
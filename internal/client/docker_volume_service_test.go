@@ -0,0 +1,59 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	dockertypes "github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/volume"
+)
+
+func TestVolumeServicePruneUntilSkipsUnknownAge(t *testing.T) {
+	t.Parallel()
+
+	old := time.Now().Add(-48 * time.Hour).Format(time.RFC3339)
+	anonymous := map[string]string{anonymousVolumeLabel: ""}
+	var removed []string
+	cli := newTestEngineClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/system/df"):
+			w.Header().Set("Content-Type", "application/json")
+			if err := json.NewEncoder(w).Encode(dockertypes.DiskUsage{Volumes: []*volume.Volume{
+				{Name: "old", CreatedAt: old, Labels: anonymous, UsageData: &volume.UsageData{Size: 1024}},
+				{Name: "unknown", CreatedAt: "not a time", Labels: anonymous, UsageData: &volume.UsageData{Size: 2048}},
+			}}); err != nil {
+				t.Errorf("failed to encode response: %v", err)
+			}
+		case r.Method == http.MethodDelete:
+			removed = append(removed, r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:])
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	})
+	service := &volumeService{cli: cli}
+	opts := PruneOptions{Until: "24h"}
+
+	candidates, err := service.PrunePreview(context.Background(), opts)
+	if err != nil {
+		t.Fatalf("PrunePreview() error = %v", err)
+	}
+	if len(candidates) != 2 || candidates[0].Skipped != "" || candidates[1].Skipped == "" {
+		t.Fatalf("PrunePreview() = %v, want old removed and unknown skipped", candidates)
+	}
+
+	report, err := service.Prune(context.Background(), opts)
+	if err != nil {
+		t.Fatalf("Prune() error = %v", err)
+	}
+	if report.ItemsDeleted != 1 || report.SpaceReclaimed != 1024 {
+		t.Errorf("Prune() = %+v, want only old deleted", report)
+	}
+	if len(removed) != 1 || removed[0] != "old" {
+		t.Errorf("removed %v, want [old]", removed)
+	}
+}
@@ -0,0 +1,327 @@
+package client
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// logLinesBuffer is how many lines the session buffers ahead of the reader.
+	logLinesBuffer = 256
+	// maxLogBatch caps the number of lines returned by a single Read.
+	maxLogBatch = 512
+	// mergeWindow is how long a merged session holds lines back while waiting
+	// for older lines from a quieter source.
+	mergeWindow = 100 * time.Millisecond
+)
+
+// errLogsSessionClosed stops the copy goroutine once the session is closed.
+var errLogsSessionClosed = errors.New("logs session closed")
+
+// LogsSession streams the log lines of a container, in the order they were
+// written, tagged with their stream.
+type LogsSession struct {
+	lines     chan LogLine
+	done      chan struct{}
+	err       error // set before lines is closed
+	closer    func()
+	closeOnce sync.Once
+}
+
+// NewLogsSession creates a session that reads plain, non-multiplexed output
+// from reader and reports every line as stdout.
+func NewLogsSession(reader io.ReadCloser, closer func()) *LogsSession {
+	return newLogsSession(closer, false, func(stdout, _ io.Writer) error {
+		_, err := io.Copy(stdout, reader)
+		return err
+	})
+}
+
+// newLogsSession runs copyFn in the background and turns what it writes to
+// the stdout and stderr writers into tagged lines. When timestamps is true
+// every line is expected to start with an RFC 3339 timestamp, as the daemon
+// writes them for LogOptions.Timestamps.
+func newLogsSession(closer func(), timestamps bool, copyFn func(stdout, stderr io.Writer) error) *LogsSession {
+	s := &LogsSession{
+		lines:  make(chan LogLine, logLinesBuffer),
+		done:   make(chan struct{}),
+		closer: closer,
+	}
+	go func() {
+		w := &logLineWriter{session: s, timestamps: timestamps}
+		err := copyFn(w.stream(LogStreamStdout), w.stream(LogStreamStderr))
+		if flushErr := w.flush(); err == nil {
+			err = flushErr
+		}
+		if err == nil {
+			err = io.EOF
+		}
+		s.err = err
+		close(s.lines)
+	}()
+	return s
+}
+
+// Read blocks until at least one line is available and returns it together
+// with every other line already received. Once the stream has ended it
+// returns the error that ended it, io.EOF when the stream ended normally.
+func (s *LogsSession) Read() ([]LogLine, error) {
+	line, ok := <-s.lines
+	if !ok {
+		return nil, s.err
+	}
+	batch := []LogLine{line}
+	for len(batch) < maxLogBatch {
+		select {
+		case line, ok = <-s.lines:
+			if !ok {
+				// The error is reported by the next Read.
+				return batch, nil
+			}
+			batch = append(batch, line)
+		default:
+			return batch, nil
+		}
+	}
+	return batch, nil
+}
+
+// Close stops the session and releases the underlying stream.
+func (s *LogsSession) Close() {
+	s.closeOnce.Do(func() {
+		close(s.done)
+		if s.closer != nil {
+			s.closer()
+		}
+	})
+}
+
+func (s *LogsSession) send(line LogLine) error {
+	select {
+	case s.lines <- line:
+		return nil
+	case <-s.done:
+		return errLogsSessionClosed
+	}
+}
+
+// logLineWriter splits the output written for each stream into lines. Every
+// stream keeps its own partial line, so interleaved writes never mix.
+type logLineWriter struct {
+	session    *LogsSession
+	timestamps bool
+	partial    map[LogStream][]byte
+}
+
+func (w *logLineWriter) stream(stream LogStream) io.Writer {
+	return streamWriter{w: w, stream: stream}
+}
+
+func (w *logLineWriter) write(stream LogStream, p []byte) error {
+	if w.partial == nil {
+		w.partial = make(map[LogStream][]byte)
+	}
+	buf := append(w.partial[stream], p...)
+	for {
+		i := bytes.IndexByte(buf, '\n')
+		if i < 0 {
+			break
+		}
+		if err := w.session.send(w.parse(stream, string(buf[:i]))); err != nil {
+			return err
+		}
+		buf = buf[i+1:]
+	}
+	w.partial[stream] = append([]byte(nil), buf...)
+	return nil
+}
+
+// flush emits the partial lines left when the stream ends.
+func (w *logLineWriter) flush() error {
+	for _, stream := range []LogStream{LogStreamStdout, LogStreamStderr} {
+		if len(w.partial[stream]) == 0 {
+			continue
+		}
+		if err := w.session.send(w.parse(stream, string(w.partial[stream]))); err != nil {
+			return err
+		}
+		delete(w.partial, stream)
+	}
+	return nil
+}
+
+// parse builds a LogLine, splitting off the leading timestamp when the
+// session was created with timestamps.
+func (w *logLineWriter) parse(stream LogStream, raw string) LogLine {
+	line := LogLine{Stream: stream, Content: raw}
+	if !w.timestamps {
+		return line
+	}
+	ts, content, found := strings.Cut(raw, " ")
+	if !found {
+		ts, content = raw, ""
+	}
+	t, err := time.Parse(time.RFC3339Nano, ts)
+	if err != nil {
+		return line
+	}
+	line.Timestamp = t
+	line.Content = content
+	return line
+}
+
+// streamWriter tags everything written to it with a stream.
+type streamWriter struct {
+	w      *logLineWriter
+	stream LogStream
+}
+
+func (s streamWriter) Write(p []byte) (int, error) {
+	if err := s.w.write(s.stream, p); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// LogSource is a session to merge with MergeLogsSessions and the name its
+// lines are tagged with.
+type LogSource struct {
+	Name    string
+	Session *LogsSession
+}
+
+// sourceBatch is a Read result from one of the merged sessions.
+type sourceBatch struct {
+	source int
+	lines  []LogLine
+	err    error
+}
+
+// MergeLogsSessions fans the lines of several sessions into one session, like
+// docker compose logs. Lines are tagged with the name of their source and
+// interleaved by timestamp, so the sources should be opened with
+// LogOptions.Timestamps. A line is held back for up to mergeWindow while a
+// source has nothing buffered, in case an older line from it is on its way.
+// The merged session ends once every source has ended, or as soon as one of
+// them fails. Closing it closes every source.
+func MergeLogsSessions(sources []LogSource) *LogsSession {
+	s := &LogsSession{
+		lines: make(chan LogLine, logLinesBuffer),
+		done:  make(chan struct{}),
+		closer: func() {
+			for _, src := range sources {
+				src.Session.Close()
+			}
+		},
+	}
+	batches := make(chan sourceBatch)
+	for i, src := range sources {
+		go func() {
+			for {
+				lines, err := src.Session.Read()
+				select {
+				case batches <- sourceBatch{source: i, lines: lines, err: err}:
+				case <-s.done:
+					return
+				}
+				if err != nil {
+					return
+				}
+			}
+		}()
+	}
+	go func() {
+		m := &logMerger{
+			sources: sources,
+			queues:  make([][]LogLine, len(sources)),
+			ended:   make([]bool, len(sources)),
+			open:    len(sources),
+		}
+		s.err = m.run(s, batches)
+		close(s.lines)
+	}()
+	return s
+}
+
+// logMerger interleaves the lines of the merged sessions by timestamp.
+type logMerger struct {
+	sources []LogSource
+	queues  [][]LogLine // lines received but not sent yet, per source
+	ended   []bool
+	open    int // sources that have not ended
+}
+
+func (m *logMerger) run(s *LogsSession, batches <-chan sourceBatch) error {
+	var timeout <-chan time.Time
+	for m.open > 0 {
+		force := false
+		select {
+		case b := <-batches:
+			for _, line := range b.lines {
+				line.Source = m.sources[b.source].Name
+				m.queues[b.source] = append(m.queues[b.source], line)
+			}
+			if b.err != nil {
+				if !errors.Is(b.err, io.EOF) {
+					return fmt.Errorf("%s: %w", m.sources[b.source].Name, b.err)
+				}
+				m.ended[b.source] = true
+				m.open--
+			}
+		case <-timeout:
+			timeout = nil
+			force = true
+		case <-s.done:
+			return errLogsSessionClosed
+		}
+		if err := m.emit(s, force); err != nil {
+			return err
+		}
+		if timeout == nil && m.pending() {
+			timeout = time.After(mergeWindow)
+		}
+	}
+	return io.EOF
+}
+
+// emit sends the queued lines in timestamp order. Unless force is set, it
+// stops as soon as a source that has not ended has no line queued, since that
+// source could still send an older line.
+func (m *logMerger) emit(s *LogsSession, force bool) error {
+	for {
+		next := -1
+		for i, queue := range m.queues {
+			if len(queue) == 0 {
+				if !m.ended[i] && !force {
+					return nil
+				}
+				continue
+			}
+			if next < 0 || queue[0].Timestamp.Before(m.queues[next][0].Timestamp) {
+				next = i
+			}
+		}
+		if next < 0 {
+			return nil
+		}
+		if err := s.send(m.queues[next][0]); err != nil {
+			return err
+		}
+		m.queues[next] = m.queues[next][1:]
+	}
+}
+
+// pending reports whether any line is waiting to be sent.
+func (m *logMerger) pending() bool {
+	for _, queue := range m.queues {
+		if len(queue) > 0 {
+			return true
+		}
+	}
+	return false
+}
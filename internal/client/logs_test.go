@@ -0,0 +1,201 @@
+package client
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"slices"
+	"testing"
+	"time"
+)
+
+// readAllLines reads s until the end of the stream.
+func readAllLines(t *testing.T, s *LogsSession) ([]LogLine, error) {
+	t.Helper()
+	var all []LogLine
+	for {
+		lines, err := s.Read()
+		all = append(all, lines...)
+		if err != nil {
+			return all, err
+		}
+	}
+}
+
+func TestLogsSessionKeepsStreamsApartAndInOrder(t *testing.T) {
+	var input bytes.Buffer
+	input.Write(buildMuxFrame(0x01, []byte("out one\nout ")))
+	input.Write(buildMuxFrame(0x02, []byte("err one\n")))
+	input.Write(buildMuxFrame(0x01, []byte("two\n")))
+	input.Write(buildMuxFrame(0x02, []byte("err tail")))
+
+	s := newLogsSession(nil, false, func(stdout, stderr io.Writer) error {
+		return copyStream(stdout, stderr, &input)
+	})
+	got, err := readAllLines(t, s)
+	if !errors.Is(err, io.EOF) {
+		t.Fatalf("Read() error = %v, want io.EOF", err)
+	}
+
+	want := []LogLine{
+		{Stream: LogStreamStdout, Content: "out one"},
+		{Stream: LogStreamStderr, Content: "err one"},
+		{Stream: LogStreamStdout, Content: "out two"},
+		{Stream: LogStreamStderr, Content: "err tail"},
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("lines = %+v, want %+v", got, want)
+	}
+}
+
+func TestLogsSessionParsesTimestamps(t *testing.T) {
+	s := newLogsSession(nil, true, func(stdout, _ io.Writer) error {
+		_, err := io.WriteString(stdout, "2024-01-15T10:30:00.5Z hello world\nnot-a-time line\n")
+		return err
+	})
+	got, _ := readAllLines(t, s)
+
+	want := []LogLine{
+		{
+			Stream:    LogStreamStdout,
+			Timestamp: time.Date(2024, 1, 15, 10, 30, 0, 500000000, time.UTC),
+			Content:   "hello world",
+		},
+		{Stream: LogStreamStdout, Content: "not-a-time line"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("lines = %+v, want %+v", got, want)
+	}
+	for i := range want {
+		if got[i].Content != want[i].Content || !got[i].Timestamp.Equal(want[i].Timestamp) {
+			t.Errorf("line %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestLogsSessionReportsCopyError(t *testing.T) {
+	boom := errors.New("boom")
+	s := newLogsSession(nil, false, func(_, _ io.Writer) error { return boom })
+	if _, err := readAllLines(t, s); !errors.Is(err, boom) {
+		t.Errorf("Read() error = %v, want %v", err, boom)
+	}
+}
+
+func TestLogsSessionCloseUnblocksWriter(t *testing.T) {
+	closed := false
+	finished := make(chan error, 1)
+	s := newLogsSession(func() { closed = true }, false, func(stdout, _ io.Writer) error {
+		// Write more lines than the session buffers, nobody reads them.
+		for range logLinesBuffer * 2 {
+			if _, err := io.WriteString(stdout, "line\n"); err != nil {
+				finished <- err
+				return err
+			}
+		}
+		finished <- nil
+		return nil
+	})
+
+	s.Close()
+	s.Close()
+	if !closed {
+		t.Error("Close() should call the closer")
+	}
+	select {
+	case err := <-finished:
+		if !errors.Is(err, errLogsSessionClosed) {
+			t.Errorf("writer error = %v, want errLogsSessionClosed", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("writer still blocked after Close()")
+	}
+}
+
+func TestMockLogsTagsStderr(t *testing.T) {
+	s, err := NewMockClient().Containers().Logs(t.Context(), "abc123def456", LogOptions{Timestamps: true})
+	if err != nil {
+		t.Fatalf("Logs() error = %v", err)
+	}
+	got, _ := readAllLines(t, s)
+
+	var stderr int
+	for _, line := range got {
+		if line.Stream == LogStreamStderr {
+			stderr++
+		}
+		if line.Timestamp.IsZero() {
+			t.Errorf("line %q has no timestamp", line.Content)
+		}
+	}
+	if stderr == 0 {
+		t.Error("mock logs should include stderr lines")
+	}
+}
+
+// timestampedSession returns a session that writes text as timestamped
+// output and then ends with err.
+func timestampedSession(text string, err error) *LogsSession {
+	return newLogsSession(nil, true, func(stdout, _ io.Writer) error {
+		if _, writeErr := io.WriteString(stdout, text); writeErr != nil {
+			return writeErr
+		}
+		return err
+	})
+}
+
+func TestMergeLogsSessionsInterleavesByTimestamp(t *testing.T) {
+	api := timestampedSession("2024-01-15T10:30:00Z GET /\n2024-01-15T10:30:02Z GET /a\n", nil)
+	db := timestampedSession("2024-01-15T10:30:01Z query\n2024-01-15T10:30:03Z commit\n", nil)
+
+	got, err := readAllLines(t, MergeLogsSessions([]LogSource{{Name: "api", Session: api}, {Name: "db", Session: db}}))
+	if !errors.Is(err, io.EOF) {
+		t.Fatalf("Read() error = %v, want io.EOF", err)
+	}
+
+	var rows []string
+	for _, line := range got {
+		rows = append(rows, line.Source+" "+line.Content)
+	}
+	want := []string{"api GET /", "db query", "api GET /a", "db commit"}
+	if !slices.Equal(rows, want) {
+		t.Errorf("merged lines = %q, want %q", rows, want)
+	}
+}
+
+func TestMergeLogsSessionsDoesNotWaitForever(t *testing.T) {
+	pr, pw := io.Pipe()
+	quiet := newLogsSession(func() { pr.Close() }, true, func(stdout, _ io.Writer) error {
+		_, err := io.Copy(stdout, pr)
+		return err
+	})
+	busy := timestampedSession("2024-01-15T10:30:00Z hello\n", nil)
+	s := MergeLogsSessions([]LogSource{{Name: "quiet", Session: quiet}, {Name: "busy", Session: busy}})
+	defer func() {
+		s.Close()
+		pw.Close()
+	}()
+
+	read := make(chan []LogLine, 1)
+	go func() {
+		lines, _ := s.Read()
+		read <- lines
+	}()
+	select {
+	case lines := <-read:
+		if len(lines) != 1 || lines[0].Source != "busy" {
+			t.Errorf("Read() = %+v, want the busy line", lines)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("a quiet source should not hold back the others")
+	}
+}
+
+func TestMergeLogsSessionsReportsSourceError(t *testing.T) {
+	ok := timestampedSession("", nil)
+	failing := timestampedSession("", errors.New("connection reset"))
+
+	_, err := readAllLines(t, MergeLogsSessions([]LogSource{{Name: "ok", Session: ok}, {Name: "db", Session: failing}}))
+	if err == nil || err.Error() != "db: connection reset" {
+		t.Errorf("Read() error = %v, want the failing source error", err)
+	}
+}
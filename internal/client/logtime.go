@@ -0,0 +1,76 @@
+package client
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// logClockLayouts are the accepted times of day, after "today", "yesterday"
+// or on their own for today.
+var logClockLayouts = []string{"15:04", "15:04:05"}
+
+// logDateLayouts are the accepted absolute times, in local time unless they
+// carry a zone.
+var logDateLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02",
+}
+
+// ParseLogTime parses the Since and Until values of LogOptions relative to
+// now. It accepts a duration meaning that long ago ("2h"), an RFC 3339 or
+// "2006-01-02 15:04" time, "now", and "today" or "yesterday" optionally
+// followed by a time of day ("yesterday 14:00"). A time of day on its own is
+// today.
+func ParseLogTime(value string, now time.Time) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return time.Time{}, invalidLogTime(value)
+	}
+	if d, err := time.ParseDuration(value); err == nil {
+		if d < 0 {
+			return time.Time{}, fmt.Errorf("invalid time %q: duration must not be negative", value)
+		}
+		return now.Add(-d), nil
+	}
+	for _, layout := range logDateLayouts {
+		if t, err := time.ParseInLocation(layout, value, now.Location()); err == nil {
+			return t, nil
+		}
+	}
+
+	day, clock, _ := strings.Cut(strings.ToLower(value), " ")
+	midnight := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	switch day {
+	case "now":
+		if clock == "" {
+			return now, nil
+		}
+		return time.Time{}, invalidLogTime(value)
+	case "today":
+	case "yesterday":
+		midnight = midnight.AddDate(0, 0, -1)
+	default:
+		// A time of day on its own.
+		clock = value
+	}
+	if clock == "" {
+		return midnight, nil
+	}
+	for _, layout := range logClockLayouts {
+		if t, err := time.Parse(layout, strings.TrimSpace(clock)); err == nil {
+			return midnight.Add(time.Duration(t.Hour())*time.Hour +
+				time.Duration(t.Minute())*time.Minute +
+				time.Duration(t.Second())*time.Second), nil
+		}
+	}
+	return time.Time{}, invalidLogTime(value)
+}
+
+func invalidLogTime(value string) error {
+	return fmt.Errorf("invalid time %q: use a duration (2h), an RFC 3339 time or a day and time (yesterday 14:00)",
+		value)
+}
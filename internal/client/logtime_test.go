@@ -0,0 +1,40 @@
+package client
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseLogTime(t *testing.T) {
+	now := time.Date(2024, 3, 10, 9, 30, 0, 0, time.UTC)
+	tests := []struct {
+		value string
+		want  time.Time
+	}{
+		{value: "2h", want: now.Add(-2 * time.Hour)},
+		{value: "now", want: now},
+		{value: "2024-01-15T10:00:00.5Z", want: time.Date(2024, 1, 15, 10, 0, 0, 500_000_000, time.UTC)},
+		{value: "2024-01-15 10:00", want: time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)},
+		{value: "2024-01-15", want: time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)},
+		{value: "today", want: time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC)},
+		{value: "yesterday 14:00", want: time.Date(2024, 3, 9, 14, 0, 0, 0, time.UTC)},
+		{value: "Yesterday", want: time.Date(2024, 3, 9, 0, 0, 0, 0, time.UTC)},
+		{value: "08:15:30", want: time.Date(2024, 3, 10, 8, 15, 30, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		got, err := ParseLogTime(tt.value, now)
+		if err != nil {
+			t.Errorf("ParseLogTime(%q) error = %v", tt.value, err)
+			continue
+		}
+		if !got.Equal(tt.want) {
+			t.Errorf("ParseLogTime(%q) = %s, want %s", tt.value, got, tt.want)
+		}
+	}
+
+	for _, value := range []string{"", "-1h", "soon", "yesterday noon", "now 10:00", "25:00"} {
+		if _, err := ParseLogTime(value, now); err == nil {
+			t.Errorf("ParseLogTime(%q) = nil error, want an error", value)
+		}
+	}
+}
@@ -19,6 +19,7 @@ type MockClient struct {
 	volumes    *mockVolumeService
 	networks   *mockNetworkService
 	compose    *MockComposeProjectService
+	buildCache *mockBuildCacheService
 	info       *mockSystemInfo
 }
 
@@ -30,6 +31,7 @@ func NewMockClient() *MockClient {
 		volumes:    newMockVolumeService(),
 		networks:   newMockNetworkService(),
 		compose:    NewMockComposeProjectService(),
+		buildCache: newMockBuildCacheService(),
 		info:       newMockSystemInfo(),
 	}
 }
@@ -39,6 +41,7 @@ func (c *MockClient) Images() ImageService                                     {
 func (c *MockClient) Volumes() VolumeService                                   { return c.volumes }
 func (c *MockClient) Networks() NetworkService                                 { return c.networks }
 func (c *MockClient) Compose() ComposeProjectService                           { return c.compose }
+func (c *MockClient) BuildCache() BuildCacheService                            { return c.buildCache }
 func (c *MockClient) Info(ctx context.Context) (SystemInfo, error)             { return c.info.SystemInfo, nil }
 func (c *MockClient) Ping(ctx context.Context) error                           { return nil }
 func (c *MockClient) Close() error                                             { return nil }
@@ -47,6 +50,9 @@ func (c *MockClient) Kill(ctx context.Context, id string, signal string) error {
 // mockContainerService provides mock container data.
 type mockContainerService struct {
 	containers []Container
+	processes  map[string][]Process
+	// statsEpoch is when the cumulative stats counters of the mock start.
+	statsEpoch time.Time
 }
 
 func newMockContainerService() *mockContainerService {
@@ -84,8 +90,22 @@ func newMockContainerService() *mockContainerService {
 					FailingStreak: 2,
 					LastCheck:     time.Now(),
 					Output:        "HTTP/1.1 200 OK",
+					Log: []HealthProbe{
+						mockProbe(now.Add(-2*time.Minute), 0, "HTTP/1.1 200 OK"),
+						mockProbe(now.Add(-90*time.Second), 1, "curl: (7) Failed to connect to localhost port 80"),
+						mockProbe(now.Add(-time.Minute), 0, "HTTP/1.1 200 OK"),
+						mockProbe(now.Add(-30*time.Second), 0, "HTTP/1.1 200 OK"),
+						mockProbe(now, 0, "HTTP/1.1 200 OK"),
+					},
+					Config: &HealthcheckConfig{
+						Test:     []string{"CMD-SHELL", "curl -fsI http://localhost/ || exit 1"},
+						Interval: 30 * time.Second,
+						Timeout:  5 * time.Second,
+						Retries:  3,
+					},
 				},
 				Labels:        map[string]string{"maintainer": "NGINX Docker Maintainers"},
+				StartedAt:     now.Add(-2 * time.Hour),
 				MemoryLimit:   512 * 1024 * 1024, // 512 MB
 				CPUShares:     1024,
 				RestartPolicy: "unless-stopped",
@@ -119,8 +139,17 @@ func newMockContainerService() *mockContainerService {
 					FailingStreak: 0,
 					LastCheck:     now.Add(-1 * time.Hour),
 					Output:        "HTTP/1.1 404 Not Found",
+					Log: []HealthProbe{
+						mockProbe(now.Add(-1*time.Hour), 1, "HTTP/1.1 404 Not Found"),
+					},
+					Config: &HealthcheckConfig{
+						Test:        []string{"CMD", "wget", "-q", "--spider", "http://localhost:3000/health"},
+						Interval:    time.Minute,
+						StartPeriod: 2 * time.Minute,
+					},
 				},
 				Labels:        map[string]string{"com.docker.compose.service": "api"},
+				StartedAt:     now.Add(-5 * time.Hour),
 				MemoryLimit:   0, // unlimited
 				CPUShares:     512,
 				RestartPolicy: "on-failure:3",
@@ -153,8 +182,25 @@ func newMockContainerService() *mockContainerService {
 					FailingStreak: 2,
 					LastCheck:     now.Add(-3 * time.Hour),
 					Output:        "connection refused",
+					Log: []HealthProbe{
+						mockProbe(
+							now.Add(-3*time.Hour).Add(-20*time.Second),
+							0,
+							"/var/run/postgresql:5432 - accepting connections",
+						),
+						mockProbe(now.Add(-3*time.Hour).Add(-10*time.Second), 1, "connection refused"),
+						mockProbe(now.Add(-3*time.Hour), 1, "connection refused"),
+					},
+					Config: &HealthcheckConfig{
+						Test:     []string{"CMD-SHELL", "pg_isready -U admin"},
+						Interval: 10 * time.Second,
+						Timeout:  3 * time.Second,
+						Retries:  5,
+					},
 				},
 				Labels:        map[string]string{},
+				RestartCount:  2,
+				StartedAt:     now.Add(-24 * time.Hour),
 				MemoryLimit:   1024 * 1024 * 1024, // 1 GB
 				CPUShares:     0,
 				RestartPolicy: "always",
@@ -182,6 +228,8 @@ func newMockContainerService() *mockContainerService {
 					LastCheck:     now.Add(-4 * time.Hour),
 				},
 				Labels:        map[string]string{},
+				StartedAt:     now.Add(-72 * time.Hour),
+				FinishedAt:    now.Add(-72 * time.Hour).Add(5 * time.Minute),
 				MemoryLimit:   0,
 				CPUShares:     0,
 				RestartPolicy: "no",
@@ -214,6 +262,7 @@ func newMockContainerService() *mockContainerService {
 					FailingStreak: 0,
 				},
 				Labels:        map[string]string{"com.docker.compose.service": "cache"},
+				StartedAt:     now.Add(-3 * time.Hour),
 				MemoryLimit:   256 * 1024 * 1024, // 256 MB
 				CPUShares:     256,
 				RestartPolicy: "unless-stopped",
@@ -223,7 +272,7 @@ func newMockContainerService() *mockContainerService {
 				ID:          "pqr678stu901",
 				Name:        "worker-crashed",
 				Image:       "python:3.11-slim",
-				Status:      "Exited (1) 1 hour ago",
+				Status:      "Exited (137) 1 hour ago",
 				State:       StateStopped,
 				Created:     now.Add(-6 * time.Hour),
 				Ports:       []PortMapping{},
@@ -240,17 +289,54 @@ func newMockContainerService() *mockContainerService {
 					FailingStreak: 0,
 				},
 				Labels:        map[string]string{"com.docker.compose.service": "worker"},
+				ExitCode:      137,
+				OOMKilled:     true,
+				RestartCount:  5,
+				StartedAt:     now.Add(-1 * time.Hour).Add(-2 * time.Minute),
+				FinishedAt:    now.Add(-1 * time.Hour),
 				MemoryLimit:   512 * 1024 * 1024, // 512 MB
 				CPUShares:     512,
 				RestartPolicy: "on-failure:5",
 				Privileged:    false,
 			},
 		},
+		statsEpoch: now,
+		processes: map[string][]Process{
+			"abc123def456": {
+				{PID: 1201, User: "root", CPU: 0.0, Memory: 0.1, Command: "nginx: master process nginx -g daemon off;"},
+				{PID: 1254, User: "nginx", CPU: 1.2, Memory: 0.3, Command: "nginx: worker process"},
+				{PID: 1255, User: "nginx", CPU: 0.8, Memory: 0.3, Command: "nginx: worker process"},
+			},
+			"def456ghi789": {
+				{PID: 2310, User: "node", CPU: 12.5, Memory: 4.2, Command: "node server.js"},
+			},
+			"ghi789jkl012": {
+				{PID: 3401, User: "postgres", CPU: 0.3, Memory: 1.5, Command: "postgres"},
+				{PID: 3466, User: "postgres", CPU: 0.0, Memory: 0.4, Command: "postgres: checkpointer"},
+				{PID: 3467, User: "postgres", CPU: 0.1, Memory: 0.2, Command: "postgres: background writer"},
+				{PID: 3469, User: "postgres", CPU: 0.0, Memory: 0.2, Command: "postgres: autovacuum launcher"},
+			},
+			"mno345pqr678": {
+				{PID: 4512, User: "redis", CPU: 2.1, Memory: 0.9, Command: "redis-server *:6379"},
+			},
+		},
 	}
 }
 
+// mockProbeDuration is how long each mock healthcheck probe takes.
+const mockProbeDuration = 40 * time.Millisecond
+
+// mockProbe returns a healthcheck probe result that finished at end.
+func mockProbe(end time.Time, exitCode int, output string) HealthProbe {
+	return HealthProbe{Start: end.Add(-mockProbeDuration), End: end, ExitCode: exitCode, Output: output}
+}
+
 func (s *mockContainerService) List(ctx context.Context) ([]Container, error) {
-	return s.containers, nil
+	containers := slices.Clone(s.containers)
+	for i := range containers {
+		containers[i].Size = int64(i+1) * mockContainerSize
+	}
+	return containers, nil
 }
 
 func (s *mockContainerService) Run(_ context.Context, _ Image, _ RunOptions) (string, error) {
@@ -271,17 +357,21 @@ func (s *mockContainerService) Start(ctx context.Context, id string) error {
 		if c.ID == id || c.Name == id {
 			s.containers[i].State = StateRunning
 			s.containers[i].Status = "Up 1 second"
+			s.containers[i].StartedAt = time.Now()
+			s.containers[i].ExitCode = 0
+			s.containers[i].OOMKilled = false
 			return nil
 		}
 	}
 	return fmt.Errorf("container not found: %s", id)
 }
 
-func (s *mockContainerService) Stop(ctx context.Context, id string) error {
+func (s *mockContainerService) Stop(ctx context.Context, id string, _ ContainerStopOptions) error {
 	for i, c := range s.containers {
 		if c.ID == id || c.Name == id {
 			s.containers[i].State = StateStopped
 			s.containers[i].Status = "Exited (0) 1 second ago"
+			s.containers[i].FinishedAt = time.Now()
 			return nil
 		}
 	}
@@ -395,11 +485,14 @@ func mockReaderCloser(entries []mockTarEntry) (io.ReadCloser, error) {
 	return io.NopCloser(bytes.NewReader(buf.Bytes())), nil
 }
 
-func (s *mockContainerService) Restart(ctx context.Context, id string) error {
+func (s *mockContainerService) Restart(ctx context.Context, id string, _ ContainerStopOptions) error {
 	for i, c := range s.containers {
 		if c.ID == id || c.Name == id {
 			s.containers[i].State = StateRunning
 			s.containers[i].Status = "Up 1 second"
+			s.containers[i].StartedAt = time.Now()
+			s.containers[i].ExitCode = 0
+			s.containers[i].OOMKilled = false
 			return nil
 		}
 	}
@@ -422,9 +515,20 @@ func (s *mockContainerService) Remove(ctx context.Context, id string, force bool
 func (s *mockContainerService) Kill(ctx context.Context, id, signal string) error {
 	for i, c := range s.containers {
 		if c.ID == id || c.Name == id {
-			if signal == "SIGKILL" {
+			if c.State != StateRunning {
+				return fmt.Errorf("cannot kill container: %s: container %s is not running", id, c.ID)
+			}
+			switch signal {
+			case "SIGKILL", "9":
 				s.containers[i].State = StateStopped
 				s.containers[i].Status = "Exited (137) 2 seconds ago"
+				s.containers[i].ExitCode = 137
+				s.containers[i].FinishedAt = time.Now()
+			case "SIGTERM", "15":
+				s.containers[i].State = StateStopped
+				s.containers[i].Status = "Exited (143) 2 seconds ago"
+				s.containers[i].ExitCode = 143
+				s.containers[i].FinishedAt = time.Now()
 			}
 			return nil
 		}
@@ -433,22 +537,78 @@ func (s *mockContainerService) Kill(ctx context.Context, id, signal string) erro
 	return fmt.Errorf("container not found: %s", id)
 }
 
-func (s *mockContainerService) Logs(ctx context.Context, id string, opts LogOptions) (*LogsSession, error) {
-	logs := `2024-01-15T10:30:00Z Starting application...
-2024-01-15T10:30:01Z Loading configuration...
-2024-01-15T10:30:02Z Connected to database
-2024-01-15T10:30:03Z Server listening on port 3000
-2024-01-15T10:30:10Z GET /health 200 5ms
-2024-01-15T10:30:15Z GET /api/users 200 25ms
-`
-
-	pr, pw := io.Pipe()
-	go func() {
-		pw.Write([]byte(logs))
-		pw.Close()
-	}()
+func (s *mockContainerService) Top(_ context.Context, id string) ([]Process, error) {
+	for _, c := range s.containers {
+		if c.ID == id || c.Name == id {
+			if c.State != StateRunning {
+				return nil, fmt.Errorf("container %s is not running", id)
+			}
+			return slices.Clone(s.processes[c.ID]), nil
+		}
+	}
+	return nil, fmt.Errorf("container not found: %s", id)
+}
 
-	return NewLogsSession(io.NopCloser(pr), func() {}), nil
+func (s *mockContainerService) SignalProcess(_ context.Context, id string, pid int, signal string) error {
+	for _, c := range s.containers {
+		if c.ID == id || c.Name == id {
+			if c.State != StateRunning {
+				return fmt.Errorf("container %s is not running", id)
+			}
+			idx := slices.IndexFunc(s.processes[c.ID], func(p Process) bool { return p.PID == pid })
+			if idx < 0 {
+				return fmt.Errorf("kill: (%d) - No such process", pid)
+			}
+			if signal == "SIGKILL" || signal == "SIGTERM" {
+				s.processes[c.ID] = slices.Delete(s.processes[c.ID], idx, idx+1)
+			}
+			return nil
+		}
+	}
+	return fmt.Errorf("container not found: %s", id)
+}
+
+// mockLogLine is a line of mock container output.
+type mockLogLine struct {
+	stream  LogStream
+	at      string
+	content string
+}
+
+var mockLogLines = []mockLogLine{
+	{LogStreamStdout, "2024-01-15T10:30:00Z", "Starting application..."},
+	{LogStreamStdout, "2024-01-15T10:30:01Z", "Loading configuration..."},
+	{LogStreamStderr, "2024-01-15T10:30:01Z", "warning: config key \"cache.ttl\" is deprecated"},
+	{LogStreamStdout, "2024-01-15T10:30:02Z", "Connected to database"},
+	{LogStreamStdout, "2024-01-15T10:30:03Z", "Server listening on port 3000"},
+	{LogStreamStdout, "2024-01-15T10:30:10Z", "GET /health 200 5ms"},
+	{LogStreamStderr, "2024-01-15T10:30:12Z", "error: upstream timeout after 30s"},
+	{LogStreamStdout, "2024-01-15T10:30:15Z", "GET /api/users 200 25ms"},
+	{
+		LogStreamStdout,
+		"2024-01-15T10:30:16Z",
+		`{"time":"2024-01-15T10:30:16Z","level":"warn","msg":"slow query","request_id":"abc","duration_ms":812}`,
+	},
+	{LogStreamStdout, "2024-01-15T10:30:17Z", `level=info msg="cache refreshed" keys=120 request_id=def`},
+}
+
+func (s *mockContainerService) Logs(ctx context.Context, id string, opts LogOptions) (*LogsSession, error) {
+	return newLogsSession(func() {}, opts.Timestamps, func(stdout, stderr io.Writer) error {
+		for _, line := range mockLogLines {
+			w := stdout
+			if line.stream == LogStreamStderr {
+				w = stderr
+			}
+			text := line.content + "\n"
+			if opts.Timestamps {
+				text = line.at + " " + text
+			}
+			if _, err := io.WriteString(w, text); err != nil {
+				return err
+			}
+		}
+		return nil
+	}), nil
 }
 
 func (s *mockContainerService) Exec(ctx context.Context, id string) (*ExecSession, error) {
@@ -510,6 +670,55 @@ func (s *mockContainerService) Stats(ctx context.Context, id string) (*StatsSess
 	return nil, fmt.Errorf("container not found: %s", id)
 }
 
+// mockStatsCPUs is the number of CPUs of the mock host.
+const mockStatsCPUs = 4
+
+// StatsOnce derives cumulative counters from the time elapsed since the mock
+// was created, so each running container keeps a steady load: the nth uses
+// n*10% of a CPU, n*64 MiB of memory and reads n KiB/s from the network.
+func (s *mockContainerService) StatsOnce(_ context.Context, id string) (ContainerStats, error) {
+	for i, c := range s.containers {
+		if c.ID == id || c.Name == id {
+			if c.State != StateRunning {
+				return ContainerStats{}, fmt.Errorf("container %s is not running", id)
+			}
+			now := time.Now()
+			elapsed := uint64(now.Sub(s.statsEpoch)) //nolint:gosec // always positive
+			n := uint64(i + 1)                       //nolint:gosec // a small index
+			seconds := elapsed / uint64(time.Second)
+			// CPU quota periods are 100ms; the nth container is throttled in
+			// (n-1)*10% of them.
+			periods := elapsed / uint64(100*time.Millisecond)
+			return ContainerStats{
+				Read:             now,
+				CPUTotal:         elapsed * n / 10,
+				SystemCPU:        elapsed * mockStatsCPUs,
+				OnlineCPUs:       mockStatsCPUs,
+				CPUPeriods:       periods,
+				ThrottledPeriods: periods * (n - 1) / 10,
+				ThrottledTime:    elapsed * (n - 1) / 20,
+				MemoryUsage:      n * 64 << 20,
+				MemoryLimit:      8 << 30,
+				MemoryCache:      n * 16 << 20,
+				MemoryRSS:        n * 56 << 20,
+				NetworkRx:        seconds * n << 10,
+				NetworkTx:        seconds * n << 9,
+				BlockRead:        n << 20,
+				BlockWrite:       n << 19,
+				Networks: map[string]IOCounters{
+					"eth0": {In: seconds * n << 10, Out: seconds * n << 9},
+				},
+				BlockDevices: map[string]IOCounters{
+					"8:0": {In: n << 20, Out: n << 19},
+				},
+				PIDs:      n + 1,
+				PIDsLimit: 100,
+			}, nil
+		}
+	}
+	return ContainerStats{}, fmt.Errorf("container not found: %s", id)
+}
+
 // mockExecWriter simulates shell output by echoing back commands with a fake prompt.
 type mockExecWriter struct {
 	pw *io.PipeWriter
@@ -547,18 +756,55 @@ func (s *mockContainerService) FileTree(_ context.Context, _ string) (*FileNode,
 	return root, nil
 }
 
-func (s *mockContainerService) Prune(_ context.Context, _ PruneOptions) (PruneReport, error) {
-	var count int
-	var remaining []Container
-	for _, c := range s.containers {
-		if c.State == StateStopped {
-			count++
-		} else {
-			remaining = append(remaining, c)
+// Events reports no events: the mock containers only change when the
+// dashboard acts on them, and it reloads the list afterwards anyway.
+func (s *mockContainerService) Events(ctx context.Context) (<-chan ContainerEvent, <-chan error) {
+	events := make(chan ContainerEvent)
+	go func() {
+		<-ctx.Done()
+		close(events)
+	}()
+	return events, make(chan error)
+}
+
+func (s *mockContainerService) Prune(ctx context.Context, opts PruneOptions) (PruneReport, error) {
+	candidates, err := s.PrunePreview(ctx, opts)
+	if err != nil {
+		return PruneReport{}, err
+	}
+	s.containers = slices.DeleteFunc(s.containers, func(c Container) bool { return isCandidate(candidates, c.ID) })
+	return pruneReport(candidates), nil
+}
+
+func (s *mockContainerService) PrunePreview(_ context.Context, opts PruneOptions) ([]PruneCandidate, error) {
+	var objects []pruneObject
+	for i, c := range s.containers {
+		if c.State != StateStopped {
+			continue
 		}
+		size := int64(i+1) * mockContainerSize
+		objects = append(objects, pruneObject{ID: c.ID, Name: c.Name, Size: size, Created: c.Created, Labels: c.Labels})
 	}
-	s.containers = remaining
-	return PruneReport{ItemsDeleted: count, SpaceReclaimed: 0}, nil
+	return pruneCandidates(objects, opts, time.Now())
+}
+
+// isCandidate reports whether the object with id is one of candidates the
+// prune removes.
+func isCandidate(candidates []PruneCandidate, id string) bool {
+	return slices.ContainsFunc(candidates, func(c PruneCandidate) bool { return c.ID == id && c.Skipped == "" })
+}
+
+// pruneReport is the report of a mock prune removing candidates.
+func pruneReport(candidates []PruneCandidate) PruneReport {
+	var report PruneReport
+	for _, c := range candidates {
+		if c.Skipped != "" {
+			continue
+		}
+		report.ItemsDeleted++
+		report.SpaceReclaimed += uint64(max(c.Size, 0))
+	}
+	return report
 }
 
 // mockImageService provides mock image data.
@@ -675,21 +921,34 @@ func (s *mockImageService) Remove(ctx context.Context, id string, force bool) er
 	return fmt.Errorf("image not found: %s", id)
 }
 
-func (s *mockImageService) Prune(_ context.Context, opts PruneOptions) (PruneReport, error) {
-	var count int
-	var spaceReclaimed uint64
-	var remaining []Image
+func (s *mockImageService) Prune(ctx context.Context, opts PruneOptions) (PruneReport, error) {
+	candidates, err := s.PrunePreview(ctx, opts)
+	if err != nil {
+		return PruneReport{}, err
+	}
+	s.images = slices.DeleteFunc(s.images, func(img Image) bool { return isCandidate(candidates, img.ID) })
+	return pruneReport(candidates), nil
+}
+
+func (s *mockImageService) PrunePreview(_ context.Context, opts PruneOptions) ([]PruneCandidate, error) {
+	var objects []pruneObject
 	for _, img := range s.images {
-		unused := img.Dangling || (opts.All && len(img.UsedBy) == 0)
-		if unused {
-			count++
-			spaceReclaimed += uint64(img.Size)
-		} else {
-			remaining = append(remaining, img)
+		if !img.Dangling && (!opts.All || len(img.UsedBy) > 0) {
+			continue
 		}
+		var labels map[string]string
+		if img.Config != nil {
+			labels = img.Config.Labels
+		}
+		objects = append(objects, pruneObject{
+			ID:      img.ID,
+			Name:    img.Name(),
+			Size:    img.Size,
+			Created: img.Created,
+			Labels:  labels,
+		})
 	}
-	s.images = remaining
-	return PruneReport{ItemsDeleted: count, SpaceReclaimed: spaceReclaimed}, nil
+	return pruneCandidates(objects, opts, time.Now())
 }
 
 func (s *mockImageService) Pull(_ context.Context, imageRef, platform string) error {
@@ -774,23 +1033,33 @@ func (s *mockVolumeService) Remove(ctx context.Context, name string, force bool)
 	return fmt.Errorf("volume not found: %s", name)
 }
 
-func (s *mockVolumeService) Prune(_ context.Context, opts PruneOptions) (PruneReport, error) {
-	var count int
-	var spaceReclaimed uint64
-	var remaining []Volume
+func (s *mockVolumeService) Prune(ctx context.Context, opts PruneOptions) (PruneReport, error) {
+	candidates, err := s.PrunePreview(ctx, opts)
+	if err != nil {
+		return PruneReport{}, err
+	}
+	s.volumes = slices.DeleteFunc(s.volumes, func(v Volume) bool { return isCandidate(candidates, v.Name) })
+	return pruneReport(candidates), nil
+}
+
+func (s *mockVolumeService) PrunePreview(_ context.Context, opts PruneOptions) ([]PruneCandidate, error) {
+	var objects []pruneObject
 	for _, v := range s.volumes {
-		// Without All, only prune anonymous volumes (UsedCount==0 and no name).
-		// In the mock all volumes are named, so without All nothing is pruned.
-		// With All, prune all unused (UsedCount==0) volumes.
-		if opts.All && v.UsedCount == 0 {
-			count++
-			spaceReclaimed += uint64(v.Size)
-		} else {
-			remaining = append(remaining, v)
+		// Without All, only unused anonymous volumes are pruned. The mock
+		// volumes are all named, so without All nothing is pruned.
+		_, anonymous := v.Labels[anonymousVolumeLabel]
+		if v.UsedCount > 0 || (!opts.All && !anonymous) {
+			continue
 		}
+		objects = append(objects, pruneObject{
+			ID:      v.Name,
+			Name:    v.Name,
+			Size:    v.Size,
+			Created: v.Created,
+			Labels:  v.Labels,
+		})
 	}
-	s.volumes = remaining
-	return PruneReport{ItemsDeleted: count, SpaceReclaimed: spaceReclaimed}, nil
+	return pruneCandidates(objects, opts, time.Now())
 }
 
 // mockNetworkService provides mock network data.
@@ -878,18 +1147,24 @@ func (s *mockNetworkService) Remove(ctx context.Context, id string) error {
 	return fmt.Errorf("network not found: %s", id)
 }
 
-func (s *mockNetworkService) Prune(_ context.Context, _ PruneOptions) (PruneReport, error) {
-	var count int
-	var remaining []Network
+func (s *mockNetworkService) Prune(ctx context.Context, opts PruneOptions) (PruneReport, error) {
+	candidates, err := s.PrunePreview(ctx, opts)
+	if err != nil {
+		return PruneReport{}, err
+	}
+	s.networks = slices.DeleteFunc(s.networks, func(n Network) bool { return isCandidate(candidates, n.ID) })
+	return pruneReport(candidates), nil
+}
+
+func (s *mockNetworkService) PrunePreview(_ context.Context, opts PruneOptions) ([]PruneCandidate, error) {
+	var objects []pruneObject
 	for _, n := range s.networks {
-		if len(n.ConnectedContainers) == 0 {
-			count++
-		} else {
-			remaining = append(remaining, n)
+		if len(n.ConnectedContainers) > 0 {
+			continue
 		}
+		objects = append(objects, pruneObject{ID: n.ID, Name: n.Name, Created: n.Created, Labels: n.Labels})
 	}
-	s.networks = remaining
-	return PruneReport{ItemsDeleted: count, SpaceReclaimed: 0}, nil
+	return pruneCandidates(objects, opts, time.Now())
 }
 
 // MockComposeProjectService provides mock Compose project data.
@@ -909,9 +1184,9 @@ func NewMockComposeProjectService() *MockComposeProjectService {
 				WorkingDir:  "/home/user/projects/web-app",
 				ConfigFiles: "/home/user/projects/web-app/docker-compose.yml",
 				Services: []ComposeServiceInfo{
-					{Name: "api", State: "running", Image: "node:18-alpine"},
-					{Name: "db", State: "running", Image: "postgres:15"},
-					{Name: "cache", State: "running", Image: "redis:7-alpine"},
+					{Name: "api", State: "running", Image: "node:18-alpine", ContainerID: "def456ghi789"},
+					{Name: "db", State: "running", Image: "postgres:15", ContainerID: "ghi789jkl012"},
+					{Name: "cache", State: "running", Image: "redis:7-alpine", ContainerID: "mno345pqr678"},
 				},
 			},
 			{
@@ -919,8 +1194,8 @@ func NewMockComposeProjectService() *MockComposeProjectService {
 				WorkingDir:  "/home/user/projects/monitoring",
 				ConfigFiles: "/home/user/projects/monitoring/compose.yaml",
 				Services: []ComposeServiceInfo{
-					{Name: "prometheus", State: "running", Image: "prom/prometheus:latest"},
-					{Name: "grafana", State: "exited", Image: "grafana/grafana:latest"},
+					{Name: "prometheus", State: "running", Image: "prom/prometheus:latest", ContainerID: "stu901vwx234"},
+					{Name: "grafana", State: "exited", Image: "grafana/grafana:latest", ContainerID: "vwx234yza567"},
 				},
 			},
 		},
@@ -981,6 +1256,116 @@ func (s *MockComposeProjectService) setProjectState(project ComposeProject, stat
 	return fmt.Errorf("compose project not found: %s", project.Name)
 }
 
+// mockContainerSize is the writable layer size of the nth mock container.
+const mockContainerSize = 8 * 1024 * 1024
+
+// DiskUsage accounts for the mock images, containers, volumes and build cache
+// as they are now, so removals and prunes show up.
+func (c *MockClient) DiskUsage(_ context.Context) (DiskUsageReport, error) {
+	report := DiskUsageReport{LayersSize: c.info.DiskUsage.LayerSize}
+	for _, img := range c.images.images {
+		entry := DiskUsageEntry{Kind: DiskUsageImage, ID: img.ID, Name: img.Name(), Size: img.Size}
+		entry.InUse = len(img.UsedBy) > 0
+		if !entry.InUse {
+			entry.Reclaimable = img.Size
+		}
+		report.Entries = append(report.Entries, entry)
+	}
+	for i, ctr := range c.containers.containers {
+		entry := DiskUsageEntry{Kind: DiskUsageContainer, ID: ctr.ID, Name: ctr.Name}
+		entry.Size = int64(i+1) * mockContainerSize
+		entry.InUse = ctr.State != StateStopped
+		if !entry.InUse {
+			entry.Reclaimable = entry.Size
+		}
+		report.Entries = append(report.Entries, entry)
+	}
+	for _, v := range c.volumes.volumes {
+		entry := DiskUsageEntry{Kind: DiskUsageVolume, ID: v.Name, Name: v.Name, Size: v.Size, InUse: v.UsedCount > 0}
+		if !entry.InUse {
+			entry.Reclaimable = v.Size
+		}
+		report.Entries = append(report.Entries, entry)
+	}
+	for _, record := range c.buildCache.records {
+		report.Entries = append(report.Entries, record.DiskUsageEntry)
+	}
+	return report, nil
+}
+
+// mockCacheRecord is a build cache record of the mock.
+type mockCacheRecord struct {
+	DiskUsageEntry
+	created time.Time
+	// dangling records are pruned without BuildCachePruneOptions.All.
+	dangling bool
+}
+
+type mockBuildCacheService struct {
+	records []mockCacheRecord
+}
+
+func newMockBuildCacheService() *mockBuildCacheService {
+	now := time.Now()
+	record := func(id, name string, size int64, inUse, dangling bool, age time.Duration) mockCacheRecord {
+		entry := DiskUsageEntry{Kind: DiskUsageBuildCache, ID: id, Name: name, Size: size, InUse: inUse}
+		if !inUse {
+			entry.Reclaimable = size
+		}
+		return mockCacheRecord{DiskUsageEntry: entry, created: now.Add(-age), dangling: dangling}
+	}
+	return &mockBuildCacheService{records: []mockCacheRecord{
+		record("cache1", "[build 3/5] RUN npm ci", 320*1024*1024, false, false, 72*time.Hour),
+		record("cache2", "[build 4/5] COPY . .", 12*1024*1024, false, true, 2*time.Hour),
+		record("cache3", "[stage-1 2/2] RUN go build ./...", 96*1024*1024, true, false, time.Hour),
+	}}
+}
+
+// Prune removes the unused records, oldest first, until KeepStorage is left.
+func (s *mockBuildCacheService) Prune(_ context.Context, opts BuildCachePruneOptions) (PruneReport, error) {
+	cutoff := time.Now()
+	if opts.Until != "" {
+		d, err := time.ParseDuration(opts.Until)
+		if err != nil {
+			return PruneReport{}, fmt.Errorf("invalid until %q: %w", opts.Until, err)
+		}
+		cutoff = cutoff.Add(-d)
+	}
+	var total int64
+	for _, record := range s.records {
+		total += record.Size
+	}
+	slices.SortFunc(s.records, func(a, b mockCacheRecord) int { return a.created.Compare(b.created) })
+
+	var report PruneReport
+	var remaining []mockCacheRecord
+	for _, record := range s.records {
+		prunable := !record.InUse && (opts.All || record.dangling) && record.created.Before(cutoff)
+		if !prunable || total <= opts.KeepStorage {
+			remaining = append(remaining, record)
+			continue
+		}
+		total -= record.Size
+		report.ItemsDeleted++
+		report.SpaceReclaimed += uint64(record.Size) //nolint:gosec // sizes are positive
+	}
+	s.records = remaining
+	return report, nil
+}
+
+func (s *mockBuildCacheService) Remove(_ context.Context, id string) error {
+	for i, record := range s.records {
+		if record.ID == id {
+			if record.InUse {
+				return fmt.Errorf("build cache record %s is in use", id)
+			}
+			s.records = append(s.records[:i], s.records[i+1:]...)
+			return nil
+		}
+	}
+	return fmt.Errorf("build cache record not found: %s", id)
+}
+
 type mockSystemInfo struct {
 	SystemInfo
 }
@@ -1013,6 +1398,7 @@ func newMockSystemInfo() *mockSystemInfo {
 				ImagesSize:     1 * 1024 * 1024 * 1024,
 				VolumesSize:    512 * 1024 * 1024,
 				ContainersSize: 256 * 1024 * 1024,
+				BuildCacheSize: 428 * 1024 * 1024,
 			},
 			Warnings: []string{
 				"WARNING: DOCKER_INSECURE_NO_IPTABLES_RAW is set",
@@ -59,6 +59,45 @@ func TestMockClient_VolumeList(t *testing.T) {
 	}
 }
 
+func TestMockClient_BuildCachePrune(t *testing.T) {
+	client := NewMockClient()
+	defer client.Close()
+	ctx := context.Background()
+
+	report, err := client.BuildCache().Prune(ctx, BuildCachePruneOptions{})
+	if err != nil {
+		t.Fatalf("Prune() error = %v", err)
+	}
+	if report.ItemsDeleted != 1 {
+		t.Errorf("Prune() deleted %d records, want only the dangling one", report.ItemsDeleted)
+	}
+
+	report, err = client.BuildCache().Prune(ctx, BuildCachePruneOptions{All: true, Until: "24h"})
+	if err != nil {
+		t.Fatalf("Prune() error = %v", err)
+	}
+	if report.ItemsDeleted != 1 {
+		t.Errorf("Prune() deleted %d records, want the unused one older than 24h", report.ItemsDeleted)
+	}
+
+	usage, err := client.DiskUsage(ctx)
+	if err != nil {
+		t.Fatalf("DiskUsage() error = %v", err)
+	}
+	var cache []string
+	for _, entry := range usage.Entries {
+		if entry.Kind == DiskUsageBuildCache {
+			cache = append(cache, entry.ID)
+		}
+	}
+	if len(cache) != 1 || cache[0] != "cache3" {
+		t.Errorf("build cache = %v, want only the record in use", cache)
+	}
+	if err := client.BuildCache().Remove(ctx, "cache3"); err == nil {
+		t.Error("Remove() should refuse a record in use")
+	}
+}
+
 func TestMockClient_ContainerExec(t *testing.T) {
 	client := NewMockClient()
 	defer client.Close()
@@ -218,3 +257,39 @@ func TestMockClient_CopyFromContainer(t *testing.T) {
 		})
 	}
 }
+
+func TestMockClient_ContainerTopAndSignalProcess(t *testing.T) {
+	client := NewMockClient()
+	ctx := context.Background()
+
+	processes, err := client.Containers().Top(ctx, "abc123def456")
+	if err != nil {
+		t.Fatalf("Top() error = %v", err)
+	}
+	if len(processes) == 0 {
+		t.Fatal("Top() returned no processes, want sample processes")
+	}
+
+	pid := processes[len(processes)-1].PID
+	if err = client.Containers().SignalProcess(ctx, "abc123def456", pid, "SIGHUP"); err != nil {
+		t.Fatalf("SignalProcess(SIGHUP) error = %v", err)
+	}
+	if err = client.Containers().SignalProcess(ctx, "abc123def456", pid, "SIGTERM"); err != nil {
+		t.Fatalf("SignalProcess(SIGTERM) error = %v", err)
+	}
+
+	after, err := client.Containers().Top(ctx, "abc123def456")
+	if err != nil {
+		t.Fatalf("Top() error = %v", err)
+	}
+	if len(after) != len(processes)-1 {
+		t.Errorf("Top() after SIGTERM returned %d processes, want %d", len(after), len(processes)-1)
+	}
+
+	if err = client.Containers().SignalProcess(ctx, "abc123def456", 99999, "SIGTERM"); err == nil {
+		t.Error("SignalProcess() on unknown pid error = nil, want error")
+	}
+	if _, err = client.Containers().Top(ctx, "jkl012mno345"); err == nil {
+		t.Error("Top() on stopped container error = nil, want error")
+	}
+}
@@ -0,0 +1,190 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types/filters"
+)
+
+// pruneUntilLayouts are the timestamp layouts accepted for
+// PruneOptions.Until besides durations and Unix timestamps, as the daemon
+// accepts them.
+var pruneUntilLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02",
+}
+
+// ParsePruneUntil returns the time an until filter stands for: a duration
+// before now ("24h"), a Unix timestamp or an RFC 3339 timestamp or date.
+func ParsePruneUntil(until string, now time.Time) (time.Time, error) {
+	if d, err := time.ParseDuration(until); err == nil {
+		return now.Add(-d), nil
+	}
+	if secs, err := strconv.ParseInt(until, 10, 64); err == nil {
+		return time.Unix(secs, 0), nil
+	}
+	for _, layout := range pruneUntilLayouts {
+		if t, err := time.ParseInLocation(layout, until, time.Local); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid until %q: use a duration (24h) or a timestamp", until)
+}
+
+// PreviewSystemPrune runs the dry-run of every prune SystemPrune would run.
+func PreviewSystemPrune(ctx context.Context, c Client, opts PruneOptions) (SystemPrunePreview, error) {
+	var preview SystemPrunePreview
+	var err error
+	if preview.Containers, err = c.Containers().PrunePreview(ctx, opts); err != nil {
+		return preview, fmt.Errorf("containers: %w", err)
+	}
+	if preview.Networks, err = c.Networks().PrunePreview(ctx, opts); err != nil {
+		return preview, fmt.Errorf("networks: %w", err)
+	}
+	if preview.Volumes, err = c.Volumes().PrunePreview(ctx, opts); err != nil {
+		return preview, fmt.Errorf("volumes: %w", err)
+	}
+	if preview.Images, err = c.Images().PrunePreview(ctx, opts); err != nil {
+		return preview, fmt.Errorf("images: %w", err)
+	}
+	return preview, nil
+}
+
+// SystemPrune prunes containers, networks, volumes, images and the build
+// cache with the same options, in the order docker system prune does:
+// removing containers first frees the objects they used. It stops at the
+// first failing prune and returns what was pruned until then.
+func SystemPrune(ctx context.Context, c Client, opts PruneOptions) (SystemPruneReport, error) {
+	var report SystemPruneReport
+	var err error
+	if report.Containers, err = c.Containers().Prune(ctx, opts); err != nil {
+		return report, fmt.Errorf("containers: %w", err)
+	}
+	if report.Networks, err = c.Networks().Prune(ctx, opts); err != nil {
+		return report, fmt.Errorf("networks: %w", err)
+	}
+	if report.Volumes, err = c.Volumes().Prune(ctx, opts); err != nil {
+		return report, fmt.Errorf("volumes: %w", err)
+	}
+	if report.Images, err = c.Images().Prune(ctx, opts); err != nil {
+		return report, fmt.Errorf("images: %w", err)
+	}
+	if len(opts.Labels) > 0 {
+		report.BuildCacheSkipped = true
+		return report, nil
+	}
+	cacheOpts, err := opts.buildCacheOptions(time.Now())
+	if err != nil {
+		return report, fmt.Errorf("build cache: %w", err)
+	}
+	if report.BuildCache, err = c.BuildCache().Prune(ctx, cacheOpts); err != nil {
+		return report, fmt.Errorf("build cache: %w", err)
+	}
+	return report, nil
+}
+
+// buildCacheOptions are the build cache prune options matching o. The build
+// cache only understands until as a duration, so timestamps are turned into
+// the time elapsed since then.
+func (o PruneOptions) buildCacheOptions(now time.Time) (BuildCachePruneOptions, error) {
+	opts := BuildCachePruneOptions{All: o.All}
+	if o.Until == "" {
+		return opts, nil
+	}
+	cutoff, err := ParsePruneUntil(o.Until, now)
+	if err != nil {
+		return opts, err
+	}
+	opts.Until = now.Sub(cutoff).Round(time.Second).String()
+	return opts, nil
+}
+
+// filterArgs are the daemon prune filters for the until and label options.
+func (o PruneOptions) filterArgs() filters.Args {
+	args := filters.NewArgs()
+	if o.Until != "" {
+		args.Add("until", o.Until)
+	}
+	for _, label := range o.Labels {
+		args.Add("label", label)
+	}
+	for _, label := range o.ExcludeLabels {
+		args.Add("label!", label)
+	}
+	return args
+}
+
+// pruneObject is an object eligible for a prune, before the until and label
+// filters.
+type pruneObject struct {
+	ID      string
+	Name    string
+	Size    int64
+	Created time.Time
+	Labels  map[string]string
+}
+
+// skippedUnknownAge is why an until filter keeps an object whose creation
+// time is unknown.
+const skippedUnknownAge = "unknown age"
+
+// pruneCandidates keeps the objects the until and label filters of opts
+// match, the way the daemon applies them. With an until filter, the objects
+// whose creation time is unknown are returned as skipped rather than removed.
+func pruneCandidates(objects []pruneObject, opts PruneOptions, now time.Time) ([]PruneCandidate, error) {
+	var cutoff time.Time
+	if opts.Until != "" {
+		var err error
+		if cutoff, err = ParsePruneUntil(opts.Until, now); err != nil {
+			return nil, err
+		}
+	}
+	candidates := []PruneCandidate{}
+	for _, obj := range objects {
+		if !hasAllLabels(obj.Labels, opts.Labels) || hasAnyLabel(obj.Labels, opts.ExcludeLabels) {
+			continue
+		}
+		candidate := PruneCandidate{ID: obj.ID, Name: obj.Name, Size: obj.Size}
+		switch {
+		case cutoff.IsZero():
+		case obj.Created.IsZero():
+			candidate.Skipped = skippedUnknownAge
+		case !obj.Created.Before(cutoff):
+			continue
+		}
+		candidates = append(candidates, candidate)
+	}
+	return candidates, nil
+}
+
+func hasAllLabels(labels map[string]string, filters []string) bool {
+	for _, f := range filters {
+		if !hasLabel(labels, f) {
+			return false
+		}
+	}
+	return true
+}
+
+func hasAnyLabel(labels map[string]string, filters []string) bool {
+	for _, f := range filters {
+		if hasLabel(labels, f) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasLabel reports whether labels match filter, given as "key" or
+// "key=value".
+func hasLabel(labels map[string]string, filter string) bool {
+	key, value, withValue := strings.Cut(filter, "=")
+	got, ok := labels[key]
+	return ok && (!withValue || got == value)
+}
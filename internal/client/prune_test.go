@@ -0,0 +1,230 @@
+package client
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestParsePruneUntil(t *testing.T) {
+	now := time.Date(2024, 5, 10, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		until string
+		want  time.Time
+	}{
+		{"24h", now.Add(-24 * time.Hour)},
+		{"90m", now.Add(-90 * time.Minute)},
+		{"1715299200", time.Unix(1715299200, 0)},
+		{"2024-05-01T10:00:00Z", time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)},
+		{"2024-05-01", time.Date(2024, 5, 1, 0, 0, 0, 0, time.Local)},
+	}
+	for _, tt := range tests {
+		got, err := ParsePruneUntil(tt.until, now)
+		if err != nil {
+			t.Errorf("ParsePruneUntil(%q) error = %v", tt.until, err)
+			continue
+		}
+		if !got.Equal(tt.want) {
+			t.Errorf("ParsePruneUntil(%q) = %v, want %v", tt.until, got, tt.want)
+		}
+	}
+
+	if _, err := ParsePruneUntil("last week", now); err == nil {
+		t.Error("ParsePruneUntil() should reject an unknown format")
+	}
+}
+
+func TestPruneOptionsFilterArgs(t *testing.T) {
+	opts := PruneOptions{Until: "24h", Labels: []string{"env=test", "tmp"}, ExcludeLabels: []string{"keep"}}
+	args := opts.filterArgs()
+
+	if got := args.Get("until"); !slices.Equal(got, []string{"24h"}) {
+		t.Errorf("until = %v, want [24h]", got)
+	}
+	if !args.ExactMatch("label", "env=test") || !args.ExactMatch("label", "tmp") {
+		t.Errorf("label = %v, want env=test and tmp", args.Get("label"))
+	}
+	if got := args.Get("label!"); !slices.Equal(got, []string{"keep"}) {
+		t.Errorf("label! = %v, want [keep]", got)
+	}
+	if (PruneOptions{All: true}).filterArgs().Len() != 0 {
+		t.Error("filterArgs() without until or labels should be empty")
+	}
+}
+
+func TestPruneCandidates(t *testing.T) {
+	now := time.Now()
+	objects := []pruneObject{
+		{ID: "old", Created: now.Add(-48 * time.Hour), Labels: map[string]string{"env": "test"}},
+		{ID: "new", Created: now.Add(-time.Hour), Labels: map[string]string{"env": "test"}},
+		{ID: "kept", Created: now.Add(-48 * time.Hour), Labels: map[string]string{"env": "test", "keep": ""}},
+		{ID: "prod", Created: now.Add(-48 * time.Hour), Labels: map[string]string{"env": "prod"}},
+	}
+	tests := []struct {
+		name string
+		opts PruneOptions
+		want []string
+	}{
+		{"no filters", PruneOptions{}, []string{"old", "new", "kept", "prod"}},
+		{"until", PruneOptions{Until: "24h"}, []string{"old", "kept", "prod"}},
+		{"label value", PruneOptions{Labels: []string{"env=test"}}, []string{"old", "new", "kept"}},
+		{"label key", PruneOptions{Labels: []string{"keep"}}, []string{"kept"}},
+		{"exclude label", PruneOptions{Until: "24h", ExcludeLabels: []string{"keep", "env=prod"}}, []string{"old"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			candidates, err := pruneCandidates(objects, tt.opts, now)
+			if err != nil {
+				t.Fatalf("pruneCandidates() error = %v", err)
+			}
+			var got []string
+			for _, c := range candidates {
+				got = append(got, c.ID)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("pruneCandidates() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMockClient_ContainerPruneUntil(t *testing.T) {
+	client := NewMockClient()
+	defer client.Close()
+	ctx := context.Background()
+
+	// old-container exited 3 days ago, worker-crashed was created 6 hours ago.
+	opts := PruneOptions{Until: "24h"}
+	candidates, err := client.Containers().PrunePreview(ctx, opts)
+	if err != nil {
+		t.Fatalf("PrunePreview() error = %v", err)
+	}
+	if len(candidates) != 1 || candidates[0].Name != "old-container" {
+		t.Fatalf("PrunePreview() = %+v, want only old-container", candidates)
+	}
+
+	report, err := client.Containers().Prune(ctx, opts)
+	if err != nil {
+		t.Fatalf("Prune() error = %v", err)
+	}
+	if report.ItemsDeleted != 1 || report.SpaceReclaimed != uint64(candidates[0].Size) {
+		t.Errorf("Prune() = %+v, want the previewed container removed", report)
+	}
+
+	containers, err := client.Containers().List(ctx)
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	names := make([]string, 0, len(containers))
+	for _, c := range containers {
+		names = append(names, c.Name)
+	}
+	if slices.Contains(names, "old-container") || !slices.Contains(names, "worker-crashed") {
+		t.Errorf("containers after prune = %v, want old-container removed and worker-crashed kept", names)
+	}
+}
+
+func TestSystemPrune(t *testing.T) {
+	client := NewMockClient()
+	defer client.Close()
+	ctx := context.Background()
+	opts := PruneOptions{All: true}
+
+	preview, err := PreviewSystemPrune(ctx, client, opts)
+	if err != nil {
+		t.Fatalf("PreviewSystemPrune() error = %v", err)
+	}
+	report, err := SystemPrune(ctx, client, opts)
+	if err != nil {
+		t.Fatalf("SystemPrune() error = %v", err)
+	}
+
+	for _, tt := range []struct {
+		kind       string
+		candidates []PruneCandidate
+		report     PruneReport
+	}{
+		{"containers", preview.Containers, report.Containers},
+		{"networks", preview.Networks, report.Networks},
+		{"volumes", preview.Volumes, report.Volumes},
+		{"images", preview.Images, report.Images},
+	} {
+		if len(tt.candidates) == 0 {
+			t.Errorf("the mock should have %s to prune", tt.kind)
+		}
+		if tt.report.ItemsDeleted != len(tt.candidates) {
+			t.Errorf("%s deleted = %d, want the %d previewed", tt.kind, tt.report.ItemsDeleted, len(tt.candidates))
+		}
+	}
+	if report.BuildCache.ItemsDeleted == 0 || report.BuildCacheSkipped {
+		t.Errorf("BuildCache = %+v, want unused records pruned", report.BuildCache)
+	}
+
+	want := report.Containers.SpaceReclaimed + report.Networks.SpaceReclaimed + report.Volumes.SpaceReclaimed +
+		report.Images.SpaceReclaimed + report.BuildCache.SpaceReclaimed
+	if got := report.SpaceReclaimed(); got != want || got == 0 {
+		t.Errorf("SpaceReclaimed() = %d, want %d", got, want)
+	}
+
+	again, err := PreviewSystemPrune(ctx, client, opts)
+	if err != nil {
+		t.Fatalf("PreviewSystemPrune() error = %v", err)
+	}
+	if n := len(again.Containers) + len(again.Networks) + len(again.Volumes) + len(again.Images); n != 0 {
+		t.Errorf("PreviewSystemPrune() after the prune = %+v, want nothing left", again)
+	}
+}
+
+func TestSystemPruneSkipsBuildCacheWithLabels(t *testing.T) {
+	client := NewMockClient()
+	defer client.Close()
+
+	report, err := SystemPrune(context.Background(), client, PruneOptions{Labels: []string{"env=test"}})
+	if err != nil {
+		t.Fatalf("SystemPrune() error = %v", err)
+	}
+	if !report.BuildCacheSkipped || report.BuildCache.ItemsDeleted != 0 {
+		t.Errorf("BuildCache = %+v skipped=%t, want the build cache left alone", report.BuildCache,
+			report.BuildCacheSkipped)
+	}
+}
+
+func TestPruneOptionsBuildCacheOptions(t *testing.T) {
+	now := time.Date(2024, 5, 10, 12, 0, 0, 0, time.UTC)
+	got, err := PruneOptions{All: true, Until: "2024-05-09T12:00:00Z"}.buildCacheOptions(now)
+	if err != nil {
+		t.Fatalf("buildCacheOptions() error = %v", err)
+	}
+	if want := (BuildCachePruneOptions{All: true, Until: "24h0m0s"}); got != want {
+		t.Errorf("buildCacheOptions() = %+v, want %+v", got, want)
+	}
+	if _, err := (PruneOptions{Until: "soon"}).buildCacheOptions(now); err == nil {
+		t.Error("buildCacheOptions() should reject an invalid until")
+	}
+}
+
+func TestPruneCandidatesSkipsUnknownAge(t *testing.T) {
+	now := time.Now()
+	objects := []pruneObject{
+		{ID: "old", Created: now.Add(-48 * time.Hour)},
+		{ID: "unknown"},
+	}
+
+	candidates, err := pruneCandidates(objects, PruneOptions{Until: "24h"}, now)
+	if err != nil {
+		t.Fatalf("pruneCandidates() error = %v", err)
+	}
+	want := []PruneCandidate{{ID: "old"}, {ID: "unknown", Skipped: skippedUnknownAge}}
+	if !slices.Equal(candidates, want) {
+		t.Errorf("pruneCandidates() = %v, want %v", candidates, want)
+	}
+
+	candidates, err = pruneCandidates(objects, PruneOptions{}, now)
+	if err != nil {
+		t.Fatalf("pruneCandidates() error = %v", err)
+	}
+	if len(candidates) != 2 || candidates[1].Skipped != "" {
+		t.Errorf("pruneCandidates() = %v, want every object removed without until", candidates)
+	}
+}
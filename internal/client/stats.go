@@ -0,0 +1,227 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+)
+
+const percentFactor = 100.0 // multiplier to convert a ratio to a percentage
+
+// ContainerStats is a sample of the resource usage of a container. The CPU,
+// network and block IO counters are cumulative; RatesSince turns two samples
+// into rates.
+type ContainerStats struct {
+	// Read is when the daemon took the sample.
+	Read time.Time
+	// CPUPercent is the CPU usage since the sample the daemon took before
+	// this one. It is zero for one-shot samples, which come without it.
+	CPUPercent float64
+	CPUTotal   uint64 // CPU time used by the container, in nanoseconds
+	SystemCPU  uint64 // CPU time of the host, in nanoseconds
+	OnlineCPUs uint32
+
+	// CPUPeriods counts the enforcement periods of the CPU quota, and
+	// ThrottledPeriods those in which the container used it up and had to
+	// wait. Both are zero without a quota.
+	CPUPeriods       uint64
+	ThrottledPeriods uint64
+	ThrottledTime    uint64 // time spent throttled, in nanoseconds
+
+	// MemoryUsage leaves out the page cache.
+	MemoryUsage uint64
+	MemoryLimit uint64
+	// MemoryCache is the page cache, MemoryRSS the anonymous memory and
+	// MemorySwap the swap used. The daemon only reports swap on cgroup v1.
+	MemoryCache uint64
+	MemoryRSS   uint64
+	MemorySwap  uint64
+
+	// NetworkRx, NetworkTx, BlockRead and BlockWrite add up every network
+	// interface and block device.
+	NetworkRx  uint64
+	NetworkTx  uint64
+	BlockRead  uint64
+	BlockWrite uint64
+	// Networks are the counters of each network interface, by name, with the
+	// bytes received as In and sent as Out.
+	Networks map[string]IOCounters
+	// BlockDevices are the counters of each block device, by "major:minor",
+	// with the bytes read as In and written as Out.
+	BlockDevices map[string]IOCounters
+
+	PIDs uint64
+	// PIDsLimit is the maximum number of PIDs, or 0 without a limit.
+	PIDsLimit uint64
+}
+
+// IOCounters are the cumulative bytes in and out of a network interface or
+// block device.
+type IOCounters struct {
+	In  uint64
+	Out uint64
+}
+
+// IORates are the bytes per second in and out of a network interface or
+// block device.
+type IORates struct {
+	In  float64
+	Out float64
+}
+
+// MemoryPercent returns the memory usage as a percentage of the limit.
+func (s ContainerStats) MemoryPercent() float64 {
+	if s.MemoryLimit == 0 {
+		return 0
+	}
+	return float64(s.MemoryUsage) / float64(s.MemoryLimit) * percentFactor
+}
+
+// StatsRates are the rates of a container between two stats samples.
+type StatsRates struct {
+	CPUPercent float64
+	// ThrottledPercent is the share of the CPU quota periods in which the
+	// container was throttled.
+	ThrottledPercent float64
+	// The network and block IO rates are in bytes per second.
+	NetworkRx    float64
+	NetworkTx    float64
+	BlockRead    float64
+	BlockWrite   float64
+	Networks     map[string]IORates
+	BlockDevices map[string]IORates
+}
+
+// RatesSince returns the rates between prev and s, an older sample of the
+// same container. Counters that went down, as after a restart, count as zero.
+func (s ContainerStats) RatesSince(prev ContainerStats) StatsRates {
+	var rates StatsRates
+	if s.SystemCPU > prev.SystemCPU && s.CPUTotal >= prev.CPUTotal {
+		rates.CPUPercent = cpuPercent(s.CPUTotal-prev.CPUTotal, s.SystemCPU-prev.SystemCPU, s.OnlineCPUs)
+	}
+	if s.CPUPeriods > prev.CPUPeriods && s.ThrottledPeriods >= prev.ThrottledPeriods {
+		rates.ThrottledPercent = float64(s.ThrottledPeriods-prev.ThrottledPeriods) /
+			float64(s.CPUPeriods-prev.CPUPeriods) * percentFactor
+	}
+	elapsed := s.Read.Sub(prev.Read).Seconds()
+	if elapsed <= 0 {
+		return rates
+	}
+	perSecond := func(cur, old uint64) float64 {
+		if cur < old {
+			return 0
+		}
+		return float64(cur-old) / elapsed
+	}
+	rates.NetworkRx = perSecond(s.NetworkRx, prev.NetworkRx)
+	rates.NetworkTx = perSecond(s.NetworkTx, prev.NetworkTx)
+	rates.BlockRead = perSecond(s.BlockRead, prev.BlockRead)
+	rates.BlockWrite = perSecond(s.BlockWrite, prev.BlockWrite)
+	ioRates := func(cur, old map[string]IOCounters) map[string]IORates {
+		if len(cur) == 0 {
+			return nil
+		}
+		r := make(map[string]IORates, len(cur))
+		for name, c := range cur {
+			o := old[name]
+			r[name] = IORates{In: perSecond(c.In, o.In), Out: perSecond(c.Out, o.Out)}
+		}
+		return r
+	}
+	rates.Networks = ioRates(s.Networks, prev.Networks)
+	rates.BlockDevices = ioRates(s.BlockDevices, prev.BlockDevices)
+	return rates
+}
+
+func cpuPercent(cpuDelta, systemDelta uint64, onlineCPUs uint32) float64 {
+	if systemDelta == 0 {
+		return 0
+	}
+	return float64(cpuDelta) / float64(systemDelta) * float64(onlineCPUs) * percentFactor
+}
+
+// DecodeStats decodes a stats frame as sent by the daemon.
+func DecodeStats(data []byte) (ContainerStats, error) {
+	var frame container.StatsResponse
+	if err := json.Unmarshal(data, &frame); err != nil {
+		return ContainerStats{}, err
+	}
+	return statsFromResponse(frame), nil
+}
+
+func statsFromResponse(frame container.StatsResponse) ContainerStats {
+	stats := ContainerStats{
+		Read:             frame.Read,
+		CPUTotal:         frame.CPUStats.CPUUsage.TotalUsage,
+		SystemCPU:        frame.CPUStats.SystemUsage,
+		OnlineCPUs:       frame.CPUStats.OnlineCPUs,
+		CPUPeriods:       frame.CPUStats.ThrottlingData.Periods,
+		ThrottledPeriods: frame.CPUStats.ThrottlingData.ThrottledPeriods,
+		ThrottledTime:    frame.CPUStats.ThrottlingData.ThrottledTime,
+		MemoryUsage:      frame.MemoryStats.Usage,
+		MemoryLimit:      frame.MemoryStats.Limit,
+		MemorySwap:       frame.MemoryStats.Stats["swap"],
+		PIDs:             frame.PidsStats.Current,
+		PIDsLimit:        frame.PidsStats.Limit,
+	}
+	if stats.PIDsLimit == math.MaxUint64 {
+		stats.PIDsLimit = 0
+	}
+	if stats.OnlineCPUs == 0 {
+		stats.OnlineCPUs = uint32(len(frame.CPUStats.CPUUsage.PercpuUsage)) //nolint:gosec // a CPU count
+	}
+	if frame.PreCPUStats.SystemUsage > 0 && stats.SystemCPU > frame.PreCPUStats.SystemUsage &&
+		stats.CPUTotal >= frame.PreCPUStats.CPUUsage.TotalUsage {
+		stats.CPUPercent = cpuPercent(
+			stats.CPUTotal-frame.PreCPUStats.CPUUsage.TotalUsage,
+			stats.SystemCPU-frame.PreCPUStats.SystemUsage,
+			stats.OnlineCPUs,
+		)
+	}
+	// cgroup v1 reports cache and rss, cgroup v2 file and anon.
+	stats.MemoryCache = memoryStat(frame.MemoryStats.Stats, "cache", "file")
+	stats.MemoryRSS = memoryStat(frame.MemoryStats.Stats, "rss", "anon")
+	if stats.MemoryCache < stats.MemoryUsage {
+		stats.MemoryUsage -= stats.MemoryCache
+	}
+	for name, network := range frame.Networks {
+		if stats.Networks == nil {
+			stats.Networks = make(map[string]IOCounters, len(frame.Networks))
+		}
+		stats.Networks[name] = IOCounters{In: network.RxBytes, Out: network.TxBytes}
+		stats.NetworkRx += network.RxBytes
+		stats.NetworkTx += network.TxBytes
+	}
+	for _, entry := range frame.BlkioStats.IoServiceBytesRecursive {
+		device := fmt.Sprintf("%d:%d", entry.Major, entry.Minor)
+		counters := stats.BlockDevices[device]
+		switch entry.Op {
+		case "read", "Read":
+			stats.BlockRead += entry.Value
+			counters.In += entry.Value
+		case "write", "Write":
+			stats.BlockWrite += entry.Value
+			counters.Out += entry.Value
+		default:
+			continue
+		}
+		if stats.BlockDevices == nil {
+			stats.BlockDevices = map[string]IOCounters{}
+		}
+		stats.BlockDevices[device] = counters
+	}
+	return stats
+}
+
+// memoryStat returns the first of keys found in the memory stats.
+func memoryStat(stats map[string]uint64, keys ...string) uint64 {
+	for _, key := range keys {
+		if v, ok := stats[key]; ok {
+			return v
+		}
+	}
+	return 0
+}
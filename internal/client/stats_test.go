@@ -0,0 +1,97 @@
+package client
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestDecodeStats(t *testing.T) {
+	stats, err := DecodeStats([]byte(`{
+		"read": "2024-03-10T09:30:00Z",
+		"cpu_stats": {
+			"cpu_usage": {"total_usage": 200000000}, "system_cpu_usage": 20000000000, "online_cpus": 4,
+			"throttling_data": {"periods": 40, "throttled_periods": 10, "throttled_time": 5000}
+		},
+		"precpu_stats": {"cpu_usage": {"total_usage": 100000000}, "system_cpu_usage": 10000000000},
+		"memory_stats": {"usage": 600, "limit": 1000, "stats": {"cache": 100, "rss": 450, "swap": 30}},
+		"pids_stats": {"current": 7, "limit": 100},
+		"networks": {"eth0": {"rx_bytes": 10, "tx_bytes": 20}, "eth1": {"rx_bytes": 1, "tx_bytes": 2}},
+		"blkio_stats": {"io_service_bytes_recursive": [
+			{"major": 8, "minor": 0, "op": "read", "value": 300},
+			{"major": 8, "minor": 0, "op": "write", "value": 400},
+			{"major": 8, "minor": 16, "op": "Read", "value": 5},
+			{"major": 8, "minor": 16, "op": "Total", "value": 5}
+		]}
+	}`))
+	if err != nil {
+		t.Fatalf("DecodeStats() error = %v", err)
+	}
+	want := ContainerStats{
+		Read:             time.Date(2024, 3, 10, 9, 30, 0, 0, time.UTC),
+		CPUPercent:       4,
+		CPUTotal:         200000000,
+		SystemCPU:        20000000000,
+		OnlineCPUs:       4,
+		CPUPeriods:       40,
+		ThrottledPeriods: 10,
+		ThrottledTime:    5000,
+		MemoryUsage:      500,
+		MemoryLimit:      1000,
+		MemoryCache:      100,
+		MemoryRSS:        450,
+		MemorySwap:       30,
+		NetworkRx:        11,
+		NetworkTx:        22,
+		BlockRead:        305,
+		BlockWrite:       400,
+		Networks:         map[string]IOCounters{"eth0": {In: 10, Out: 20}, "eth1": {In: 1, Out: 2}},
+		BlockDevices:     map[string]IOCounters{"8:0": {In: 300, Out: 400}, "8:16": {In: 5}},
+		PIDs:             7,
+		PIDsLimit:        100,
+	}
+	if !reflect.DeepEqual(stats, want) {
+		t.Errorf("DecodeStats() = %+v, want %+v", stats, want)
+	}
+	if got := stats.MemoryPercent(); got != 50 {
+		t.Errorf("MemoryPercent() = %v, want 50", got)
+	}
+}
+
+func TestContainerStatsRatesSince(t *testing.T) {
+	start := time.Date(2024, 3, 10, 9, 30, 0, 0, time.UTC)
+	prev := ContainerStats{
+		Read:       start,
+		CPUTotal:   1e9,
+		SystemCPU:  100e9,
+		OnlineCPUs: 2,
+		NetworkRx:  1000,
+		BlockWrite: 500,
+		CPUPeriods: 10,
+		Networks:   map[string]IOCounters{"eth0": {In: 1000}},
+	}
+	cur := prev
+	cur.Read = start.Add(2 * time.Second)
+	cur.CPUTotal += 1e9
+	cur.SystemCPU += 4e9
+	cur.NetworkRx += 4096
+	cur.BlockWrite = 0 // restarted
+	cur.CPUPeriods += 20
+	cur.ThrottledPeriods += 5
+	cur.Networks = map[string]IOCounters{"eth0": {In: 5096, Out: 10}, "eth1": {In: 2}}
+
+	got := cur.RatesSince(prev)
+	want := StatsRates{
+		CPUPercent:       50,
+		ThrottledPercent: 25,
+		NetworkRx:        2048,
+		Networks:         map[string]IORates{"eth0": {In: 2048, Out: 5}, "eth1": {In: 1}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("RatesSince() = %+v, want %+v", got, want)
+	}
+	prev.Networks = nil
+	if got := prev.RatesSince(prev); !reflect.DeepEqual(got, StatsRates{}) {
+		t.Errorf("RatesSince(itself) = %+v, want zero rates", got)
+	}
+}
@@ -33,11 +33,44 @@ type Container struct {
 	Labels     map[string]string
 	Health     *HealthInfo
 
+	// Lifecycle
+	ExitCode     int
+	OOMKilled    bool
+	RestartCount int
+	StartedAt    time.Time // zero when the container never started
+	FinishedAt   time.Time // zero when the container never exited
+	Error        string    // daemon error from the last start attempt
+
 	// Resource limits
 	MemoryLimit   int64  // bytes; 0 = unlimited
 	CPUShares     int64  // relative weight; 0 = default (1024)
 	RestartPolicy string // e.g. "no", "always", "unless-stopped", "on-failure:3"
 	Privileged    bool
+
+	// Size is the size of the writable layer in bytes, as listed.
+	Size int64
+}
+
+// crashLoopRestarts is the restart count from which a failing container is
+// considered to be crash looping.
+const crashLoopRestarts = 3
+
+// CrashLooping reports whether the container keeps failing and being
+// restarted by its restart policy.
+func (c Container) CrashLooping() bool {
+	if c.RestartCount < crashLoopRestarts {
+		return false
+	}
+	return c.State == StateRestarting || (c.State == StateStopped && c.ExitCode != 0)
+}
+
+// Process is a single process running inside a container, as reported by top.
+type Process struct {
+	PID     int
+	User    string
+	CPU     float64 // percentage of a single CPU
+	Memory  float64 // percentage of host memory
+	Command string
 }
 
 type FileNode struct {
@@ -58,6 +91,42 @@ type HealthInfo struct {
 	FailingStreak int
 	LastCheck     time.Time
 	Output        string
+	// Log holds the most recent probe results kept by the daemon, oldest first.
+	Log []HealthProbe
+	// Config is the configured healthcheck, nil when the container has none.
+	Config *HealthcheckConfig
+}
+
+// HealthProbe is the result of a single healthcheck run.
+type HealthProbe struct {
+	Start    time.Time
+	End      time.Time
+	ExitCode int // 0 healthy, 1 unhealthy, anything else is an error running the probe
+	Output   string
+}
+
+// HealthcheckConfig describes how the daemon probes a container. Zero
+// durations and retries mean the daemon defaults apply.
+type HealthcheckConfig struct {
+	// Test is the probe as configured, e.g. ["CMD-SHELL", "curl -f localhost"].
+	Test        []string
+	Interval    time.Duration
+	Timeout     time.Duration
+	StartPeriod time.Duration
+	Retries     int
+}
+
+// Command returns the probe command as it would be typed in a shell.
+func (h HealthcheckConfig) Command() string {
+	if len(h.Test) == 0 {
+		return ""
+	}
+	switch h.Test[0] {
+	case "CMD", "CMD-SHELL":
+		return strings.Join(h.Test[1:], " ")
+	default:
+		return strings.Join(h.Test, " ")
+	}
 }
 
 type HealthStatus string
@@ -138,6 +207,7 @@ type Volume struct {
 	Size      int64
 	Created   time.Time
 	UsedCount int
+	Labels    map[string]string
 }
 
 // NetworkIPAM holds IPAM configuration for a network.
@@ -164,6 +234,7 @@ type Network struct {
 	Created             time.Time
 	ConnectedContainers []NetworkContainer
 	IPAM                NetworkIPAM
+	Labels              map[string]string
 }
 
 // ComposeProject represents a Docker Compose project detected from container labels.
@@ -177,9 +248,10 @@ type ComposeProject struct {
 
 // ComposeServiceInfo holds information about a single service within a Compose project.
 type ComposeServiceInfo struct {
-	Name  string
-	State string
-	Image string
+	Name        string
+	State       string
+	Image       string
+	ContainerID string
 }
 
 func (p ComposeProject) Identity() string {
@@ -197,29 +269,130 @@ type PruneReport struct {
 // All=true for volumes includes named unused volumes in addition to anonymous ones.
 type PruneOptions struct {
 	All bool
+	// Until only removes the objects created before this time, as a duration
+	// ago ("24h") or a timestamp.
+	Until string
+	// Labels only removes the objects that have every one of these labels,
+	// given as "key" or "key=value".
+	Labels []string
+	// ExcludeLabels keeps the objects that have any of these labels, given as
+	// "key" or "key=value".
+	ExcludeLabels []string
+}
+
+// PruneCandidate is an object a prune with the same options would remove,
+// or keep when Skipped is set.
+type PruneCandidate struct {
+	ID   string
+	Name string
+	// Size is the space removing the object would free, 0 when unknown.
+	Size int64
+	// Skipped is why the prune keeps the object although it is otherwise
+	// eligible, empty when the prune removes it.
+	Skipped string
+}
+
+// BuildCachePruneOptions controls which build cache records a prune removes.
+type BuildCachePruneOptions struct {
+	// All removes every unused record, not only the dangling ones.
+	All bool
+	// Until only removes the records created before this time, as a duration
+	// ago ("24h") or a timestamp.
+	Until string
+	// KeepStorage is the amount of build cache, in bytes, left after the
+	// prune. 0 keeps none.
+	KeepStorage int64
+}
+
+// SystemPrunePreview lists, per object type, what a system prune with the
+// same options would remove. The build cache has no dry-run.
+type SystemPrunePreview struct {
+	Containers []PruneCandidate
+	Networks   []PruneCandidate
+	Volumes    []PruneCandidate
+	Images     []PruneCandidate
 }
 
-// LogOptions configures log streaming.
+// SystemPruneReport summarises a system prune per object type.
+type SystemPruneReport struct {
+	Containers PruneReport
+	Networks   PruneReport
+	Volumes    PruneReport
+	Images     PruneReport
+	BuildCache PruneReport
+	// BuildCacheSkipped is set when Labels kept the build cache, whose records
+	// have no labels, out of the prune.
+	BuildCacheSkipped bool
+}
+
+// SpaceReclaimed is the space freed by the system prune, in bytes.
+func (r SystemPruneReport) SpaceReclaimed() uint64 {
+	return r.Containers.SpaceReclaimed + r.Networks.SpaceReclaimed + r.Volumes.SpaceReclaimed +
+		r.Images.SpaceReclaimed + r.BuildCache.SpaceReclaimed
+}
+
+// DiskUsageKind is the type of object a DiskUsageEntry accounts for.
+type DiskUsageKind string
+
+const (
+	DiskUsageImage      DiskUsageKind = "image"
+	DiskUsageContainer  DiskUsageKind = "container"
+	DiskUsageVolume     DiskUsageKind = "volume"
+	DiskUsageBuildCache DiskUsageKind = "build cache"
+)
+
+// DiskUsageEntry is the disk space used by an image, a container, a volume or
+// a build cache record.
+type DiskUsageEntry struct {
+	Kind DiskUsageKind
+	// ID identifies the entry to remove it: the image or container ID, the
+	// volume name or the build cache record ID.
+	ID   string
+	Name string
+	// Size is the space used by the entry. For images it includes the layers
+	// shared with other images, and for containers only their writable layer.
+	Size int64
+	// Reclaimable is the space removing the entry frees: the layers unique to
+	// an unused image, the writable layer of a stopped container, an unused
+	// volume or a build cache record not in use.
+	Reclaimable int64
+	InUse       bool
+}
+
+// DiskUsageReport breaks down the disk space used by the daemon.
+type DiskUsageReport struct {
+	LayersSize int64
+	Entries    []DiskUsageEntry
+}
+
+// LogOptions configures log streaming. Since and Until take any time accepted
+// by ParseLogTime, such as "2h" or "yesterday 14:00".
 type LogOptions struct {
 	Follow     bool
 	Tail       string
 	Timestamps bool
 	Since      string
+	Until      string
 }
 
-type LogsSession struct {
-	Reader io.ReadCloser
-	closer func()
-}
+// LogStream identifies the output stream a log line was written to.
+type LogStream string
 
-func NewLogsSession(reader io.ReadCloser, closer func()) *LogsSession {
-	return &LogsSession{Reader: reader, closer: closer}
-}
+const (
+	LogStreamStdout LogStream = "stdout"
+	LogStreamStderr LogStream = "stderr"
+)
 
-func (e *LogsSession) Close() {
-	if e.closer != nil {
-		e.closer()
-	}
+// LogLine is a single line of container output.
+type LogLine struct {
+	Stream LogStream
+	// Timestamp is when the daemon received the line. It is only set when
+	// the logs were requested with LogOptions.Timestamps.
+	Timestamp time.Time
+	Content   string
+	// Source names the container that wrote the line in a session merged by
+	// MergeLogsSessions. It is empty otherwise.
+	Source string
 }
 
 // ExecSession represents an interactive exec session inside a container.
@@ -239,6 +412,17 @@ func (e *ExecSession) Close() {
 	}
 }
 
+// ContainerEvent is a change of a container reported by the daemon, such as
+// "start", "die" or "health_status: unhealthy".
+type ContainerEvent struct {
+	ID     string
+	Name   string
+	Action string
+	Time   time.Time
+	// ExitCode is the exit code of the container on "die" events.
+	ExitCode int
+}
+
 // StatsSession represents an interactive exec session inside a container.
 type StatsSession struct {
 	Reader io.ReadCloser
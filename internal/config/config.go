@@ -1,13 +1,20 @@
 package config
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"io/fs"
+	"net/url"
 	"os"
 	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/BurntSushi/toml"
+	"github.com/docker/go-units"
 )
 
 // Config holds all docker-dash configuration.
@@ -17,6 +24,14 @@ type Config struct {
 	Debug       DebugConfig       `toml:"debug"`
 	UpdateCheck UpdateCheckConfig `toml:"update_check"`
 	Logs        LogsConfig        `toml:"logs"`
+	Stop        StopConfig        `toml:"stop"`
+	Stats       StatsConfig       `toml:"stats"`
+	Alerts      AlertsConfig      `toml:"alerts"`
+	Tables      TablesConfig      `toml:"tables"`
+
+	// Path is the file the config was loaded from, where the settings
+	// changed from the UI are saved.
+	Path string `toml:"-"`
 }
 
 // DockerConfig holds Docker client connection settings.
@@ -54,14 +69,452 @@ type LogsConfig struct {
 	Timestamps bool `toml:"timestamps"`
 	// Since shows logs since a relative duration or timestamp (e.g. "2h", "10m").
 	Since string `toml:"since"`
+	// Fields maps structured (JSON or logfmt) log keys to the columns shown
+	// in the Logs panel.
+	Fields LogFieldsConfig `toml:"fields"`
+	// Retention bounds the log lines the Logs panel keeps in memory.
+	Retention LogRetentionConfig `toml:"retention"`
+}
+
+// LogRetentionConfig bounds the log lines the Logs panel keeps in memory.
+// Once a limit is reached the oldest lines are dropped, or moved to a
+// temporary file when Spill is set so they can still be scrolled back to.
+type LogRetentionConfig struct {
+	// MaxLines is the number of lines kept in memory. 0 means no limit.
+	MaxLines int `toml:"max_lines"`
+	// MaxBytes is the size of the lines kept in memory (e.g. "64MB"). Empty
+	// means no limit.
+	MaxBytes string `toml:"max_bytes"`
+	// Spill moves the lines over the limits to a temporary file instead of
+	// dropping them.
+	Spill bool `toml:"spill"`
+}
+
+// Bytes returns MaxBytes in bytes, or 0 when it is empty.
+func (c LogRetentionConfig) Bytes() (int64, error) {
+	if c.MaxBytes == "" {
+		return 0, nil
+	}
+	n, err := units.RAMInBytes(c.MaxBytes)
+	if err != nil {
+		return 0, fmt.Errorf("invalid logs max_bytes %q: %w", c.MaxBytes, err)
+	}
+	return n, nil
+}
+
+// Validate reports limits that are negative or not valid sizes.
+func (c LogRetentionConfig) Validate() error {
+	if c.MaxLines < 0 {
+		return fmt.Errorf("invalid logs max_lines %d: must not be negative", c.MaxLines)
+	}
+	n, err := c.Bytes()
+	if err != nil {
+		return err
+	}
+	if n < 0 {
+		return fmt.Errorf("invalid logs max_bytes %q: must not be negative", c.MaxBytes)
+	}
+	return nil
+}
+
+// LogFieldsConfig lists, for each column of a structured log line, the keys
+// that may hold it. The first key present in a line wins. Nested JSON keys
+// are written with dots, e.g. "log.level".
+type LogFieldsConfig struct {
+	Level     []string `toml:"level"`
+	Message   []string `toml:"message"`
+	Timestamp []string `toml:"timestamp"`
+}
+
+// StopConfig holds the defaults used when stopping or restarting containers.
+// Blank values leave the choice to the container's own configuration.
+type StopConfig struct {
+	// Timeout is the grace period before the container is killed (e.g. "30s").
+	// "0s" kills the container right after the stop signal.
+	Timeout string `toml:"timeout"`
+	// Signal is sent to stop the container (e.g. "SIGINT").
+	Signal string `toml:"signal"`
+	// Rules override Timeout and Signal for matching containers.
+	Rules []StopRule `toml:"rules"`
+}
+
+// StopRule overrides the stop defaults for containers matched by name or
+// label. Blank Timeout or Signal values keep the inherited default.
+type StopRule struct {
+	// Container matches a container by name.
+	Container string `toml:"container"`
+	// Label matches containers carrying a label, as "key" or "key=value".
+	Label   string `toml:"label"`
+	Timeout string `toml:"timeout"`
+	Signal  string `toml:"signal"`
+}
+
+// Defaults returns the stop timeout and signal for a container. Label rules
+// override the global values and container rules override label rules; within
+// each kind, later rules win.
+func (c StopConfig) Defaults(name string, labels map[string]string) (timeout, signal string) {
+	timeout, signal = c.Timeout, c.Signal
+	apply := func(rule StopRule) {
+		if rule.Timeout != "" {
+			timeout = rule.Timeout
+		}
+		if rule.Signal != "" {
+			signal = rule.Signal
+		}
+	}
+	for _, rule := range c.Rules {
+		if rule.Container == "" && rule.matchesLabel(labels) {
+			apply(rule)
+		}
+	}
+	for _, rule := range c.Rules {
+		if rule.Container != "" && rule.Container == name {
+			apply(rule)
+		}
+	}
+	return timeout, signal
 }
 
+func (r StopRule) matchesLabel(labels map[string]string) bool {
+	return r.Label != "" && hasLabel(labels, r.Label)
+}
+
+// hasLabel reports whether labels hold label, written as "key" or
+// "key=value".
+func hasLabel(labels map[string]string, label string) bool {
+	key, value, hasValue := strings.Cut(label, "=")
+	got, ok := labels[key]
+	return ok && (!hasValue || got == value)
+}
+
+// Validate reports timeouts that are not valid durations and rules that do not
+// say which containers they apply to.
+func (c StopConfig) Validate() error {
+	if err := validateStopTimeout(c.Timeout); err != nil {
+		return err
+	}
+	for i, rule := range c.Rules {
+		if (rule.Container == "") == (rule.Label == "") {
+			return fmt.Errorf("stop rule %d: set exactly one of container or label", i+1)
+		}
+		if err := validateStopTimeout(rule.Timeout); err != nil {
+			return fmt.Errorf("stop rule %d: %w", i+1, err)
+		}
+	}
+	return nil
+}
+
+func validateStopTimeout(timeout string) error {
+	if timeout == "" {
+		return nil
+	}
+	d, err := time.ParseDuration(timeout)
+	if err != nil {
+		return fmt.Errorf("invalid stop timeout %q: %w", timeout, err)
+	}
+	if d < 0 {
+		return fmt.Errorf("invalid stop timeout %q: must not be negative", timeout)
+	}
+	return nil
+}
+
+// Values of StatsConfig.ListColumns.
+const (
+	StatsColumnsOff        = ""
+	StatsColumnsValues     = "values"
+	StatsColumnsSparklines = "sparklines"
+)
+
+// StatsConfig holds the settings of the container resource usage shown in the
+// container list and recorded for the Stats panel.
+type StatsConfig struct {
+	// ListColumns shows the CPU and memory usage of running containers in the
+	// container list, as "values" or "sparklines". Empty hides them.
+	ListColumns string `toml:"list_columns"`
+	// CPUThreshold tints containers using more CPU, in percent of one CPU.
+	// 0 disables it.
+	CPUThreshold float64 `toml:"cpu_threshold"`
+	// MemoryThreshold tints containers using more memory, in percent of their
+	// limit. 0 disables it.
+	MemoryThreshold float64 `toml:"memory_threshold"`
+	// History is the usage of each container recorded in the background for
+	// the Stats panel charts.
+	History StatsHistoryConfig `toml:"history"`
+}
+
+// StatsHistoryConfig bounds the usage history recorded for each container.
+type StatsHistoryConfig struct {
+	// Window is how long the samples of a container are kept (e.g. "1h").
+	// Empty keeps an hour.
+	Window string `toml:"window"`
+	// Path persists the samples to a file, so the history survives restarts.
+	// Empty keeps them in memory only.
+	Path string `toml:"path"`
+}
+
+// defaultStatsHistoryWindow is the usage history kept when no window is set.
+const defaultStatsHistoryWindow = time.Hour
+
+// Enabled reports whether the usage history is recorded in the background,
+// which takes a [stats.history] window or path.
+func (c StatsHistoryConfig) Enabled() bool {
+	return c.Window != "" || c.Path != ""
+}
+
+// Duration returns Window as a duration, or an hour when it is empty.
+func (c StatsHistoryConfig) Duration() (time.Duration, error) {
+	if c.Window == "" {
+		return defaultStatsHistoryWindow, nil
+	}
+	d, err := time.ParseDuration(c.Window)
+	if err != nil {
+		return 0, fmt.Errorf("invalid stats history window %q: %w", c.Window, err)
+	}
+	if d <= 0 {
+		return 0, fmt.Errorf("invalid stats history window %q: must be positive", c.Window)
+	}
+	return d, nil
+}
+
+// Enabled reports whether the container list needs the usage of running
+// containers, to show it or to tint containers over a threshold.
+func (c StatsConfig) Enabled() bool {
+	return c.ListColumns != StatsColumnsOff || c.CPUThreshold > 0 || c.MemoryThreshold > 0
+}
+
+// Validate reports unknown list columns, negative thresholds and history
+// windows that are not positive durations.
+func (c StatsConfig) Validate() error {
+	switch c.ListColumns {
+	case StatsColumnsOff, StatsColumnsValues, StatsColumnsSparklines:
+	default:
+		return fmt.Errorf("invalid stats list_columns %q: use %q or %q",
+			c.ListColumns, StatsColumnsValues, StatsColumnsSparklines)
+	}
+	if c.CPUThreshold < 0 {
+		return fmt.Errorf("invalid stats cpu_threshold %v: must not be negative", c.CPUThreshold)
+	}
+	if c.MemoryThreshold < 0 {
+		return fmt.Errorf("invalid stats memory_threshold %v: must not be negative", c.MemoryThreshold)
+	}
+	_, err := c.History.Duration()
+	return err
+}
+
+// Kinds of alert conditions.
+const (
+	AlertCPU       = "cpu"
+	AlertMemory    = "memory"
+	AlertUnhealthy = "unhealthy"
+	AlertRestarted = "restarted"
+	AlertExited    = "exited"
+)
+
+// AlertsConfig holds the alert rules evaluated in the background and where
+// alerts are sent besides the Alerts panel.
+type AlertsConfig struct {
+	// Desktop shows a desktop notification for each alert, with notify-send
+	// on Linux and osascript on macOS.
+	Desktop bool `toml:"desktop"`
+	// Webhook receives each alert as a JSON POST request. Empty disables it.
+	Webhook string      `toml:"webhook"`
+	Rules   []AlertRule `toml:"rules"`
+}
+
+// AlertRule raises an alert for the containers it matches when its condition
+// holds. Without Container or Label it applies to every container.
+type AlertRule struct {
+	// Name labels the alerts of the rule. Empty uses When.
+	Name string `toml:"name"`
+	// When is the condition: "cpu > 90" (percent of one CPU), "memory > 80"
+	// (percent of the limit), "unhealthy", "restarted" (the restart count
+	// went up) or "exited" (a running container exited with a non-zero code).
+	// cpu and memory compare with >, >=, < or <=.
+	When string `toml:"when"`
+	// For is how long a cpu or memory condition must hold before the alert
+	// is raised (e.g. "2m"). Empty raises it on the first sample.
+	For string `toml:"for"`
+	// Container matches a container by name.
+	Container string `toml:"container"`
+	// Label matches containers carrying a label, as "key" or "key=value".
+	Label string `toml:"label"`
+}
+
+// AlertCondition is the parsed condition of an AlertRule.
+type AlertCondition struct {
+	// Kind is one of AlertCPU, AlertMemory, AlertUnhealthy, AlertRestarted
+	// and AlertExited.
+	Kind string
+	// Op and Threshold compare the usage of AlertCPU and AlertMemory.
+	Op        string
+	Threshold float64
+	For       time.Duration
+}
+
+// Holds reports whether value satisfies the comparison of a cpu or memory
+// condition.
+func (c AlertCondition) Holds(value float64) bool {
+	switch c.Op {
+	case ">":
+		return value > c.Threshold
+	case ">=":
+		return value >= c.Threshold
+	case "<":
+		return value < c.Threshold
+	case "<=":
+		return value <= c.Threshold
+	}
+	return false
+}
+
+// Title returns the name of the rule, or its condition when it has none.
+func (r AlertRule) Title() string {
+	if r.Name != "" {
+		return r.Name
+	}
+	title := strings.Join(strings.Fields(r.When), " ")
+	if r.For != "" {
+		title += " for " + r.For
+	}
+	return title
+}
+
+// Condition parses When and For.
+func (r AlertRule) Condition() (AlertCondition, error) {
+	fields := strings.Fields(r.When)
+	var cond AlertCondition
+	switch {
+	case len(fields) == 1 && (fields[0] == AlertUnhealthy || fields[0] == AlertRestarted || fields[0] == AlertExited):
+		cond.Kind = fields[0]
+		if r.For != "" {
+			return cond, fmt.Errorf("invalid alert %q: for only applies to cpu and memory", r.When)
+		}
+		return cond, nil
+	case len(fields) == 3 && (fields[0] == AlertCPU || fields[0] == AlertMemory):
+		cond.Kind, cond.Op = fields[0], fields[1]
+	default:
+		return cond, fmt.Errorf(
+			"invalid alert %q: use \"cpu > N\", \"memory > N\", %q, %q or %q",
+			r.When, AlertUnhealthy, AlertRestarted, AlertExited,
+		)
+	}
+	switch cond.Op {
+	case ">", ">=", "<", "<=":
+	default:
+		return cond, fmt.Errorf("invalid alert %q: unknown operator %q", r.When, cond.Op)
+	}
+	threshold, err := strconv.ParseFloat(strings.TrimSuffix(fields[2], "%"), 64)
+	if err != nil {
+		return cond, fmt.Errorf("invalid alert %q: %w", r.When, err)
+	}
+	cond.Threshold = threshold
+	if r.For != "" {
+		d, err := time.ParseDuration(r.For)
+		if err != nil {
+			return cond, fmt.Errorf("invalid alert for %q: %w", r.For, err)
+		}
+		if d < 0 {
+			return cond, fmt.Errorf("invalid alert for %q: must not be negative", r.For)
+		}
+		cond.For = d
+	}
+	return cond, nil
+}
+
+// Matches reports whether the rule applies to the container with name and
+// labels.
+func (r AlertRule) Matches(name string, labels map[string]string) bool {
+	if r.Container != "" && r.Container != name {
+		return false
+	}
+	return r.Label == "" || hasLabel(labels, r.Label)
+}
+
+// Validate reports rules whose condition cannot be parsed and webhooks that
+// are not HTTP URLs.
+func (c AlertsConfig) Validate() error {
+	if c.Webhook != "" {
+		u, err := url.Parse(c.Webhook)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("invalid alerts webhook %q: use an http or https URL", c.Webhook)
+		}
+	}
+	for i, rule := range c.Rules {
+		if _, err := rule.Condition(); err != nil {
+			return fmt.Errorf("alert rule %d: %w", i+1, err)
+		}
+	}
+	return nil
+}
+
+// Columns of the container and image tables.
+var (
+	ContainerTableColumns = []string{"name", "image", "status", "ports", "created", "cpu", "memory", "size"}
+	ImageTableColumns     = []string{"repo", "tag", "size", "created", "used_by"}
+)
+
+// TablesConfig holds the table layout of the sections that have one.
+type TablesConfig struct {
+	Containers TableConfig `toml:"containers"`
+	Images     TableConfig `toml:"images"`
+}
+
+// TableConfig holds the table layout of a section.
+type TableConfig struct {
+	// Enabled shows the items as table rows instead of a title and a
+	// description.
+	Enabled bool `toml:"enabled"`
+	// Columns lists the columns shown, in order. Empty shows every column.
+	Columns []string `toml:"columns"`
+	// Sort is the column the rows are sorted by. Empty keeps the order of
+	// the daemon.
+	Sort string `toml:"sort"`
+	// Descending sorts the rows from the largest value down.
+	Descending bool `toml:"descending"`
+}
+
+// Validate reports columns of the tables that do not exist.
+func (c TablesConfig) Validate() error {
+	if err := c.Containers.validate("containers", ContainerTableColumns); err != nil {
+		return err
+	}
+	return c.Images.validate("images", ImageTableColumns)
+}
+
+func (c TableConfig) validate(section string, columns []string) error {
+	for i, column := range c.Columns {
+		if !slices.Contains(columns, column) {
+			return fmt.Errorf("invalid tables.%s column %q: use one of %s",
+				section, column, strings.Join(columns, ", "))
+		}
+		if slices.Contains(c.Columns[:i], column) {
+			return fmt.Errorf("invalid tables.%s columns: %q is listed twice", section, column)
+		}
+	}
+	if c.Sort != "" && !slices.Contains(columns, c.Sort) {
+		return fmt.Errorf("invalid tables.%s sort %q: use one of %s", section, c.Sort, strings.Join(columns, ", "))
+	}
+	return nil
+}
+
+// defaultLogsMaxLines is the default number of log lines kept in memory.
+const defaultLogsMaxLines = 100_000
+
 // DefaultLogsConfig returns sensible defaults for log streaming.
 func DefaultLogsConfig() LogsConfig {
 	return LogsConfig{
 		Follow: true,
 		Tail:   "100",
 		Since:  "2h",
+		Fields: LogFieldsConfig{
+			Level:     []string{"level", "lvl", "severity", "log.level"},
+			Message:   []string{"msg", "message", "log"},
+			Timestamp: []string{"time", "ts", "timestamp", "@timestamp"},
+		},
+		Retention: LogRetentionConfig{
+			MaxLines: defaultLogsMaxLines,
+			MaxBytes: "64MB",
+		},
 	}
 }
 
@@ -77,7 +530,7 @@ func DefaultPath() string {
 // Load parses a TOML config file at path.
 // If the file does not exist, an empty Config is returned with no error.
 func Load(path string) (*Config, error) {
-	cfg := &Config{}
+	cfg := &Config{Path: path}
 	cfg.Logs = DefaultLogsConfig()
 	_, err := toml.DecodeFile(path, cfg)
 	if err != nil {
@@ -89,3 +542,252 @@ func Load(path string) (*Config, error) {
 	}
 	return cfg, nil
 }
+
+// Modes of the config file and directory created by SaveTable.
+const (
+	configFileMode = 0o600
+	configDirMode  = 0o750
+)
+
+// SaveTable writes table as the [tables.<section>] settings of the config
+// file at path, creating it when it does not exist. Only that table is
+// rewritten, in place or appended at the end, so the rest of the file and its
+// comments are kept. When the file defines the table in another form, such as
+// an inline table, it is left alone and an error is returned.
+func SaveTable(path, section string, table TableConfig) error {
+	content, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	var body bytes.Buffer
+	if err = toml.NewEncoder(&body).Encode(table); err != nil {
+		return err
+	}
+	updated := replaceTable(string(content), "tables."+section, body.String())
+
+	var saved struct {
+		Tables map[string]TableConfig `toml:"tables"`
+	}
+	if _, err = toml.Decode(updated, &saved); err != nil {
+		return fmt.Errorf("cannot update [tables.%s] in %s: %w", section, path, err)
+	}
+	if !saved.Tables[section].equal(table) {
+		return fmt.Errorf("cannot update [tables.%s] in %s: it is defined in another form", section, path)
+	}
+
+	if err = os.MkdirAll(filepath.Dir(path), configDirMode); err != nil {
+		return err
+	}
+	return replaceFile(path, []byte(updated))
+}
+
+// replaceFile writes content to a temporary file next to path and renames it
+// over path, so that a failed write leaves the previous file whole. A symbolic
+// link at path is followed, and the file keeps its permissions.
+func replaceFile(path string, content []byte) error {
+	if resolved, err := filepath.EvalSymlinks(path); err == nil {
+		path = resolved
+	}
+	mode := fs.FileMode(configFileMode)
+	if info, err := os.Stat(path); err == nil {
+		mode = info.Mode().Perm()
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name()) // fails harmlessly once renamed
+	if _, err = tmp.Write(content); err == nil {
+		err = tmp.Sync()
+	}
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Chmod(tmp.Name(), mode)
+	}
+	if err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// replaceTable replaces the keys of the [name] table of the TOML document
+// content with body, or appends the table when content has none. Comments
+// and blank lines at the end of the table are kept for the table after it.
+func replaceTable(content, name, body string) string {
+	lines := strings.SplitAfter(content, "\n")
+	headers := tableHeaders(lines)
+	start := slices.Index(headers, name)
+	if start < 0 {
+		if content != "" && !strings.HasSuffix(content, "\n") {
+			content += "\n"
+		}
+		if content != "" {
+			content += "\n"
+		}
+		return content + "[" + name + "]\n" + body
+	}
+
+	end := len(lines)
+	for i := start + 1; i < len(lines); i++ {
+		if headers[i] != "" {
+			end = i
+			break
+		}
+	}
+	for end > start+1 && isBlankOrComment(lines[end-1]) {
+		end--
+	}
+	head := strings.Join(lines[:start+1], "")
+	if !strings.HasSuffix(head, "\n") {
+		head += "\n"
+	}
+	return head + body + strings.Join(lines[end:], "")
+}
+
+// tableHeaders returns, for each of lines, the name of the table the line
+// opens, or "" when it opens none. Lines inside a multi-line array or string
+// value open no table, whatever they look like.
+func tableHeaders(lines []string) []string {
+	headers := make([]string, len(lines))
+	var value tomlValueScanner
+	for i, line := range lines {
+		if !value.open() {
+			if header, ok := tableHeader(line); ok {
+				headers[i] = header
+				continue
+			}
+		}
+		value.scan(line)
+	}
+	return headers
+}
+
+// tableHeader returns the name of the table a [name] or [[name]] line opens,
+// without the spaces around its dots. Only a comment may follow the header.
+func tableHeader(line string) (string, bool) {
+	line = strings.TrimSpace(line)
+	open, closing := "[", "]"
+	if strings.HasPrefix(line, "[[") {
+		open, closing = "[[", "]]"
+	} else if !strings.HasPrefix(line, "[") {
+		return "", false
+	}
+	key, rest, ok := headerKey(line[len(open):])
+	if !ok || !strings.HasPrefix(rest, closing) {
+		return "", false
+	}
+	if rest = strings.TrimSpace(rest[len(closing):]); rest != "" && !strings.HasPrefix(rest, "#") {
+		return "", false
+	}
+	return key, true
+}
+
+// headerKey reads the dotted key at the start of s, up to the first "]" out
+// of quotes, and returns it without the spaces around its dots, along with
+// what follows it.
+func headerKey(s string) (string, string, bool) {
+	var parts []string
+	var part strings.Builder
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; c {
+		case '"', '\'':
+			end := closingQuote(s[i+1:], c)
+			if end < 0 {
+				return "", "", false
+			}
+			part.WriteString(s[i : i+end+2])
+			i += end + 1
+		case '.':
+			parts = append(parts, strings.TrimSpace(part.String()))
+			part.Reset()
+		case ']':
+			parts = append(parts, strings.TrimSpace(part.String()))
+			if slices.Contains(parts, "") {
+				return "", "", false
+			}
+			return strings.Join(parts, "."), s[i:], true
+		case '[', '=', '#':
+			return "", "", false
+		default:
+			part.WriteByte(c)
+		}
+	}
+	return "", "", false
+}
+
+// closingQuote returns the index in s of the quote closing a string opened
+// by quote, or -1 when s does not close it. Basic strings, opened by a double
+// quote, escape characters with a backslash.
+func closingQuote(s string, quote byte) int {
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case quote:
+			return i
+		case '\\':
+			if quote == '"' {
+				i++
+			}
+		}
+	}
+	return -1
+}
+
+// tomlValueScanner follows the values of a TOML document line by line, to
+// tell when a line continues a multi-line array or string.
+type tomlValueScanner struct {
+	// arrays is the number of arrays opened and not closed yet.
+	arrays int
+	// multiline is the delimiter of the multi-line string being read, if any.
+	multiline string
+}
+
+func (v *tomlValueScanner) open() bool {
+	return v.arrays > 0 || v.multiline != ""
+}
+
+func (v *tomlValueScanner) scan(line string) {
+	for i := 0; i < len(line); i++ {
+		if v.multiline != "" {
+			end := strings.Index(line[i:], v.multiline)
+			if end < 0 {
+				return
+			}
+			i += end + len(v.multiline) - 1
+			v.multiline = ""
+			continue
+		}
+		switch c := line[i]; c {
+		case '#':
+			return
+		case '"', '\'':
+			if delim := strings.Repeat(string(c), 3); strings.HasPrefix(line[i:], delim) {
+				v.multiline = delim
+				i += len(delim) - 1
+				continue
+			}
+			end := closingQuote(line[i+1:], c)
+			if end < 0 {
+				return
+			}
+			i += end + 1
+		case '[':
+			v.arrays++
+		case ']':
+			v.arrays = max(v.arrays-1, 0)
+		}
+	}
+}
+
+func isBlankOrComment(line string) bool {
+	line = strings.TrimSpace(line)
+	return line == "" || strings.HasPrefix(line, "#")
+}
+
+func (c TableConfig) equal(other TableConfig) bool {
+	return c.Enabled == other.Enabled &&
+		slices.Equal(c.Columns, other.Columns) &&
+		c.Sort == other.Sort &&
+		c.Descending == other.Descending
+}
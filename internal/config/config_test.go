@@ -3,7 +3,9 @@ package config_test
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/GustavoCaso/docker-dash/internal/config"
 )
@@ -182,6 +184,38 @@ func TestDefaultLogsConfig(t *testing.T) {
 	if cfg.Logs.Since != "2h" {
 		t.Errorf("default Since = %q, want %q", cfg.Logs.Since, "2h")
 	}
+	if len(cfg.Logs.Fields.Level) == 0 || cfg.Logs.Fields.Level[0] != "level" {
+		t.Errorf("default Fields.Level = %v, want it to start with %q", cfg.Logs.Fields.Level, "level")
+	}
+	if len(cfg.Logs.Fields.Message) == 0 || cfg.Logs.Fields.Message[0] != "msg" {
+		t.Errorf("default Fields.Message = %v, want it to start with %q", cfg.Logs.Fields.Message, "msg")
+	}
+}
+
+func TestLogFieldsConfigFromTOML(t *testing.T) {
+	f, err := os.CreateTemp(t.TempDir(), "config*.toml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _ = f.WriteString(`
+[logs.fields]
+level = ["severity"]
+message = ["text", "msg"]
+`)
+	f.Close()
+	cfg, err := config.Load(f.Name())
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	if got := cfg.Logs.Fields.Level; len(got) != 1 || got[0] != "severity" {
+		t.Errorf("Fields.Level = %v, want [severity]", got)
+	}
+	if got := cfg.Logs.Fields.Message; len(got) != 2 || got[0] != "text" {
+		t.Errorf("Fields.Message = %v, want [text msg]", got)
+	}
+	if len(cfg.Logs.Fields.Timestamp) == 0 {
+		t.Error("Fields.Timestamp should keep its default when not set in TOML")
+	}
 }
 
 func TestLogsConfigFromTOML(t *testing.T) {
@@ -214,3 +248,449 @@ since = "30m"
 		t.Errorf("Since = %q, want %q", cfg.Logs.Since, "30m")
 	}
 }
+
+func TestLogRetentionConfigFromTOML(t *testing.T) {
+	f, err := os.CreateTemp(t.TempDir(), "config*.toml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _ = f.WriteString(`
+[logs.retention]
+max_lines = 5000
+max_bytes = "2MB"
+spill = true
+`)
+	f.Close()
+	cfg, err := config.Load(f.Name())
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	retention := cfg.Logs.Retention
+	if retention.MaxLines != 5000 || !retention.Spill {
+		t.Errorf("Retention = %+v, want max_lines 5000 and spill", retention)
+	}
+	if n, err := retention.Bytes(); err != nil || n != 2*1024*1024 {
+		t.Errorf("Retention.Bytes() = %d, %v, want %d", n, err, 2*1024*1024)
+	}
+}
+
+func TestLogRetentionConfigValidate(t *testing.T) {
+	if err := config.DefaultLogsConfig().Retention.Validate(); err != nil {
+		t.Errorf("default Retention.Validate() = %v, want nil", err)
+	}
+	for _, cfg := range []config.LogRetentionConfig{{MaxLines: -1}, {MaxBytes: "lots"}, {MaxBytes: "-1MB"}} {
+		if err := cfg.Validate(); err == nil {
+			t.Errorf("%+v.Validate() = nil, want an error", cfg)
+		}
+	}
+}
+
+func TestStopConfigFromTOML(t *testing.T) {
+	f, err := os.CreateTemp(t.TempDir(), "config*.toml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _ = f.WriteString(`
+[stop]
+timeout = "10s"
+
+[[stop.rules]]
+label = "com.example.role=db"
+timeout = "2m"
+signal = "SIGINT"
+
+[[stop.rules]]
+container = "postgres"
+timeout = "5m"
+
+[[stop.rules]]
+label = "com.example.disposable"
+timeout = "0s"
+`)
+	f.Close()
+	cfg, err := config.Load(f.Name())
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	if err = cfg.Stop.Validate(); err != nil {
+		t.Fatalf("Validate() = %v, want nil", err)
+	}
+
+	dbLabels := map[string]string{"com.example.role": "db"}
+	tests := []struct {
+		name        string
+		labels      map[string]string
+		wantTimeout string
+		wantSignal  string
+	}{
+		{name: "web", wantTimeout: "10s"},
+		{name: "mysql", labels: dbLabels, wantTimeout: "2m", wantSignal: "SIGINT"},
+		{name: "postgres", labels: dbLabels, wantTimeout: "5m", wantSignal: "SIGINT"},
+		{name: "cache", labels: map[string]string{"com.example.role": "cache"}, wantTimeout: "10s"},
+		{name: "job", labels: map[string]string{"com.example.disposable": ""}, wantTimeout: "0s"},
+	}
+	for _, tt := range tests {
+		timeout, signal := cfg.Stop.Defaults(tt.name, tt.labels)
+		if timeout != tt.wantTimeout || signal != tt.wantSignal {
+			t.Errorf("Defaults(%q) = (%q, %q), want (%q, %q)", tt.name, timeout, signal, tt.wantTimeout, tt.wantSignal)
+		}
+	}
+}
+
+func TestStopConfigValidate(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  config.StopConfig
+	}{
+		{name: "invalid timeout", cfg: config.StopConfig{Timeout: "soon"}},
+		{name: "negative timeout", cfg: config.StopConfig{Timeout: "-1s"}},
+		{name: "rule without matcher", cfg: config.StopConfig{Rules: []config.StopRule{{Timeout: "1m"}}}},
+		{
+			name: "rule with both matchers",
+			cfg:  config.StopConfig{Rules: []config.StopRule{{Container: "db", Label: "role=db"}}},
+		},
+		{
+			name: "invalid rule timeout",
+			cfg:  config.StopConfig{Rules: []config.StopRule{{Container: "db", Timeout: "1 minute"}}},
+		},
+	}
+	for _, tt := range tests {
+		if err := tt.cfg.Validate(); err == nil {
+			t.Errorf("%s: Validate() = nil, want an error", tt.name)
+		}
+	}
+}
+
+func TestStatsConfigValidate(t *testing.T) {
+	valid := []config.StatsConfig{
+		{},
+		{ListColumns: "values", CPUThreshold: 80},
+		{ListColumns: "sparklines", MemoryThreshold: 90},
+		{History: config.StatsHistoryConfig{Window: "15m", Path: "stats.jsonl"}},
+	}
+	for _, cfg := range valid {
+		if err := cfg.Validate(); err != nil {
+			t.Errorf("Validate(%+v) = %v, want nil", cfg, err)
+		}
+	}
+	invalid := []config.StatsConfig{
+		{ListColumns: "graphs"},
+		{CPUThreshold: -1},
+		{MemoryThreshold: -5},
+		{History: config.StatsHistoryConfig{Window: "an hour"}},
+		{History: config.StatsHistoryConfig{Window: "0s"}},
+	}
+	for _, cfg := range invalid {
+		if err := cfg.Validate(); err == nil {
+			t.Errorf("Validate(%+v) = nil, want an error", cfg)
+		}
+	}
+}
+
+func TestStatsHistoryConfigDuration(t *testing.T) {
+	for window, want := range map[string]time.Duration{"": time.Hour, "30m": 30 * time.Minute} {
+		got, err := config.StatsHistoryConfig{Window: window}.Duration()
+		if err != nil || got != want {
+			t.Errorf("Duration() of %q = %v, %v, want %v", window, got, err, want)
+		}
+	}
+}
+
+func TestStatsHistoryConfigEnabled(t *testing.T) {
+	if (config.StatsHistoryConfig{}).Enabled() {
+		t.Error("Enabled() = true without a window or path, want false")
+	}
+	if !(config.StatsHistoryConfig{Window: "1h"}).Enabled() || !(config.StatsHistoryConfig{Path: "s.jsonl"}).Enabled() {
+		t.Error("Enabled() = false with a window or path, want true")
+	}
+}
+
+func TestStatsConfigEnabled(t *testing.T) {
+	if (config.StatsConfig{}).Enabled() {
+		t.Error("Enabled() = true for the zero config, want false")
+	}
+	if !(config.StatsConfig{CPUThreshold: 50}).Enabled() {
+		t.Error("Enabled() = false with a threshold, want true to tint containers")
+	}
+}
+
+func TestAlertRuleCondition(t *testing.T) {
+	cond, err := config.AlertRule{When: "cpu > 90%", For: "2m"}.Condition()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := config.AlertCondition{Kind: config.AlertCPU, Op: ">", Threshold: 90, For: 2 * time.Minute}
+	if cond != want {
+		t.Errorf("Condition() = %+v, want %+v", cond, want)
+	}
+	if !cond.Holds(90.5) || cond.Holds(90) {
+		t.Error("Holds() should compare with the threshold")
+	}
+	cond, err = config.AlertRule{When: "unhealthy"}.Condition()
+	if err != nil || cond.Kind != config.AlertUnhealthy {
+		t.Errorf("Condition() = %+v, %v, want an unhealthy condition", cond, err)
+	}
+}
+
+func TestAlertsConfigValidate(t *testing.T) {
+	valid := config.AlertsConfig{
+		Webhook: "http://localhost:8080/hook",
+		Rules: []config.AlertRule{
+			{When: "memory >= 80"},
+			{When: "exited", Container: "api"},
+			{When: "restarted", Label: "tier=db"},
+		},
+	}
+	if err := valid.Validate(); err != nil {
+		t.Errorf("Validate() = %v, want nil", err)
+	}
+	invalid := []config.AlertsConfig{
+		{Webhook: "localhost:8080"},
+		{Rules: []config.AlertRule{{When: "disk > 90"}}},
+		{Rules: []config.AlertRule{{When: "cpu = 90"}}},
+		{Rules: []config.AlertRule{{When: "cpu > lots"}}},
+		{Rules: []config.AlertRule{{When: "cpu > 90", For: "soon"}}},
+		{Rules: []config.AlertRule{{When: "unhealthy", For: "1m"}}},
+	}
+	for _, cfg := range invalid {
+		if err := cfg.Validate(); err == nil {
+			t.Errorf("Validate(%+v) = nil, want an error", cfg)
+		}
+	}
+}
+
+func TestAlertRuleMatches(t *testing.T) {
+	labels := map[string]string{"tier": "db"}
+	tests := []struct {
+		rule config.AlertRule
+		want bool
+	}{
+		{config.AlertRule{}, true},
+		{config.AlertRule{Container: "api"}, true},
+		{config.AlertRule{Container: "web"}, false},
+		{config.AlertRule{Label: "tier=db"}, true},
+		{config.AlertRule{Container: "api", Label: "tier=web"}, false},
+	}
+	for _, tt := range tests {
+		if got := tt.rule.Matches("api", labels); got != tt.want {
+			t.Errorf("%+v.Matches() = %v, want %v", tt.rule, got, tt.want)
+		}
+	}
+	if got := (config.AlertRule{When: "cpu > 90", For: "2m"}).Title(); got != "cpu > 90 for 2m" {
+		t.Errorf("Title() = %q, want the condition", got)
+	}
+}
+
+func TestTablesConfigValidate(t *testing.T) {
+	valid := config.TablesConfig{
+		Containers: config.TableConfig{Columns: []string{"name", "cpu"}, Sort: "cpu"},
+		Images:     config.TableConfig{Sort: "used_by"},
+	}
+	if err := valid.Validate(); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+	for _, invalid := range []config.TablesConfig{
+		{Containers: config.TableConfig{Columns: []string{"name", "tag"}}},
+		{Containers: config.TableConfig{Columns: []string{"name", "name"}}},
+		{Images: config.TableConfig{Sort: "ports"}},
+	} {
+		if err := invalid.Validate(); err == nil {
+			t.Errorf("Validate(%+v) should fail", invalid)
+		}
+	}
+}
+
+func TestSaveTable(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.toml")
+	existing := "[docker]\nhost = \"tcp://remote:2375\"\n\n[tables.images]\nenabled = true\n"
+	err := os.WriteFile(path, []byte(existing), 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	table := config.TableConfig{Enabled: true, Columns: []string{"name", "cpu"}, Sort: "cpu", Descending: true}
+	if err = config.SaveTable(path, "containers", table); err != nil {
+		t.Fatalf("SaveTable() error = %v", err)
+	}
+
+	cfg, err := config.Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if cfg.Docker.Host != "tcp://remote:2375" || !cfg.Tables.Images.Enabled {
+		t.Errorf("SaveTable() should keep the other settings, got %+v", cfg)
+	}
+	got := cfg.Tables.Containers
+	if !got.Enabled || got.Sort != "cpu" || !got.Descending || len(got.Columns) != 2 || got.Columns[1] != "cpu" {
+		t.Errorf("Tables.Containers = %+v, want %+v", got, table)
+	}
+	if cfg.Path != path {
+		t.Errorf("Path = %q, want %q", cfg.Path, path)
+	}
+}
+
+func TestSaveTable_KeepsComments(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.toml")
+	existing := `# docker-dash settings
+[docker]
+host = "tcp://remote:2375" # the build box
+
+[tables.containers]
+# shown on start
+enabled = false
+sort = "name"
+
+# Alerts for production.
+[alerts]
+desktop = true
+`
+	if err := os.WriteFile(path, []byte(existing), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	table := config.TableConfig{Enabled: true, Columns: []string{"name", "cpu"}, Sort: "cpu"}
+	if err := config.SaveTable(path, "containers", table); err != nil {
+		t.Fatalf("SaveTable() error = %v", err)
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := string(content)
+	for _, kept := range []string{
+		"# docker-dash settings\n[docker]\nhost = \"tcp://remote:2375\" # the build box\n\n[tables.containers]\n",
+		"\n\n# Alerts for production.\n[alerts]\ndesktop = true\n",
+	} {
+		if !strings.Contains(got, kept) {
+			t.Errorf("SaveTable() should keep %q, got:\n%s", kept, got)
+		}
+	}
+	if strings.Contains(got, `sort = "name"`) || strings.Count(got, "[tables.containers]") != 1 {
+		t.Errorf("SaveTable() should replace the table in place, got:\n%s", got)
+	}
+	cfg, err := config.Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if c := cfg.Tables.Containers; !c.Enabled || c.Sort != "cpu" || len(c.Columns) != 2 {
+		t.Errorf("Tables.Containers = %+v, want %+v", c, table)
+	}
+}
+
+func TestSaveTable_InlineTable(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.toml")
+	existing := "[tables]\nimages = { enabled = true }\n"
+	if err := os.WriteFile(path, []byte(existing), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := config.SaveTable(path, "images", config.TableConfig{Sort: "size"}); err == nil {
+		t.Error("SaveTable() should fail on a table defined inline")
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(content) != existing {
+		t.Errorf("SaveTable() should leave the file alone on error, got:\n%s", content)
+	}
+}
+
+func TestSaveTable_MissingFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "docker-dash", "config.toml")
+	if err := config.SaveTable(path, "images", config.TableConfig{Sort: "size"}); err != nil {
+		t.Fatalf("SaveTable() error = %v", err)
+	}
+	cfg, err := config.Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if cfg.Tables.Images.Sort != "size" {
+		t.Errorf("Tables.Images.Sort = %q, want size", cfg.Tables.Images.Sort)
+	}
+}
+
+func TestSaveTable_IgnoresHeadersInsideValues(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.toml")
+	existing := `[tables.images] # picked by hand
+notes = """
+[tables.containers]
+sort = "name"
+"""
+matrix = [
+  [1]
+]
+sort = "name"
+
+[alerts]
+desktop = true
+`
+	if err := os.WriteFile(path, []byte(existing), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := config.SaveTable(path, "containers", config.TableConfig{Sort: "cpu"}); err != nil {
+		t.Fatalf("SaveTable() error = %v", err)
+	}
+	if err := config.SaveTable(path, "images", config.TableConfig{Sort: "size"}); err != nil {
+		t.Fatalf("SaveTable() error = %v", err)
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := string(content)
+	if !strings.HasPrefix(got, "[tables.images] # picked by hand\n") || strings.Contains(got, "notes") {
+		t.Errorf("SaveTable() should replace the whole images table, got:\n%s", got)
+	}
+	cfg, err := config.Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if cfg.Tables.Containers.Sort != "cpu" || cfg.Tables.Images.Sort != "size" || !cfg.Alerts.Desktop {
+		t.Errorf("Tables = %+v, Alerts = %+v, want both tables saved", cfg.Tables, cfg.Alerts)
+	}
+}
+
+func TestSaveTable_ReplacesTheFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.toml")
+	if err := os.WriteFile(path, []byte("[docker]\nhost = \"tcp://remote:2375\"\n"), 0o640); err != nil {
+		t.Fatal(err)
+	}
+	link := filepath.Join(dir, "link.toml")
+	if err := os.Symlink(path, link); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := config.SaveTable(link, "images", config.TableConfig{Sort: "size"}); err != nil {
+		t.Fatalf("SaveTable() error = %v", err)
+	}
+
+	if info, err := os.Lstat(link); err != nil || info.Mode()&os.ModeSymlink == 0 {
+		t.Errorf("SaveTable() should keep the link, got %v, %v", info, err)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() != 0o640 {
+		t.Errorf("mode = %v, want the file's own 0640", info.Mode().Perm())
+	}
+	cfg, err := config.Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if cfg.Docker.Host != "tcp://remote:2375" || cfg.Tables.Images.Sort != "size" {
+		t.Errorf("config = %+v, want the table saved in the linked file", cfg)
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 2 {
+		t.Errorf("directory holds %d entries, want no temporary file left", len(entries))
+	}
+}
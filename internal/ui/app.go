@@ -25,6 +25,7 @@ import (
 	"github.com/GustavoCaso/docker-dash/internal/ui/sections"
 	"github.com/GustavoCaso/docker-dash/internal/ui/sections/compose"
 	"github.com/GustavoCaso/docker-dash/internal/ui/sections/containers"
+	"github.com/GustavoCaso/docker-dash/internal/ui/sections/disk"
 	"github.com/GustavoCaso/docker-dash/internal/ui/sections/images"
 	"github.com/GustavoCaso/docker-dash/internal/ui/sections/networks"
 	"github.com/GustavoCaso/docker-dash/internal/ui/sections/volumes"
@@ -85,6 +86,7 @@ type model struct {
 	volumeSection    sections.Section
 	networkSection   sections.Section
 	composeSection   sections.Section
+	diskSection      sections.Section
 	statusBar        *statusbar.StatusBar
 	keys             *keys.KeyMap
 	imagesKeys       *keys.ViewKeyMap
@@ -92,6 +94,7 @@ type model struct {
 	volumeKeys       *keys.ViewKeyMap
 	networkKeys      *keys.ViewKeyMap
 	composeKeys      *keys.ViewKeyMap
+	diskKeys         *keys.ViewKeyMap
 	activeKeys       *keys.ViewKeyMap
 	width            int
 	height           int
@@ -124,6 +127,10 @@ func New(ctx context.Context, version string, cfg *config.Config, client client.
 	sp.Spinner = spinner.Dot
 	sp.Style = lipgloss.NewStyle().Foreground(lipgloss.Color("205"))
 
+	containerSection := containers.New(
+		ctx, client.Containers(), cfg.Logs, cfg.Stop, cfg.Stats, cfg.Alerts, cfg.Tables.Containers,
+	)
+
 	return &model{
 		cfg:              cfg,
 		client:           client,
@@ -133,16 +140,18 @@ func New(ctx context.Context, version string, cfg *config.Config, client client.
 		volumeKeys:       keys.Keys.VolumeKeyMap(),
 		networkKeys:      keys.Keys.NetworkKeyMap(),
 		composeKeys:      keys.Keys.ComposeKeyMap(),
+		diskKeys:         keys.Keys.DiskKeyMap(),
 		header:           header.New(version),
 		statusBar:        statusbar.New(),
 		spinner:          sp,
 		spinnerRequests:  make(map[string]spinnerRequest),
 		confirmation:     confirmation.New(),
-		containerSection: containers.New(ctx, client.Containers(), cfg.Logs),
-		imageSection:     images.New(ctx, client, cfg.UpdateCheck),
+		containerSection: containerSection,
+		imageSection:     images.New(ctx, client, cfg.UpdateCheck, cfg.Tables.Images),
 		volumeSection:    volumes.New(ctx, client.Volumes()),
 		networkSection:   networks.New(ctx, client.Networks()),
-		composeSection:   compose.New(ctx, client.Compose()),
+		composeSection:   compose.New(ctx, client.Compose(), client.Containers(), cfg.Logs),
+		diskSection:      disk.New(ctx, client),
 		systemInfo:       systeminfo.New(ctx, client),
 	}
 }
@@ -168,6 +177,7 @@ func (m *model) Init() tea.Cmd {
 		m.volumeSection.Init(),
 		m.networkSection.Init(),
 		m.composeSection.Init(),
+		m.diskSection.Init(),
 	}...)
 
 	if m.refreshInterval > 0 {
@@ -191,7 +201,23 @@ func (m *model) handleFormUpdate(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.showForm = false
 		m.formModel = nil
 	}
-	return m, cmd
+	// Keep background work (log streams, refresh ticks, container events)
+	// flowing to every section while the form owns the keyboard.
+	if isInputMsg(msg) {
+		return m, cmd
+	}
+	_, sectionsCmd := m.forwardMessageToAll(msg)
+	return m, tea.Batch(cmd, sectionsCmd)
+}
+
+// isInputMsg reports whether msg is keyboard or mouse input, which only goes
+// to whatever owns the keyboard.
+func isInputMsg(msg tea.Msg) bool {
+	switch msg.(type) {
+	case tea.KeyMsg, tea.PasteMsg, tea.MouseMsg:
+		return true
+	}
+	return false
 }
 
 func (m *model) handleConfirmationUpdate(msg tea.Msg) (tea.Model, tea.Cmd, bool) {
@@ -243,6 +269,13 @@ func (m *model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, tea.Batch(cmds...)
 	}
 
+	// The alerts count shows in the header whatever overlay is open.
+	if alertsMsg, ok := msg.(message.AlertsChangedMsg); ok {
+		log.Printf("[app] AlertsChangedMsg: count=%d", alertsMsg.Count)
+		m.header.SetAlerts(alertsMsg.Count)
+		return m, tea.Batch(cmds...)
+	}
+
 	if m.showForm {
 		if _, ok := msg.(tea.WindowSizeMsg); !ok {
 			m, cmd := m.handleFormUpdate(msg)
@@ -251,8 +284,15 @@ func (m *model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 	}
 
+	// The filter owns the keyboard of the active section; background work of
+	// the other sections keeps flowing.
 	if m.isFilterActive() {
-		m, cmd := m.forwardMessageToActive(msg)
+		if isInputMsg(msg) {
+			m, cmd := m.forwardMessageToActive(msg)
+			cmds = append(cmds, cmd)
+			return m, tea.Batch(cmds...)
+		}
+		m, cmd := m.forwardMessageToAll(msg)
 		cmds = append(cmds, cmd)
 		return m, tea.Batch(cmds...)
 	}
@@ -265,15 +305,16 @@ func (m *model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 	}
 
-	if m.showSystemInfo {
-		km, ok := msg.(tea.KeyPressMsg)
-		if ok {
-			switch {
-			case key.Matches(km, m.keys.Esc), key.Matches(km, keys.Keys.SystemInfo):
-				m.showSystemInfo = false
-				return m, tea.Batch(cmds...)
-			}
+	// The system info modal owns the keyboard; other messages, such as the
+	// system prune form and confirmation, go through as usual.
+	if km, ok := msg.(tea.KeyPressMsg); ok && m.showSystemInfo {
+		if key.Matches(km, m.keys.Esc) || key.Matches(km, keys.Keys.SystemInfo) {
+			m.showSystemInfo = false
+			return m, tea.Batch(cmds...)
 		}
+		updatedSystemInfo, cmd := m.systemInfo.Update(km)
+		m.systemInfo = updatedSystemInfo
+		cmds = append(cmds, cmd)
 		return m, tea.Batch(cmds...)
 	}
 
@@ -300,6 +341,7 @@ func (m *model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.volumeSection.SetSize(msg.Width, contentHeight)
 		m.networkSection.SetSize(msg.Width, contentHeight)
 		m.composeSection.SetSize(msg.Width, contentHeight)
+		m.diskSection.SetSize(msg.Width, contentHeight)
 		m.statusBar.SetSize(msg.Width, statusBarHeight)
 		m.systemInfo.SetSize(msg.Width, contentHeight)
 
@@ -368,6 +410,18 @@ func (m *model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		return m, tea.Batch(cmds...)
 
+	case message.SystemPrunePreviewMsg, message.SystemPrunedMsg:
+		log.Printf("[app] %T", msg)
+		updatedSystemInfo, cmd := m.systemInfo.Update(msg)
+		m.systemInfo = updatedSystemInfo
+		cmds = append(cmds, cmd)
+		return m, tea.Batch(cmds...)
+
+	case message.SaveTableMsg:
+		log.Printf("[app] SaveTableMsg: section=%q", msg.Section)
+		cmds = append(cmds, m.saveTableCmd(msg))
+		return m, tea.Batch(cmds...)
+
 	case message.ShowSpinnerMsg:
 		log.Printf("[app] ShowSpinnerMsg: id=%q text=%q", msg.ID, msg.Text)
 		if cmd := m.showSpinner(msg); cmd != nil {
@@ -528,6 +582,9 @@ func (m *model) View() tea.View {
 	case header.ViewCompose:
 		listView = m.composeSection.View()
 		listKeyMap = m.composeKeys
+	case header.ViewDisk:
+		listView = m.diskSection.View()
+		listKeyMap = m.diskKeys
 	}
 
 	m.activeKeys = listKeyMap
@@ -560,6 +617,20 @@ func (m *model) View() tea.View {
 	return v
 }
 
+// saveTableCmd saves the table layout of a section to the config file.
+func (m *model) saveTableCmd(msg message.SaveTableMsg) tea.Cmd {
+	path := m.cfg.Path
+	return func() tea.Msg {
+		if path == "" {
+			return message.ShowBannerMsg{Message: "No config file to save the table columns to", IsError: true}
+		}
+		if err := config.SaveTable(path, msg.Section, msg.Table); err != nil {
+			return message.ShowBannerMsg{Message: "Error saving table columns: " + err.Error(), IsError: true}
+		}
+		return message.ShowBannerMsg{Message: "Saved the table columns to " + path, IsError: false}
+	}
+}
+
 func (m *model) updateSpinner(msg tea.Msg) tea.Cmd {
 	if len(m.spinnerRequests) == 0 {
 		return nil
@@ -660,6 +731,8 @@ func (m *model) activeSectionName() string {
 		return string(sections.NetworksSection)
 	case header.ViewCompose:
 		return string(sections.ComposeSection)
+	case header.ViewDisk:
+		return string(sections.DiskSection)
 	default:
 		return ""
 	}
@@ -719,6 +792,7 @@ func (m *model) allSections() []sections.Section {
 		m.volumeSection,
 		m.networkSection,
 		m.composeSection,
+		m.diskSection,
 	}
 }
 
@@ -735,6 +809,8 @@ func (m *model) activeSection() sections.Section {
 		section = m.networkSection
 	case header.ViewCompose:
 		section = m.composeSection
+	case header.ViewDisk:
+		section = m.diskSection
 	}
 	return section
 }
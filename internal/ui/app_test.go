@@ -9,11 +9,13 @@ import (
 
 	"charm.land/bubbles/v2/key"
 	tea "charm.land/bubbletea/v2"
+	"charm.land/huh/v2"
 
 	"github.com/charmbracelet/x/exp/teatest/v2"
 
 	"github.com/GustavoCaso/docker-dash/internal/client"
 	"github.com/GustavoCaso/docker-dash/internal/config"
+	"github.com/GustavoCaso/docker-dash/internal/ui/components/form"
 	"github.com/GustavoCaso/docker-dash/internal/ui/components/header"
 	"github.com/GustavoCaso/docker-dash/internal/ui/message"
 	"github.com/GustavoCaso/docker-dash/internal/ui/sections"
@@ -114,7 +116,12 @@ func TestExecPanel(t *testing.T) {
 	tm.Send(tea.KeyPressMsg{Code: tea.KeyDown})
 	// Set focus on panels
 	tm.Send(tea.KeyPressMsg{Code: tea.KeyTab})
-	// Navigate to exec panel using shift+right (panels: details=0, logs=1, stats=2, filetree=3, exec=4)
+	// Navigate to exec panel using shift+right
+	// (panels: details=0, logs=1, stats=2, top=3, alerts=4, health=5, processes=6, filetree=7, exec=8)
+	tm.Send(tea.KeyPressMsg{Code: tea.KeyRight, Mod: tea.ModShift})
+	tm.Send(tea.KeyPressMsg{Code: tea.KeyRight, Mod: tea.ModShift})
+	tm.Send(tea.KeyPressMsg{Code: tea.KeyRight, Mod: tea.ModShift})
+	tm.Send(tea.KeyPressMsg{Code: tea.KeyRight, Mod: tea.ModShift})
 	tm.Send(tea.KeyPressMsg{Code: tea.KeyRight, Mod: tea.ModShift})
 	tm.Send(tea.KeyPressMsg{Code: tea.KeyRight, Mod: tea.ModShift})
 	tm.Send(tea.KeyPressMsg{Code: tea.KeyRight, Mod: tea.ModShift})
@@ -187,7 +194,7 @@ func TestContainerListPrune(t *testing.T) {
 
 	waitForString(t, tm, "old-container") // stopped container present initially
 	tm.Send(tea.KeyPressMsg{Code: 'P', Text: "P"})
-	time.Sleep(500 * time.Millisecond)
+	waitForString(t, tm, "Remove 2 containers")    // the preview lists old-container
 	tm.Send(tea.KeyPressMsg{Code: 'y', Text: "y"}) // confirm prune command
 
 	waitFor(t, tm, func(b []byte) bool {
@@ -199,7 +206,7 @@ func TestContainerListPrune(t *testing.T) {
 	tm.WaitFinished(t, teatest.WithFinalTimeout(time.Second))
 }
 
-func TestContainerListRestartConfirmationModal(t *testing.T) {
+func TestContainerListRestartForm(t *testing.T) {
 	m := New(context.Background(), "test", &config.Config{}, client.NewMockClient())
 	tm := teatest.NewTestModel(t, m, teatest.WithInitialTermSize(300, 100))
 	waitForString(t, tm, "Images")
@@ -210,11 +217,11 @@ func TestContainerListRestartConfirmationModal(t *testing.T) {
 	tm.Send(tea.KeyPressMsg{Code: 'R', Text: "R"})
 	waitFor(t, tm, func(b []byte) bool {
 		s := string(b)
-		return strings.Contains(s, "Restart Container") && strings.Contains(s, "[y] confirm")
+		return strings.Contains(s, "Restart — nginx-proxy") && strings.Contains(s, "Timeout")
 	})
 
-	// Dismiss without confirming — banner text from a reload cycle is unreliable.
-	tm.Send(tea.KeyPressMsg{Code: 'n', Text: "n"})
+	// Dismiss without submitting — banner text from a reload cycle is unreliable.
+	tm.Send(tea.KeyPressMsg{Code: tea.KeyEscape})
 	waitForString(t, tm, "nginx-proxy")
 
 	tm.Send(tea.KeyPressMsg{Code: 'q', Text: "q"})
@@ -229,11 +236,16 @@ func TestContainerFilesPanelShowsEntries(t *testing.T) {
 	tm.Send(tea.KeyPressMsg{Code: tea.KeyRight})
 	waitForString(t, tm, "nginx-proxy")
 
-	// Set focus on panels then navigate to files panel (details=0, logs=1, stats=2, files=3)
+	// Set focus on panels then navigate to files panel
+	// (details=0, logs=1, stats=2, top=3, alerts=4, health=5, processes=6, files=7)
 	tm.Send(tea.KeyPressMsg{Code: tea.KeyTab})
 	tm.Send(tea.KeyPressMsg{Code: tea.KeyRight, Mod: tea.ModShift})
 	tm.Send(tea.KeyPressMsg{Code: tea.KeyRight, Mod: tea.ModShift})
 	tm.Send(tea.KeyPressMsg{Code: tea.KeyRight, Mod: tea.ModShift})
+	tm.Send(tea.KeyPressMsg{Code: tea.KeyRight, Mod: tea.ModShift})
+	tm.Send(tea.KeyPressMsg{Code: tea.KeyRight, Mod: tea.ModShift})
+	tm.Send(tea.KeyPressMsg{Code: tea.KeyRight, Mod: tea.ModShift})
+	tm.Send(tea.KeyPressMsg{Code: tea.KeyRight, Mod: tea.ModShift})
 
 	// Wait for both directory and file entries to appear together
 	waitFor(t, tm, func(b []byte) bool {
@@ -346,7 +358,7 @@ func TestVolumePruneCommand(t *testing.T) {
 
 	waitForString(t, tm, "app_data") // unused volume present initially
 	tm.Send(tea.KeyPressMsg{Code: 'P', Text: "P"})
-	time.Sleep(500 * time.Millisecond)
+	waitForString(t, tm, "Remove 2 volumes")       // the preview lists app_data
 	tm.Send(tea.KeyPressMsg{Code: 'y', Text: "y"}) // confirm prune
 
 	waitFor(t, tm, func(b []byte) bool {
@@ -582,6 +594,10 @@ func TestSpinnerOverlayShowsNestedSpinnerForActivePanel(t *testing.T) {
 	appModel.Update(tea.KeyPressMsg{Code: tea.KeyRight, Mod: tea.ModShift})
 	appModel.Update(tea.KeyPressMsg{Code: tea.KeyRight, Mod: tea.ModShift})
 	appModel.Update(tea.KeyPressMsg{Code: tea.KeyRight, Mod: tea.ModShift})
+	appModel.Update(tea.KeyPressMsg{Code: tea.KeyRight, Mod: tea.ModShift})
+	appModel.Update(tea.KeyPressMsg{Code: tea.KeyRight, Mod: tea.ModShift})
+	appModel.Update(tea.KeyPressMsg{Code: tea.KeyRight, Mod: tea.ModShift})
+	appModel.Update(tea.KeyPressMsg{Code: tea.KeyRight, Mod: tea.ModShift})
 	appModel.Update(message.ShowSpinnerMsg{
 		ID:   "containers.files.1",
 		Text: "Loading files...",
@@ -673,6 +689,66 @@ func TestContextualKeyBindingsProcessedDuringFilterMode(t *testing.T) {
 	}
 }
 
+// eventChainMsg stands for the messages that carry the container events
+// chain, such as the next event or a scheduled reload.
+type eventChainMsg struct{}
+
+// recordingSection records the messages a section receives.
+type recordingSection struct {
+	sections.Section
+	received []tea.Msg
+}
+
+func (r *recordingSection) Update(msg tea.Msg) tea.Cmd {
+	r.received = append(r.received, msg)
+	return r.Section.Update(msg)
+}
+
+func newRecordingContainersModel(t *testing.T) (*model, *recordingSection) {
+	t.Helper()
+	appModel, ok := New(context.Background(), "test", &config.Config{}, client.NewMockClient()).(*model)
+	if !ok {
+		t.Fatal("New should return *model")
+	}
+	containers := &recordingSection{Section: appModel.containerSection}
+	appModel.containerSection = containers
+	appModel.Update(tea.WindowSizeMsg{Width: 300, Height: 100})
+	return appModel, containers
+}
+
+func TestContainerEventsFlowWhileAnotherSectionFilters(t *testing.T) {
+	appModel, containers := newRecordingContainersModel(t)
+
+	// Images is the active section; open its filter.
+	appModel.Update(tea.KeyPressMsg{Code: '/', Text: "/"})
+	if !appModel.isFilterActive() {
+		t.Fatal("filter mode did not activate")
+	}
+
+	appModel.Update(eventChainMsg{})
+	if !slices.Contains(containers.received, tea.Msg(eventChainMsg{})) {
+		t.Error("containers should receive the event chain message while images is filtering")
+	}
+	if slices.ContainsFunc(containers.received, func(msg tea.Msg) bool { _, ok := msg.(tea.KeyPressMsg); return ok }) {
+		t.Error("keys typed into the images filter should not reach containers")
+	}
+}
+
+func TestContainerEventsFlowWhileAFormIsOpen(t *testing.T) {
+	appModel, containers := newRecordingContainersModel(t)
+
+	f := huh.NewForm(huh.NewGroup(huh.NewInput().Title("Name")))
+	appModel.Update(message.ShowFormMsg{Form: form.New("Test", f, func(*huh.Form) tea.Cmd { return nil })})
+	if !appModel.showForm {
+		t.Fatal("the form did not open")
+	}
+
+	appModel.Update(eventChainMsg{})
+	if !slices.Contains(containers.received, tea.Msg(eventChainMsg{})) {
+		t.Error("containers should receive the event chain message while a form is open")
+	}
+}
+
 func TestArrowKeysStayInContainersWhenLogsPanelFocused(t *testing.T) {
 	appModel, ok := New(context.Background(), "test", &config.Config{}, client.NewMockClient()).(*model)
 	if !ok {
@@ -844,3 +920,26 @@ func waitFor(t *testing.T, tm *teatest.TestModel, f func(b []byte) bool) {
 		teatest.WithDuration(time.Second*10),
 	)
 }
+
+func TestSaveTableWritesConfigFile(t *testing.T) {
+	path := t.TempDir() + "/config.toml"
+	appModel, ok := New(context.Background(), "test", &config.Config{Path: path}, client.NewMockClient()).(*model)
+	if !ok {
+		t.Fatal("New() should return *model")
+	}
+
+	table := config.TableConfig{Enabled: true, Columns: []string{"repo", "size"}, Sort: "size"}
+	_, cmd := appModel.Update(message.SaveTableMsg{Section: "images", Table: table})
+	banner, isBanner := cmd().(message.ShowBannerMsg)
+	if !isBanner || banner.IsError {
+		t.Fatalf("saving should report success, got %#v", banner)
+	}
+
+	cfg, err := config.Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := cfg.Tables.Images; !got.Enabled || got.Sort != "size" || !slices.Equal(got.Columns, table.Columns) {
+		t.Errorf("Tables.Images = %+v, want %+v", got, table)
+	}
+}
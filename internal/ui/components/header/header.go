@@ -1,6 +1,8 @@
 package header
 
 import (
+	"fmt"
+
 	"charm.land/lipgloss/v2"
 
 	"github.com/GustavoCaso/docker-dash/internal/ui/theme"
@@ -15,6 +17,7 @@ const (
 	ViewVolumes
 	ViewNetworks
 	ViewCompose
+	ViewDisk
 )
 
 func (v View) String() string {
@@ -29,6 +32,8 @@ func (v View) String() string {
 		return "Networks"
 	case ViewCompose:
 		return "Compose"
+	case ViewDisk:
+		return "Disk"
 	default:
 		return "Unknown"
 	}
@@ -46,6 +51,7 @@ type Header struct {
 	items       []headerItem
 	activeIndex int
 	width       int
+	alerts      int
 }
 
 // New creates a new header with all sections.
@@ -58,6 +64,7 @@ func New(version string) *Header {
 			{icon: theme.IconVolume, label: "Volumes", view: ViewVolumes},
 			{icon: theme.IconNetwork, label: "Networks", view: ViewNetworks},
 			{icon: theme.IconCompose, label: "Compose", view: ViewCompose},
+			{icon: theme.IconDisk, label: "Disk", view: ViewDisk},
 		},
 		activeIndex: 0,
 	}
@@ -68,6 +75,11 @@ func (h *Header) SetWidth(width int) {
 	h.width = width
 }
 
+// SetAlerts sets the number of alerts shown next to the logo.
+func (h *Header) SetAlerts(count int) {
+	h.alerts = count
+}
+
 // ActiveView returns the currently selected view.
 func (h *Header) ActiveView() View {
 	if h.activeIndex >= 0 && h.activeIndex < len(h.items) {
@@ -113,6 +125,14 @@ func (h *Header) View() string {
 
 	tabBar := lipgloss.JoinHorizontal(lipgloss.Center, tabParts...)
 	logo := theme.HeaderDockerStyle.Render(h.logo)
+	if h.alerts > 0 {
+		label := "alerts"
+		if h.alerts == 1 {
+			label = "alert"
+		}
+		badge := theme.HeaderAlertStyle.Render(fmt.Sprintf("%s %d %s", theme.IconWarning, h.alerts, label))
+		logo = lipgloss.JoinHorizontal(lipgloss.Center, badge, logo)
+	}
 
 	tabBarWidth := lipgloss.Width(tabBar)
 	iconWidth := lipgloss.Width(logo)
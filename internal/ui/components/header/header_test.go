@@ -41,6 +41,11 @@ func TestHeaderMoveRight(t *testing.T) {
 		t.Errorf("expected ViewCompose after fourth MoveRight, got %v", h.ActiveView())
 	}
 
+	h.MoveRight()
+	if h.ActiveView() != ViewDisk {
+		t.Errorf("expected ViewDisk after fifth MoveRight, got %v", h.ActiveView())
+	}
+
 	h.MoveRight()
 	if h.ActiveView() != ViewImages {
 		t.Errorf("expected ViewImages after wrapping MoveRight, got %v", h.ActiveView())
@@ -50,29 +55,34 @@ func TestHeaderMoveRight(t *testing.T) {
 func TestHeaderMoveLeft(t *testing.T) {
 	h := New("test")
 
+	h.MoveLeft()
+	if h.ActiveView() != ViewDisk {
+		t.Errorf("expected ViewDisk after MoveLeft from start, got %v", h.ActiveView())
+	}
+
 	h.MoveLeft()
 	if h.ActiveView() != ViewCompose {
-		t.Errorf("expected ViewCompose after MoveLeft from start, got %v", h.ActiveView())
+		t.Errorf("expected ViewCompose after second MoveLeft, got %v", h.ActiveView())
 	}
 
 	h.MoveLeft()
 	if h.ActiveView() != ViewNetworks {
-		t.Errorf("expected ViewNetworks after second MoveLeft, got %v", h.ActiveView())
+		t.Errorf("expected ViewNetworks after third MoveLeft, got %v", h.ActiveView())
 	}
 
 	h.MoveLeft()
 	if h.ActiveView() != ViewVolumes {
-		t.Errorf("expected ViewVolumes after third MoveLeft, got %v", h.ActiveView())
+		t.Errorf("expected ViewVolumes after fourth MoveLeft, got %v", h.ActiveView())
 	}
 
 	h.MoveLeft()
 	if h.ActiveView() != ViewContainers {
-		t.Errorf("expected ViewContainers after fourth MoveLeft, got %v", h.ActiveView())
+		t.Errorf("expected ViewContainers after fifth MoveLeft, got %v", h.ActiveView())
 	}
 
 	h.MoveLeft()
 	if h.ActiveView() != ViewImages {
-		t.Errorf("expected ViewImages after fifth MoveLeft, got %v", h.ActiveView())
+		t.Errorf("expected ViewImages after sixth MoveLeft, got %v", h.ActiveView())
 	}
 }
 
@@ -82,7 +92,7 @@ func TestHeaderViewContainsSectionNames(t *testing.T) {
 
 	output := h.View()
 
-	for _, expected := range []string{"Images", "Containers", "Volumes", "Networks", "Compose"} {
+	for _, expected := range []string{"Images", "Containers", "Volumes", "Networks", "Compose", "Disk"} {
 		if !strings.Contains(output, expected) {
 			t.Errorf("expected View() output to contain %q", expected)
 		}
@@ -99,3 +109,19 @@ func TestHeaderViewContainsDockerIcon(t *testing.T) {
 		t.Error("expected View() output to contain Docker icon")
 	}
 }
+
+func TestHeaderShowsAlerts(t *testing.T) {
+	h := New("test")
+	h.SetWidth(160)
+	if strings.Contains(h.View(), "alert") {
+		t.Error("expected no alerts badge without alerts")
+	}
+	h.SetAlerts(1)
+	if !strings.Contains(h.View(), "1 alert") {
+		t.Errorf("expected the alerts badge, got %q", h.View())
+	}
+	h.SetAlerts(3)
+	if !strings.Contains(h.View(), "3 alerts") {
+		t.Errorf("expected 3 alerts in the badge, got %q", h.View())
+	}
+}
@@ -0,0 +1,181 @@
+// Package prune provides the filter form and the dry-run summary shared by the
+// prune actions of the sections.
+package prune
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/huh/v2"
+
+	"github.com/GustavoCaso/docker-dash/internal/client"
+	"github.com/GustavoCaso/docker-dash/internal/ui/components/form"
+	"github.com/GustavoCaso/docker-dash/internal/ui/helper"
+	"github.com/GustavoCaso/docker-dash/internal/ui/message"
+)
+
+const (
+	// maxListed is the number of candidates listed in the confirmation.
+	maxListed       = 10
+	nameColumnWidth = 32
+	sizeColumnWidth = 9
+	ellipsisWidth   = 1
+)
+
+// ShowForm asks for the until and label filters of a prune, then hands
+// defaults with those filters to onSubmit.
+func ShowForm(title string, defaults client.PruneOptions, onSubmit func(client.PruneOptions) tea.Cmd) tea.Cmd {
+	var until, labels, excludeLabels string
+	f := huh.NewForm(
+		huh.NewGroup(
+			huh.NewInput().
+				Key("until").
+				Title("Until").
+				Description("Only remove objects created before this: a duration ago (e.g. 24h) or a timestamp. "+
+					"Leave blank for any age.").
+				Value(&until).
+				Validate(ValidateUntil),
+
+			huh.NewInput().
+				Key("labels").
+				Title("Labels").
+				Description("Only remove objects with all these labels, comma separated (e.g. env=test,tmp).").
+				Value(&labels).
+				Validate(validateLabels),
+
+			huh.NewInput().
+				Key("excludeLabels").
+				Title("Exclude labels").
+				Description("Keep objects with any of these labels, comma separated (e.g. keep).").
+				Value(&excludeLabels).
+				Validate(validateLabels),
+		),
+	)
+	pruneForm := form.New(title, f, func(_ *huh.Form) tea.Cmd {
+		return onSubmit(Options(defaults, until, labels, excludeLabels))
+	})
+	return func() tea.Msg {
+		return message.ShowFormMsg{Form: pruneForm}
+	}
+}
+
+// Options returns defaults with the filters entered in the form.
+func Options(defaults client.PruneOptions, until, labels, excludeLabels string) client.PruneOptions {
+	opts := defaults
+	opts.Until = strings.TrimSpace(until)
+	opts.Labels = splitLabels(labels)
+	opts.ExcludeLabels = splitLabels(excludeLabels)
+	return opts
+}
+
+// ValidateUntil accepts a blank until filter or one the daemon understands.
+func ValidateUntil(s string) error {
+	until := strings.TrimSpace(s)
+	if until == "" {
+		return nil
+	}
+	_, err := client.ParsePruneUntil(until, time.Now())
+	return err
+}
+
+func validateLabels(s string) error {
+	for _, label := range splitLabels(s) {
+		if strings.HasPrefix(label, "=") {
+			return fmt.Errorf("invalid label %q: missing key", label)
+		}
+	}
+	return nil
+}
+
+func splitLabels(s string) []string {
+	var labels []string
+	for label := range strings.SplitSeq(s, ",") {
+		if label = strings.TrimSpace(label); label != "" {
+			labels = append(labels, label)
+		}
+	}
+	return labels
+}
+
+// Body describes what a prune will remove for its confirmation: how many
+// objects of kind noun, the space freed and the largest of them, then the
+// objects it keeps with the reason why.
+func Body(noun string, candidates []client.PruneCandidate) string {
+	removed, skipped := Split(candidates)
+	var total int64
+	for _, c := range removed {
+		total += c.Size
+	}
+	largest := slices.Clone(removed)
+	slices.SortStableFunc(largest, func(a, b client.PruneCandidate) int { return cmp.Compare(b.Size, a.Size) })
+
+	var b strings.Builder
+	if total > 0 {
+		fmt.Fprintf(&b, "Remove %d %s, freeing %s?\n", len(removed), plural(noun, len(removed)),
+			helper.FormatSize(total))
+	} else {
+		fmt.Fprintf(&b, "Remove %d %s?\n", len(removed), plural(noun, len(removed)))
+	}
+	for i, c := range largest {
+		if i == maxListed {
+			fmt.Fprintf(&b, "\n… and %d more", len(removed)-maxListed)
+			break
+		}
+		size := ""
+		if c.Size > 0 {
+			size = helper.FormatSize(c.Size)
+		}
+		fmt.Fprintf(&b, "\n%-*s %*s", nameColumnWidth, truncate(c.Name, nameColumnWidth), sizeColumnWidth, size)
+	}
+	if len(skipped) == 0 {
+		return b.String()
+	}
+	fmt.Fprintf(&b, "\n\nSkipped %d %s:", len(skipped), plural(noun, len(skipped)))
+	for i, c := range skipped {
+		if i == maxListed {
+			fmt.Fprintf(&b, "\n… and %d more", len(skipped)-maxListed)
+			break
+		}
+		fmt.Fprintf(&b, "\n%-*s %s", nameColumnWidth, truncate(c.Name, nameColumnWidth), c.Skipped)
+	}
+	return b.String()
+}
+
+// Split separates the candidates a prune removes from those it skips.
+func Split(candidates []client.PruneCandidate) ([]client.PruneCandidate, []client.PruneCandidate) {
+	var removed, skipped []client.PruneCandidate
+	for _, c := range candidates {
+		if c.Skipped != "" {
+			skipped = append(skipped, c)
+		} else {
+			removed = append(removed, c)
+		}
+	}
+	return removed, skipped
+}
+
+func plural(noun string, n int) string {
+	if n != 1 {
+		return noun + "s"
+	}
+	return noun
+}
+
+// NothingToPrune is the banner shown when a dry-run finds nothing to remove.
+func NothingToPrune(noun string) tea.Cmd {
+	return func() tea.Msg {
+		return message.ShowBannerMsg{Message: fmt.Sprintf("No %ss to prune", noun), IsError: false}
+	}
+}
+
+func truncate(s string, width int) string {
+	runes := []rune(s)
+	if len(runes) <= width {
+		return s
+	}
+	return string(runes[:width-ellipsisWidth]) + "…"
+}
@@ -0,0 +1,78 @@
+package prune
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/GustavoCaso/docker-dash/internal/client"
+)
+
+func TestOptions(t *testing.T) {
+	got := Options(client.PruneOptions{All: true}, " 24h ", "env=test, tmp ,", "keep")
+
+	if !got.All || got.Until != "24h" {
+		t.Errorf("Options() = %+v, want All and Until 24h", got)
+	}
+	if !slices.Equal(got.Labels, []string{"env=test", "tmp"}) {
+		t.Errorf("Labels = %v, want [env=test tmp]", got.Labels)
+	}
+	if !slices.Equal(got.ExcludeLabels, []string{"keep"}) {
+		t.Errorf("ExcludeLabels = %v, want [keep]", got.ExcludeLabels)
+	}
+	if empty := Options(client.PruneOptions{}, "", "", ""); empty.Labels != nil || empty.ExcludeLabels != nil {
+		t.Errorf("Options() with blank fields = %+v, want no labels", empty)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	if err := ValidateUntil(""); err != nil {
+		t.Errorf("ValidateUntil(\"\") error = %v, want nil", err)
+	}
+	if err := ValidateUntil("48h"); err != nil {
+		t.Errorf("ValidateUntil(\"48h\") error = %v, want nil", err)
+	}
+	if err := ValidateUntil("soon"); err == nil {
+		t.Error("ValidateUntil(\"soon\") should fail")
+	}
+	if err := validateLabels("env=test,=value"); err == nil {
+		t.Error("validateLabels() should reject a label without key")
+	}
+}
+
+func TestBody(t *testing.T) {
+	got := Body("volume", []client.PruneCandidate{
+		{Name: "small", Size: 1024},
+		{Name: "large", Size: 4096},
+	})
+	if !strings.HasPrefix(got, "Remove 2 volumes, freeing 5.0 KB?") {
+		t.Errorf("Body() = %q, want the count and space freed first", got)
+	}
+	if strings.Index(got, "large") > strings.Index(got, "small") {
+		t.Errorf("Body() = %q, want the largest listed first", got)
+	}
+
+	if got := Body("network", []client.PruneCandidate{{Name: "app"}}); !strings.HasPrefix(got, "Remove 1 network?") {
+		t.Errorf("Body() = %q, want no size for sizeless candidates", got)
+	}
+
+	var many []client.PruneCandidate
+	for i := range maxListed + 2 {
+		many = append(many, client.PruneCandidate{Name: fmt.Sprintf("c%d", i)})
+	}
+	if got := Body("container", many); !strings.HasSuffix(got, "… and 2 more") {
+		t.Errorf("Body() = %q, want the rest summarized", got)
+	}
+
+	got = Body("volume", []client.PruneCandidate{
+		{Name: "old", Size: 1024},
+		{Name: "unknown", Size: 4096, Skipped: "unknown age"},
+	})
+	if !strings.HasPrefix(got, "Remove 1 volume, freeing 1.0 KB?") {
+		t.Errorf("Body() = %q, want the skipped volume left out of the count", got)
+	}
+	if !strings.Contains(got, "Skipped 1 volume:") || !strings.HasSuffix(got, "unknown age") {
+		t.Errorf("Body() = %q, want the skipped volume listed with its reason", got)
+	}
+}
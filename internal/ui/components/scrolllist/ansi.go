@@ -0,0 +1,172 @@
+package scrolllist
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/GustavoCaso/docker-dash/internal/ui/theme"
+)
+
+// span is a run of text along with the SGR sequences (colors and attributes)
+// in effect for it.
+type span struct {
+	sgr  string
+	text string
+}
+
+// parseSpans splits s into runs of text, keeping the SGR sequences in effect
+// for each. Other escape sequences and control characters, which would move
+// the cursor or otherwise garble the list, are dropped.
+func parseSpans(s string) []span {
+	if !strings.ContainsRune(s, ansi.ESC) {
+		return []span{{text: s}}
+	}
+	var spans []span
+	var sgr, text strings.Builder
+	flush := func() {
+		if text.Len() > 0 {
+			spans = append(spans, span{sgr: sgr.String(), text: text.String()})
+			text.Reset()
+		}
+	}
+	var state byte
+	for len(s) > 0 {
+		seq, width, n, newState := ansi.DecodeSequence(s, state, nil)
+		state = newState
+		s = s[n:]
+		switch {
+		case width > 0 || seq == "\t":
+			text.WriteString(seq)
+		case isSGR(seq):
+			flush()
+			params, reset := sgrAfterReset(seq[2 : len(seq)-1])
+			if reset {
+				sgr.Reset()
+			}
+			if params != "" {
+				sgr.WriteString("\x1b[" + params + "m")
+			}
+		}
+	}
+	flush()
+	return spans
+}
+
+// isSGR reports whether seq is a CSI SGR sequence such as "\x1b[1;31m".
+func isSGR(seq string) bool {
+	if len(seq) < len("\x1b[m") || !strings.HasPrefix(seq, "\x1b[") || seq[len(seq)-1] != 'm' {
+		return false
+	}
+	return strings.Trim(seq[2:len(seq)-1], "0123456789;:") == ""
+}
+
+// sgrAfterReset returns the SGR parameters following the last reset in
+// params, reporting whether there is a reset. The parameters of extended
+// colors, as in "38;5;0", are never taken as a reset.
+func sgrAfterReset(params string) (string, bool) {
+	if params == "" {
+		return "", true
+	}
+	parts := strings.Split(params, ";")
+	last := -1
+	for i := 0; i < len(parts); i++ {
+		switch parts[i] {
+		case "", "0":
+			last = i
+		case "38", "48", "58":
+			if i+1 < len(parts) && parts[i+1] == "5" {
+				i += 2
+			} else if i+1 < len(parts) && parts[i+1] == "2" {
+				i += 4
+			}
+		}
+	}
+	if last < 0 {
+		return params, false
+	}
+	return strings.Join(parts[last+1:], ";"), true
+}
+
+// spansWidth returns the width of the text of spans in cells.
+func spansWidth(spans []span) int {
+	width := 0
+	for _, sp := range spans {
+		width += ansi.StringWidth(sp.text)
+	}
+	return width
+}
+
+// cutSpans returns the part of spans between the cells left and right.
+func cutSpans(spans []span, left, right int) []span {
+	var cut []span
+	pos := 0
+	for _, sp := range spans {
+		width := ansi.StringWidth(sp.text)
+		from, to := max(left-pos, 0), min(right-pos, width)
+		if from < to {
+			if from == 0 && to == width {
+				cut = append(cut, sp)
+			} else {
+				cut = append(cut, span{sgr: sp.sgr, text: ansi.Cut(sp.text, from, to)})
+			}
+		}
+		pos += width
+		if pos >= right {
+			break
+		}
+	}
+	return cut
+}
+
+// renderSpans renders spans with render, rendering the non-empty matches of
+// re with theme.SearchMatch instead. Each part is rendered on its own so its
+// colors, and a match, do not reset the styling of the rest of the line.
+func renderSpans(spans []span, re *regexp.Regexp, render func(...string) string) string {
+	var matches [][]int
+	if re != nil {
+		var plain strings.Builder
+		for _, sp := range spans {
+			plain.WriteString(sp.text)
+		}
+		for _, loc := range re.FindAllStringIndex(plain.String(), -1) {
+			if loc[0] != loc[1] {
+				matches = append(matches, loc)
+			}
+		}
+	}
+
+	var b strings.Builder
+	base := 0 // offset of the span text in the line
+	for _, sp := range spans {
+		end := base + len(sp.text)
+		for start := base; start < end; {
+			// Render up to the next match boundary.
+			next, matched := end, false
+			for len(matches) > 0 && matches[0][1] <= start {
+				matches = matches[1:]
+			}
+			if len(matches) > 0 {
+				if loc := matches[0]; loc[0] <= start {
+					next, matched = min(loc[1], end), true
+				} else {
+					next = min(loc[0], end)
+				}
+			}
+			text := sp.text[start-base : next-base]
+			start = next
+			switch {
+			case matched:
+				b.WriteString(theme.SearchMatch.Render(text))
+			case sp.sgr != "":
+				b.WriteString(render(sp.sgr + text))
+				b.WriteString(ansi.ResetStyle)
+			default:
+				b.WriteString(render(text))
+			}
+		}
+		base = end
+	}
+	return b.String()
+}
@@ -0,0 +1,81 @@
+package scrolllist
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/GustavoCaso/docker-dash/internal/ui/theme"
+)
+
+func TestParseSpansKeepsOnlySGR(t *testing.T) {
+	got := parseSpans("\x1b[1;32mPASS\x1b[0m \x1b[2Kok\x1b]0;title\x07 \x1b[38;5;0mdim\x1b[m")
+	want := []span{
+		{sgr: "\x1b[1;32m", text: "PASS"},
+		{text: " ok "},
+		{sgr: "\x1b[38;5;0m", text: "dim"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("parseSpans() = %q, want %q", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("span %d = %q, want %q", i, got[i], want[i])
+		}
+	}
+}
+
+func TestSGRAfterReset(t *testing.T) {
+	tests := []struct {
+		params    string
+		want      string
+		wantReset bool
+	}{
+		{params: "", want: "", wantReset: true},
+		{params: "0", want: "", wantReset: true},
+		{params: "0;31", want: "31", wantReset: true},
+		{params: "1;31", want: "1;31", wantReset: false},
+		{params: "38;5;0", want: "38;5;0", wantReset: false},
+		{params: "48;2;0;0;0;0;4", want: "4", wantReset: true},
+	}
+	for _, tt := range tests {
+		got, reset := sgrAfterReset(tt.params)
+		if got != tt.want || reset != tt.wantReset {
+			t.Errorf("sgrAfterReset(%q) = %q, %v, want %q, %v", tt.params, got, reset, tt.want, tt.wantReset)
+		}
+	}
+}
+
+func TestRenderLineScrollsAcrossEscapes(t *testing.T) {
+	content := "\x1b[31m0123456789\x1b[0m\x1b[34mabcdefghij\x1b[0m"
+	var b strings.Builder
+	renderLine(&b, Line{Content: content}, 10, true, 5, nil)
+	got := b.String()
+	if plain := ansi.Strip(got); plain != "56789abcde" {
+		t.Errorf("scrolled line shows %q, want %q", plain, "56789abcde")
+	}
+	if !strings.Contains(got, "\x1b[31m56789") || !strings.Contains(got, "\x1b[34mabcde") {
+		t.Errorf("scrolled line %q should keep the colors of both parts", got)
+	}
+
+	b.Reset()
+	renderLine(&b, Line{Content: content}, 10, false, 0, nil)
+	if plain := ansi.Strip(b.String()); plain != "012345678…" {
+		t.Errorf("truncated line shows %q, want %q", plain, "012345678…")
+	}
+	if !strings.HasSuffix(b.String(), "…") {
+		t.Errorf("truncated line %q should reset the colors before the ellipsis", b.String())
+	}
+}
+
+func TestRenderSpansHighlightsAcrossColors(t *testing.T) {
+	spans := parseSpans("\x1b[31mfatal er\x1b[0mror here")
+	got := renderSpans(spans, regexp.MustCompile(`error`), plain)
+	want := "\x1b[31mfatal " + ansi.ResetStyle + theme.SearchMatch.Render("er") + theme.SearchMatch.Render("ror") +
+		" here"
+	if got != want {
+		t.Errorf("renderSpans() = %q, want %q", got, want)
+	}
+}
@@ -3,10 +3,13 @@ package scrolllist
 import (
 	"fmt"
 	"io"
+	"regexp"
+	"strings"
 
 	"charm.land/bubbles/v2/key"
 	"charm.land/bubbles/v2/list"
 	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
 
 	"github.com/GustavoCaso/docker-dash/internal/ui/keys"
 	"github.com/GustavoCaso/docker-dash/internal/ui/theme"
@@ -20,15 +23,29 @@ const (
 // line is a single text line displayed in the list.
 type line struct {
 	Content string
+	// style renders the line when it is not selected; nil renders it as is.
+	style *lipgloss.Style
+	// prefix is rendered before the content and does not scroll.
+	prefix *Prefix
+}
+
+// Prefix is a label rendered at the start of a line, such as the name of the
+// container that wrote it. It stays in place while the line scrolls
+// horizontally and is not searched or highlighted.
+type Prefix struct {
+	Text  string
+	Style lipgloss.Style
 }
 
 func (l line) Title() string       { return l.Content }
 func (l line) Description() string { return "" }
 func (l line) FilterValue() string { return l.Content }
 
-// delegate is the list item delegate that handles horizontal scrolling.
+// delegate is the list item delegate that handles horizontal scrolling and
+// highlights the matches of an optional search pattern.
 type delegate struct {
-	hOffset int
+	hOffset   int
+	highlight *regexp.Regexp
 }
 
 // newDelegate creates a new delegate with zero offset.
@@ -50,28 +67,53 @@ func (d *delegate) Render(w io.Writer, m list.Model, index int, item list.Item)
 	if !ok {
 		return
 	}
-	width := m.Width()
+	renderLine(w, Line{Content: lineItem.Content, Style: lineItem.style, Prefix: lineItem.prefix},
+		m.Width(), index == m.Index(), d.hOffset, d.highlight)
+}
+
+// renderLine renders l within width. The selected line starts at hOffset
+// and is not truncated with an ellipsis. SGR sequences in the content are
+// kept; offsets and widths count cells, not bytes or escape codes.
+func renderLine(w io.Writer, l Line, width int, selected bool, hOffset int, highlight *regexp.Regexp) {
+	if l.Prefix != nil {
+		prefixWidth := lipgloss.Width(l.Prefix.Text)
+		if width-prefixWidth >= 2 { //nolint:mnd // minimum width for content + ellipsis
+			fmt.Fprint(w, l.Prefix.Style.Render(l.Prefix.Text))
+			width -= prefixWidth
+		}
+	}
 	if width < 2 { //nolint:mnd // minimum width for content + ellipsis
 		return
 	}
-	content := lineItem.Content
-	if index == m.Index() {
-		runes := []rune(content)
-		start := min(d.hOffset, max(0, len(runes)-1))
-		visible := string(runes[start:])
-		if len([]rune(visible)) > width {
-			visible = string([]rune(visible)[:width])
-		}
-		fmt.Fprint(w, theme.SelectedLogLine.Render(visible))
-	} else {
-		runes := []rune(content)
-		if len(runes) > width-ellipsisWidth {
-			content = string(runes[:width-ellipsisWidth]) + "…"
-		}
-		fmt.Fprint(w, content)
+	spans := parseSpans(l.Content)
+	contentWidth := spansWidth(spans)
+	if selected {
+		start := min(hOffset, max(0, contentWidth-1))
+		fmt.Fprint(w, renderSpans(cutSpans(spans, start, start+width), highlight, theme.SelectedLogLine.Render))
+		return
+	}
+	if contentWidth > width-ellipsisWidth {
+		spans = append(cutSpans(spans, 0, width-ellipsisWidth), span{text: "…"})
+	}
+	render := plain
+	if l.Style != nil {
+		render = l.Style.Render
+	}
+	fmt.Fprint(w, renderSpans(spans, highlight, render))
+}
+
+// maxHOffset returns the furthest the line with content can scroll within
+// width.
+func maxHOffset(content string, prefix *Prefix, width int) int {
+	if prefix != nil {
+		width -= lipgloss.Width(prefix.Text)
 	}
+	return max(0, spansWidth(parseSpans(content))-width)
 }
 
+// plain renders strs unstyled.
+func plain(strs ...string) string { return strings.Join(strs, "") }
+
 // Model is a scrollable list that supports horizontal scrolling on the selected line.
 type Model struct {
 	list      list.Model
@@ -109,6 +151,38 @@ func (m *Model) AppendLine(content string) {
 	m.list.InsertItem(len(m.list.Items()), line{Content: content})
 }
 
+// AppendStyledLine adds a line at the end of the list that is rendered with
+// style when it is not selected.
+func (m *Model) AppendStyledLine(content string, style lipgloss.Style) {
+	m.list.InsertItem(len(m.list.Items()), line{Content: content, style: &style})
+}
+
+// AppendPrefixedLine adds a line at the end of the list that starts with
+// prefix. A nil style renders the content as is when it is not selected.
+func (m *Model) AppendPrefixedLine(prefix Prefix, content string, style *lipgloss.Style) {
+	m.list.InsertItem(len(m.list.Items()), line{Content: content, style: style, prefix: &prefix})
+}
+
+// SetHighlight highlights the matches of re in every rendered line. A nil re
+// turns highlighting off.
+func (m *Model) SetHighlight(re *regexp.Regexp) {
+	m.delegate.highlight = re
+}
+
+// Select moves the selection to the line at index.
+func (m *Model) Select(index int) {
+	m.list.Select(index)
+	if index != m.prevIndex {
+		m.delegate.hOffset = 0
+		m.prevIndex = index
+	}
+}
+
+// Index returns the index of the selected line.
+func (m *Model) Index() int {
+	return m.list.Index()
+}
+
 // Reset clears all items and resets scroll state.
 func (m *Model) Reset() {
 	m.list.SetItems([]list.Item{})
@@ -132,7 +206,7 @@ func (m *Model) Update(msg tea.Msg) tea.Cmd {
 			selected := m.list.SelectedItem()
 			if selected != nil {
 				if lineItem, lineOk := selected.(line); lineOk {
-					maxOffset := max(0, len([]rune(lineItem.Content))-m.list.Width())
+					maxOffset := maxHOffset(lineItem.Content, lineItem.prefix, m.list.Width())
 					m.delegate.hOffset = min(m.delegate.hOffset+hScrollStep, maxOffset)
 				}
 			}
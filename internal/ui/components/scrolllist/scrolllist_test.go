@@ -1,11 +1,16 @@
 package scrolllist
 
 import (
+	"regexp"
 	"strings"
 	"testing"
 
 	"charm.land/bubbles/v2/list"
 	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/GustavoCaso/docker-dash/internal/ui/theme"
 )
 
 func newModel(width, height int) *Model {
@@ -226,3 +231,72 @@ func TestDelegateAppliesHOffsetOnSelectedLine(t *testing.T) {
 		t.Errorf("hOffset not applied: first 5 chars still visible in %q", rendered)
 	}
 }
+
+func TestRenderSpansHighlightsMatches(t *testing.T) {
+	re := regexp.MustCompile(`err\w*`)
+	wrap := func(strs ...string) string { return "[" + strings.Join(strs, "") + "]" }
+
+	got := renderSpans(parseSpans("an error and more errors"), re, wrap)
+	want := "[an ]" + theme.SearchMatch.Render("error") + "[ and more ]" + theme.SearchMatch.Render("errors")
+	if got != want {
+		t.Errorf("renderSpans() = %q, want %q", got, want)
+	}
+
+	if got := renderSpans(parseSpans("all good"), re, wrap); got != "[all good]" {
+		t.Errorf("renderSpans(no match) = %q, want %q", got, "[all good]")
+	}
+	if got := renderSpans(parseSpans("abc"), regexp.MustCompile(`x*`), wrap); got != "[abc]" {
+		t.Errorf("renderSpans(empty matches) = %q, want %q", got, "[abc]")
+	}
+}
+
+func TestSelectResetsHOffset(t *testing.T) {
+	m := newModel(10, 10)
+	m.SetLines([]string{strings.Repeat("x", 50), "second"})
+	m.Update(tea.KeyPressMsg{Code: tea.KeyRight})
+	if m.delegate.hOffset == 0 {
+		t.Fatal("expected non-zero hOffset after scrolling right")
+	}
+
+	m.Select(1)
+	if m.Index() != 1 {
+		t.Errorf("Index() = %d, want 1", m.Index())
+	}
+	if m.delegate.hOffset != 0 {
+		t.Errorf("Select should reset hOffset, got %d", m.delegate.hOffset)
+	}
+}
+
+func TestAppendStyledLineRendersWithStyleWhenNotSelected(t *testing.T) {
+	m := newModel(80, 10)
+	m.AppendLine("first")
+	m.AppendStyledLine("second", theme.LogStderrStyle)
+
+	items := m.Items()
+	var buf strings.Builder
+	m.delegate.Render(&buf, m.list, 1, items[1])
+	if got, want := buf.String(), theme.LogStderrStyle.Render("second"); got != want {
+		t.Errorf("styled line rendered %q, want %q", got, want)
+	}
+}
+
+func TestAppendPrefixedLineKeepsPrefixWhileScrolling(t *testing.T) {
+	m := newModel(20, 10)
+	prefix := Prefix{Text: "api | ", Style: lipgloss.NewStyle()}
+	m.AppendPrefixedLine(prefix, "0123456789abcdefghij", nil)
+	m.Update(tea.KeyPressMsg{Code: tea.KeyRight})
+
+	items := m.Items()
+	var buf strings.Builder
+	m.delegate.Render(&buf, m.list, 0, items[0])
+	got := ansi.Strip(buf.String())
+	if !strings.HasPrefix(got, "api | ") {
+		t.Errorf("prefixed line rendered %q, want it to start with the prefix", got)
+	}
+	if strings.Contains(got, "0123") {
+		t.Errorf("content should scroll past the prefix, got %q", got)
+	}
+	if lipgloss.Width(got) > 20 {
+		t.Errorf("prefixed line is %d wide, want at most the list width", lipgloss.Width(got))
+	}
+}
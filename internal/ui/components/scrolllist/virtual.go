@@ -0,0 +1,219 @@
+package scrolllist
+
+import (
+	"regexp"
+	"strings"
+
+	"charm.land/bubbles/v2/key"
+	"charm.land/bubbles/v2/list"
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+
+	"github.com/GustavoCaso/docker-dash/internal/ui/keys"
+)
+
+// Line is a line provided by a Source. A nil Style renders the content as is
+// when the line is not selected; a non-nil Prefix is rendered before the
+// content and does not scroll.
+type Line struct {
+	Content string
+	Style   *lipgloss.Style
+	Prefix  *Prefix
+}
+
+// Source provides the lines shown by a Virtual list. Lines are requested when
+// they are rendered, so a source may hold far more lines than fit in memory
+// as strings.
+type Source interface {
+	Len() int
+	Line(index int) Line
+}
+
+// Virtual is a scrollable list over a Source that renders only the visible
+// lines, so its cost does not grow with the number of lines. It supports
+// horizontal scrolling on the selected line like Model.
+type Virtual struct {
+	src       Source
+	keys      list.KeyMap
+	cursor    int
+	offset    int // index of the first visible line
+	hOffset   int
+	highlight *regexp.Regexp
+	width     int
+	height    int
+}
+
+// emptySource is the Source of a Virtual list before SetSource is called.
+type emptySource struct{}
+
+func (emptySource) Len() int      { return 0 }
+func (emptySource) Line(int) Line { return Line{} }
+
+// NewVirtual creates a Virtual list with no lines.
+func NewVirtual() Virtual {
+	return Virtual{src: emptySource{}, keys: list.DefaultKeyMap()}
+}
+
+// SetSource sets the lines shown by the list. The selection is kept in range.
+func (v *Virtual) SetSource(src Source) {
+	v.src = src
+	v.clamp()
+}
+
+// Len returns the number of lines.
+func (v *Virtual) Len() int {
+	return v.src.Len()
+}
+
+// Index returns the index of the selected line.
+func (v *Virtual) Index() int {
+	return v.cursor
+}
+
+// Select moves the selection to the line at index.
+func (v *Virtual) Select(index int) {
+	if index != v.cursor {
+		v.hOffset = 0
+	}
+	v.cursor = index
+	v.clamp()
+}
+
+// Shift tells the list that n lines were removed from the start of its
+// source, so the selection stays on the same line while it exists.
+func (v *Virtual) Shift(n int) {
+	if n <= 0 {
+		return
+	}
+	if v.cursor < n {
+		v.hOffset = 0
+	}
+	v.cursor -= n
+	v.offset -= n
+	v.clamp()
+}
+
+// Reset moves the selection back to the first line and clears the
+// horizontal scroll.
+func (v *Virtual) Reset() {
+	v.cursor = 0
+	v.offset = 0
+	v.hOffset = 0
+}
+
+// SetHighlight highlights the matches of re in every rendered line. A nil re
+// turns highlighting off.
+func (v *Virtual) SetHighlight(re *regexp.Regexp) {
+	v.highlight = re
+}
+
+// SetSize sets the width and height of the list.
+func (v *Virtual) SetSize(width, height int) {
+	v.width = width
+	v.height = height
+	v.clamp()
+}
+
+// Width returns the list width.
+func (v *Virtual) Width() int {
+	return v.width
+}
+
+// Update handles the navigation and horizontal scroll keys.
+func (v *Virtual) Update(msg tea.Msg) tea.Cmd {
+	keyMsg, ok := msg.(tea.KeyPressMsg)
+	if !ok {
+		return nil
+	}
+	page := max(v.height, 1)
+	prev := v.cursor
+	switch {
+	case key.Matches(keyMsg, keys.Keys.LogScrollLeft):
+		v.hOffset = max(0, v.hOffset-hScrollStep)
+		return nil
+	case key.Matches(keyMsg, keys.Keys.LogScrollRight):
+		if v.cursor < v.src.Len() {
+			selected := v.src.Line(v.cursor)
+			maxOffset := maxHOffset(selected.Content, selected.Prefix, v.width)
+			v.hOffset = min(v.hOffset+hScrollStep, maxOffset)
+		}
+		return nil
+	case key.Matches(keyMsg, v.keys.CursorUp):
+		v.cursor--
+	case key.Matches(keyMsg, v.keys.CursorDown):
+		v.cursor++
+	case key.Matches(keyMsg, v.keys.PrevPage):
+		v.cursor -= page
+		v.offset -= page
+	case key.Matches(keyMsg, v.keys.NextPage):
+		v.cursor += page
+		v.offset += page
+	case key.Matches(keyMsg, v.keys.GoToStart):
+		v.cursor = 0
+	case key.Matches(keyMsg, v.keys.GoToEnd):
+		v.cursor = v.src.Len() - 1
+	default:
+		return nil
+	}
+	v.clamp()
+	v.skipEmpty(prev)
+	if v.cursor != prev {
+		v.hOffset = 0
+	}
+	return nil
+}
+
+// skipEmpty moves the selection off an empty line, in the direction it
+// moved from prev.
+func (v *Virtual) skipEmpty(prev int) {
+	n := v.src.Len()
+	if v.cursor >= n || v.src.Line(v.cursor).Content != "" {
+		return
+	}
+	step := 1
+	if v.cursor < prev {
+		step = -1
+	}
+	for i := v.cursor + step; i >= 0 && i < n; i += step {
+		if v.src.Line(i).Content != "" {
+			v.cursor = i
+			v.clamp()
+			return
+		}
+	}
+}
+
+// clamp keeps the selection on a line and within the visible window.
+func (v *Virtual) clamp() {
+	n := v.src.Len()
+	v.cursor = min(max(v.cursor, 0), max(n-1, 0))
+	if v.height <= 0 {
+		v.offset = 0
+		return
+	}
+	if v.cursor < v.offset {
+		v.offset = v.cursor
+	}
+	if v.cursor >= v.offset+v.height {
+		v.offset = v.cursor - v.height + 1
+	}
+	v.offset = min(max(v.offset, 0), max(n-v.height, 0))
+}
+
+// View renders the visible lines, padded to the list height.
+func (v *Virtual) View() string {
+	if v.height <= 0 {
+		return ""
+	}
+	var b strings.Builder
+	end := min(v.offset+v.height, v.src.Len())
+	for i := v.offset; i < v.offset+v.height; i++ {
+		if i > v.offset {
+			b.WriteString("\n")
+		}
+		if i < end {
+			renderLine(&b, v.src.Line(i), v.width, i == v.cursor, v.hOffset, v.highlight)
+		}
+	}
+	return b.String()
+}
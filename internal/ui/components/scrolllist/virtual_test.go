@@ -0,0 +1,137 @@
+package scrolllist
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// countingSource numbers its lines and records which ones were requested.
+type countingSource struct {
+	n         int
+	requested map[int]bool
+}
+
+func (s *countingSource) Len() int { return s.n }
+
+func (s *countingSource) Line(i int) Line {
+	s.requested[i] = true
+	return Line{Content: "line " + strconv.Itoa(i)}
+}
+
+func newVirtual(n, height int) (*Virtual, *countingSource) {
+	src := &countingSource{n: n, requested: map[int]bool{}}
+	v := NewVirtual()
+	v.SetSize(40, height)
+	v.SetSource(src)
+	return &v, src
+}
+
+func TestVirtualRendersOnlyVisibleLines(t *testing.T) {
+	v, src := newVirtual(1_000_000, 5)
+	v.Select(500_000)
+
+	view := ansi.Strip(v.View())
+	lines := strings.Split(view, "\n")
+	if len(lines) != 5 {
+		t.Fatalf("View() has %d lines, want 5", len(lines))
+	}
+	if lines[4] != "line 500000" {
+		t.Errorf("last visible line = %q, want the selected line", lines[4])
+	}
+	if len(src.requested) != 5 {
+		t.Errorf("View() requested %d lines, want only the 5 visible ones", len(src.requested))
+	}
+}
+
+func TestVirtualPadsToHeight(t *testing.T) {
+	v, _ := newVirtual(2, 4)
+	if got := strings.Count(v.View(), "\n"); got != 3 {
+		t.Errorf("View() has %d line breaks, want 3", got)
+	}
+}
+
+func TestVirtualNavigation(t *testing.T) {
+	v, _ := newVirtual(100, 10)
+
+	v.Update(tea.KeyPressMsg{Code: tea.KeyDown})
+	if v.Index() != 1 {
+		t.Errorf("down: Index() = %d, want 1", v.Index())
+	}
+	v.Update(tea.KeyPressMsg{Code: tea.KeyPgDown})
+	if v.Index() != 11 {
+		t.Errorf("page down: Index() = %d, want 11", v.Index())
+	}
+	v.Update(tea.KeyPressMsg{Code: tea.KeyEnd})
+	if v.Index() != 99 {
+		t.Errorf("end: Index() = %d, want 99", v.Index())
+	}
+	v.Update(tea.KeyPressMsg{Code: tea.KeyDown})
+	if v.Index() != 99 {
+		t.Errorf("down at the end: Index() = %d, want 99", v.Index())
+	}
+	v.Update(tea.KeyPressMsg{Code: tea.KeyHome})
+	if v.Index() != 0 {
+		t.Errorf("home: Index() = %d, want 0", v.Index())
+	}
+}
+
+func TestVirtualSkipsEmptyLines(t *testing.T) {
+	v := NewVirtual()
+	v.SetSize(40, 10)
+	v.SetSource(sliceSource{"first", "", "second"})
+
+	v.Update(tea.KeyPressMsg{Code: tea.KeyDown})
+	if v.Index() != 2 {
+		t.Errorf("expected to skip the empty line and land on 2, got %d", v.Index())
+	}
+	v.Update(tea.KeyPressMsg{Code: tea.KeyUp})
+	if v.Index() != 0 {
+		t.Errorf("expected to skip the empty line and land on 0, got %d", v.Index())
+	}
+}
+
+func TestVirtualShiftKeepsSelectedLine(t *testing.T) {
+	src := &countingSource{n: 100, requested: map[int]bool{}}
+	v := NewVirtual()
+	v.SetSize(40, 10)
+	v.SetSource(src)
+	v.Select(50)
+
+	src.n = 90
+	v.Shift(10)
+	if v.Index() != 40 {
+		t.Errorf("Shift(10): Index() = %d, want 40", v.Index())
+	}
+
+	v.Shift(60)
+	if v.Index() != 0 {
+		t.Errorf("Shift past the selection: Index() = %d, want 0", v.Index())
+	}
+}
+
+func TestVirtualScrollRightClampsAtMax(t *testing.T) {
+	v := NewVirtual()
+	v.SetSize(10, 10)
+	v.SetSource(sliceSource{"0123456789abcdef"})
+
+	for range 5 {
+		v.Update(tea.KeyPressMsg{Code: tea.KeyRight})
+	}
+	if v.hOffset != 6 {
+		t.Errorf("hOffset = %d, want 6", v.hOffset)
+	}
+	v.Update(tea.KeyPressMsg{Code: tea.KeyLeft})
+	if v.hOffset != 0 {
+		t.Errorf("hOffset after scrolling left = %d, want 0", v.hOffset)
+	}
+}
+
+// sliceSource serves fixed lines.
+type sliceSource []string
+
+func (s sliceSource) Len() int        { return len(s) }
+func (s sliceSource) Line(i int) Line { return Line{Content: s[i]} }
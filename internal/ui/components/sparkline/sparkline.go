@@ -0,0 +1,32 @@
+// Package sparkline renders series of values as a line of block characters.
+package sparkline
+
+import "strings"
+
+// blocks are the characters of increasing height a value is drawn with.
+var blocks = []rune("▁▂▃▄▅▆▇█")
+
+// Render draws values, one character each, scaled so the largest value is a
+// full block. Zero and negative values are drawn as spaces.
+func Render(values []float64) string {
+	peak := 0.0
+	for _, v := range values {
+		peak = max(peak, v)
+	}
+	return RenderScaled(values, peak)
+}
+
+// RenderScaled draws values like Render, scaled so peak is a full block.
+// Values over peak are drawn as full blocks.
+func RenderScaled(values []float64, peak float64) string {
+	var b strings.Builder
+	for _, v := range values {
+		if v <= 0 || peak <= 0 {
+			b.WriteRune(' ')
+			continue
+		}
+		i := int(v / peak * float64(len(blocks)-1))
+		b.WriteRune(blocks[min(i, len(blocks)-1)])
+	}
+	return b.String()
+}
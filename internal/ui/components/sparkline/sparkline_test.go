@@ -0,0 +1,29 @@
+package sparkline
+
+import "testing"
+
+func TestRender(t *testing.T) {
+	tests := []struct {
+		name   string
+		values []float64
+		want   string
+	}{
+		{name: "empty", values: nil, want: ""},
+		{name: "all zero", values: []float64{0, 0}, want: "  "},
+		{name: "scaled to the peak", values: []float64{0, 1, 4, 8}, want: " ▁▄█"},
+		{name: "constant", values: []float64{3, 3}, want: "██"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Render(tt.values); got != tt.want {
+				t.Errorf("Render(%v) = %q, want %q", tt.values, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRenderScaled(t *testing.T) {
+	if got := RenderScaled([]float64{0, 50, 100, 200}, 100); got != " ▄██" {
+		t.Errorf("RenderScaled() = %q, want values over the peak drawn as full blocks", got)
+	}
+}
@@ -5,10 +5,14 @@ import (
 	"fmt"
 	"strings"
 
+	"charm.land/bubbles/v2/key"
 	tea "charm.land/bubbletea/v2"
 	"charm.land/lipgloss/v2"
 
 	"github.com/GustavoCaso/docker-dash/internal/client"
+	"github.com/GustavoCaso/docker-dash/internal/ui/components/prune"
+	"github.com/GustavoCaso/docker-dash/internal/ui/helper"
+	"github.com/GustavoCaso/docker-dash/internal/ui/keys"
 	"github.com/GustavoCaso/docker-dash/internal/ui/message"
 	"github.com/GustavoCaso/docker-dash/internal/ui/theme"
 )
@@ -28,10 +32,19 @@ const (
 	defaultModalWidth  = 100
 	defaultModalHeight = 30
 	columnsNumber      = 2
+	pruneLabelWidth    = 12
+	pruneCountWidth    = 4
+	pruneSizeWidth     = 10
+	// removedColumn separates the count from the size in the prune report.
+	removedColumn = " removed "
 )
 
 var title = titleStyle.Render("System Information")
-var hintText = hintStyle.Render("[i/esc] exit")
+var hintText = hintStyle.Render("[i/esc] exit  [P] system prune")
+
+// systemPruneOptions prune what pressing P in every section would: unused
+// images and volumes go even when tagged or named.
+var systemPruneOptions = client.PruneOptions{All: true}
 
 type Model struct {
 	ctx        context.Context
@@ -39,6 +52,9 @@ type Model struct {
 	systemInfo *client.SystemInfo
 	width      int
 	height     int
+	// pruneReport is the outcome of the last system prune, nil before any.
+	pruneReport *client.SystemPruneReport
+	pruneErr    error
 }
 
 func New(ctx context.Context, c client.Client) Model {
@@ -66,17 +82,142 @@ func (m Model) Init() tea.Cmd {
 }
 
 func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
-	sysMsg, ok := msg.(message.SystemInfoOutputMsg)
-	if !ok {
-		return m, nil
+	switch msg := msg.(type) {
+	case message.SystemInfoOutputMsg:
+		if msg.Err != nil || msg.Info == nil {
+			return m, nil
+		}
+		m.systemInfo = msg.Info
+	case tea.KeyPressMsg:
+		if key.Matches(msg, keys.Keys.Prune) {
+			return m, prune.ShowForm("System Prune", systemPruneOptions, m.previewPruneCmd)
+		}
+	case message.SystemPrunePreviewMsg:
+		if msg.Err != nil {
+			return m, bannerCmd("Error previewing system prune: "+msg.Err.Error(), true)
+		}
+		return m, m.confirmPrune(msg.Opts, msg.Preview)
+	case message.SystemPrunedMsg:
+		m.pruneReport = &msg.Report
+		m.pruneErr = msg.Err
+		refresh := func() tea.Msg {
+			return message.BubbleUpMsg{KeyMsg: tea.KeyPressMsg{Code: 'r', Text: "r"}, OnlyActive: false}
+		}
+		if msg.Err != nil {
+			return m, tea.Batch(bannerCmd("Error running system prune: "+msg.Err.Error(), true), refresh, m.Init())
+		}
+		reclaimed := helper.FormatSize(msg.Report.SpaceReclaimed())
+		return m, tea.Batch(bannerCmd("System prune reclaimed "+reclaimed, false), refresh, m.Init())
+	}
+	return m, nil
+}
+
+func (m Model) previewPruneCmd(opts client.PruneOptions) tea.Cmd {
+	return func() tea.Msg {
+		preview, err := client.PreviewSystemPrune(m.ctx, m.client, opts)
+		return message.SystemPrunePreviewMsg{Opts: opts, Preview: preview, Err: err}
 	}
+}
 
-	if sysMsg.Err != nil || sysMsg.Info == nil {
-		return m, nil
+func (m Model) pruneCmd(opts client.PruneOptions) tea.Cmd {
+	return func() tea.Msg {
+		report, err := client.SystemPrune(m.ctx, m.client, opts)
+		return message.SystemPrunedMsg{Report: report, Err: err}
 	}
+}
 
-	m.systemInfo = sysMsg.Info
-	return m, nil
+func (m Model) confirmPrune(opts client.PruneOptions, preview client.SystemPrunePreview) tea.Cmd {
+	onConfirm := tea.Batch(bannerCmd("Running system prune...", false), m.pruneCmd(opts))
+	return func() tea.Msg {
+		return message.ShowConfirmationMsg{
+			Title:     "System Prune",
+			Body:      previewBody(opts, preview),
+			OnConfirm: onConfirm,
+		}
+	}
+}
+
+// previewBody lists how many objects of each type the system prune removes
+// and the space they hold.
+func previewBody(opts client.PruneOptions, preview client.SystemPrunePreview) string {
+	var b strings.Builder
+	b.WriteString("Remove these unused objects?\n")
+	var total int64
+	for _, row := range []struct {
+		label      string
+		candidates []client.PruneCandidate
+	}{
+		{"Containers", preview.Containers},
+		{"Networks", preview.Networks},
+		{"Volumes", preview.Volumes},
+		{"Images", preview.Images},
+	} {
+		removed, skipped := prune.Split(row.candidates)
+		var size int64
+		for _, c := range removed {
+			size += c.Size
+		}
+		total += size
+		fmt.Fprintf(&b, "\n%-*s %*d %*s", pruneLabelWidth, row.label, pruneCountWidth, len(removed),
+			pruneSizeWidth, optionalSize(size))
+		if len(skipped) > 0 {
+			fmt.Fprintf(&b, "  %d skipped", len(skipped))
+		}
+	}
+	if len(opts.Labels) > 0 {
+		fmt.Fprintf(&b, "\n%-*s skipped, its records have no labels", pruneLabelWidth, "Build cache")
+		fmt.Fprintf(&b, "\n\nFrees %s.", helper.FormatSize(total))
+		return b.String()
+	}
+	fmt.Fprintf(&b, "\n%-*s unused records", pruneLabelWidth, "Build cache")
+	fmt.Fprintf(&b, "\n\nFrees %s plus the build cache.", helper.FormatSize(total))
+	return b.String()
+}
+
+// reportView renders the outcome of the last system prune per object type.
+func reportView(report client.SystemPruneReport, err error) string {
+	var b strings.Builder
+	b.WriteString(diskTitleStyle.Render("Last System Prune") + "\n")
+	rows := []struct {
+		label  string
+		report client.PruneReport
+	}{
+		{"Containers", report.Containers},
+		{"Networks", report.Networks},
+		{"Volumes", report.Volumes},
+		{"Images", report.Images},
+		{"Build cache", report.BuildCache},
+	}
+	for _, row := range rows {
+		if row.label == "Build cache" && report.BuildCacheSkipped {
+			fmt.Fprintf(&b, "\n%-*s skipped", pruneLabelWidth, row.label)
+			continue
+		}
+		fmt.Fprintf(&b, "\n%-*s %*d%s%*s", pruneLabelWidth, row.label, pruneCountWidth,
+			row.report.ItemsDeleted, removedColumn, pruneSizeWidth, optionalSize(row.report.SpaceReclaimed))
+	}
+	// The total lines up with the sizes above it.
+	fmt.Fprintf(&b, "\n%-*s %*s", pruneLabelWidth, "Total", pruneCountWidth+len(removedColumn)+pruneSizeWidth,
+		helper.FormatSize(report.SpaceReclaimed()))
+	if err != nil {
+		fmt.Fprintf(&b, "\n\nStopped: %v", err)
+	}
+	return b.String()
+}
+
+// optionalSize is the formatted size, or blank for 0 since networks free no
+// space.
+func optionalSize[T int64 | uint64](b T) string {
+	if b == 0 {
+		return ""
+	}
+	return helper.FormatSize(b)
+}
+
+func bannerCmd(text string, isError bool) tea.Cmd {
+	return func() tea.Msg {
+		return message.ShowBannerMsg{Message: text, IsError: isError}
+	}
 }
 
 func (m Model) View() string {
@@ -121,6 +262,7 @@ func (m Model) View() string {
 	containersSize := formatBytes(m.systemInfo.DiskUsage.ContainersSize)
 	imagesSize := formatBytes(m.systemInfo.DiskUsage.ImagesSize)
 	volumesSize := formatBytes(m.systemInfo.DiskUsage.VolumesSize)
+	buildCacheSize := formatBytes(m.systemInfo.DiskUsage.BuildCacheSize)
 
 	var rightCol strings.Builder
 	rightCol.WriteString("\n\n")
@@ -130,7 +272,8 @@ func (m Model) View() string {
 	fmt.Fprintf(&rightCol, "Layer size: %s\n\n", layerSize)
 	fmt.Fprintf(&rightCol, "Containers size: %s\n\n", containersSize)
 	fmt.Fprintf(&rightCol, "Images size: %s\n\n", imagesSize)
-	fmt.Fprintf(&rightCol, "Volumes size: %s", volumesSize)
+	fmt.Fprintf(&rightCol, "Volumes size: %s\n\n", volumesSize)
+	fmt.Fprintf(&rightCol, "Build cache size: %s", buildCacheSize)
 
 	columns := lipgloss.JoinHorizontal(
 		lipgloss.Left,
@@ -153,6 +296,16 @@ func (m Model) View() string {
 		columns,
 		lipgloss.NewStyle().Width(modalWidth).Render(warnings.String()),
 	)
+	if m.pruneReport != nil {
+		report := reportView(*m.pruneReport, m.pruneErr)
+		// Pad the rows to one width so the report is centred as a block.
+		report = lipgloss.NewStyle().Width(lipgloss.Width(report)).Render(report)
+		content = lipgloss.JoinVertical(
+			lipgloss.Top,
+			content,
+			lipgloss.PlaceHorizontal(modalWidth, lipgloss.Center, report),
+		)
+	}
 
 	return modalStyle.Width(modalWidth).Height(modalHeight).Render(content)
 }
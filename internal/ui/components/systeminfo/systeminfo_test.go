@@ -2,9 +2,12 @@ package systeminfo
 
 import (
 	"context"
+	"errors"
 	"strings"
 	"testing"
 
+	tea "charm.land/bubbletea/v2"
+
 	"github.com/GustavoCaso/docker-dash/internal/client"
 	"github.com/GustavoCaso/docker-dash/internal/ui/message"
 )
@@ -71,3 +74,72 @@ func TestSystemInfoViewWithoutInfo(t *testing.T) {
 		t.Errorf("view does not contain Loading state")
 	}
 }
+
+func TestSystemPruneFlow(t *testing.T) {
+	c := client.NewMockClient()
+	m := New(context.Background(), c)
+
+	if _, ok := mustUpdate(t, &m, tea.KeyPressMsg{Code: 'P', Text: "P"}).(message.ShowFormMsg); !ok {
+		t.Fatal("P should open the system prune filters")
+	}
+
+	preview := m.previewPruneCmd(systemPruneOptions)()
+	confirm, ok := mustUpdate(t, &m, preview).(message.ShowConfirmationMsg)
+	if !ok {
+		t.Fatal("the dry-run should ask for confirmation")
+	}
+	for _, want := range []string{"Containers", "Networks", "Volumes", "Images", "Build cache"} {
+		if !strings.Contains(confirm.Body, want) {
+			t.Errorf("confirmation body = %q, want it to contain %q", confirm.Body, want)
+		}
+	}
+
+	m, _ = m.Update(m.pruneCmd(systemPruneOptions)())
+	if m.pruneReport == nil || m.pruneErr != nil {
+		t.Fatalf("pruneReport = %+v, err = %v, want a report", m.pruneReport, m.pruneErr)
+	}
+	m, _ = m.Update(m.Init()())
+	view := m.View()
+	for _, want := range []string{"Last System Prune", "Build cache", "Total"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("View() should contain %q:\n%s", want, view)
+		}
+	}
+}
+
+func TestSystemPruneError(t *testing.T) {
+	m := New(context.Background(), client.NewMockClient())
+	report := client.SystemPruneReport{Containers: client.PruneReport{ItemsDeleted: 2, SpaceReclaimed: 2048}}
+
+	m, cmd := m.Update(message.SystemPrunedMsg{Report: report, Err: errors.New("networks: daemon busy")})
+	if cmd == nil {
+		t.Fatal("a failed system prune should report it")
+	}
+	got := reportView(*m.pruneReport, m.pruneErr)
+	for _, want := range []string{"Containers      2 removed", "Stopped: networks: daemon busy"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("reportView() = %q, want it to contain %q", got, want)
+		}
+	}
+}
+
+func TestPreviewBodySkipsBuildCacheWithLabels(t *testing.T) {
+	body := previewBody(client.PruneOptions{Labels: []string{"env=test"}}, client.SystemPrunePreview{
+		Volumes: []client.PruneCandidate{{Name: "data", Size: 1024}},
+	})
+	if !strings.Contains(body, "Build cache  skipped") || !strings.Contains(body, "Frees 1.0 KB.") {
+		t.Errorf("previewBody() = %q, want the build cache skipped", body)
+	}
+}
+
+// mustUpdate runs msg through m and returns the message of the command it
+// returns.
+func mustUpdate(t *testing.T, m *Model, msg tea.Msg) tea.Msg {
+	t.Helper()
+	updated, cmd := m.Update(msg)
+	*m = updated
+	if cmd == nil {
+		t.Fatalf("Update(%T) returned no command", msg)
+	}
+	return cmd()
+}
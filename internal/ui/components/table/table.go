@@ -0,0 +1,228 @@
+// Package table lays list items out as rows of configurable columns under a
+// header, sorted by any of the columns.
+package table
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+
+	"charm.land/bubbles/v2/list"
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/GustavoCaso/docker-dash/internal/config"
+	"github.com/GustavoCaso/docker-dash/internal/ui/sections"
+)
+
+const (
+	// minFlexWidth is the narrowest a column without a fixed width gets.
+	minFlexWidth = 8
+	separator    = " "
+	ellipsis     = "…"
+)
+
+// Column is a column a section can show in its table.
+type Column struct {
+	// Key names the column in the config.
+	Key string
+	// Title is the header of the column.
+	Title string
+	// Width is the width of the column. 0 makes it share the width left by
+	// the fixed columns.
+	Width int
+	// Right aligns the values to the right, as for sizes.
+	Right bool
+	// Value renders the value of item in the column.
+	Value func(item sections.ListItem) string
+	// Compare orders two items by the column. nil compares their values.
+	Compare func(a, b sections.ListItem) int
+}
+
+// Table holds the columns a section can show and its table layout.
+type Table struct {
+	columns []Column
+	cfg     config.TableConfig
+}
+
+// New returns a table offering columns, laid out as cfg says.
+func New(columns []Column, cfg config.TableConfig) *Table {
+	return &Table{columns: columns, cfg: cfg}
+}
+
+// Config returns the layout of the table, to save it.
+func (t *Table) Config() config.TableConfig {
+	return t.cfg
+}
+
+// Enabled reports whether the items are shown as table rows.
+func (t *Table) Enabled() bool {
+	return t.cfg.Enabled
+}
+
+// SetEnabled shows the items as table rows, or in the list layout.
+func (t *Table) SetEnabled(enabled bool) {
+	t.cfg.Enabled = enabled
+}
+
+// Available returns every column the table offers.
+func (t *Table) Available() []Column {
+	return t.columns
+}
+
+// Columns returns the columns shown, in order.
+func (t *Table) Columns() []Column {
+	if len(t.cfg.Columns) == 0 {
+		return t.columns
+	}
+	shown := make([]Column, 0, len(t.cfg.Columns))
+	for _, key := range t.cfg.Columns {
+		if c, ok := t.column(key); ok {
+			shown = append(shown, c)
+		}
+	}
+	return shown
+}
+
+// SetColumns shows the columns named by keys, in order. Unknown keys are
+// ignored and none left shows every column.
+func (t *Table) SetColumns(keys []string) {
+	t.cfg.Columns = nil
+	for _, key := range keys {
+		if _, ok := t.column(key); ok {
+			t.cfg.Columns = append(t.cfg.Columns, key)
+		}
+	}
+}
+
+// CycleSort sorts the rows by the next column shown, in ascending order.
+func (t *Table) CycleSort() {
+	shown := t.Columns()
+	if len(shown) == 0 {
+		return
+	}
+	next := 0
+	for i, c := range shown {
+		if c.Key == t.cfg.Sort {
+			next = (i + 1) % len(shown)
+			break
+		}
+	}
+	t.cfg.Sort = shown[next].Key
+	t.cfg.Descending = false
+}
+
+// ReverseSort flips the order of the rows.
+func (t *Table) ReverseSort() {
+	t.cfg.Descending = !t.cfg.Descending
+}
+
+// Sort orders items by the sort column, keeping the order of equal items.
+// Without a sort column the items are left as they are.
+func (t *Table) Sort(items []list.Item) {
+	column, ok := t.column(t.cfg.Sort)
+	if !ok {
+		return
+	}
+	compare := column.Compare
+	if compare == nil {
+		compare = func(a, b sections.ListItem) int { return cmp.Compare(column.Value(a), column.Value(b)) }
+	}
+	slices.SortStableFunc(items, func(a, b list.Item) int {
+		itemA, okA := a.(sections.ListItem)
+		itemB, okB := b.(sections.ListItem)
+		if !okA || !okB {
+			return 0
+		}
+		if t.cfg.Descending {
+			return compare(itemB, itemA)
+		}
+		return compare(itemA, itemB)
+	})
+}
+
+// Header renders the titles of the columns shown in width, marking the sort
+// column with its direction.
+func (t *Table) Header(width int) string {
+	shown := t.Columns()
+	titles := make([]string, len(shown))
+	for i, c := range shown {
+		titles[i] = c.Title
+		if c.Key == t.cfg.Sort {
+			titles[i] += sortIndicator(t.cfg.Descending)
+		}
+	}
+	return t.layout(shown, titles, width)
+}
+
+// Row renders the values of item in the columns shown in width.
+func (t *Table) Row(item sections.ListItem, width int) string {
+	shown := t.Columns()
+	values := make([]string, len(shown))
+	for i, c := range shown {
+		values[i] = c.Value(item)
+	}
+	return t.layout(shown, values, width)
+}
+
+// layout pads or truncates each cell to the width of its column.
+func (t *Table) layout(columns []Column, cells []string, width int) string {
+	widths := columnWidths(columns, width)
+	parts := make([]string, len(columns))
+	for i, c := range columns {
+		cell := ansi.Truncate(strings.ReplaceAll(cells[i], "\n", " "), widths[i], ellipsis)
+		pad := strings.Repeat(" ", max(widths[i]-ansi.StringWidth(cell), 0))
+		if c.Right {
+			parts[i] = pad + cell
+		} else {
+			parts[i] = cell + pad
+		}
+	}
+	return ansi.Truncate(strings.Join(parts, separator), width, "")
+}
+
+// columnWidths shares the width the fixed columns leave between the others.
+func columnWidths(columns []Column, width int) []int {
+	widths := make([]int, len(columns))
+	remaining := width - len(separator)*max(len(columns)-1, 0)
+	flex := 0
+	for i, c := range columns {
+		if c.Width > 0 {
+			widths[i] = c.Width
+			remaining -= c.Width
+		} else {
+			flex++
+		}
+	}
+	if flex == 0 {
+		return widths
+	}
+	share := max(remaining/flex, minFlexWidth)
+	extra := max(remaining-share*flex, 0)
+	for i, c := range columns {
+		if c.Width > 0 {
+			continue
+		}
+		widths[i] = share
+		if extra > 0 {
+			widths[i]++
+			extra--
+		}
+	}
+	return widths
+}
+
+func (t *Table) column(key string) (Column, bool) {
+	for _, c := range t.columns {
+		if c.Key == key {
+			return c, true
+		}
+	}
+	return Column{}, false
+}
+
+func sortIndicator(descending bool) string {
+	if descending {
+		return "▼"
+	}
+	return "▲"
+}
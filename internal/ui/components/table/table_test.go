@@ -0,0 +1,111 @@
+package table
+
+import (
+	"cmp"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+
+	"charm.land/bubbles/v2/list"
+
+	"github.com/GustavoCaso/docker-dash/internal/config"
+	"github.com/GustavoCaso/docker-dash/internal/ui/sections"
+)
+
+type fakeItem struct {
+	name string
+	size int
+}
+
+func (i fakeItem) ID() string          { return i.name }
+func (i fakeItem) Title() string       { return i.name }
+func (i fakeItem) Description() string { return "" }
+func (i fakeItem) FilterValue() string { return i.name }
+func (i fakeItem) InnerItem() any      { return i }
+
+func sizeOf(item sections.ListItem) int {
+	i, _ := item.(fakeItem)
+	return i.size
+}
+
+var testColumns = []Column{
+	{Key: "name", Title: "NAME", Value: func(item sections.ListItem) string { return item.Title() }},
+	{
+		Key:     "size",
+		Title:   "SIZE",
+		Width:   6,
+		Right:   true,
+		Value:   func(item sections.ListItem) string { return strconv.Itoa(sizeOf(item)) },
+		Compare: func(a, b sections.ListItem) int { return cmp.Compare(sizeOf(a), sizeOf(b)) },
+	},
+}
+
+func names(items []list.Item) []string {
+	out := make([]string, len(items))
+	for i, item := range items {
+		out[i] = item.FilterValue()
+	}
+	return out
+}
+
+func TestColumns(t *testing.T) {
+	table := New(testColumns, config.TableConfig{})
+	if got := len(table.Columns()); got != 2 {
+		t.Fatalf("Columns() without a config = %d columns, want every column", got)
+	}
+
+	table.SetColumns([]string{"size", "unknown"})
+	if got := table.Config().Columns; !slices.Equal(got, []string{"size"}) {
+		t.Errorf("Config().Columns = %v, want [size]", got)
+	}
+	if shown := table.Columns(); len(shown) != 1 || shown[0].Key != "size" {
+		t.Errorf("Columns() = %+v, want only size", shown)
+	}
+}
+
+func TestSort(t *testing.T) {
+	items := []list.Item{fakeItem{"b", 20}, fakeItem{"c", 5}, fakeItem{"a", 20}}
+	table := New(testColumns, config.TableConfig{})
+
+	table.Sort(items)
+	if got := names(items); !slices.Equal(got, []string{"b", "c", "a"}) {
+		t.Errorf("Sort() without a sort column = %v, want the order kept", got)
+	}
+
+	table.CycleSort()
+	table.Sort(items)
+	if got := names(items); !slices.Equal(got, []string{"a", "b", "c"}) {
+		t.Errorf("Sort() by name = %v", got)
+	}
+
+	table.CycleSort()
+	table.ReverseSort()
+	table.Sort(items)
+	if got := names(items); !slices.Equal(got, []string{"a", "b", "c"}) {
+		t.Errorf("Sort() by size descending = %v, want equal sizes kept in order", got)
+	}
+	if cfg := table.Config(); cfg.Sort != "size" || !cfg.Descending {
+		t.Errorf("Config() = %+v, want size descending", cfg)
+	}
+
+	table.CycleSort()
+	if cfg := table.Config(); cfg.Sort != "name" || cfg.Descending {
+		t.Errorf("CycleSort() should wrap to name ascending, got %+v", cfg)
+	}
+}
+
+func TestRowAndHeader(t *testing.T) {
+	table := New(testColumns, config.TableConfig{Sort: "size", Descending: true})
+
+	if got := table.Header(20); got != "NAME           SIZE▼" {
+		t.Errorf("Header() = %q", got)
+	}
+	if got := table.Row(fakeItem{"web", 42}, 20); got != "web               42" {
+		t.Errorf("Row() = %q", got)
+	}
+	row := table.Row(fakeItem{"a-very-long-container-name", 1}, 20)
+	if !strings.HasPrefix(row, "a-very-long-…") || len([]rune(row)) != 20 {
+		t.Errorf("Row() = %q, want the name truncated to its column", row)
+	}
+}
@@ -20,6 +20,8 @@ type KeyMap struct {
 	Delete     key.Binding
 	Filter     key.Binding
 	CopyID     key.Binding
+	Mark       key.Binding
+	MarkAll    key.Binding
 
 	CreateAndRunContainer key.Binding
 	PullImage             key.Binding
@@ -30,6 +32,15 @@ type KeyMap struct {
 	ContainerRestart      key.Binding
 	ContainerPauseUnpause key.Binding
 	ContainerKill         key.Binding
+	ContainerMergeLogs    key.Binding
+
+	ProcessSort   key.Binding
+	ProcessSignal key.Binding
+
+	StatsWindow key.Binding
+	StatsExport key.Binding
+
+	AlertDismiss key.Binding
 
 	ComposeUp        key.Binding
 	ComposeDown      key.Binding
@@ -38,15 +49,37 @@ type KeyMap struct {
 
 	NetworkDelete key.Binding
 
+	DiskSort key.Binding
+
+	TableLayout      key.Binding
+	TableSort        key.Binding
+	TableSortReverse key.Binding
+	TableColumns     key.Binding
+
 	PanelNext key.Binding
 	PanelPrev key.Binding
 
-	LogScrollLeft  key.Binding
-	LogScrollRight key.Binding
+	LogScrollLeft   key.Binding
+	LogScrollRight  key.Binding
+	LogSearch       key.Binding
+	LogSearchNext   key.Binding
+	LogSearchPrev   key.Binding
+	LogSearchFilter key.Binding
+	LogStreams      key.Binding
+	LogFieldFilter  key.Binding
+	LogStructured   key.Binding
+	LogExpand       key.Binding
+	LogExport       key.Binding
+	LogRange        key.Binding
+	LogFold         key.Binding
+	LogFoldAll      key.Binding
+	LogPatterns     key.Binding
+	LogPatternSort  key.Binding
 
 	CpFromContainerToHost key.Binding
 
-	Prune key.Binding
+	Prune         key.Binding
+	PruneFiltered key.Binding
 
 	SystemInfo key.Binding
 	Help       key.Binding
@@ -133,6 +166,14 @@ var Keys = &KeyMap{
 		key.WithKeys("y"),
 		key.WithHelp("y", "copy ID"),
 	),
+	Mark: key.NewBinding(
+		key.WithKeys("space"),
+		key.WithHelp("space", "mark item"),
+	),
+	MarkAll: key.NewBinding(
+		key.WithKeys("ctrl+a"),
+		key.WithHelp("ctrl+a", "mark all matching filter"),
+	),
 	CreateAndRunContainer: key.NewBinding(
 		key.WithKeys("c"),
 		key.WithHelp("c", "create and run container"),
@@ -165,6 +206,30 @@ var Keys = &KeyMap{
 		key.WithKeys("K"),
 		key.WithHelp("K", "kill container"),
 	),
+	ContainerMergeLogs: key.NewBinding(
+		key.WithKeys("M"),
+		key.WithHelp("M", "merge logs of marked on/off"),
+	),
+	ProcessSort: key.NewBinding(
+		key.WithKeys("o"),
+		key.WithHelp("o", "cycle sort"),
+	),
+	ProcessSignal: key.NewBinding(
+		key.WithKeys("x"),
+		key.WithHelp("x", "signal process"),
+	),
+	StatsWindow: key.NewBinding(
+		key.WithKeys("w"),
+		key.WithHelp("w", "1m/15m/1h"),
+	),
+	StatsExport: key.NewBinding(
+		key.WithKeys("S"),
+		key.WithHelp("S", "save stats to CSV"),
+	),
+	AlertDismiss: key.NewBinding(
+		key.WithKeys("x"),
+		key.WithHelp("x", "dismiss alert"),
+	),
 	ComposeUp: key.NewBinding(
 		key.WithKeys("u"),
 		key.WithHelp("u", "compose up"),
@@ -185,6 +250,26 @@ var Keys = &KeyMap{
 		key.WithKeys("D"),
 		key.WithHelp("D", "delete network"),
 	),
+	DiskSort: key.NewBinding(
+		key.WithKeys("o"),
+		key.WithHelp("o", "cycle sort"),
+	),
+	TableLayout: key.NewBinding(
+		key.WithKeys("T"),
+		key.WithHelp("T", "table/list layout"),
+	),
+	TableSort: key.NewBinding(
+		key.WithKeys("o"),
+		key.WithHelp("o", "cycle sort column"),
+	),
+	TableSortReverse: key.NewBinding(
+		key.WithKeys("O"),
+		key.WithHelp("O", "reverse sort"),
+	),
+	TableColumns: key.NewBinding(
+		key.WithKeys("C"),
+		key.WithHelp("C", "choose and save columns"),
+	),
 	PanelNext: key.NewBinding(
 		key.WithKeys("shift+right"),
 		key.WithHelp("shift+→", "next panel"),
@@ -201,10 +286,70 @@ var Keys = &KeyMap{
 		key.WithKeys("right"),
 		key.WithHelp("→", "scroll line right"),
 	),
+	LogSearch: key.NewBinding(
+		key.WithKeys("/"),
+		key.WithHelp("/", "search logs"),
+	),
+	LogSearchNext: key.NewBinding(
+		key.WithKeys("n"),
+		key.WithHelp("n", "next match"),
+	),
+	LogSearchPrev: key.NewBinding(
+		key.WithKeys("N"),
+		key.WithHelp("N", "prev match"),
+	),
+	LogSearchFilter: key.NewBinding(
+		key.WithKeys("f"),
+		key.WithHelp("f", "only matching lines"),
+	),
+	LogStreams: key.NewBinding(
+		key.WithKeys("e"),
+		key.WithHelp("e", "stdout/stderr/both"),
+	),
+	LogFieldFilter: key.NewBinding(
+		key.WithKeys("F"),
+		key.WithHelp("F", "filter by field"),
+	),
+	LogStructured: key.NewBinding(
+		key.WithKeys("j"),
+		key.WithHelp("j", "structured/raw"),
+	),
+	LogExpand: key.NewBinding(
+		key.WithKeys("enter"),
+		key.WithHelp("enter", "expand line"),
+	),
+	LogExport: key.NewBinding(
+		key.WithKeys("S"),
+		key.WithHelp("S", "save logs to file"),
+	),
+	LogRange: key.NewBinding(
+		key.WithKeys("t"),
+		key.WithHelp("t", "time range"),
+	),
+	LogFold: key.NewBinding(
+		key.WithKeys("z"),
+		key.WithHelp("z", "fold/unfold"),
+	),
+	LogFoldAll: key.NewBinding(
+		key.WithKeys("Z"),
+		key.WithHelp("Z", "fold/unfold all"),
+	),
+	LogPatterns: key.NewBinding(
+		key.WithKeys("c"),
+		key.WithHelp("c", "patterns"),
+	),
+	LogPatternSort: key.NewBinding(
+		key.WithKeys("o"),
+		key.WithHelp("o", "sort patterns"),
+	),
 	Prune: key.NewBinding(
 		key.WithKeys("P"),
 		key.WithHelp("P", "prune"),
 	),
+	PruneFiltered: key.NewBinding(
+		key.WithKeys("ctrl+p"),
+		key.WithHelp("ctrl+p", "prune with filters"),
+	),
 	CpFromContainerToHost: key.NewBinding(
 		key.WithKeys("c"),
 		key.WithHelp("c", "copy from container to host"),
@@ -253,7 +398,9 @@ func (k KeyMap) ImageKeyMap() *ViewKeyMap {
 			{k.Left, k.Right, k.PanelNext, k.PanelPrev},
 			{k.Up, k.Down, k.Tab, k.CopyID},
 			{k.Delete, k.CreateAndRunContainer, k.Prune, k.Filter},
-			{k.PullImage, k.PullImageUpdate},
+			{k.PullImage, k.PullImageUpdate, k.PruneFiltered},
+			{k.Mark, k.MarkAll},
+			{k.TableLayout, k.TableSort, k.TableSortReverse, k.TableColumns},
 			{k.Help, k.Quit, k.SystemInfo},
 		},
 		contextualKeys: []key.Binding{},
@@ -267,7 +414,9 @@ func (k KeyMap) ContainerKeyMap() *ViewKeyMap {
 			{k.Left, k.Right, k.PanelNext, k.PanelPrev},
 			{k.Up, k.Down, k.Tab, k.CopyID},
 			{k.ContainerDelete, k.ContainerStartStop, k.ContainerRestart, k.Prune},
-			{k.ContainerPauseUnpause, k.ContainerKill, k.Filter},
+			{k.ContainerPauseUnpause, k.ContainerKill, k.Mark, k.Filter},
+			{k.ContainerMergeLogs, k.PruneFiltered, k.MarkAll},
+			{k.TableLayout, k.TableSort, k.TableSortReverse, k.TableColumns},
 			{k.Help, k.Quit, k.SystemInfo},
 		},
 		contextualKeys: []key.Binding{},
@@ -280,7 +429,8 @@ func (k KeyMap) VolumeKeyMap() *ViewKeyMap {
 		full: [][]key.Binding{
 			{k.Left, k.Right, k.PanelNext, k.PanelPrev},
 			{k.Up, k.Down, k.Tab, k.CopyID},
-			{k.Delete, k.Prune, k.Filter},
+			{k.Delete, k.Prune, k.PruneFiltered, k.Filter},
+			{k.Mark, k.MarkAll},
 			{k.Help, k.Quit, k.SystemInfo},
 		},
 		contextualKeys: []key.Binding{},
@@ -293,7 +443,8 @@ func (k KeyMap) NetworkKeyMap() *ViewKeyMap {
 		full: [][]key.Binding{
 			{k.Left, k.Right, k.PanelNext, k.PanelPrev},
 			{k.Up, k.Down, k.Tab, k.CopyID},
-			{k.NetworkDelete, k.Prune, k.Filter},
+			{k.NetworkDelete, k.Prune, k.PruneFiltered, k.Filter},
+			{k.Mark, k.MarkAll},
 			{k.Help, k.Quit, k.SystemInfo},
 		},
 		contextualKeys: []key.Binding{},
@@ -313,3 +464,16 @@ func (k KeyMap) ComposeKeyMap() *ViewKeyMap {
 		contextualKeys: []key.Binding{},
 	}
 }
+
+func (k KeyMap) DiskKeyMap() *ViewKeyMap {
+	return &ViewKeyMap{
+		short: k.navigationKeys(),
+		full: [][]key.Binding{
+			{k.Left, k.Right, k.Up, k.Down},
+			{k.Delete, k.Prune, k.DiskSort, k.CopyID},
+			{k.Refresh, k.Filter, k.Mark, k.MarkAll},
+			{k.Help, k.Quit, k.SystemInfo},
+		},
+		contextualKeys: []key.Binding{},
+	}
+}
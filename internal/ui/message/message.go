@@ -7,6 +7,7 @@ import (
 	tea "charm.land/bubbletea/v2"
 
 	"github.com/GustavoCaso/docker-dash/internal/client"
+	"github.com/GustavoCaso/docker-dash/internal/config"
 	"github.com/GustavoCaso/docker-dash/internal/ui/components/form"
 )
 
@@ -79,3 +80,30 @@ type SystemInfoOutputMsg struct {
 	Info *client.SystemInfo
 	Err  error
 }
+
+// SystemPrunePreviewMsg carries the dry-run of a system prune.
+type SystemPrunePreviewMsg struct {
+	Opts    client.PruneOptions
+	Preview client.SystemPrunePreview
+	Err     error
+}
+
+// SystemPrunedMsg is sent when a system prune completes, or stops at a
+// failing prune with Report holding what was pruned before it.
+type SystemPrunedMsg struct {
+	Report client.SystemPruneReport
+	Err    error
+}
+
+// AlertsChangedMsg is sent when alerts are raised or dismissed. Count is the
+// number of alerts listed in the Alerts panel.
+type AlertsChangedMsg struct {
+	Count int
+}
+
+// SaveTableMsg is sent by a section to save its table layout to the config
+// file.
+type SaveTableMsg struct {
+	Section string
+	Table   config.TableConfig
+}
@@ -5,6 +5,7 @@ package base
 
 import (
 	"fmt"
+	"image/color"
 	"log"
 	"time"
 
@@ -14,6 +15,7 @@ import (
 	"charm.land/lipgloss/v2"
 	"github.com/atotto/clipboard"
 
+	"github.com/GustavoCaso/docker-dash/internal/ui/components/table"
 	"github.com/GustavoCaso/docker-dash/internal/ui/keys"
 	"github.com/GustavoCaso/docker-dash/internal/ui/message"
 	"github.com/GustavoCaso/docker-dash/internal/ui/sections"
@@ -29,8 +31,17 @@ import (
 // Panels and use the panel-aware helpers
 // (RemoveItemAndUpdatePanel)
 //
+// Items can be marked with the Mark key while the list is focused, or all the
+// items matching the filter with MarkAll; concrete sections read them back
+// with MarkedItems to act on several items at once, usually through
+// ConfirmBulk.
+//
+// Sections given a table with SetTable can show their items as table rows
+// instead, switched with the TableLayout key, sorted by any column and with
+// the columns chosen in a form and saved to the config file.
+//
 // To eliminate per-section boilerplate, set the strategy callbacks
-// (LoadingText, RefreshCmd, PruneCmd, HandleMsg, HandleKey).
+// (LoadingText, RefreshCmd, PruneCmd, PruneFilteredCmd, HandleMsg, HandleKey).
 // The shared Init, SetSize, View, Reset, and Update methods on Section will then
 // handle the full lifecycle; each concrete section only needs handleMsg and
 // handleKey for its domain-specific logic.
@@ -43,6 +54,8 @@ type Section struct {
 	width          int
 	height         int
 	listOuterWidth int
+	marked         map[string]struct{}
+	table          *table.Table
 
 	panels         []sections.Panel
 	activePanelIdx int
@@ -52,6 +65,9 @@ type Section struct {
 	LoadingText string
 	RefreshCmd  func() tea.Cmd
 	PruneCmd    func() tea.Cmd
+	// PruneFilteredCmd, when set, asks for the filters of a prune before
+	// running it.
+	PruneFilteredCmd func() tea.Cmd
 	// HandleMsg handles section-specific messages inside Update.
 	// Return Handled=true when the message was consumed.
 	HandleMsg func(msg tea.Msg) UpdateResult
@@ -60,6 +76,10 @@ type Section struct {
 	// can intercept any key (e.g. exec-panel routing in the containers section).
 	// Return Handled=true when the key was consumed.
 	HandleKey func(msg tea.KeyPressMsg) UpdateResult
+	// Tint, when set, returns the color an item is drawn in to make it stand
+	// out, such as a container over its usage thresholds, and whether it has
+	// one.
+	Tint func(item sections.ListItem) (color.Color, bool)
 }
 
 // UpdateResult describes the outcome of a section-specific handler.
@@ -82,18 +102,21 @@ func New(
 	name sections.SectionName,
 	panels []sections.Panel,
 ) *Section {
-	l := list.New([]list.Item{}, list.NewDefaultDelegate(), 0, 0)
-	l.SetShowTitle(false)
-	l.SetShowHelp(false)
-	l.SetShowStatusBar(true)
-
-	return &Section{
+	b := &Section{
 		name:           name,
-		List:           l,
+		marked:         map[string]struct{}{},
 		panels:         panels,
 		activePanelIdx: 0,
 		focus:          focusList,
 	}
+
+	l := list.New([]list.Item{}, newMarkDelegate(b.IsMarked, b.tint), 0, 0)
+	l.SetShowTitle(false)
+	l.SetShowHelp(false)
+	l.SetShowStatusBar(true)
+	b.List = l
+
+	return b
 }
 
 // Init implements the bubbletea Model Init method. It fires RefreshCmd to
@@ -128,6 +151,7 @@ func (b *Section) View() string {
 // Reset resets internal state to the initial condition.
 func (b *Section) Reset() tea.Cmd {
 	b.isFilter = false
+	b.ClearMarks()
 	b.focus = focusList
 	if len(b.panels) > 0 {
 		cmd := b.ActivePanel().Close()
@@ -151,6 +175,20 @@ func (b *Section) Update(msg tea.Msg) tea.Cmd {
 		}
 	}
 
+	if done, ok := msg.(bulkDoneMsg); ok {
+		if done.section != b.name {
+			return nil
+		}
+		return b.applyUpdateResult(b.handleBulkDone(done))
+	}
+
+	if chosen, ok := msg.(tableColumnsChosenMsg); ok {
+		if chosen.section != b.name {
+			return nil
+		}
+		return b.handleColumnsChosen(chosen)
+	}
+
 	if handled, filterCmds := b.handleFilterKey(msg); handled {
 		return tea.Batch(filterCmds...)
 	}
@@ -178,6 +216,10 @@ func (b *Section) Update(msg tea.Msg) tea.Cmd {
 			}
 		}
 
+		if cmd, handled := b.handleTableKey(keyMsg); handled {
+			return cmd
+		}
+
 		switch {
 		case key.Matches(keyMsg, keys.Keys.Refresh):
 			if b.RefreshCmd != nil {
@@ -220,10 +262,22 @@ func (b *Section) Update(msg tea.Msg) tea.Cmd {
 				)
 			}
 
+		case key.Matches(keyMsg, keys.Keys.Mark) && b.focus == focusList:
+			b.toggleMark()
+			return nil
+
+		case key.Matches(keyMsg, keys.Keys.MarkAll) && b.focus == focusList:
+			b.toggleMarkAll()
+			return nil
+
 		case key.Matches(keyMsg, keys.Keys.Prune):
 			if b.PruneCmd != nil {
 				return b.PruneCmd()
 			}
+		case key.Matches(keyMsg, keys.Keys.PruneFiltered):
+			if b.PruneFilteredCmd != nil {
+				return b.PruneFilteredCmd()
+			}
 		case key.Matches(keyMsg, keys.Keys.Up, keys.Keys.Down):
 			if len(b.panels) > 0 {
 				if b.focus == focusPanel {
@@ -232,12 +286,18 @@ func (b *Section) Update(msg tea.Msg) tea.Cmd {
 				currentPanel := b.ActivePanel()
 				var listCmd tea.Cmd
 				b.List, listCmd = b.List.Update(keyMsg)
+				if isSectionPanel(currentPanel) {
+					return listCmd
+				}
 				return tea.Batch(listCmd, currentPanel.Close(), b.clearActivePanel())
 			}
 			var listCmd tea.Cmd
 			b.List, listCmd = b.List.Update(keyMsg)
 			return listCmd
 		case key.Matches(keyMsg, keys.Keys.Filter):
+			if _, ok := b.focusedFilterablePanel(); ok {
+				return b.ActivePanel().Update(keyMsg)
+			}
 			return tea.Batch(b.toggleFilter(keyMsg)...)
 		}
 	}
@@ -254,6 +314,10 @@ func (b *Section) Update(msg tea.Msg) tea.Cmd {
 }
 
 func (b *Section) UpdateItems(items []list.Item) []tea.Cmd {
+	b.pruneMarks(items)
+	if b.tableEnabled() {
+		b.table.Sort(items)
+	}
 	cmds := []tea.Cmd{b.List.SetItems(items)}
 	if len(items) > 0 {
 		cmds = append(cmds, b.UpdateActivePanel())
@@ -278,6 +342,22 @@ func (b *Section) ActivePanelName() string {
 	return b.ActivePanel().Name()
 }
 
+// ShowPanel makes the panel called name active and focuses it, initialising
+// it for the selected item. It returns nil when the section has no such panel.
+func (b *Section) ShowPanel(name string) tea.Cmd {
+	for idx, panel := range b.panels {
+		if panel.Name() != name {
+			continue
+		}
+		currentPanel := b.ActivePanel()
+		b.activePanelIdx = idx
+		b.focus = focusPanel
+		log.Printf("[%s] showing panel: %q", b.name, name)
+		return tea.Batch(currentPanel.Close(), b.UpdateActivePanel())
+	}
+	return nil
+}
+
 // RemoveItemAndUpdatePanel removes the item at idx from the list, clamps the
 // selection, and re-initialises the active panel for the new selection.
 // When the list becomes empty it closes the active panel instead.
@@ -338,10 +418,32 @@ func (b *Section) clearActivePanel() tea.Cmd {
 	return b.ActivePanel().Init(listItem)
 }
 
+// isSectionPanel reports whether panel shows the whole section, so it stays
+// open while the selection moves.
+func isSectionPanel(panel sections.Panel) bool {
+	sectionPanel, ok := panel.(sections.SectionPanel)
+	return ok && sectionPanel.SectionWide()
+}
+
+// IsFilter reports whether the section list, or the focused panel, is
+// capturing keys for a filter input.
 func (b *Section) IsFilter() bool {
+	if p, ok := b.focusedFilterablePanel(); ok && p.IsFilter() {
+		return true
+	}
 	return b.isFilter
 }
 
+// focusedFilterablePanel returns the active panel when it has the focus and
+// owns a filter input.
+func (b *Section) focusedFilterablePanel() (sections.FilterablePanel, bool) {
+	if !b.IsPanelFocused() {
+		return nil, false
+	}
+	p, ok := b.ActivePanel().(sections.FilterablePanel)
+	return p, ok
+}
+
 func (b *Section) IsPanelFocused() bool {
 	return len(b.panels) > 0 && b.focus == focusPanel
 }
@@ -356,11 +458,15 @@ func (b *Section) toggleFocus() {
 
 // handleFilterKey processes keyboard events while filter mode is active.
 // It forwards every key to the list and, when Esc is pressed, deactivates
-// filter mode and clears the contextual key bindings.
+// filter mode and clears the contextual key bindings. When the focused panel
+// is filtering instead, every message is forwarded to that panel.
 //
 // Returns (true, cmds) when filter mode was active and the event was consumed,
 // or (false, nil) when filter mode is not active.
 func (b *Section) handleFilterKey(msg tea.Msg) (bool, []tea.Cmd) {
+	if p, ok := b.focusedFilterablePanel(); ok && p.IsFilter() {
+		return true, []tea.Cmd{p.Update(msg)}
+	}
 	if !b.isFilter {
 		return false, nil
 	}
@@ -476,22 +582,27 @@ func (b *Section) setListSize(width, height int) {
 	b.height = height
 	listX, listY := theme.ListStyle.GetFrameSize()
 	b.listOuterWidth = width
-	b.List.SetSize(width-listX, height-listY)
+	b.List.SetSize(width-listX, height-listY-b.headerHeight())
 }
 
-// renderList renders the list content.
+// renderList renders the list content, under the column header in the table
+// layout.
 func (b *Section) renderList() string {
 	borderColor := theme.Border
 	if b.focus == focusList {
 		borderColor = theme.BorderActive
 	}
+	listView := b.List.View()
+	if b.tableEnabled() {
+		listView = b.renderTable(listView)
+	}
 	// Width() on ListStyle is the total outer width (border included). Passing
 	// b.List.Width() (inner) would make lipgloss compute a wrapAt 2 chars too
 	// narrow, wrapping lines that exactly fill the list.
 	return theme.ListStyle.
 		BorderForeground(borderColor).
 		Width(b.listOuterWidth).
-		Render(b.List.View())
+		Render(listView)
 }
 
 // DetailsMenu renders the tab bar that appears above the active detail panel.
@@ -542,7 +653,7 @@ func (b *Section) setSizeWithPanels(width, height int) {
 	detailWidth := width - listWidth
 
 	b.listOuterWidth = listWidth
-	b.List.SetSize(listWidth-listX, height-listY)
+	b.List.SetSize(listWidth-listX, height-listY-b.headerHeight())
 	b.panelWidth = detailWidth - panelX - menuX
 	// TODO: Figure out the + 1
 	b.panelHeight = height - menuHeight - menuY - panelY + 1
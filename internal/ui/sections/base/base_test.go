@@ -1,15 +1,22 @@
 package base
 
 import (
+	"errors"
+	"image/color"
 	"slices"
+	"strings"
 	"testing"
+	"time"
 
 	"charm.land/bubbles/v2/list"
 	tea "charm.land/bubbletea/v2"
 	"github.com/atotto/clipboard"
 
+	"github.com/GustavoCaso/docker-dash/internal/config"
+	"github.com/GustavoCaso/docker-dash/internal/ui/components/table"
 	"github.com/GustavoCaso/docker-dash/internal/ui/message"
 	"github.com/GustavoCaso/docker-dash/internal/ui/sections"
+	"github.com/GustavoCaso/docker-dash/internal/ui/theme"
 )
 
 type fakeItem struct {
@@ -236,6 +243,42 @@ func TestPanelClosedOnUpNavigation(t *testing.T) {
 	}
 }
 
+// fakeSectionPanel is a panel showing the whole section.
+type fakeSectionPanel struct {
+	fakePanel
+}
+
+func (f *fakeSectionPanel) SectionWide() bool {
+	return true
+}
+
+func TestSectionPanelStaysOpenOnNavigation(t *testing.T) {
+	details := &fakePanel{name: "details"}
+	top := &fakeSectionPanel{fakePanel{name: "top"}}
+	items := []list.Item{
+		fakeItem{name: "item1"},
+		fakeItem{name: "item2"},
+	}
+	section := newSectionWithItems(items, []sections.Panel{details, top})
+	section.ShowPanel("top")
+	section.toggleFocus()
+
+	section.Update(tea.KeyPressMsg{Code: tea.KeyDown})
+
+	if section.ActivePanelName() != "top" {
+		t.Errorf("active panel = %q, want the section panel to stay open", section.ActivePanelName())
+	}
+	if top.closed {
+		t.Error("Navigation should not close a section panel")
+	}
+	if len(top.ids) != 1 {
+		t.Errorf("section panel should not be re-initialised on navigation, got %q", top.ids)
+	}
+	if selected := section.List.SelectedItem().(fakeItem); selected.name != "item2" {
+		t.Errorf("selected = %q, want item2", selected.name)
+	}
+}
+
 func TestPanelNextSwitchesActivePanel(t *testing.T) {
 	panelA := &fakePanel{name: "panelA"}
 	panelB := &fakePanel{name: "panelB"}
@@ -629,3 +672,370 @@ func TestHandledResultWithoutRefreshAllSectionsDoesNotBroadcast(t *testing.T) {
 		t.Error("BubbleUpMsg should not be emitted when RefreshAllSections is false")
 	}
 }
+
+// fakeFilterablePanel is a fakePanel that owns a filter input, toggled on by
+// the filter key and off by esc.
+type fakeFilterablePanel struct {
+	fakePanel
+	filtering bool
+}
+
+func (f *fakeFilterablePanel) Update(msg tea.Msg) tea.Cmd {
+	if keyMsg, ok := msg.(tea.KeyPressMsg); ok {
+		switch keyMsg.String() {
+		case "/":
+			f.filtering = true
+		case "esc":
+			f.filtering = false
+		}
+	}
+	return f.fakePanel.Update(msg)
+}
+
+func (f *fakeFilterablePanel) IsFilter() bool {
+	return f.filtering
+}
+
+func TestFilterKeyRoutedToFocusedFilterablePanel(t *testing.T) {
+	fp := &fakeFilterablePanel{}
+	section := newSectionWithItems([]list.Item{fakeItem{name: "a"}}, []sections.Panel{fp})
+
+	section.Update(tea.KeyPressMsg{Code: tea.KeyTab})
+	section.Update(tea.KeyPressMsg{Code: '/', Text: "/"})
+
+	if section.isFilter {
+		t.Error("filter key with a focused filterable panel should not filter the section list")
+	}
+	if !section.IsFilter() {
+		t.Error("IsFilter() should report true while the focused panel is filtering")
+	}
+
+	// While filtering, keys that are normally shortcuts reach the panel.
+	section.Update(tea.KeyPressMsg{Code: tea.KeyTab})
+	section.Update(tea.KeyPressMsg{Code: 'r', Text: "r"})
+	section.Update(tea.KeyPressMsg{Code: tea.KeyEscape})
+
+	want := []string{"/", "tab", "r", "esc"}
+	if !slices.Equal(fp.updatedKeys, want) {
+		t.Errorf("panel keys = %v, want %v", fp.updatedKeys, want)
+	}
+	if !section.IsPanelFocused() {
+		t.Error("tab while the panel is filtering should not move the focus")
+	}
+	if section.IsFilter() {
+		t.Error("IsFilter() should report false once the panel stops filtering")
+	}
+}
+
+func TestFilterKeyFiltersListWhenFilterablePanelNotFocused(t *testing.T) {
+	fp := &fakeFilterablePanel{}
+	section := newSectionWithItems([]list.Item{fakeItem{name: "a"}}, []sections.Panel{fp})
+
+	section.Update(tea.KeyPressMsg{Code: '/', Text: "/"})
+
+	if !section.isFilter {
+		t.Error("filter key with the list focused should filter the section list")
+	}
+	if fp.filtering {
+		t.Error("filter key with the list focused should not reach the panel")
+	}
+}
+
+func markedIDs(s *Section) []string {
+	var ids []string
+	for _, item := range s.MarkedItems() {
+		ids = append(ids, item.ID())
+	}
+	return ids
+}
+
+func TestMarkTogglesSelectedItem(t *testing.T) {
+	items := []list.Item{fakeItem{name: "a"}, fakeItem{name: "b"}, fakeItem{name: "c"}}
+	section := newSectionWithItems(items, nil)
+	space := tea.KeyPressMsg{Code: tea.KeySpace, Text: " "}
+
+	section.List.Select(2)
+	section.Update(space)
+	section.List.Select(0)
+	section.Update(space)
+	if got := markedIDs(section); !slices.Equal(got, []string{"a", "c"}) {
+		t.Errorf("MarkedItems() = %v, want [a c] in list order", got)
+	}
+
+	section.Update(space)
+	if got := markedIDs(section); !slices.Equal(got, []string{"c"}) {
+		t.Errorf("MarkedItems() after unmarking a = %v, want [c]", got)
+	}
+}
+
+func TestMarkIgnoredWhenPanelFocused(t *testing.T) {
+	fp := &fakePanel{}
+	section := newSectionWithItems([]list.Item{fakeItem{name: "a"}}, []sections.Panel{fp})
+	section.Update(tea.KeyPressMsg{Code: tea.KeyTab})
+
+	section.Update(tea.KeyPressMsg{Code: tea.KeySpace, Text: " "})
+
+	if section.IsMarked("a") {
+		t.Error("space should not mark items while the panel is focused")
+	}
+	if !slices.Equal(fp.updatedKeys, []string{"space"}) {
+		t.Errorf("panel keys = %v, want [space]", fp.updatedKeys)
+	}
+}
+
+func TestUpdateItemsDropsStaleMarks(t *testing.T) {
+	section := newSectionWithItems([]list.Item{fakeItem{name: "a"}, fakeItem{name: "b"}}, nil)
+	section.Update(tea.KeyPressMsg{Code: tea.KeySpace, Text: " "})
+	section.List.Select(1)
+	section.Update(tea.KeyPressMsg{Code: tea.KeySpace, Text: " "})
+
+	section.UpdateItems([]list.Item{fakeItem{name: "b"}})
+	section.UpdateItems([]list.Item{fakeItem{name: "a"}, fakeItem{name: "b"}})
+
+	if got := markedIDs(section); !slices.Equal(got, []string{"b"}) {
+		t.Errorf("MarkedItems() = %v, want [b]", got)
+	}
+}
+
+func TestResetClearsMarks(t *testing.T) {
+	section := newSectionWithItems([]list.Item{fakeItem{name: "a"}}, nil)
+	section.Update(tea.KeyPressMsg{Code: tea.KeySpace, Text: " "})
+
+	section.Reset()
+
+	if section.IsMarked("a") {
+		t.Error("Reset() should clear marks")
+	}
+}
+
+func TestMarkedItemRendersMarkIcon(t *testing.T) {
+	section := newSectionWithItems([]list.Item{fakeItem{name: "a"}, fakeItem{name: "b"}}, nil)
+	section.SetSize(80, 20)
+	if strings.Contains(section.View(), theme.IconMarked) {
+		t.Fatal("View() should not show the mark icon before marking")
+	}
+
+	section.Update(tea.KeyPressMsg{Code: tea.KeySpace, Text: " "})
+
+	if !strings.Contains(section.View(), theme.IconMarked) {
+		t.Error("View() should show the mark icon for marked items")
+	}
+}
+
+func TestTintDrawsItemsInTheirColor(t *testing.T) {
+	section := newSectionWithItems([]list.Item{fakeItem{name: "a"}, fakeItem{name: "b"}}, nil)
+	section.SetSize(80, 20)
+	section.Tint = func(item sections.ListItem) (color.Color, bool) {
+		return theme.StatusError, item.ID() == "b"
+	}
+
+	view := section.View()
+	if !strings.Contains(view, "\x1b[38;2;231;76;60mb") {
+		t.Errorf("item b should be tinted:\n%q", view)
+	}
+	if strings.Contains(view, "\x1b[38;2;231;76;60ma") {
+		t.Errorf("item a should not be tinted:\n%q", view)
+	}
+}
+
+func TestShowPanelActivatesAndFocusesPanel(t *testing.T) {
+	details := &fakePanel{name: "Details"}
+	logs := &fakePanel{name: "Logs"}
+	section := newSectionWithItems([]list.Item{fakeItem{name: "a"}}, []sections.Panel{details, logs})
+
+	cmd := section.ShowPanel("Logs")
+	if cmd == nil {
+		t.Fatal("ShowPanel() should return the panel init cmd")
+	}
+	if section.ActivePanelName() != "Logs" || !section.IsPanelFocused() {
+		t.Errorf(
+			"ShowPanel() active=%q focused=%t, want Logs focused",
+			section.ActivePanelName(),
+			section.IsPanelFocused(),
+		)
+	}
+	if !details.closed || !slices.Equal(logs.ids, []string{"a"}) {
+		t.Error("ShowPanel() should close the previous panel and init the new one with the selection")
+	}
+	if section.ShowPanel("Missing") != nil {
+		t.Error("ShowPanel() should return nil for an unknown panel")
+	}
+}
+
+func TestMarkAllMarksItemsMatchingFilter(t *testing.T) {
+	items := []list.Item{fakeItem{name: "test-a"}, fakeItem{name: "prod"}, fakeItem{name: "test-b"}}
+	section := newSectionWithItems(items, nil)
+	section.SetSize(80, 20)
+	ctrlA := tea.KeyPressMsg{Code: 'a', Mod: tea.ModCtrl}
+
+	section.List.SetFilterText("test")
+	section.Update(ctrlA)
+	if got := markedIDs(section); !slices.Equal(got, []string{"test-a", "test-b"}) {
+		t.Errorf("MarkedItems() = %v, want the items matching the filter", got)
+	}
+
+	section.Update(ctrlA)
+	if got := markedIDs(section); len(got) != 0 {
+		t.Errorf("MarkedItems() = %v, want marks cleared when every match was marked", got)
+	}
+
+	section.List.ResetFilter()
+	section.Update(ctrlA)
+	if got := markedIDs(section); len(got) != len(items) {
+		t.Errorf("MarkedItems() = %v, want every item marked without a filter", got)
+	}
+}
+
+func TestConfirmBulkRunsEveryTarget(t *testing.T) {
+	items := []list.Item{fakeItem{name: "a"}, fakeItem{name: "b"}, fakeItem{name: "c"}}
+	section := newSectionWithItems(items, nil)
+	other := newSectionWithItems(items, nil)
+	other.name = "other"
+	section.Update(tea.KeyPressMsg{Code: 'a', Mod: tea.ModCtrl})
+
+	var ran []string
+	action := BulkAction{Verb: "Remove", Done: "Removed", Noun: "thing", Run: func(item sections.ListItem) error {
+		ran = append(ran, item.ID())
+		if item.ID() == "b" {
+			return errors.New("in use")
+		}
+		return nil
+	}}
+	confirm, ok := section.ConfirmBulk(action, section.MarkedItems())().(message.ShowConfirmationMsg)
+	if !ok {
+		t.Fatal("ConfirmBulk() should ask for confirmation")
+	}
+	if confirm.Title != "Remove 3 things" || !strings.Contains(confirm.Body, "Remove 3 things?\n\na\nb\nc") {
+		t.Errorf("confirmation = %q / %q, want the targets listed", confirm.Title, confirm.Body)
+	}
+
+	done := section.BulkCmd(action, section.MarkedItems())()
+	if other.Update(done) != nil {
+		t.Error("another section should ignore the bulk result")
+	}
+	if !slices.Equal(ran, []string{"a", "b", "c"}) {
+		t.Errorf("ran on %v, want every target despite the failure", ran)
+	}
+
+	result := section.handleBulkDone(done.(bulkDoneMsg))
+	if !result.Handled || !result.RefreshAllSections || result.StopSpinner {
+		t.Errorf("result = %+v, want a refresh since items changed", result)
+	}
+	banner, ok := result.Cmd().(message.ShowBannerMsg)
+	if !ok || !banner.IsError || banner.Message != "Removed 2 of 3 things, failed: b (in use)" {
+		t.Errorf("banner = %#v, want the per-item summary", result.Cmd())
+	}
+	if len(section.MarkedItems()) != 0 {
+		t.Error("marks should be cleared after a bulk action")
+	}
+}
+
+func TestBulkSummary(t *testing.T) {
+	action := BulkAction{Done: "Stopped", Noun: "container"}
+	banner, done := bulkSummary(action, []itemResult{{item: fakeItem{name: "a"}}})
+	if done != 1 || banner.IsError || banner.Message != "Stopped 1 container" {
+		t.Errorf("bulkSummary() = %+v, %d", banner, done)
+	}
+
+	banner, done = bulkSummary(action, []itemResult{{item: fakeItem{name: "a"}, err: errors.New("gone")}})
+	if done != 0 || !banner.IsError || banner.Message != "Stopped 0 of 1 container, failed: a (gone)" {
+		t.Errorf("bulkSummary() = %+v, %d", banner, done)
+	}
+
+	action.Skipped, action.SkipReason = []sections.ListItem{fakeItem{name: "b"}, fakeItem{name: "c"}}, "not running"
+	banner, _ = bulkSummary(action, []itemResult{{item: fakeItem{name: "a"}}})
+	if banner.IsError || banner.Message != "Stopped 1 container, skipped 2 not running: b, c" {
+		t.Errorf("bulkSummary() = %+v, want the skipped items named", banner)
+	}
+}
+
+func TestBulkCmdRunsUpToLimitAtOnce(t *testing.T) {
+	section := newSectionWithItems(nil, nil)
+	started := make(chan string)
+	release := make(chan struct{})
+	action := BulkAction{Done: "Stopped", Noun: "thing", Limit: 2, Run: func(item sections.ListItem) error {
+		started <- item.ID()
+		<-release
+		return nil
+	}}
+	targets := []sections.ListItem{fakeItem{name: "a"}, fakeItem{name: "b"}, fakeItem{name: "c"}}
+	msgs := make(chan tea.Msg)
+	go func() { msgs <- section.BulkCmd(action, targets)() }()
+
+	// Two items run together; the third waits for one of them.
+	for range action.Limit {
+		select {
+		case <-started:
+		case <-time.After(time.Second):
+			t.Fatal("BulkCmd() should run Limit items at once")
+		}
+	}
+	select {
+	case id := <-started:
+		t.Fatalf("BulkCmd() started %s beyond the limit", id)
+	default:
+	}
+	close(release)
+	<-started
+
+	done, ok := (<-msgs).(bulkDoneMsg)
+	if !ok {
+		t.Fatal("BulkCmd() should report the results")
+	}
+	var ids []string
+	for _, result := range done.results {
+		ids = append(ids, result.item.ID())
+	}
+	if !slices.Equal(ids, []string{"a", "b", "c"}) {
+		t.Errorf("results = %v, want them in target order", ids)
+	}
+}
+
+func TestTableLayoutSortsAndSavesColumns(t *testing.T) {
+	section := newSectionWithItems([]list.Item{fakeItem{name: "b"}, fakeItem{name: "c"}, fakeItem{name: "a"}}, nil)
+	section.SetTable(table.New([]table.Column{
+		{Key: "name", Title: "NAME", Value: func(item sections.ListItem) string { return item.Title() }},
+		{Key: "id", Title: "ID", Width: 4, Value: func(item sections.ListItem) string { return item.ID() }},
+	}, config.TableConfig{}))
+	section.SetSize(80, 20)
+	if strings.Contains(section.View(), "NAME") {
+		t.Fatal("View() should use the list layout until the table is enabled")
+	}
+
+	section.Update(tea.KeyPressMsg{Code: 'T', Text: "T"})
+	section.Update(tea.KeyPressMsg{Code: 'o', Text: "o"})
+
+	if view := section.View(); !strings.Contains(view, "NAME▲") {
+		t.Errorf("View() should show the header with the sort column:\n%s", view)
+	}
+	var got []string
+	for _, item := range section.List.Items() {
+		got = append(got, item.FilterValue())
+	}
+	if !slices.Equal(got, []string{"a", "b", "c"}) {
+		t.Errorf("items = %v, want them sorted by name", got)
+	}
+	if selected := section.List.SelectedItem().FilterValue(); selected != "b" {
+		t.Errorf("selected = %q, want the cursor kept on b", selected)
+	}
+
+	if msgs := collectMsgs(section.Update(tea.KeyPressMsg{Code: 'C', Text: "C"})); len(msgs) != 1 {
+		t.Fatalf("C should show the columns form, got %v", msgs)
+	} else if _, ok := msgs[0].(message.ShowFormMsg); !ok {
+		t.Fatalf("C should show the columns form, got %T", msgs[0])
+	}
+	if cmd := section.Update(tableColumnsChosenMsg{section: "other", columns: []string{"id"}}); cmd != nil {
+		t.Error("columns chosen for another section should be ignored")
+	}
+	var saved *message.SaveTableMsg
+	for _, msg := range collectMsgs(section.Update(tableColumnsChosenMsg{section: "test", columns: []string{"id"}})) {
+		if save, ok := msg.(message.SaveTableMsg); ok {
+			saved = &save
+		}
+	}
+	want := config.TableConfig{Enabled: true, Columns: []string{"id"}, Sort: "name"}
+	if saved == nil || saved.Section != "test" || !slices.Equal(saved.Table.Columns, want.Columns) ||
+		saved.Table.Sort != want.Sort || !saved.Table.Enabled {
+		t.Errorf("saved = %+v, want %+v", saved, want)
+	}
+}
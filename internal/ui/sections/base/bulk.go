@@ -0,0 +1,150 @@
+package base
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/GustavoCaso/docker-dash/internal/ui/message"
+	"github.com/GustavoCaso/docker-dash/internal/ui/sections"
+)
+
+// maxBulkListed is the number of targets listed in a bulk confirmation.
+const maxBulkListed = 10
+
+// BulkAction is an action applied at once to several items of a section.
+type BulkAction struct {
+	// Verb names the action in the confirmation, e.g. "Remove".
+	Verb string
+	// Done is the past tense of Verb used in the summary, e.g. "Removed".
+	Done string
+	// Noun is the kind of item acted on, e.g. "container".
+	Noun string
+	// Run applies the action to one item.
+	Run func(item sections.ListItem) error
+	// Limit is how many items Run acts on at once. 0 or 1 runs them in turn,
+	// for actions whose Run is not safe to call concurrently.
+	Limit int
+	// Skipped are the marked items the action leaves out, reported in the
+	// summary with SkipReason, e.g. "not running".
+	Skipped    []sections.ListItem
+	SkipReason string
+}
+
+// itemResult is the outcome of a bulk action on one item.
+type itemResult struct {
+	item sections.ListItem
+	err  error
+}
+
+// bulkDoneMsg is sent when a bulk action ran on every target. Every section
+// receives it, so it carries the name of the one that started it.
+type bulkDoneMsg struct {
+	section sections.SectionName
+	action  BulkAction
+	results []itemResult
+}
+
+// ConfirmBulk asks once before running action on every target, listing them.
+func (b *Section) ConfirmBulk(action BulkAction, targets []sections.ListItem) tea.Cmd {
+	if len(targets) == 0 {
+		return nil
+	}
+	runCmd := b.BulkCmd(action, targets)
+	return func() tea.Msg {
+		return message.ShowConfirmationMsg{
+			Title:     fmt.Sprintf("%s %s", action.Verb, plural(action.Noun, len(targets))),
+			Body:      bulkBody(action, targets),
+			OnConfirm: b.WithSpinner(runCmd),
+		}
+	}
+}
+
+// BulkCmd runs action on every target, at most action.Limit at a time. One
+// failing item does not stop the others; the summary reports each failure.
+func (b *Section) BulkCmd(action BulkAction, targets []sections.ListItem) tea.Cmd {
+	name := b.name
+	return func() tea.Msg {
+		results := make([]itemResult, len(targets))
+		var group errgroup.Group
+		group.SetLimit(max(action.Limit, 1))
+		for i, item := range targets {
+			group.Go(func() error {
+				results[i] = itemResult{item: item, err: action.Run(item)}
+				return nil
+			})
+		}
+		_ = group.Wait()
+		return bulkDoneMsg{section: name, action: action, results: results}
+	}
+}
+
+func (b *Section) handleBulkDone(msg bulkDoneMsg) UpdateResult {
+	b.ClearMarks()
+	banner, done := bulkSummary(msg.action, msg.results)
+	for _, result := range msg.results {
+		log.Printf("[%s] %s %s %q: err=%v", b.name, msg.action.Verb, msg.action.Noun, result.item.ID(), result.err)
+	}
+	return UpdateResult{
+		Cmd:     func() tea.Msg { return banner },
+		Handled: true,
+		// When an item changed the broadcast refresh reloads this section too,
+		// and its load message stops the spinner.
+		StopSpinner:        done == 0,
+		RefreshAllSections: done > 0,
+	}
+}
+
+// bulkSummary returns the banner summing up a bulk action, naming every item
+// it failed on or skipped, and how many items it succeeded on.
+func bulkSummary(action BulkAction, results []itemResult) (message.ShowBannerMsg, int) {
+	var failed []string
+	for _, result := range results {
+		if result.err != nil {
+			failed = append(failed, fmt.Sprintf("%s (%s)", result.item.Title(), result.err.Error()))
+		}
+	}
+	done := len(results) - len(failed)
+	var banner message.ShowBannerMsg
+	if len(failed) == 0 {
+		banner.Message = fmt.Sprintf("%s %s", action.Done, plural(action.Noun, done))
+	} else {
+		banner.Message = fmt.Sprintf("%s %d of %s, failed: %s",
+			action.Done, done, plural(action.Noun, len(results)), strings.Join(failed, ", "))
+		banner.IsError = true
+	}
+	if len(action.Skipped) > 0 {
+		skipped := make([]string, len(action.Skipped))
+		for i, item := range action.Skipped {
+			skipped[i] = item.Title()
+		}
+		banner.Message += fmt.Sprintf(", skipped %d %s: %s",
+			len(action.Skipped), action.SkipReason, strings.Join(skipped, ", "))
+	}
+	return banner, done
+}
+
+// bulkBody asks to confirm action and lists its targets.
+func bulkBody(action BulkAction, targets []sections.ListItem) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s %s?\n", action.Verb, plural(action.Noun, len(targets)))
+	for i, item := range targets {
+		if i == maxBulkListed {
+			fmt.Fprintf(&b, "\n… and %d more", len(targets)-maxBulkListed)
+			break
+		}
+		b.WriteString("\n" + item.Title())
+	}
+	return b.String()
+}
+
+// plural counts n items of kind noun, e.g. "1 volume" or "3 volumes".
+func plural(noun string, n int) string {
+	if n == 1 {
+		return "1 " + noun
+	}
+	return fmt.Sprintf("%d %ss", n, noun)
+}
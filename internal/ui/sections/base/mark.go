@@ -0,0 +1,142 @@
+package base
+
+import (
+	"image/color"
+	"io"
+
+	"charm.land/bubbles/v2/list"
+
+	"github.com/GustavoCaso/docker-dash/internal/ui/sections"
+	"github.com/GustavoCaso/docker-dash/internal/ui/theme"
+)
+
+// IsMarked reports whether the item with the given ID is marked.
+func (b *Section) IsMarked(id string) bool {
+	_, ok := b.marked[id]
+	return ok
+}
+
+// MarkedItems returns the marked items in list order.
+func (b *Section) MarkedItems() []sections.ListItem {
+	if len(b.marked) == 0 {
+		return nil
+	}
+	var items []sections.ListItem
+	for _, item := range b.List.Items() {
+		if listItem, ok := item.(sections.ListItem); ok && b.IsMarked(listItem.ID()) {
+			items = append(items, listItem)
+		}
+	}
+	return items
+}
+
+// ClearMarks unmarks every item.
+func (b *Section) ClearMarks() {
+	clear(b.marked)
+}
+
+// toggleMark marks the selected item, or unmarks it when it is already marked.
+func (b *Section) toggleMark() {
+	listItem, ok := b.List.SelectedItem().(sections.ListItem)
+	if !ok {
+		return
+	}
+	if b.IsMarked(listItem.ID()) {
+		delete(b.marked, listItem.ID())
+		return
+	}
+	b.marked[listItem.ID()] = struct{}{}
+}
+
+// toggleMarkAll marks every item matching the current filter, or unmarks
+// them when they are all marked already.
+func (b *Section) toggleMarkAll() {
+	var visible []string
+	allMarked := true
+	for _, item := range b.List.VisibleItems() {
+		if listItem, ok := item.(sections.ListItem); ok {
+			visible = append(visible, listItem.ID())
+			allMarked = allMarked && b.IsMarked(listItem.ID())
+		}
+	}
+	for _, id := range visible {
+		if allMarked {
+			delete(b.marked, id)
+		} else {
+			b.marked[id] = struct{}{}
+		}
+	}
+}
+
+// pruneMarks drops marks for items that are no longer in the list.
+func (b *Section) pruneMarks(items []list.Item) {
+	present := make(map[string]struct{}, len(items))
+	for _, item := range items {
+		if listItem, ok := item.(sections.ListItem); ok {
+			present[listItem.ID()] = struct{}{}
+		}
+	}
+	for id := range b.marked {
+		if _, ok := present[id]; !ok {
+			delete(b.marked, id)
+		}
+	}
+}
+
+// markDelegate renders marked items with a mark icon and a highlighted title.
+// The icon goes in the description so the filter match highlighting on the
+// title stays aligned. Tinted items are drawn in their color.
+type markDelegate struct {
+	list.DefaultDelegate
+	marked       list.DefaultDelegate
+	isMarkedFunc func(id string) bool
+	tintFunc     func(item sections.ListItem) (color.Color, bool)
+}
+
+func newMarkDelegate(
+	isMarked func(id string) bool,
+	tint func(item sections.ListItem) (color.Color, bool),
+) markDelegate {
+	d := list.NewDefaultDelegate()
+	marked := list.NewDefaultDelegate()
+	marked.Styles.NormalTitle = marked.Styles.NormalTitle.Foreground(theme.DockerBlue).Bold(true)
+	marked.Styles.SelectedTitle = marked.Styles.SelectedTitle.Bold(true)
+	return markDelegate{DefaultDelegate: d, marked: marked, isMarkedFunc: isMarked, tintFunc: tint}
+}
+
+func (d markDelegate) Render(w io.Writer, m list.Model, index int, item list.Item) {
+	listItem, ok := item.(sections.ListItem)
+	if !ok {
+		d.DefaultDelegate.Render(w, m, index, item)
+		return
+	}
+	delegate := d.DefaultDelegate
+	if d.isMarkedFunc(listItem.ID()) {
+		delegate = d.marked
+		item = markedItem{ListItem: listItem}
+	}
+	if c, tinted := d.tintFunc(listItem); tinted {
+		delegate.Styles.NormalTitle = delegate.Styles.NormalTitle.Foreground(c)
+		delegate.Styles.SelectedTitle = delegate.Styles.SelectedTitle.Foreground(c)
+		delegate.Styles.NormalDesc = delegate.Styles.NormalDesc.Foreground(c)
+		delegate.Styles.SelectedDesc = delegate.Styles.SelectedDesc.Foreground(c)
+	}
+	delegate.Render(w, m, index, item)
+}
+
+// tint returns the color of item given by Tint, if any.
+func (b *Section) tint(item sections.ListItem) (color.Color, bool) {
+	if b.Tint == nil {
+		return nil, false
+	}
+	return b.Tint(item)
+}
+
+// markedItem prefixes the description of a marked item with the mark icon.
+type markedItem struct {
+	sections.ListItem
+}
+
+func (i markedItem) Description() string {
+	return theme.MarkedStyle.Render(theme.IconMarked) + " " + i.ListItem.Description()
+}
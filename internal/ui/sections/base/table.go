@@ -0,0 +1,210 @@
+package base
+
+import (
+	"errors"
+	"fmt"
+	"image/color"
+	"io"
+	"log"
+	"slices"
+
+	"charm.land/bubbles/v2/key"
+	"charm.land/bubbles/v2/list"
+	tea "charm.land/bubbletea/v2"
+	"charm.land/huh/v2"
+	"charm.land/lipgloss/v2"
+
+	"github.com/GustavoCaso/docker-dash/internal/ui/components/form"
+	"github.com/GustavoCaso/docker-dash/internal/ui/components/table"
+	"github.com/GustavoCaso/docker-dash/internal/ui/keys"
+	"github.com/GustavoCaso/docker-dash/internal/ui/message"
+	"github.com/GustavoCaso/docker-dash/internal/ui/sections"
+	"github.com/GustavoCaso/docker-dash/internal/ui/theme"
+)
+
+const (
+	// rowPrefixWidth is the room left for the mark icon before each row.
+	rowPrefixWidth = 2
+	// tableHeaderHeight is the column header above the rows.
+	tableHeaderHeight = 1
+)
+
+var tableHeaderStyle = lipgloss.NewStyle().Bold(true).Foreground(theme.TextMuted)
+
+// tableColumnsChosenMsg is sent when the columns of a section's table have
+// been chosen in the columns form.
+type tableColumnsChosenMsg struct {
+	section sections.SectionName
+	columns []string
+}
+
+// SetTable offers a table layout of the items with the columns of t, shown
+// right away when its config enables it.
+func (b *Section) SetTable(t *table.Table) {
+	b.table = t
+	b.applyLayout()
+}
+
+// Table returns the table layout of the section, or nil when it has none.
+func (b *Section) Table() *table.Table {
+	return b.table
+}
+
+func (b *Section) tableEnabled() bool {
+	return b.table != nil && b.table.Enabled()
+}
+
+// headerHeight is the height the table header takes above the list.
+func (b *Section) headerHeight() int {
+	if b.tableEnabled() {
+		return tableHeaderHeight
+	}
+	return 0
+}
+
+// applyLayout renders the items as table rows or as a title and a
+// description, and resizes the list around the header once the section has
+// a size.
+func (b *Section) applyLayout() {
+	if b.tableEnabled() {
+		b.List.SetDelegate(tableDelegate{table: b.table, isMarked: b.IsMarked, tint: b.tint})
+	} else {
+		b.List.SetDelegate(newMarkDelegate(b.IsMarked, b.tint))
+	}
+	if b.width > 0 {
+		b.SetSize(b.width, b.height)
+	}
+}
+
+// handleTableKey switches the layout, sorts the rows and opens the columns
+// form. It reports false when the section has no table or msg is not one of
+// its keys.
+func (b *Section) handleTableKey(msg tea.KeyPressMsg) (tea.Cmd, bool) {
+	if b.table == nil || b.focus != focusList {
+		return nil, false
+	}
+	switch {
+	case key.Matches(msg, keys.Keys.TableLayout):
+		b.table.SetEnabled(!b.table.Enabled())
+		log.Printf("[%s] table layout: %t", b.name, b.table.Enabled())
+		b.applyLayout()
+		return b.sortItems(), true
+	case key.Matches(msg, keys.Keys.TableSort) && b.table.Enabled():
+		b.table.CycleSort()
+		return b.sortItems(), true
+	case key.Matches(msg, keys.Keys.TableSortReverse) && b.table.Enabled():
+		b.table.ReverseSort()
+		return b.sortItems(), true
+	case key.Matches(msg, keys.Keys.TableColumns):
+		return b.showColumnsForm(), true
+	}
+	return nil, false
+}
+
+// showColumnsForm asks which columns the table shows.
+func (b *Section) showColumnsForm() tea.Cmd {
+	name := b.name
+	var selected []string
+	options := make([]huh.Option[string], 0, len(b.table.Available()))
+	for _, c := range b.table.Columns() {
+		selected = append(selected, c.Key)
+	}
+	for _, c := range b.table.Available() {
+		options = append(options, huh.NewOption(c.Title, c.Key).Selected(slices.Contains(selected, c.Key)))
+	}
+	f := huh.NewForm(
+		huh.NewGroup(
+			huh.NewMultiSelect[string]().
+				Key("columns").
+				Title("Columns").
+				Description("The columns of the table, saved to the config file.").
+				Options(options...).
+				Value(&selected).
+				Validate(func(columns []string) error {
+					if len(columns) == 0 {
+						return errors.New("choose at least one column")
+					}
+					return nil
+				}),
+		),
+	)
+	columnsForm := form.New("Table Columns", f, func(_ *huh.Form) tea.Cmd {
+		return func() tea.Msg {
+			return tableColumnsChosenMsg{section: name, columns: selected}
+		}
+	})
+	return func() tea.Msg {
+		return message.ShowFormMsg{Form: columnsForm}
+	}
+}
+
+// handleColumnsChosen shows the chosen columns in the table layout and saves
+// the layout.
+func (b *Section) handleColumnsChosen(msg tableColumnsChosenMsg) tea.Cmd {
+	b.table.SetColumns(msg.columns)
+	b.table.SetEnabled(true)
+	log.Printf("[%s] table columns: %v", b.name, msg.columns)
+	b.applyLayout()
+	saved := message.SaveTableMsg{Section: string(b.name), Table: b.table.Config()}
+	return tea.Batch(b.sortItems(), func() tea.Msg { return saved })
+}
+
+// sortItems orders the rows by the sort column of the table, keeping the
+// cursor on the selected item.
+func (b *Section) sortItems() tea.Cmd {
+	if !b.tableEnabled() {
+		return nil
+	}
+	var selectedID string
+	if listItem, ok := b.List.SelectedItem().(sections.ListItem); ok {
+		selectedID = listItem.ID()
+	}
+	items := slices.Clone(b.List.Items())
+	b.table.Sort(items)
+	cmd := b.List.SetItems(items)
+	for i, item := range b.List.VisibleItems() {
+		if listItem, ok := item.(sections.ListItem); ok && listItem.ID() == selectedID {
+			b.List.Select(i)
+			break
+		}
+	}
+	return cmd
+}
+
+// renderTable renders the column header above the list.
+func (b *Section) renderTable(listView string) string {
+	header := fmt.Sprintf("%*s%s", rowPrefixWidth, "", b.table.Header(b.List.Width()-rowPrefixWidth))
+	return lipgloss.JoinVertical(lipgloss.Left, tableHeaderStyle.Render(header), listView)
+}
+
+// tableDelegate renders an item as a single table row, drawn in the mark
+// color when marked and in its tint when it has one.
+type tableDelegate struct {
+	table    *table.Table
+	isMarked func(id string) bool
+	tint     func(item sections.ListItem) (color.Color, bool)
+}
+
+func (d tableDelegate) Height() int                             { return 1 }
+func (d tableDelegate) Spacing() int                            { return 0 }
+func (d tableDelegate) Update(_ tea.Msg, _ *list.Model) tea.Cmd { return nil }
+
+func (d tableDelegate) Render(w io.Writer, m list.Model, index int, item list.Item) {
+	listItem, ok := item.(sections.ListItem)
+	if !ok {
+		return
+	}
+	style := lipgloss.NewStyle()
+	if index == m.Index() {
+		style = theme.SelectedLogLine
+	}
+	prefix := "  "
+	if d.isMarked(listItem.ID()) {
+		prefix = theme.MarkedStyle.Render(theme.IconMarked) + " "
+		style = style.Foreground(theme.DockerBlue).Bold(true)
+	}
+	if c, tinted := d.tint(listItem); tinted {
+		style = style.Foreground(c)
+	}
+	fmt.Fprint(w, prefix+style.Render(d.table.Row(listItem, m.Width()-rowPrefixWidth)))
+}
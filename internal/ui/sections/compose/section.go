@@ -13,11 +13,13 @@ import (
 	"charm.land/huh/v2"
 
 	"github.com/GustavoCaso/docker-dash/internal/client"
+	"github.com/GustavoCaso/docker-dash/internal/config"
 	"github.com/GustavoCaso/docker-dash/internal/ui/components/form"
 	"github.com/GustavoCaso/docker-dash/internal/ui/keys"
 	"github.com/GustavoCaso/docker-dash/internal/ui/message"
 	"github.com/GustavoCaso/docker-dash/internal/ui/sections"
 	"github.com/GustavoCaso/docker-dash/internal/ui/sections/base"
+	"github.com/GustavoCaso/docker-dash/internal/ui/sections/containers"
 	"github.com/GustavoCaso/docker-dash/internal/ui/theme"
 )
 
@@ -69,12 +71,21 @@ type Section struct {
 	composeService client.ComposeProjectService
 }
 
-// New creates a new Compose section.
-func New(ctx context.Context, svc client.ComposeProjectService) *Section {
+// New creates a new Compose section. containerSvc streams the merged logs of
+// the services of the selected project.
+func New(
+	ctx context.Context,
+	svc client.ComposeProjectService,
+	containerSvc client.ContainerService,
+	logsCfg config.LogsConfig,
+) *Section {
 	s := &Section{
 		ctx:            ctx,
 		composeService: svc,
-		Section:        base.New(sections.ComposeSection, []sections.Panel{newDetailsPanel()}),
+		Section: base.New(sections.ComposeSection, []sections.Panel{
+			newDetailsPanel(),
+			containers.NewComposeLogsPanel(ctx, containerSvc, logsCfg),
+		}),
 	}
 
 	s.LoadingText = "Loading..."
@@ -13,6 +13,7 @@ import (
 	"github.com/charmbracelet/x/exp/teatest/v2"
 
 	"github.com/GustavoCaso/docker-dash/internal/client"
+	"github.com/GustavoCaso/docker-dash/internal/config"
 	"github.com/GustavoCaso/docker-dash/internal/ui/message"
 )
 
@@ -22,7 +23,7 @@ type composeSectionModel struct {
 
 func newModel() composeSectionModel {
 	c := client.NewMockClient()
-	section := New(context.Background(), c.Compose())
+	section := New(context.Background(), c.Compose(), c.Containers(), config.DefaultLogsConfig())
 	section.SetSize(120, 40)
 	// Load data synchronously so tests that call View/Update directly work without teatest.
 	section.Update(section.RefreshCmd()())
@@ -126,7 +127,7 @@ func TestComposeLoadedMsgRefreshesActivePanel(t *testing.T) {
 
 func TestComposeLoadedMsgCallsUpdateItems(t *testing.T) {
 	c := client.NewMockClient()
-	section := New(context.Background(), c.Compose())
+	section := New(context.Background(), c.Compose(), c.Containers(), config.DefaultLogsConfig())
 	section.SetSize(120, 40)
 
 	// Only call Init; do not pre-load so the list starts empty.
@@ -147,7 +148,7 @@ func TestComposeLoadedMsgCallsUpdateItems(t *testing.T) {
 
 func TestComposeLoadedMsgEmptyCallsUpdateItemsReset(t *testing.T) {
 	c := client.NewMockClient()
-	section := New(context.Background(), c.Compose())
+	section := New(context.Background(), c.Compose(), c.Containers(), config.DefaultLogsConfig())
 	section.SetSize(120, 40)
 
 	section.Update(section.RefreshCmd()())
@@ -335,7 +336,7 @@ func TestValidateOptionalDuration(t *testing.T) {
 
 func TestComposeUpOptsReachClient(t *testing.T) {
 	c := client.NewMockClient()
-	section := New(context.Background(), c.Compose())
+	section := New(context.Background(), c.Compose(), c.Containers(), config.DefaultLogsConfig())
 	section.SetSize(120, 40)
 	section.Update(section.RefreshCmd()())
 
@@ -366,7 +367,7 @@ func TestComposeUpOptsReachClient(t *testing.T) {
 
 func TestComposeDownOptsReachClient(t *testing.T) {
 	c := client.NewMockClient()
-	section := New(context.Background(), c.Compose())
+	section := New(context.Background(), c.Compose(), c.Containers(), config.DefaultLogsConfig())
 	section.SetSize(120, 40)
 	section.Update(section.RefreshCmd()())
 
@@ -394,7 +395,7 @@ func TestComposeDownOptsReachClient(t *testing.T) {
 
 func TestComposeRestartOptsReachClient(t *testing.T) {
 	c := client.NewMockClient()
-	section := New(context.Background(), c.Compose())
+	section := New(context.Background(), c.Compose(), c.Containers(), config.DefaultLogsConfig())
 	section.SetSize(120, 40)
 	section.Update(section.RefreshCmd()())
 
@@ -426,7 +427,7 @@ func TestComposeRestartOptsReachClient(t *testing.T) {
 
 func TestComposeFormKeysReturnNilOnEmptyList(t *testing.T) {
 	c := client.NewMockClient()
-	section := New(context.Background(), c.Compose())
+	section := New(context.Background(), c.Compose(), c.Containers(), config.DefaultLogsConfig())
 	section.SetSize(120, 40)
 	// Do NOT load items — list is empty, selectedProject() returns false.
 
@@ -0,0 +1,265 @@
+package containers
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/GustavoCaso/docker-dash/internal/client"
+	"github.com/GustavoCaso/docker-dash/internal/config"
+)
+
+// maxAlerts caps the alerts kept for the Alerts panel, dropping the oldest.
+const maxAlerts = 100
+
+// alert is raised when a rule matches a container. Alerts stay listed until
+// they are dismissed; the ones raised by a cpu, memory or unhealthy rule are
+// resolved once the condition stops holding.
+type alert struct {
+	id          int
+	rule        string
+	containerID string
+	container   string
+	detail      string
+	firedAt     time.Time
+	resolvedAt  time.Time // zero while the condition holds
+}
+
+func (a *alert) resolved() bool {
+	return !a.resolvedAt.IsZero()
+}
+
+// alertRule is a configured rule with its parsed condition.
+type alertRule struct {
+	config.AlertRule
+	cond config.AlertCondition
+}
+
+// alertKey identifies the condition of a rule on a container.
+type alertKey struct {
+	rule        int
+	containerID string
+}
+
+// alertEngine evaluates the alert rules against the samples of the stats
+// collector, the container list and the container events, whichever panel is
+// open. The section feeds it and notifies the alerts it raises.
+type alertEngine struct {
+	rules []alertRule
+	// pending holds when the condition of a cpu or memory rule started to
+	// hold, until it held for the rule's duration.
+	pending map[alertKey]time.Time
+	firing  map[alertKey]*alert
+	alerts  []*alert // newest first
+	// known holds the containers of the last list load, by ID, to spot
+	// restarts. It is nil until the first load.
+	known  map[string]client.Container
+	nextID int
+}
+
+func newAlertEngine(cfg config.AlertsConfig) *alertEngine {
+	e := &alertEngine{pending: map[alertKey]time.Time{}, firing: map[alertKey]*alert{}}
+	for _, rule := range cfg.Rules {
+		cond, err := rule.Condition()
+		if err != nil {
+			log.Printf("[containers][alerts] skipping rule: %v", err)
+			continue
+		}
+		e.rules = append(e.rules, alertRule{AlertRule: rule, cond: cond})
+	}
+	return e
+}
+
+// watchesUsage reports whether a rule needs the stats collector.
+func (e *alertEngine) watchesUsage() bool {
+	for _, rule := range e.rules {
+		if rule.cond.Kind == config.AlertCPU || rule.cond.Kind == config.AlertMemory {
+			return true
+		}
+	}
+	return false
+}
+
+// observeUsage evaluates the cpu and memory rules against the latest samples
+// and returns the alerts raised. The alerts of containers no longer sampled
+// are resolved.
+func (e *alertEngine) observeUsage(now time.Time, usage map[string]*containerUsage) []*alert {
+	var fired []*alert
+	for i, rule := range e.rules {
+		if rule.cond.Kind != config.AlertCPU && rule.cond.Kind != config.AlertMemory {
+			continue
+		}
+		for id, u := range usage {
+			if !rule.Matches(u.container.Name, u.container.Labels) {
+				continue
+			}
+			value, label := u.stats.MemoryPercent(), "memory"
+			if rule.cond.Kind == config.AlertCPU {
+				if !u.hasRates {
+					continue
+				}
+				value, label = u.rates.CPUPercent, "CPU"
+			}
+			detail := label + " " + formatPercent(value)
+			if a := e.level(alertKey{i, id}, rule, u.container, rule.cond.Holds(value), now, detail); a != nil {
+				fired = append(fired, a)
+			}
+		}
+		e.resolveMissing(i, now, func(id string) bool { return usage[id] != nil })
+	}
+	return fired
+}
+
+// observeContainers evaluates the unhealthy and restarted rules against a
+// load of the container list and returns the alerts raised. The first load
+// only records the restart counts.
+func (e *alertEngine) observeContainers(now time.Time, containers []client.Container) []*alert {
+	var fired []*alert
+	listed := make(map[string]client.Container, len(containers))
+	for _, c := range containers {
+		listed[c.ID] = c
+	}
+	for i, rule := range e.rules {
+		switch rule.cond.Kind {
+		case config.AlertUnhealthy:
+			for _, c := range containers {
+				if !rule.Matches(c.Name, c.Labels) {
+					continue
+				}
+				unhealthy := c.Health != nil && c.Health.Status == client.HealthUnhealthy
+				detail := "unhealthy"
+				if unhealthy && c.Health.FailingStreak > 0 {
+					detail = fmt.Sprintf("unhealthy, %d failing checks in a row", c.Health.FailingStreak)
+				}
+				if a := e.level(alertKey{i, c.ID}, rule, c, unhealthy, now, detail); a != nil {
+					fired = append(fired, a)
+				}
+			}
+			e.resolveMissing(i, now, func(id string) bool { _, ok := listed[id]; return ok })
+		case config.AlertRestarted:
+			if e.known == nil {
+				continue
+			}
+			for _, c := range containers {
+				prev, ok := e.known[c.ID]
+				if !ok || c.RestartCount <= prev.RestartCount || !rule.Matches(c.Name, c.Labels) {
+					continue
+				}
+				detail := fmt.Sprintf("restart count %d → %d", prev.RestartCount, c.RestartCount)
+				fired = append(fired, e.raise(rule, c, detail, now))
+			}
+		}
+	}
+	e.known = listed
+	return fired
+}
+
+// observeEvent evaluates the exited rules against a container event and
+// returns the alerts raised.
+func (e *alertEngine) observeEvent(event client.ContainerEvent) []*alert {
+	if event.Action != "die" || event.ExitCode == 0 {
+		return nil
+	}
+	c, ok := e.known[event.ID]
+	if !ok {
+		c = client.Container{ID: event.ID, Name: event.Name}
+	}
+	var fired []*alert
+	for _, rule := range e.rules {
+		if rule.cond.Kind != config.AlertExited || !rule.Matches(c.Name, c.Labels) {
+			continue
+		}
+		fired = append(fired, e.raise(rule, c, fmt.Sprintf("exited with code %d", event.ExitCode), event.Time))
+	}
+	return fired
+}
+
+// level tracks a condition that holds for a while, such as high CPU, and
+// returns the alert raised once it held for the rule's duration. A firing
+// alert keeps the latest detail until the condition stops holding.
+func (e *alertEngine) level(
+	key alertKey,
+	rule alertRule,
+	c client.Container,
+	holds bool,
+	now time.Time,
+	detail string,
+) *alert {
+	if !holds {
+		e.resolve(key, now)
+		return nil
+	}
+	if a := e.firing[key]; a != nil {
+		a.detail = detail
+		return nil
+	}
+	since, ok := e.pending[key]
+	if !ok {
+		e.pending[key] = now
+		since = now
+	}
+	if now.Sub(since) < rule.cond.For {
+		return nil
+	}
+	delete(e.pending, key)
+	a := e.raise(rule, c, detail, now)
+	e.firing[key] = a
+	return a
+}
+
+func (e *alertEngine) resolve(key alertKey, now time.Time) {
+	delete(e.pending, key)
+	if a := e.firing[key]; a != nil {
+		a.resolvedAt = now
+		delete(e.firing, key)
+	}
+}
+
+// resolveMissing resolves the conditions of the rule on the containers that
+// are not present.
+func (e *alertEngine) resolveMissing(rule int, now time.Time, present func(id string) bool) {
+	var missing []alertKey
+	for key := range e.pending {
+		if key.rule == rule && !present(key.containerID) {
+			missing = append(missing, key)
+		}
+	}
+	for key := range e.firing {
+		if key.rule == rule && !present(key.containerID) {
+			missing = append(missing, key)
+		}
+	}
+	for _, key := range missing {
+		e.resolve(key, now)
+	}
+}
+
+// raise lists a new alert of rule on container c.
+func (e *alertEngine) raise(rule alertRule, c client.Container, detail string, now time.Time) *alert {
+	e.nextID++
+	a := &alert{
+		id:          e.nextID,
+		rule:        rule.Title(),
+		containerID: c.ID,
+		container:   c.Name,
+		detail:      detail,
+		firedAt:     now,
+	}
+	log.Printf("[containers][alerts] fired: rule=%q container=%q detail=%q", a.rule, a.container, a.detail)
+	e.alerts = append([]*alert{a}, e.alerts...)
+	if len(e.alerts) > maxAlerts {
+		e.alerts = e.alerts[:maxAlerts]
+	}
+	return a
+}
+
+// dismiss removes the alert with id from the list. A firing alert that is
+// dismissed is not raised again until its condition stops holding.
+func (e *alertEngine) dismiss(id int) {
+	for i, a := range e.alerts {
+		if a.id == id {
+			e.alerts = append(e.alerts[:i], e.alerts[i+1:]...)
+			return
+		}
+	}
+}
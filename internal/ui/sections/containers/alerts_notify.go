@@ -0,0 +1,129 @@
+package containers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/GustavoCaso/docker-dash/internal/config"
+)
+
+// notifyTimeout bounds each webhook request and desktop notification.
+const notifyTimeout = 10 * time.Second
+
+// webhookPayload is the JSON body posted to the alerts webhook.
+type webhookPayload struct {
+	Rule        string    `json:"rule"`
+	Container   string    `json:"container"`
+	ContainerID string    `json:"container_id"`
+	Detail      string    `json:"detail"`
+	FiredAt     time.Time `json:"fired_at"`
+}
+
+// alertNotifier sends the alerts raised to the configured webhook and as
+// desktop notifications. Failures are logged; the Alerts panel still lists
+// the alert.
+type alertNotifier struct {
+	ctx     context.Context
+	webhook string
+	desktop bool
+	http    *http.Client
+	goos    string
+}
+
+func newAlertNotifier(ctx context.Context, cfg config.AlertsConfig) *alertNotifier {
+	return &alertNotifier{
+		ctx:     ctx,
+		webhook: cfg.Webhook,
+		desktop: cfg.Desktop,
+		http:    &http.Client{Timeout: notifyTimeout},
+		goos:    runtime.GOOS,
+	}
+}
+
+// notifyCmd sends a, or returns nil when no notification is configured.
+func (n *alertNotifier) notifyCmd(a *alert) tea.Cmd {
+	if n.webhook == "" && !n.desktop {
+		return nil
+	}
+	payload := webhookPayload{
+		Rule:        a.rule,
+		Container:   a.container,
+		ContainerID: a.containerID,
+		Detail:      a.detail,
+		FiredAt:     a.firedAt,
+	}
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(n.ctx, notifyTimeout)
+		defer cancel()
+		if n.webhook != "" {
+			if err := n.post(ctx, payload); err != nil {
+				log.Printf("[containers][alerts] webhook: %v", err)
+			}
+		}
+		if n.desktop {
+			if err := n.notifyDesktop(ctx, payload); err != nil {
+				log.Printf("[containers][alerts] desktop notification: %v", err)
+			}
+		}
+		return nil
+	}
+}
+
+func (n *alertNotifier) post(ctx context.Context, payload webhookPayload) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.webhook, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := n.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("POST %s: %s", n.webhook, resp.Status)
+	}
+	return nil
+}
+
+func (n *alertNotifier) notifyDesktop(ctx context.Context, payload webhookPayload) error {
+	title := "docker-dash: " + payload.Rule
+	body := payload.Container + ": " + payload.Detail
+	name, args, ok := desktopNotifyCommand(n.goos, title, body)
+	if !ok {
+		return fmt.Errorf("not supported on %s", n.goos)
+	}
+	if out, err := exec.CommandContext(ctx, name, args...).CombinedOutput(); err != nil {
+		return fmt.Errorf("%s: %w: %s", name, err, bytes.TrimSpace(out))
+	}
+	return nil
+}
+
+// desktopNotifyCommand returns the command showing a desktop notification on
+// goos: notify-send on Linux and osascript on macOS.
+func desktopNotifyCommand(goos, title, body string) (string, []string, bool) {
+	switch goos {
+	case "linux":
+		return "notify-send", []string{title, body}, true
+	case "darwin":
+		quote := strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+		script := fmt.Sprintf(`display notification "%s" with title "%s"`,
+			quote.Replace(body), quote.Replace(title))
+		return "osascript", []string{"-e", script}, true
+	}
+	return "", nil, false
+}
@@ -0,0 +1,70 @@
+package containers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/GustavoCaso/docker-dash/internal/config"
+)
+
+func TestAlertNotifierPostsToWebhook(t *testing.T) {
+	received := make(chan webhookPayload, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload webhookPayload
+		if r.Method != http.MethodPost || r.Header.Get("Content-Type") != "application/json" {
+			t.Errorf("request = %s %q, want a JSON POST", r.Method, r.Header.Get("Content-Type"))
+		}
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Error(err)
+		}
+		received <- payload
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	n := newAlertNotifier(context.Background(), config.AlertsConfig{Webhook: server.URL})
+	firedAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	a := &alert{rule: "cpu > 90", containerID: "a", container: "api", detail: "CPU 95%", firedAt: firedAt}
+	cmd := n.notifyCmd(a)
+	if cmd == nil {
+		t.Fatal("notifyCmd() should post to the webhook")
+	}
+	cmd()
+
+	want := webhookPayload{Rule: "cpu > 90", Container: "api", ContainerID: "a", Detail: "CPU 95%", FiredAt: firedAt}
+	select {
+	case got := <-received:
+		if got != want {
+			t.Errorf("payload = %+v, want %+v", got, want)
+		}
+	default:
+		t.Fatal("the webhook received nothing")
+	}
+}
+
+func TestAlertNotifierWithoutTargets(t *testing.T) {
+	n := newAlertNotifier(context.Background(), config.AlertsConfig{})
+	if cmd := n.notifyCmd(&alert{}); cmd != nil {
+		t.Error("notifyCmd() should do nothing without a webhook or desktop notifications")
+	}
+}
+
+func TestDesktopNotifyCommand(t *testing.T) {
+	name, args, ok := desktopNotifyCommand("linux", "title", "body")
+	if !ok || name != "notify-send" || !slices.Equal(args, []string{"title", "body"}) {
+		t.Errorf("linux command = %s %v", name, args)
+	}
+	name, args, ok = desktopNotifyCommand("darwin", "title", `say "hi"`)
+	want := []string{"-e", `display notification "say \"hi\"" with title "title"`}
+	if !ok || name != "osascript" || !slices.Equal(args, want) {
+		t.Errorf("darwin command = %s %v, want osascript %v", name, args, want)
+	}
+	if _, _, ok := desktopNotifyCommand("windows", "title", "body"); ok {
+		t.Error("windows should not be supported")
+	}
+}
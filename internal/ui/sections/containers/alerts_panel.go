@@ -0,0 +1,157 @@
+package containers
+
+import (
+	"fmt"
+	"io"
+	"log"
+
+	"charm.land/bubbles/v2/key"
+	"charm.land/bubbles/v2/list"
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/GustavoCaso/docker-dash/internal/ui/keys"
+	"github.com/GustavoCaso/docker-dash/internal/ui/message"
+	"github.com/GustavoCaso/docker-dash/internal/ui/sections"
+	"github.com/GustavoCaso/docker-dash/internal/ui/theme"
+)
+
+const alertsPanelName = "Alerts"
+
+// alertTimeFormat is how the Alerts panel shows when an alert fired.
+const alertTimeFormat = "15:04:05"
+
+// alertRow is an alert as a list item.
+type alertRow struct {
+	*alert
+}
+
+func (r alertRow) Title() string       { return r.rule }
+func (r alertRow) Description() string { return "" }
+func (r alertRow) FilterValue() string { return r.rule + " " + r.container }
+
+// alertDelegate renders an alert as a single line.
+type alertDelegate struct{}
+
+func (d alertDelegate) Height() int                             { return 1 }
+func (d alertDelegate) Spacing() int                            { return 0 }
+func (d alertDelegate) Update(_ tea.Msg, _ *list.Model) tea.Cmd { return nil }
+
+func (d alertDelegate) Render(w io.Writer, m list.Model, index int, item list.Item) {
+	r, ok := item.(alertRow)
+	if !ok {
+		return
+	}
+	icon := theme.StatusErrorStyle.Render(theme.IconWarning)
+	status := ""
+	if r.resolved() {
+		icon = processHeaderStyle.Render(theme.IconWarning)
+		status = "  resolved " + r.resolvedAt.Format(alertTimeFormat)
+	}
+	row := truncate(fmt.Sprintf("%s  %s  %s: %s%s",
+		r.firedAt.Format(alertTimeFormat), r.rule, r.container, r.detail, status), max(m.Width()-2, 0))
+	if index == m.Index() {
+		row = theme.SelectedLogLine.Render(row)
+	}
+	fmt.Fprint(w, icon+" "+row)
+}
+
+// alertsPanel lists the alerts raised by the section's alert rules, newest
+// first, whichever container is selected. Alerts stay until dismissed.
+type alertsPanel struct {
+	alerts *alertEngine
+	list   list.Model
+}
+
+func newAlertsPanel(alerts *alertEngine) *alertsPanel {
+	l := list.New([]list.Item{}, alertDelegate{}, 0, 0)
+	l.SetShowTitle(false)
+	l.SetShowHelp(false)
+	l.SetShowStatusBar(false)
+	l.DisableQuitKeybindings()
+	return &alertsPanel{alerts: alerts, list: l}
+}
+
+func (p *alertsPanel) Name() string {
+	return alertsPanelName
+}
+
+// SectionWide keeps the panel open while the selection moves, as it lists
+// the alerts of every container.
+func (p *alertsPanel) SectionWide() bool {
+	return true
+}
+
+func (p *alertsPanel) Init(_ sections.ListItem) tea.Cmd {
+	log.Printf("[containers][alerts-panel] Init")
+	return tea.Batch(p.refresh(), p.extendHelpCmd())
+}
+
+func (p *alertsPanel) Update(msg tea.Msg) tea.Cmd {
+	if msg, ok := msg.(tea.KeyPressMsg); ok && !p.list.SettingFilter() &&
+		key.Matches(msg, keys.Keys.AlertDismiss) {
+		r, ok := p.list.SelectedItem().(alertRow)
+		if !ok {
+			return nil
+		}
+		log.Printf("[containers][alerts-panel] dismiss: rule=%q container=%q", r.rule, r.container)
+		p.alerts.dismiss(r.id)
+		return tea.Batch(p.refresh(), alertsChangedCmd(p.alerts))
+	}
+
+	var cmd tea.Cmd
+	p.list, cmd = p.list.Update(msg)
+	return cmd
+}
+
+func (p *alertsPanel) View() string {
+	switch {
+	case len(p.alerts.rules) == 0:
+		return processHeaderStyle.Render("No alert rules configured")
+	case len(p.alerts.alerts) == 0:
+		return processHeaderStyle.Render("No alerts")
+	}
+	return p.list.View()
+}
+
+func (p *alertsPanel) Close() tea.Cmd {
+	log.Printf("[containers][alerts-panel] Close")
+	p.list.ResetFilter()
+	p.list.SetItems([]list.Item{})
+	return func() tea.Msg { return message.ClearContextualKeyBindingsMsg{} }
+}
+
+func (p *alertsPanel) SetSize(width, height int) {
+	p.list.SetSize(width, height)
+}
+
+// IsFilter reports whether the user is typing a filter, so the section routes
+// every key to the panel instead of treating it as a shortcut.
+func (p *alertsPanel) IsFilter() bool {
+	return p.list.SettingFilter()
+}
+
+// refresh shows the alerts of the engine, keeping the cursor in place.
+func (p *alertsPanel) refresh() tea.Cmd {
+	items := make([]list.Item, len(p.alerts.alerts))
+	for i, a := range p.alerts.alerts {
+		items[i] = alertRow{a}
+	}
+	return p.list.SetItems(items)
+}
+
+func (p *alertsPanel) extendHelpCmd() tea.Cmd {
+	return func() tea.Msg {
+		return message.AddContextualKeyBindingsMsg{Bindings: []key.Binding{
+			keys.Keys.ScrollUp,
+			keys.Keys.ScrollDown,
+			keys.Keys.Filter,
+			keys.Keys.AlertDismiss,
+		}}
+	}
+}
+
+// alertsChangedCmd reports the number of alerts listed, for the header.
+func alertsChangedCmd(alerts *alertEngine) tea.Cmd {
+	count := len(alerts.alerts)
+	return func() tea.Msg { return message.AlertsChangedMsg{Count: count} }
+}
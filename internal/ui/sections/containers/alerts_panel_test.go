@@ -0,0 +1,70 @@
+package containers
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/GustavoCaso/docker-dash/internal/client"
+	"github.com/GustavoCaso/docker-dash/internal/config"
+	"github.com/GustavoCaso/docker-dash/internal/ui/message"
+)
+
+func TestAlertsPanelListsAndDismissesAlerts(t *testing.T) {
+	e := newTestAlertEngine(config.AlertRule{When: "cpu > 90"})
+	e.observeUsage(time.Now(), cpuUsage(95))
+	p := newAlertsPanel(e)
+	p.SetSize(120, 10)
+	p.Init(containerItem{container: client.Container{ID: "a"}})
+
+	if view := p.View(); !strings.Contains(view, "cpu > 90  api: CPU 95.00%") {
+		t.Errorf("View() should list the alert:\n%s", view)
+	}
+
+	cmd := p.Update(tea.KeyPressMsg{Code: 'x', Text: "x"})
+	if len(e.alerts) != 0 {
+		t.Fatal("x should dismiss the selected alert")
+	}
+	if !strings.Contains(p.View(), "No alerts") {
+		t.Errorf("View() should be empty once dismissed:\n%s", p.View())
+	}
+	msgs := []tea.Msg{cmd()}
+	if batch, ok := msgs[0].(tea.BatchMsg); ok {
+		msgs = nil
+		for _, c := range batch {
+			if c != nil {
+				msgs = append(msgs, c())
+			}
+		}
+	}
+	var changed bool
+	for _, msg := range msgs {
+		if got, ok := msg.(message.AlertsChangedMsg); ok && got.Count == 0 {
+			changed = true
+		}
+	}
+	if !changed {
+		t.Error("dismissing should report the new alerts count")
+	}
+}
+
+func TestAlertsPanelWithoutRules(t *testing.T) {
+	p := newAlertsPanel(newTestAlertEngine())
+	if !strings.Contains(p.View(), "No alert rules configured") {
+		t.Errorf("View() = %q, want a hint about the missing rules", p.View())
+	}
+}
+
+func TestAlertsPanelStaysOpenWhileTheSelectionMoves(t *testing.T) {
+	section := newContainerSectionModel().section
+	section.Update(section.RefreshCmd()())
+	section.ShowPanel(alertsPanelName)
+	section.Update(tea.KeyPressMsg{Code: tea.KeyTab})
+
+	section.Update(tea.KeyPressMsg{Code: tea.KeyDown})
+	if section.ActivePanelName() != alertsPanelName {
+		t.Errorf("active panel = %q after moving the selection, want %q", section.ActivePanelName(), alertsPanelName)
+	}
+}
@@ -0,0 +1,119 @@
+package containers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/GustavoCaso/docker-dash/internal/client"
+	"github.com/GustavoCaso/docker-dash/internal/config"
+)
+
+func newTestAlertEngine(rules ...config.AlertRule) *alertEngine {
+	return newAlertEngine(config.AlertsConfig{Rules: rules})
+}
+
+// cpuUsage returns the usage of the api container at cpu percent.
+func cpuUsage(cpu float64) map[string]*containerUsage {
+	return map[string]*containerUsage{"a": {
+		container: client.Container{ID: "a", Name: "api"},
+		rates:     client.StatsRates{CPUPercent: cpu},
+		hasRates:  true,
+	}}
+}
+
+func TestAlertEngineRaisesUsageAlertsAfterTheirDuration(t *testing.T) {
+	e := newTestAlertEngine(config.AlertRule{When: "cpu > 90", For: "2m"})
+	start := time.Unix(1000, 0)
+
+	if fired := e.observeUsage(start, cpuUsage(95)); len(fired) != 0 {
+		t.Fatalf("observeUsage() = %v, want nothing before the condition held for 2m", fired)
+	}
+	fired := e.observeUsage(start.Add(2*time.Minute), cpuUsage(97))
+	if len(fired) != 1 || fired[0].container != "api" || fired[0].detail != "CPU 97.00%" {
+		t.Fatalf("observeUsage() = %v, want an alert on api", fired)
+	}
+	if fired := e.observeUsage(start.Add(3*time.Minute), cpuUsage(99)); len(fired) != 0 {
+		t.Errorf("observeUsage() = %v, want a firing alert not raised again", fired)
+	}
+	if got := e.alerts[0].detail; got != "CPU 99.00%" {
+		t.Errorf("detail = %q, want the latest value", got)
+	}
+
+	e.observeUsage(start.Add(4*time.Minute), cpuUsage(10))
+	if !e.alerts[0].resolved() {
+		t.Error("the alert should resolve once the CPU drops")
+	}
+	if fired := e.observeUsage(start.Add(5*time.Minute), cpuUsage(95)); len(fired) != 0 {
+		t.Error("a new spike should wait for the duration again")
+	}
+}
+
+func TestAlertEngineResolvesContainersNoLongerSampled(t *testing.T) {
+	e := newTestAlertEngine(config.AlertRule{When: "cpu > 90"})
+	now := time.Unix(1000, 0)
+	e.observeUsage(now, cpuUsage(95))
+	e.observeUsage(now.Add(time.Second), nil)
+	if len(e.alerts) != 1 || !e.alerts[0].resolved() {
+		t.Errorf("alerts = %v, want the alert of the stopped container resolved", e.alerts)
+	}
+}
+
+func TestAlertEngineObservesContainers(t *testing.T) {
+	e := newTestAlertEngine(
+		config.AlertRule{When: "restarted"},
+		config.AlertRule{When: "unhealthy", Container: "db"},
+	)
+	now := time.Unix(1000, 0)
+	unhealthy := &client.HealthInfo{Status: client.HealthUnhealthy, FailingStreak: 3}
+	containers := []client.Container{
+		{ID: "a", Name: "api", RestartCount: 2, Health: unhealthy},
+		{ID: "b", Name: "db", RestartCount: 0},
+	}
+	if fired := e.observeContainers(now, containers); len(fired) != 0 {
+		t.Fatalf("observeContainers() = %v, want the first load to be the baseline", fired)
+	}
+
+	containers[0].RestartCount = 3
+	containers[1].Health = unhealthy
+	fired := e.observeContainers(now, containers)
+	if len(fired) != 2 {
+		t.Fatalf("observeContainers() = %v, want a restart and an unhealthy alert", fired)
+	}
+	if fired[0].detail != "restart count 2 → 3" {
+		t.Errorf("restart detail = %q", fired[0].detail)
+	}
+	if fired[1].container != "db" || fired[1].detail != "unhealthy, 3 failing checks in a row" {
+		t.Errorf("unhealthy alert = %+v, want db only", fired[1])
+	}
+}
+
+func TestAlertEngineObservesNonZeroExits(t *testing.T) {
+	e := newTestAlertEngine(config.AlertRule{When: "exited", Label: "tier=web"})
+	e.observeContainers(time.Now(), []client.Container{
+		{ID: "a", Name: "api", Labels: map[string]string{"tier": "web"}},
+		{ID: "b", Name: "db", Labels: map[string]string{"tier": "db"}},
+	})
+	if fired := e.observeEvent(client.ContainerEvent{ID: "a", Action: "die"}); len(fired) != 0 {
+		t.Errorf("observeEvent() = %v, want clean exits ignored", fired)
+	}
+	if fired := e.observeEvent(client.ContainerEvent{ID: "b", Action: "die", ExitCode: 1}); len(fired) != 0 {
+		t.Errorf("observeEvent() = %v, want containers the rule does not match ignored", fired)
+	}
+	fired := e.observeEvent(client.ContainerEvent{ID: "a", Action: "die", ExitCode: 137})
+	if len(fired) != 1 || fired[0].detail != "exited with code 137" {
+		t.Errorf("observeEvent() = %v, want an exit alert", fired)
+	}
+}
+
+func TestAlertEngineDismiss(t *testing.T) {
+	e := newTestAlertEngine(config.AlertRule{When: "cpu > 90"})
+	now := time.Unix(1000, 0)
+	fired := e.observeUsage(now, cpuUsage(95))
+	e.dismiss(fired[0].id)
+	if len(e.alerts) != 0 {
+		t.Fatalf("alerts = %v, want the alert dismissed", e.alerts)
+	}
+	if fired := e.observeUsage(now.Add(time.Second), cpuUsage(95)); len(fired) != 0 {
+		t.Error("a dismissed alert should not be raised again while its condition holds")
+	}
+}
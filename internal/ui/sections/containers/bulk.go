@@ -0,0 +1,193 @@
+package containers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"charm.land/bubbles/v2/key"
+	tea "charm.land/bubbletea/v2"
+	"charm.land/huh/v2"
+
+	"github.com/GustavoCaso/docker-dash/internal/client"
+	"github.com/GustavoCaso/docker-dash/internal/ui/components/form"
+	"github.com/GustavoCaso/docker-dash/internal/ui/keys"
+	"github.com/GustavoCaso/docker-dash/internal/ui/message"
+	"github.com/GustavoCaso/docker-dash/internal/ui/sections"
+	"github.com/GustavoCaso/docker-dash/internal/ui/sections/base"
+)
+
+// bulkStopLimit caps the containers a bulk stop or restart acts on at once,
+// so that it takes about one stop timeout per bulkStopLimit containers rather
+// than one per container.
+const bulkStopLimit = 4
+
+// handleBulkKey applies the action of msg to the marked containers, with a
+// single confirmation. It reports false when nothing is marked or msg is not
+// a bulk action, so the selected container is acted on instead.
+func (s *Section) handleBulkKey(msg tea.KeyPressMsg) (tea.Cmd, bool) {
+	marked := s.markedContainers()
+	if len(marked) == 0 {
+		return nil, false
+	}
+	switch {
+	case key.Matches(msg, keys.Keys.ContainerDelete):
+		return s.ConfirmBulk(s.bulkAction("Remove", "Removed", func(ci containerItem) error {
+			return s.service.Remove(s.ctx, ci.ID(), true)
+		}), listItems(marked)), true
+	case key.Matches(msg, keys.Keys.ContainerStartStop):
+		return s.confirmBulkStartStop(marked), true
+	case key.Matches(msg, keys.Keys.ContainerRestart):
+		return s.showBulkStopForm("Restart", "Restarted", marked, nil, s.service.Restart), true
+	case key.Matches(msg, keys.Keys.ContainerPauseUnpause):
+		return s.confirmBulkPauseUnpause(marked), true
+	}
+	return nil, false
+}
+
+// confirmBulkStartStop stops the running marked containers, with the options
+// of the stop form, or starts them all when none is running. The summary of a
+// stop names the marked containers it skipped.
+func (s *Section) confirmBulkStartStop(marked []containerItem) tea.Cmd {
+	if running, others := splitByState(marked, client.StateRunning); len(running) > 0 {
+		return s.showBulkStopForm("Stop", "Stopped", running, others, s.service.Stop)
+	}
+	return s.ConfirmBulk(s.bulkAction("Start", "Started", func(ci containerItem) error {
+		return s.service.Start(s.ctx, ci.ID())
+	}), listItems(marked))
+}
+
+// confirmBulkPauseUnpause pauses the running marked containers, or unpauses
+// the paused ones when none is running. The summary names the marked
+// containers it skipped.
+func (s *Section) confirmBulkPauseUnpause(marked []containerItem) tea.Cmd {
+	if running, others := splitByState(marked, client.StateRunning); len(running) > 0 {
+		action := s.bulkAction("Pause", "Paused", func(ci containerItem) error {
+			return s.service.Pause(s.ctx, ci.ID())
+		})
+		action.Skipped, action.SkipReason = listItems(others), "not running"
+		return s.ConfirmBulk(action, listItems(running))
+	}
+	if paused, others := splitByState(marked, client.StatePaused); len(paused) > 0 {
+		action := s.bulkAction("Unpause", "Unpaused", func(ci containerItem) error {
+			return s.service.Unpause(s.ctx, ci.ID())
+		})
+		action.Skipped, action.SkipReason = listItems(others), "not paused"
+		return s.ConfirmBulk(action, listItems(paused))
+	}
+	return func() tea.Msg {
+		return message.ShowBannerMsg{Message: "None of the marked containers is running or paused", IsError: true}
+	}
+}
+
+// bulkAction wraps run, which acts on one container, as a bulk action.
+func (s *Section) bulkAction(verb, done string, run func(ci containerItem) error) base.BulkAction {
+	return base.BulkAction{
+		Verb: verb,
+		Done: done,
+		Noun: "container",
+		Run: func(item sections.ListItem) error {
+			ci, ok := item.(containerItem)
+			if !ok {
+				return errors.New("not a container")
+			}
+			return run(ci)
+		},
+	}
+}
+
+// stopFunc stops a container with opts, as Stop and Restart do.
+type stopFunc func(ctx context.Context, id string, opts client.ContainerStopOptions) error
+
+// showBulkStopForm asks once for the timeout and signal used to stop targets,
+// then runs stop on bulkStopLimit of them at a time with the chosen options.
+// The form is prefilled with the configured defaults the targets share; a
+// default they do not share is left blank, and a blank left there means the
+// configured default of each container. The summary names the skipped
+// containers as not running.
+func (s *Section) showBulkStopForm(verb, done string, targets, skipped []containerItem, stop stopFunc) tea.Cmd {
+	var timeouts, signals []string
+	for _, ci := range targets {
+		timeout, signal := s.stopCfg.Defaults(ci.container.Name, ci.container.Labels)
+		timeouts = append(timeouts, timeout)
+		signals = append(signals, signal)
+	}
+	timeout, timeoutShared := sharedValue(timeouts)
+	signal, signalShared := sharedValue(signals)
+
+	ctx := s.ctx
+	stopCfg := s.stopCfg
+	action := s.bulkAction(verb, done, func(ci containerItem) error {
+		defaultTimeout, defaultSignal := stopCfg.Defaults(ci.container.Name, ci.container.Labels)
+		return stop(ctx, ci.ID(), buildStopOptions(
+			withDefault(timeout, defaultTimeout, timeoutShared),
+			withDefault(signal, defaultSignal, signalShared),
+		))
+	})
+	action.Limit = bulkStopLimit
+	action.Skipped, action.SkipReason = listItems(skipped), "not running"
+	runCmd := s.BulkCmd(action, listItems(targets))
+	bulkForm := form.New(
+		fmt.Sprintf("%s — %d containers", verb, len(targets)),
+		stopForm(&timeout, &signal),
+		func(_ *huh.Form) tea.Cmd {
+			return s.WithSpinner(runCmd)
+		},
+	)
+	return func() tea.Msg {
+		return message.ShowFormMsg{Form: bulkForm}
+	}
+}
+
+// sharedValue returns the value every one of values holds, or blank and false
+// when they differ.
+func sharedValue(values []string) (string, bool) {
+	for _, v := range values[1:] {
+		if v != values[0] {
+			return "", false
+		}
+	}
+	return values[0], true
+}
+
+// withDefault returns value, or def when value is blank and stands for a
+// default the targets did not share.
+func withDefault(value, def string, shared bool) string {
+	if !shared && strings.TrimSpace(value) == "" {
+		return def
+	}
+	return value
+}
+
+// markedContainers returns the marked containers in list order.
+func (s *Section) markedContainers() []containerItem {
+	var marked []containerItem
+	for _, item := range s.MarkedItems() {
+		if ci, ok := item.(containerItem); ok {
+			marked = append(marked, ci)
+		}
+	}
+	return marked
+}
+
+// splitByState separates the containers in state from the others.
+func splitByState(containers []containerItem, state client.ContainerState) ([]containerItem, []containerItem) {
+	var matching, others []containerItem
+	for _, ci := range containers {
+		if ci.container.State == state {
+			matching = append(matching, ci)
+		} else {
+			others = append(others, ci)
+		}
+	}
+	return matching, others
+}
+
+func listItems(containers []containerItem) []sections.ListItem {
+	items := make([]sections.ListItem, len(containers))
+	for i, ci := range containers {
+		items[i] = ci
+	}
+	return items
+}
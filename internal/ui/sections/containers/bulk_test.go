@@ -0,0 +1,188 @@
+package containers
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/charmbracelet/x/exp/teatest/v2"
+
+	"github.com/GustavoCaso/docker-dash/internal/client"
+	"github.com/GustavoCaso/docker-dash/internal/config"
+	"github.com/GustavoCaso/docker-dash/internal/ui/message"
+)
+
+func newLoadedSection(t *testing.T, c client.Client) *Section {
+	t.Helper()
+	section := New(
+		context.Background(),
+		c.Containers(),
+		config.DefaultLogsConfig(),
+		config.StopConfig{},
+		config.StatsConfig{},
+		config.AlertsConfig{},
+		config.TableConfig{},
+	)
+	section.SetSize(120, 40)
+	section.Update(section.RefreshCmd()())
+	return section
+}
+
+// confirmAndRun presses key, checks the confirmation and runs its command
+// through the section.
+func confirmAndRun(t *testing.T, section *Section, key tea.KeyPressMsg) message.ShowConfirmationMsg {
+	t.Helper()
+	cmd := section.Update(key)
+	if cmd == nil {
+		t.Fatalf("%s should return a cmd", key.String())
+	}
+	confirm, ok := cmd().(message.ShowConfirmationMsg)
+	if !ok {
+		t.Fatalf("%s should ask for a single confirmation", key.String())
+	}
+	batch, ok := confirm.OnConfirm().(tea.BatchMsg)
+	if !ok {
+		t.Fatal("the confirmed command should run with the spinner")
+	}
+	for _, c := range batch {
+		if c != nil {
+			section.Update(c())
+		}
+	}
+	return confirm
+}
+
+// acceptBulkStopForm marks every container, presses key and accepts the stop
+// form as prefilled, returning the final model.
+func acceptBulkStopForm(t *testing.T, model containerSectionModel, key tea.KeyPressMsg) containerSectionModel {
+	t.Helper()
+	tm := teatest.NewTestModel(t, model, teatest.WithInitialTermSize(120, 40))
+	time.Sleep(500 * time.Millisecond)
+	tm.Send(tea.KeyPressMsg{Code: 'a', Mod: tea.ModCtrl})
+	tm.Send(key)
+	time.Sleep(500 * time.Millisecond)
+	tm.Send(tea.KeyPressMsg{Code: tea.KeyEnter})
+	tm.Send(tea.KeyPressMsg{Code: tea.KeyEnter})
+	time.Sleep(500 * time.Millisecond)
+	tm.Send(tea.KeyPressMsg{Code: 'q', Text: "q"})
+	final, ok := tm.FinalModel(t, teatest.WithFinalTimeout(time.Second)).(containerSectionModel)
+	if !ok {
+		t.Fatal("unexpected model type")
+	}
+	return final
+}
+
+func TestBulkStopMarkedContainers(t *testing.T) {
+	c := client.NewMockClient()
+	recorder := &stopRecorder{ContainerService: c.Containers()}
+	stopCfg := config.StopConfig{
+		Timeout: "10s",
+		Rules:   []config.StopRule{{Container: "nginx-proxy", Timeout: "2m", Signal: "SIGQUIT"}},
+	}
+	m := acceptBulkStopForm(t, newContainerSectionModelWith(recorder, stopCfg), tea.KeyPressMsg{Code: 's', Text: "s"})
+
+	// The running containers do not share their defaults, so the blank form
+	// stops each one with its own.
+	recorded := recorder.recorded()
+	if len(recorded) != 4 {
+		t.Fatalf("Stop called %d times, want once per running container", len(recorded))
+	}
+	opts, ok := recorder.recordedFor("abc123def456")
+	if !ok || opts.Timeout == nil || *opts.Timeout != 2*time.Minute || opts.Signal != "SIGQUIT" {
+		t.Errorf("nginx-proxy stop options = timeout %v signal %q, want 2m0s SIGQUIT", opts.Timeout, opts.Signal)
+	}
+	var defaults int
+	for _, opts := range recorded {
+		if opts.Timeout != nil && *opts.Timeout == 10*time.Second && opts.Signal == "" {
+			defaults++
+		}
+	}
+	if defaults != 3 {
+		t.Errorf("%d containers stopped with 10s and no signal, want the 3 other running ones", defaults)
+	}
+	// The stopped containers were marked too.
+	if !strings.HasPrefix(m.banner.Message, "Stopped 4 containers, skipped 2 not running: ") {
+		t.Errorf("banner = %q, want the marked containers that were not running named", m.banner.Message)
+	}
+	if len(m.section.MarkedItems()) != 0 {
+		t.Error("marks should be cleared after the bulk stop")
+	}
+	containers, err := c.Containers().List(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, container := range containers {
+		if container.State != client.StateStopped {
+			t.Errorf("%s state = %s, want stopped", container.Name, container.State)
+		}
+	}
+}
+
+func TestBulkRestartPrefillsSharedDefaults(t *testing.T) {
+	recorder := &stopRecorder{ContainerService: client.NewMockClient().Containers()}
+	stopCfg := config.StopConfig{Timeout: "10s", Signal: "SIGINT"}
+	acceptBulkStopForm(t, newContainerSectionModelWith(recorder, stopCfg), tea.KeyPressMsg{Code: 'R', Text: "R"})
+
+	recorded := recorder.recorded()
+	if len(recorded) != 6 {
+		t.Fatalf("Restart called %d times, want once per marked container", len(recorded))
+	}
+	for _, opts := range recorded {
+		if opts.Timeout == nil || *opts.Timeout != 10*time.Second || opts.Signal != "SIGINT" {
+			t.Errorf("restart options = timeout %v signal %q, want 10s SIGINT", opts.Timeout, opts.Signal)
+		}
+	}
+}
+
+func TestSharedValue(t *testing.T) {
+	if v, shared := sharedValue([]string{"10s", "10s"}); v != "10s" || !shared {
+		t.Errorf("sharedValue(same) = %q, %t, want 10s, true", v, shared)
+	}
+	if v, shared := sharedValue([]string{"10s", "2m"}); v != "" || shared {
+		t.Errorf("sharedValue(different) = %q, %t, want blank, false", v, shared)
+	}
+	if got := withDefault(" ", "2m", false); got != "2m" {
+		t.Errorf("withDefault(blank, unshared) = %q, want the container default", got)
+	}
+	if got := withDefault("", "2m", true); got != "" {
+		t.Errorf("withDefault(blank, shared) = %q, want blank for the Docker default", got)
+	}
+}
+
+func TestBulkDeleteMarkedContainers(t *testing.T) {
+	c := client.NewMockClient()
+	section := newLoadedSection(t, c)
+
+	// Mark nginx-proxy and api-server.
+	space := tea.KeyPressMsg{Code: tea.KeySpace, Text: " "}
+	section.Update(space)
+	section.Update(tea.KeyPressMsg{Code: tea.KeyDown})
+	section.Update(space)
+	confirm := confirmAndRun(t, section, tea.KeyPressMsg{Code: 'D', Text: "D"})
+
+	if confirm.Title != "Remove 2 containers" {
+		t.Errorf("confirmation title = %q", confirm.Title)
+	}
+	containers, err := c.Containers().List(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, container := range containers {
+		if container.Name == "nginx-proxy" || container.Name == "api-server" {
+			t.Errorf("%s should be removed", container.Name)
+		}
+	}
+}
+
+func TestBulkPauseWithoutRunningOrPaused(t *testing.T) {
+	section := newLoadedSection(t, client.NewMockClient())
+	section.List.Select(3) // old-container, stopped
+	section.Update(tea.KeyPressMsg{Code: tea.KeySpace, Text: " "})
+
+	banner, ok := section.confirmBulkPauseUnpause(section.markedContainers())().(message.ShowBannerMsg)
+	if !ok || !banner.IsError {
+		t.Errorf("pausing only stopped containers should explain why nothing happens, got %#v", banner)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"strconv"
 	"strings"
 	"time"
 
@@ -101,6 +102,30 @@ func formatDetails(c client.Container) string {
 	fmt.Fprintf(&b, "State:   %s\n", stateStyle.Render(stateIcon+" "+string(c.State)))
 	fmt.Fprintf(&b, "Created: %s\n\n", c.Created.Format("2006-01-02 15:04:05"))
 
+	// Lifecycle
+	b.WriteString("=== Lifecycle ===\n")
+	fmt.Fprintf(&b, "Started:    %s\n", formatStateTime(c.StartedAt))
+	if c.State != client.StateRunning {
+		fmt.Fprintf(&b, "Finished:   %s\n", formatStateTime(c.FinishedAt))
+		exitCode := strconv.Itoa(c.ExitCode)
+		if c.ExitCode != 0 {
+			exitCode = theme.StatusErrorStyle.Render(exitCode)
+		}
+		fmt.Fprintf(&b, "Exit Code:  %s\n", exitCode)
+	}
+	if c.OOMKilled {
+		fmt.Fprintf(&b, "OOM Killed: %s\n", theme.OOMKilledStyle.Render(theme.IconOOMKilled+" yes"))
+	}
+	restarts := strconv.Itoa(c.RestartCount)
+	if c.CrashLooping() {
+		restarts = theme.CrashLoopStyle.Render(theme.IconCrashLoop + " " + restarts + " (crash looping)")
+	}
+	fmt.Fprintf(&b, "Restarts:   %s\n", restarts)
+	if c.Error != "" {
+		fmt.Fprintf(&b, "Error:      %s\n", theme.StatusErrorStyle.Render(c.Error))
+	}
+	b.WriteString("\n")
+
 	// Health
 	b.WriteString("=== Health ===\n")
 	if c.Health == nil || c.Health.Status == client.HealthNone {
@@ -197,3 +222,12 @@ func formatDetails(c client.Container) string {
 
 	return b.String()
 }
+
+// formatStateTime formats a container state timestamp, or "never" when the
+// event did not happen.
+func formatStateTime(t time.Time) string {
+	if t.IsZero() {
+		return "never"
+	}
+	return t.Format("2006-01-02 15:04:05")
+}
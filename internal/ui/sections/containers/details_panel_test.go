@@ -5,6 +5,7 @@ import (
 	"errors"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/GustavoCaso/docker-dash/internal/client"
 	"github.com/GustavoCaso/docker-dash/internal/ui/message"
@@ -112,3 +113,40 @@ func TestDetailsPanelSetSizeViewport(t *testing.T) {
 		t.Errorf("viewport.Height = %d, want 29", dp.viewport.Height())
 	}
 }
+
+func TestFormatDetailsLifecycle(t *testing.T) {
+	finished := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	crashed := client.Container{
+		Name:         "worker",
+		State:        client.StateStopped,
+		ExitCode:     137,
+		OOMKilled:    true,
+		RestartCount: 5,
+		FinishedAt:   finished,
+		Error:        "cgroup limit reached",
+	}
+
+	out := formatDetails(crashed)
+	for _, want := range []string{
+		"=== Lifecycle ===",
+		"Started:    never",
+		"Finished:   2026-01-02 03:04:05",
+		"137",
+		"OOM Killed:",
+		"(crash looping)",
+		"cgroup limit reached",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("formatDetails() missing %q in:\n%s", want, out)
+		}
+	}
+
+	running := client.Container{Name: "web", State: client.StateRunning, StartedAt: finished}
+	out = formatDetails(running)
+	if strings.Contains(out, "Exit Code:") || strings.Contains(out, "OOM Killed:") {
+		t.Errorf("formatDetails() of a running container should omit exit details:\n%s", out)
+	}
+	if !strings.Contains(out, "Restarts:   0") {
+		t.Errorf("formatDetails() should show the restart count:\n%s", out)
+	}
+}
@@ -0,0 +1,231 @@
+package containers
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"charm.land/bubbles/v2/key"
+	"charm.land/bubbles/v2/viewport"
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+
+	"github.com/GustavoCaso/docker-dash/internal/client"
+	"github.com/GustavoCaso/docker-dash/internal/ui/keys"
+	"github.com/GustavoCaso/docker-dash/internal/ui/message"
+	"github.com/GustavoCaso/docker-dash/internal/ui/sections"
+	"github.com/GustavoCaso/docker-dash/internal/ui/theme"
+)
+
+const healthRefreshInterval = 5 * time.Second
+
+var probeOutputStyle = lipgloss.NewStyle().Foreground(theme.TextMuted)
+
+// Docker daemon defaults applied when a healthcheck leaves a setting unset.
+const (
+	defaultHealthInterval = 30 * time.Second
+	defaultHealthTimeout  = 30 * time.Second
+	defaultHealthRetries  = 3
+)
+
+// healthLoadedMsg is sent when the container health has been fetched.
+type healthLoadedMsg struct {
+	requestID int
+	health    *client.HealthInfo
+	err       error
+}
+
+// healthTickMsg triggers the next periodic refresh of the health history.
+type healthTickMsg struct {
+	requestID int
+}
+
+type healthPanel struct {
+	ctx         context.Context
+	service     client.ContainerService
+	containerID string
+	viewport    viewport.Model
+	requestID   int
+}
+
+// NewHealthPanel creates a new sections.Panel that shows the healthcheck
+// configuration and the recent probe history of a container.
+func NewHealthPanel(ctx context.Context, svc client.ContainerService) sections.Panel {
+	return &healthPanel{ctx: ctx, service: svc, viewport: viewport.New()}
+}
+
+func (h *healthPanel) Name() string {
+	return "Health"
+}
+
+func (h *healthPanel) Init(item sections.ListItem) tea.Cmd {
+	h.containerID = item.ID()
+	log.Printf("[containers][health-panel] Init: containerID=%q", h.containerID)
+	h.requestID++
+	h.viewport.SetContent("")
+	return tea.Batch(h.fetchCmd(h.requestID), h.extendHelpCmd())
+}
+
+func (h *healthPanel) Update(msg tea.Msg) tea.Cmd {
+	switch msg := msg.(type) {
+	case healthLoadedMsg:
+		if msg.requestID != h.requestID {
+			return nil
+		}
+		if msg.err != nil {
+			log.Printf("[containers][health-panel] healthLoadedMsg: err=%v", msg.err)
+			h.viewport.SetContent(theme.StatusErrorStyle.Render(msg.err.Error()))
+			return nil
+		}
+		h.viewport.SetContent(formatHealth(msg.health))
+		return h.tickCmd(msg.requestID)
+
+	case healthTickMsg:
+		if msg.requestID != h.requestID {
+			return nil
+		}
+		return h.fetchCmd(msg.requestID)
+	}
+
+	var cmd tea.Cmd
+	h.viewport, cmd = h.viewport.Update(msg)
+	return cmd
+}
+
+func (h *healthPanel) View() string {
+	return h.viewport.View()
+}
+
+func (h *healthPanel) Close() tea.Cmd {
+	log.Printf("[containers][health-panel] Close")
+	h.requestID++
+	h.viewport.SetContent("")
+	return func() tea.Msg { return message.ClearContextualKeyBindingsMsg{} }
+}
+
+func (h *healthPanel) SetSize(width, height int) {
+	h.viewport.SetWidth(width)
+	h.viewport.SetHeight(height)
+}
+
+func (h *healthPanel) fetchCmd(requestID int) tea.Cmd {
+	ctx := h.ctx
+	svc := h.service
+	containerID := h.containerID
+	return func() tea.Msg {
+		c, err := svc.Get(ctx, containerID)
+		return healthLoadedMsg{requestID: requestID, health: c.Health, err: err}
+	}
+}
+
+func (h *healthPanel) tickCmd(requestID int) tea.Cmd {
+	return tea.Tick(healthRefreshInterval, func(_ time.Time) tea.Msg {
+		return healthTickMsg{requestID: requestID}
+	})
+}
+
+func (h *healthPanel) extendHelpCmd() tea.Cmd {
+	return func() tea.Msg {
+		return message.AddContextualKeyBindingsMsg{Bindings: []key.Binding{
+			keys.Keys.ScrollUp,
+			keys.Keys.ScrollDown,
+		}}
+	}
+}
+
+func formatHealth(health *client.HealthInfo) string {
+	if health == nil || (health.Config == nil && health.Status == client.HealthNone) {
+		return "no healthcheck configured\n"
+	}
+
+	var b strings.Builder
+
+	b.WriteString("=== Status ===\n")
+	status := string(health.Status)
+	if health.Status == client.HealthNone {
+		status = "unknown"
+	}
+	style := theme.GetContainerHealthStatusStyle(status)
+	icon := theme.GetContainerHealthStatusIcon(status)
+	fmt.Fprintf(&b, "Status:         %s\n", style.Render(strings.TrimSpace(icon+" "+status)))
+	fmt.Fprintf(&b, "Failing Streak: %d\n\n", health.FailingStreak)
+
+	b.WriteString("=== Healthcheck ===\n")
+	if health.Config == nil {
+		b.WriteString("no healthcheck configured\n\n")
+	} else {
+		cfg := health.Config
+		fmt.Fprintf(&b, "Command:      %s\n", cfg.Command())
+		fmt.Fprintf(&b, "Interval:     %s\n", orDefault(cfg.Interval, defaultHealthInterval))
+		fmt.Fprintf(&b, "Timeout:      %s\n", orDefault(cfg.Timeout, defaultHealthTimeout))
+		if cfg.StartPeriod > 0 {
+			fmt.Fprintf(&b, "Start Period: %s\n", cfg.StartPeriod)
+		}
+		retries := cfg.Retries
+		if retries == 0 {
+			retries = defaultHealthRetries
+		}
+		fmt.Fprintf(&b, "Retries:      %d\n\n", retries)
+	}
+
+	b.WriteString("=== History ===\n")
+	if len(health.Log) == 0 {
+		b.WriteString("no probes recorded yet\n")
+		return b.String()
+	}
+	b.WriteString(formatProbeTimeline(health.Log) + "  (oldest → newest)\n\n")
+	// Newest first so the latest result is visible without scrolling.
+	for i := len(health.Log) - 1; i >= 0; i-- {
+		probe := health.Log[i]
+		fmt.Fprintf(&b, "%s  %s  %s\n",
+			probe.End.Format(time.TimeOnly),
+			formatProbeResult(probe.ExitCode),
+			probe.End.Sub(probe.Start).Round(time.Millisecond),
+		)
+		output := strings.TrimSpace(strings.ReplaceAll(probe.Output, "\r", ""))
+		for line := range strings.SplitSeq(output, "\n") {
+			if line != "" {
+				fmt.Fprintf(&b, "    %s\n", probeOutputStyle.Render(line))
+			}
+		}
+	}
+	return b.String()
+}
+
+// formatProbeTimeline renders one icon per probe, oldest first.
+func formatProbeTimeline(probes []client.HealthProbe) string {
+	var b strings.Builder
+	for _, probe := range probes {
+		if probe.ExitCode == 0 {
+			b.WriteString(theme.StatusHealthyStyle.Render(theme.IconHealthy))
+		} else {
+			b.WriteString(theme.StatusUnhealthyStyle.Render(theme.IconUnhealthy))
+		}
+		b.WriteString(" ")
+	}
+	return strings.TrimSuffix(b.String(), " ")
+}
+
+// formatProbeResult renders a probe exit code: 0 passed, 1 failed and any
+// other code means the probe itself could not run.
+func formatProbeResult(exitCode int) string {
+	switch exitCode {
+	case 0:
+		return theme.StatusHealthyStyle.Render(theme.IconSuccess + " passed")
+	case 1:
+		return theme.StatusUnhealthyStyle.Render(theme.IconError + " failed")
+	default:
+		return theme.StatusErrorStyle.Render(theme.IconWarning + " error (exit " + strconv.Itoa(exitCode) + ")")
+	}
+}
+
+// orDefault returns d, or fallback when d is unset.
+func orDefault(d, fallback time.Duration) time.Duration {
+	if d == 0 {
+		return fallback
+	}
+	return d
+}
@@ -0,0 +1,110 @@
+package containers
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/GustavoCaso/docker-dash/internal/client"
+	"github.com/GustavoCaso/docker-dash/internal/ui/message"
+)
+
+func TestHealthPanelInitFetchesHealth(t *testing.T) {
+	p := NewHealthPanel(context.Background(), client.NewMockClient().Containers()).(*healthPanel)
+	p.SetSize(120, 40)
+	cmd := p.Init(containerItem{container: client.Container{ID: "abc123def456"}})
+	batch, ok := cmd().(tea.BatchMsg)
+	if !ok {
+		t.Fatal("Init() should return a batch")
+	}
+
+	var loaded *healthLoadedMsg
+	extendCmd := false
+	for _, cmd := range batch {
+		switch msg := cmd().(type) {
+		case healthLoadedMsg:
+			loaded = &msg
+		case message.AddContextualKeyBindingsMsg:
+			extendCmd = true
+		}
+	}
+	if loaded == nil || loaded.err != nil || loaded.health == nil {
+		t.Fatalf("Init() healthLoadedMsg = %+v, want mock health", loaded)
+	}
+	if !extendCmd {
+		t.Fatal("Init() not returned AddContextualKeyBindingsMsg msg")
+	}
+
+	p.Update(*loaded)
+	view := p.View()
+	for _, want := range []string{"curl -fsI http://localhost/", "Interval:     30s", "Failed to connect"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("View() missing %q:\n%s", want, view)
+		}
+	}
+}
+
+func TestHealthPanelDropsStaleResults(t *testing.T) {
+	p := NewHealthPanel(context.Background(), client.NewMockClient().Containers()).(*healthPanel)
+	p.SetSize(120, 40)
+	p.Init(containerItem{container: client.Container{ID: "abc123def456"}})
+
+	if cmd := p.Update(healthLoadedMsg{requestID: p.requestID - 1, err: errors.New("stale")}); cmd != nil {
+		t.Error("stale healthLoadedMsg should be ignored")
+	}
+	if strings.Contains(p.View(), "stale") {
+		t.Error("stale healthLoadedMsg should not render")
+	}
+}
+
+func TestFormatHealth(t *testing.T) {
+	start := time.Date(2026, 3, 4, 5, 6, 0, 0, time.UTC)
+	health := &client.HealthInfo{
+		Status:        client.HealthUnhealthy,
+		FailingStreak: 2,
+		Log: []client.HealthProbe{
+			{Start: start, End: start.Add(20 * time.Millisecond), ExitCode: 0, Output: "ok"},
+			{
+				Start:    start.Add(time.Minute),
+				End:      start.Add(time.Minute + time.Second),
+				ExitCode: 1,
+				Output:   "refused\r\n",
+			},
+			{Start: start.Add(2 * time.Minute), End: start.Add(2 * time.Minute), ExitCode: 2, Output: "no such file"},
+		},
+		Config: &client.HealthcheckConfig{Test: []string{"CMD", "pg_isready"}, Retries: 5},
+	}
+
+	got := formatHealth(health)
+	for _, want := range []string{
+		"Failing Streak: 2",
+		"Command:      pg_isready",
+		"Interval:     30s",
+		"Timeout:      30s",
+		"Retries:      5",
+		"05:06:00",
+		"passed",
+		"failed",
+		"error (exit 2)",
+		"20ms",
+		"refused",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("formatHealth() missing %q:\n%s", want, got)
+		}
+	}
+	if strings.Index(got, "no such file") > strings.Index(got, "refused") {
+		t.Error("formatHealth() should list the newest probe first")
+	}
+}
+
+func TestFormatHealthWithoutHealthcheck(t *testing.T) {
+	got := formatHealth(&client.HealthInfo{Status: client.HealthNone})
+	if !strings.Contains(got, "no healthcheck configured") {
+		t.Errorf("formatHealth() = %q, want no healthcheck message", got)
+	}
+}
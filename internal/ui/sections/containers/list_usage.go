@@ -0,0 +1,62 @@
+package containers
+
+import (
+	"fmt"
+	"image/color"
+
+	"github.com/GustavoCaso/docker-dash/internal/config"
+	"github.com/GustavoCaso/docker-dash/internal/ui/components/sparkline"
+	"github.com/GustavoCaso/docker-dash/internal/ui/helper"
+	"github.com/GustavoCaso/docker-dash/internal/ui/theme"
+)
+
+// usagePeakPercent is the percentage drawn as a full block in the usage
+// sparklines: one CPU, or the whole memory limit.
+const usagePeakPercent = 100
+
+// listUsage shows the usage of running containers in the container list, as
+// configured, from the samples of the section's stats collector.
+type listUsage struct {
+	collector *statsCollector
+	cfg       config.StatsConfig
+}
+
+// describe returns the CPU and memory usage of the container with id for its
+// description, or an empty string when it is not shown or not known yet.
+func (u *listUsage) describe(id string) string {
+	if u == nil || u.cfg.ListColumns == config.StatsColumnsOff {
+		return ""
+	}
+	usage := u.collector.usageOf(id)
+	if usage == nil {
+		return ""
+	}
+	if u.cfg.ListColumns == config.StatsColumnsSparklines {
+		return fmt.Sprintf("CPU %s MEM %s",
+			sparkline.RenderScaled(usage.cpuHistory, usagePeakPercent),
+			sparkline.RenderScaled(usage.memHistory, usagePeakPercent))
+	}
+	cpu := "-"
+	if usage.hasRates {
+		cpu = fmt.Sprintf("%.1f%%", usage.rates.CPUPercent)
+	}
+	return fmt.Sprintf("CPU %s MEM %s", cpu, helper.FormatSize(usage.stats.MemoryUsage))
+}
+
+// tint returns the color of the container with id when its usage is over a
+// threshold.
+func (u *listUsage) tint(id string) (color.Color, bool) {
+	if u == nil {
+		return nil, false
+	}
+	usage := u.collector.usageOf(id)
+	if usage == nil {
+		return nil, false
+	}
+	overCPU := u.cfg.CPUThreshold > 0 && usage.hasRates && usage.rates.CPUPercent > u.cfg.CPUThreshold
+	overMemory := u.cfg.MemoryThreshold > 0 && usage.stats.MemoryPercent() > u.cfg.MemoryThreshold
+	if overCPU || overMemory {
+		return theme.StatusError, true
+	}
+	return nil, false
+}
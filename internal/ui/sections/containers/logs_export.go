@@ -0,0 +1,426 @@
+package containers
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/huh/v2"
+
+	"github.com/GustavoCaso/docker-dash/internal/client"
+	"github.com/GustavoCaso/docker-dash/internal/ui/components/form"
+	"github.com/GustavoCaso/docker-dash/internal/ui/message"
+)
+
+// exportFileMode is the permission of the files logs are exported to.
+const exportFileMode = 0o644
+
+// logExportFormat is the file format logs are exported in.
+type logExportFormat string
+
+const (
+	// exportPlain writes the lines as shown by docker logs.
+	exportPlain logExportFormat = "plain"
+	// exportJSONLines writes a JSON object per line.
+	exportJSONLines logExportFormat = "jsonl"
+)
+
+// logExportScope selects which lines are exported.
+type logExportScope string
+
+const (
+	// exportBuffer writes the lines received by the panel.
+	exportBuffer logExportScope = "buffer"
+	// exportHistory fetches the logs of a time range from the daemon.
+	exportHistory logExportScope = "history"
+)
+
+// logExport holds the choices made in the export form.
+type logExport struct {
+	path   string
+	scope  logExportScope
+	format logExportFormat
+	since  string
+	until  string
+	// tee keeps appending the lines received while following.
+	tee bool
+}
+
+// exportedLogLine is a line written in the JSON lines format.
+type exportedLogLine struct {
+	Time    string `json:"time,omitempty"`
+	Source  string `json:"source,omitempty"`
+	Stream  string `json:"stream,omitempty"`
+	Message string `json:"message"`
+	Marker  bool   `json:"marker,omitempty"`
+	// Continuation is set for the lines continuing the previous line of
+	// their source, such as stack trace frames.
+	Continuation bool `json:"continuation,omitempty"`
+}
+
+// logsExportedMsg reports the result of an export. file is set when the
+// export keeps appending the lines received while following.
+type logsExportedMsg struct {
+	requestID int
+	path      string
+	format    logExportFormat
+	lines     int
+	file      *os.File
+	// next is the number of the first line the export did not write, or -1
+	// to only append lines received from now on.
+	next int
+	err  error
+}
+
+// logTee appends the lines received while following to an exported file.
+type logTee struct {
+	file   *os.File
+	path   string
+	format logExportFormat
+	next   int // number of the next line to write
+}
+
+// exportForm asks where and how to export the logs. The tee question is only
+// asked when the logs are followed.
+func exportForm(export *logExport, follow bool) *huh.Form {
+	return huh.NewForm(
+		huh.NewGroup(
+			huh.NewInput().
+				Key("path").
+				Title("File").
+				Description("Path on this host. Relative paths are resolved from the current directory.").
+				Value(&export.path).
+				Validate(validateExportPath),
+
+			huh.NewSelect[logExportScope]().
+				Key("scope").
+				Title("Lines").
+				Options(
+					huh.NewOption("Current buffer", exportBuffer),
+					huh.NewOption("Full history for a time range", exportHistory),
+				).
+				Value(&export.scope),
+
+			huh.NewSelect[logExportFormat]().
+				Key("format").
+				Title("Format").
+				Options(
+					huh.NewOption("Plain text", exportPlain),
+					huh.NewOption("JSON lines", exportJSONLines),
+				).
+				Value(&export.format),
+		),
+		huh.NewGroup(
+			huh.NewInput().
+				Key("since").
+				Title("Since").
+				Description("Duration ago (e.g. 2h) or RFC 3339 time. Leave blank for the start of the logs.").
+				Value(&export.since).
+				Validate(validateLogTime),
+
+			huh.NewInput().
+				Key("until").
+				Title("Until").
+				Description("Duration ago (e.g. 10m) or RFC 3339 time. Leave blank for now.").
+				Value(&export.until).
+				Validate(validateLogTime),
+		).WithHideFunc(func() bool { return export.scope != exportHistory }),
+		huh.NewGroup(
+			huh.NewConfirm().
+				Key("tee").
+				Title("Keep appending new lines while following?").
+				Value(&export.tee),
+		).WithHideFunc(func() bool { return !follow || strings.TrimSpace(export.until) != "" }),
+	)
+}
+
+func validateExportPath(s string) error {
+	if strings.TrimSpace(s) == "" {
+		return errors.New("file path is required")
+	}
+	return nil
+}
+
+// validateLogTime accepts a blank value or a time accepted by
+// client.ParseLogTime.
+func validateLogTime(s string) error {
+	if strings.TrimSpace(s) == "" {
+		return nil
+	}
+	_, err := client.ParseLogTime(s, time.Now())
+	return err
+}
+
+// exportFileName returns the default export file name for the shown logs.
+func (l *logsPanel) exportFileName() string {
+	name := "logs"
+	if len(l.current) == 1 {
+		name = strings.TrimPrefix(l.current[0].name, "/") + "-logs"
+	}
+	return name + "-" + time.Now().Format("20060102-150405") + ".log"
+}
+
+// exportFormCmd shows the export form for the shown logs.
+func (l *logsPanel) exportFormCmd() tea.Cmd {
+	if len(l.current) == 0 {
+		return nil
+	}
+	export := &logExport{path: l.exportFileName(), scope: exportBuffer, format: exportPlain}
+	exportForm := form.New("Save Logs", exportForm(export, l.logsCfg.Follow), func(_ *huh.Form) tea.Cmd {
+		return l.exportCmd(*export)
+	})
+	return func() tea.Msg {
+		return message.ShowFormMsg{Form: exportForm}
+	}
+}
+
+// exportCmd writes the lines chosen in export to its file.
+func (l *logsPanel) exportCmd(export logExport) tea.Cmd {
+	export.path = strings.TrimSpace(export.path)
+	export.tee = export.tee && l.logsCfg.Follow
+	requestID := l.requestID
+	log.Printf("[containers][logs-panel] export: path=%q scope=%s format=%s tee=%t",
+		export.path, export.scope, export.format, export.tee)
+
+	if export.scope == exportBuffer {
+		snap, next := l.store.snapshot(), l.store.Next()
+		return func() tea.Msg {
+			file, written, err := writeExport(export, func(w io.Writer) (int, error) {
+				return writeLogSnapshot(w, export.format, snap)
+			})
+			return logsExportedMsg{
+				requestID: requestID,
+				path:      export.path,
+				format:    export.format,
+				lines:     written,
+				file:      file,
+				next:      next,
+				err:       err,
+			}
+		}
+	}
+
+	ctx, svc, targets := l.ctx, l.client, l.current
+	opts := client.LogOptions{
+		Tail:       "all",
+		Timestamps: true,
+		Since:      strings.TrimSpace(export.since),
+		Until:      strings.TrimSpace(export.until),
+	}
+	return func() tea.Msg {
+		file, written, err := writeExport(export, func(w io.Writer) (int, error) {
+			return writeLogHistory(ctx, svc, targets, opts, w, export.format)
+		})
+		return logsExportedMsg{
+			requestID: requestID,
+			path:      export.path,
+			format:    export.format,
+			lines:     written,
+			file:      file,
+			next:      -1,
+			err:       err,
+		}
+	}
+}
+
+// writeExport creates the export file and fills it with write, returning the
+// number of lines written. The file is returned open when the export keeps
+// appending lines.
+func writeExport(export logExport, write func(io.Writer) (int, error)) (*os.File, int, error) {
+	file, err := os.OpenFile(export.path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, exportFileMode)
+	if err != nil {
+		return nil, 0, err
+	}
+	w := bufio.NewWriter(file)
+	written, err := write(w)
+	if err == nil {
+		err = w.Flush()
+	}
+	if err != nil || !export.tee {
+		if closeErr := file.Close(); err == nil {
+			err = closeErr
+		}
+		return nil, written, err
+	}
+	return file, written, nil
+}
+
+// writeLogHistory streams the logs of targets selected by opts to w. Several
+// targets are merged by timestamp.
+func writeLogHistory(
+	ctx context.Context,
+	svc client.ContainerService,
+	targets []logTarget,
+	opts client.LogOptions,
+	w io.Writer,
+	format logExportFormat,
+) (int, error) {
+	var session *client.LogsSession
+	var err error
+	if len(targets) == 1 {
+		session, err = svc.Logs(ctx, targets[0].id, opts)
+	} else {
+		session, err = openMergedLogs(ctx, svc, targets, fixedLogOptions(opts))
+	}
+	if err != nil {
+		return 0, err
+	}
+	defer session.Close()
+
+	written := 0
+	for {
+		lines, err := session.Read()
+		if errors.Is(err, io.EOF) {
+			return written, nil
+		}
+		if err != nil {
+			return written, err
+		}
+		for _, line := range lines {
+			if err := writeLogEntry(w, format, logEntry{LogLine: line}); err != nil {
+				return written, err
+			}
+			written++
+		}
+	}
+}
+
+// writeLogSnapshot writes the lines of snap to w in format.
+func writeLogSnapshot(w io.Writer, format logExportFormat, snap logSnapshot) (int, error) {
+	written := 0
+	var writeErr error
+	err := snap.Each(func(entry logEntry) bool {
+		writeErr = writeLogEntry(w, format, entry)
+		if writeErr == nil {
+			written++
+		}
+		return writeErr == nil
+	})
+	if writeErr != nil {
+		return written, writeErr
+	}
+	return written, err
+}
+
+// writeLogEntries writes entries to w in format.
+func writeLogEntries(w io.Writer, format logExportFormat, entries []logEntry) (int, error) {
+	for i, entry := range entries {
+		if err := writeLogEntry(w, format, entry); err != nil {
+			return i, err
+		}
+	}
+	return len(entries), nil
+}
+
+// writeLogEntry writes entry as received, so long lines and ANSI sequences are
+// kept. Plain lines are prefixed with their timestamp and source when known.
+func writeLogEntry(w io.Writer, format logExportFormat, entry logEntry) error {
+	if format == exportJSONLines {
+		out := exportedLogLine{
+			Source:       entry.Source,
+			Message:      entry.Content,
+			Marker:       entry.marker,
+			Continuation: entry.continuation,
+		}
+		if !entry.Timestamp.IsZero() {
+			out.Time = entry.Timestamp.Format(time.RFC3339Nano)
+		}
+		if !entry.marker {
+			out.Stream = string(entry.Stream)
+			if out.Stream == "" {
+				out.Stream = string(client.LogStreamStdout)
+			}
+		}
+		enc := json.NewEncoder(w)
+		enc.SetEscapeHTML(false)
+		return enc.Encode(out)
+	}
+	line := formatLogLine(entry.LogLine)
+	if entry.Source != "" {
+		line = entry.Source + " | " + line
+	}
+	_, err := io.WriteString(w, line+"\n")
+	return err
+}
+
+// handleExported reports the result of an export and, when it keeps
+// appending, starts writing the lines received since.
+func (l *logsPanel) handleExported(msg logsExportedMsg) tea.Cmd {
+	if msg.requestID != l.requestID {
+		if msg.file != nil {
+			_ = msg.file.Close()
+		}
+		return nil
+	}
+	if msg.err != nil {
+		log.Printf("[containers][logs-panel] export failed: %v", msg.err)
+		return func() tea.Msg {
+			return message.ShowBannerMsg{
+				Message: fmt.Sprintf("Failed to save logs to %s: %s", msg.path, msg.err),
+				IsError: true,
+			}
+		}
+	}
+	text := fmt.Sprintf("Saved %d log lines to %s", msg.lines, msg.path)
+	var cmd tea.Cmd
+	if msg.file != nil {
+		l.stopTee()
+		next := msg.next
+		if next < 0 || next > l.store.Next() {
+			next = l.store.Next()
+		}
+		l.tee = &logTee{file: msg.file, path: msg.path, format: msg.format, next: next}
+		text += ", appending new lines"
+		cmd = l.flushTee()
+	}
+	log.Printf("[containers][logs-panel] %s", text)
+	return tea.Batch(cmd, func() tea.Msg {
+		return message.ShowBannerMsg{Message: text}
+	})
+}
+
+// flushTee appends the lines received since the last write to the file of a
+// following export. A failed write stops the export.
+func (l *logsPanel) flushTee() tea.Cmd {
+	tee := l.tee
+	if tee == nil || tee.next >= l.store.Next() {
+		return nil
+	}
+	var err error
+	l.store.Each(tee.next, func(_ int, entry logEntry) bool {
+		err = writeLogEntry(tee.file, tee.format, entry)
+		return err == nil
+	})
+	tee.next = l.store.Next()
+	if err == nil {
+		return nil
+	}
+	log.Printf("[containers][logs-panel] export append failed: %v", err)
+	l.stopTee()
+	return func() tea.Msg {
+		return message.ShowBannerMsg{
+			Message: fmt.Sprintf("Stopped appending logs to %s: %s", tee.path, err),
+			IsError: true,
+		}
+	}
+}
+
+// stopTee closes the file of a following export, if any.
+func (l *logsPanel) stopTee() {
+	if l.tee == nil {
+		return
+	}
+	log.Printf("[containers][logs-panel] stop appending to %q", l.tee.path)
+	if err := l.tee.file.Close(); err != nil {
+		log.Printf("[containers][logs-panel] closing %q: %v", l.tee.path, err)
+	}
+	l.tee = nil
+}
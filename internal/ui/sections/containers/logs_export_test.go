@@ -0,0 +1,216 @@
+package containers
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/GustavoCaso/docker-dash/internal/client"
+	"github.com/GustavoCaso/docker-dash/internal/ui/message"
+)
+
+func TestWriteLogEntry(t *testing.T) {
+	at := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+	entries := []logEntry{
+		{LogLine: client.LogLine{Content: "\x1b[31mred\x1b[0m", Stream: client.LogStreamStdout}},
+		{LogLine: client.LogLine{Content: "boom", Stream: client.LogStreamStderr, Timestamp: at, Source: "web"}},
+		{LogLine: client.LogLine{Content: "— container restarted —"}, marker: true},
+	}
+
+	var plain bytes.Buffer
+	if _, err := writeLogEntries(&plain, exportPlain, entries); err != nil {
+		t.Fatalf("writeLogEntries(plain) error = %v", err)
+	}
+	want := "\x1b[31mred\x1b[0m\nweb | 2024-01-15T10:00:00Z boom\n— container restarted —\n"
+	if plain.String() != want {
+		t.Errorf("plain export = %q, want %q", plain.String(), want)
+	}
+
+	var jsonl bytes.Buffer
+	if _, err := writeLogEntries(&jsonl, exportJSONLines, entries); err != nil {
+		t.Fatalf("writeLogEntries(jsonl) error = %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(jsonl.String()), "\n")
+	if len(lines) != len(entries) {
+		t.Fatalf("jsonl export has %d lines, want %d", len(lines), len(entries))
+	}
+	wantLines := []exportedLogLine{
+		{Stream: "stdout", Message: "\x1b[31mred\x1b[0m"},
+		{Time: "2024-01-15T10:00:00Z", Source: "web", Stream: "stderr", Message: "boom"},
+		{Message: "— container restarted —", Marker: true},
+	}
+	for i, line := range lines {
+		var got exportedLogLine
+		if err := json.Unmarshal([]byte(line), &got); err != nil {
+			t.Fatalf("jsonl line %d = %q: %v", i, line, err)
+		}
+		if got != wantLines[i] {
+			t.Errorf("jsonl line %d = %+v, want %+v", i, got, wantLines[i])
+		}
+	}
+}
+
+func TestValidateLogTime(t *testing.T) {
+	tests := []struct {
+		input   string
+		wantErr bool
+	}{
+		{input: "", wantErr: false},
+		{input: "2h", wantErr: false},
+		{input: "2024-01-15T10:00:00Z", wantErr: false},
+		{input: "-5m", wantErr: true},
+		{input: "yesterday 14:00", wantErr: false},
+		{input: "last week", wantErr: true},
+	}
+	for _, tt := range tests {
+		err := validateLogTime(tt.input)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("validateLogTime(%q): wantErr=%v, got %v", tt.input, tt.wantErr, err)
+		}
+	}
+}
+
+// exportLogs runs the export of p described by export and returns the banner
+// it shows.
+func exportLogs(t *testing.T, p *logsPanel, export logExport) message.ShowBannerMsg {
+	t.Helper()
+	cmd := p.exportCmd(export)
+	if cmd == nil {
+		t.Fatal("exportCmd() returned nil")
+	}
+	cmd = p.Update(cmd())
+	if cmd == nil {
+		t.Fatal("export did not show a banner")
+	}
+	msgs := []tea.Msg{cmd()}
+	if batch, ok := msgs[0].(tea.BatchMsg); ok {
+		msgs = nil
+		for _, cmd := range batch {
+			if cmd != nil {
+				msgs = append(msgs, cmd())
+			}
+		}
+	}
+	for _, msg := range msgs {
+		if banner, ok := msg.(message.ShowBannerMsg); ok {
+			return banner
+		}
+	}
+	t.Fatalf("export returned %v, want a message.ShowBannerMsg", msgs)
+	return message.ShowBannerMsg{}
+}
+
+func readFile(t *testing.T, path string) string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile(%q) error = %v", path, err)
+	}
+	return string(data)
+}
+
+func TestLogsPanelExportsBuffer(t *testing.T) {
+	p := newTestLogsPanel()
+	p.current = []logTarget{{id: "abc123def456", name: "web"}}
+	p.appendLines(stdoutLines("first", "second"))
+	p.cycleStreams() // filters do not change what is exported
+
+	path := filepath.Join(t.TempDir(), "web.log")
+	banner := exportLogs(t, p, logExport{path: path, scope: exportBuffer, format: exportPlain})
+	if banner.IsError || !strings.Contains(banner.Message, "Saved 2 log lines") {
+		t.Errorf("banner = %+v, want the number of saved lines", banner)
+	}
+	if got := readFile(t, path); got != "first\nsecond\n" {
+		t.Errorf("exported file = %q, want both lines", got)
+	}
+	if p.tee != nil {
+		t.Error("export without tee should not keep the file open")
+	}
+}
+
+func TestLogsPanelExportKeepsAppendingWhileFollowing(t *testing.T) {
+	p := newTestLogsPanel()
+	p.current = []logTarget{{id: "abc123def456", name: "web"}}
+	p.appendLines(stdoutLines("first"))
+
+	path := filepath.Join(t.TempDir(), "web.jsonl")
+	cmd := p.exportCmd(logExport{path: path, scope: exportBuffer, format: exportJSONLines, tee: true})
+	msg := cmd()
+	// A line received while the file was being written is appended once the
+	// export completes.
+	p.appendLines(stdoutLines("second"))
+	p.Update(msg)
+	if p.tee == nil {
+		t.Fatal("export with tee should keep the file open")
+	}
+	p.Update(logsOutputMsg{lines: stdoutLines("third")})
+
+	got := readFile(t, path)
+	for _, want := range []string{`"first"`, `"second"`, `"third"`} {
+		if strings.Count(got, want) != 1 {
+			t.Errorf("exported file = %q, want %s once", got, want)
+		}
+	}
+
+	p.Close()
+	if p.tee != nil {
+		t.Error("Close() should stop appending to the export")
+	}
+}
+
+func TestLogsPanelExportsHistory(t *testing.T) {
+	p := newTestLogsPanel()
+	p.current = []logTarget{{id: "abc123def456", name: "web"}}
+
+	path := filepath.Join(t.TempDir(), "history.jsonl")
+	banner := exportLogs(t, p, logExport{path: path, scope: exportHistory, format: exportJSONLines})
+	if banner.IsError {
+		t.Fatalf("banner = %+v, want success", banner)
+	}
+	lines := strings.Split(strings.TrimSpace(readFile(t, path)), "\n")
+	if len(lines) == 0 || !strings.Contains(banner.Message, "Saved "+strconv.Itoa(len(lines))) {
+		t.Errorf("banner = %q, want %d saved lines", banner.Message, len(lines))
+	}
+	for _, line := range lines {
+		var got exportedLogLine
+		if err := json.Unmarshal([]byte(line), &got); err != nil {
+			t.Fatalf("history line %q: %v", line, err)
+		}
+		if got.Time == "" {
+			t.Errorf("history line %q has no timestamp", line)
+		}
+	}
+}
+
+func TestLogsPanelExportFailureShowsError(t *testing.T) {
+	p := newTestLogsPanel()
+	p.current = []logTarget{{id: "abc123def456", name: "web"}}
+
+	path := filepath.Join(t.TempDir(), "missing", "web.log")
+	banner := exportLogs(t, p, logExport{path: path, scope: exportBuffer, format: exportPlain})
+	if !banner.IsError {
+		t.Errorf("banner = %+v, want an error", banner)
+	}
+}
+
+func TestLogsPanelIgnoresStaleExport(t *testing.T) {
+	p := newTestLogsPanel()
+	p.current = []logTarget{{id: "abc123def456", name: "web"}}
+
+	path := filepath.Join(t.TempDir(), "web.log")
+	msg := p.exportCmd(logExport{path: path, scope: exportBuffer, format: exportPlain, tee: true})()
+	p.Close()
+	if cmd := p.Update(msg); cmd != nil {
+		t.Error("Update(stale export) should be ignored")
+	}
+	if p.tee != nil {
+		t.Error("a stale export should not keep appending")
+	}
+}
@@ -0,0 +1,321 @@
+package containers
+
+import (
+	"fmt"
+	"log"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"charm.land/bubbles/v2/key"
+	"charm.land/bubbles/v2/textinput"
+	"charm.land/bubbles/v2/viewport"
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/GustavoCaso/docker-dash/internal/config"
+	"github.com/GustavoCaso/docker-dash/internal/ui/keys"
+	"github.com/GustavoCaso/docker-dash/internal/ui/theme"
+)
+
+// fieldOperators lists the field filter operators, two-character ones first
+// so ">=" is not read as ">".
+var fieldOperators = []string{"!=", ">=", "<=", "=", ">", "<", "~"}
+
+// fieldCondition is a single "key<op>value" term of a field filter.
+type fieldCondition struct {
+	key   string
+	op    string
+	value string
+	re    *regexp.Regexp // set for the "~" operator
+}
+
+// fieldFilter keeps the structured lines matching every condition.
+type fieldFilter struct {
+	query      string
+	conditions []fieldCondition
+}
+
+// parseFieldFilter parses space separated conditions such as
+// "level>=warn request_id=abc". Supported operators are =, !=, >, >=, <, <=
+// and ~ (regex match).
+func parseFieldFilter(query string) (*fieldFilter, error) {
+	terms := strings.Fields(query)
+	if len(terms) == 0 {
+		return nil, nil //nolint:nilnil // an empty query clears the filter
+	}
+	filter := &fieldFilter{query: strings.Join(terms, " ")}
+	for _, term := range terms {
+		cond, err := parseFieldCondition(term)
+		if err != nil {
+			return nil, err
+		}
+		filter.conditions = append(filter.conditions, cond)
+	}
+	return filter, nil
+}
+
+func parseFieldCondition(term string) (fieldCondition, error) {
+	at := strings.IndexAny(term, "!=<>~")
+	if at <= 0 {
+		return fieldCondition{}, fmt.Errorf("%q: expected key<op>value", term)
+	}
+	for _, op := range fieldOperators {
+		if !strings.HasPrefix(term[at:], op) {
+			continue
+		}
+		cond := fieldCondition{key: term[:at], op: op, value: term[at+len(op):]}
+		if cond.value == "" {
+			return fieldCondition{}, fmt.Errorf("%q: missing value", term)
+		}
+		if op == "~" {
+			re, err := regexp.Compile(cond.value)
+			if err != nil {
+				return fieldCondition{}, fmt.Errorf("%q: %w", term, err)
+			}
+			cond.re = re
+		}
+		return cond, nil
+	}
+	return fieldCondition{}, fmt.Errorf("%q: unknown operator", term)
+}
+
+// matches reports whether s satisfies every condition. Lines that are not
+// structured never match.
+func (f *fieldFilter) matches(s *structuredLog) bool {
+	if s == nil {
+		return false
+	}
+	for _, cond := range f.conditions {
+		if !cond.matches(s) {
+			return false
+		}
+	}
+	return true
+}
+
+// matches reports whether s satisfies the condition. The keys "level",
+// "message" and "timestamp" resolve through the field mapping. A missing
+// field only satisfies "!=".
+func (c fieldCondition) matches(s *structuredLog) bool {
+	field, isLevel := c.resolve(s)
+	if field == nil {
+		return c.op == "!="
+	}
+	if c.op == "~" {
+		return c.re.MatchString(field.value)
+	}
+
+	cmp, ok := 0, false
+	if isLevel {
+		cmp, ok = compareLevels(field.value, c.value)
+	}
+	if !ok {
+		cmp, ok = compareNumbers(field.value, c.value)
+	}
+	if !ok {
+		if c.op == "=" || c.op == "!=" {
+			equal := strings.EqualFold(field.value, c.value)
+			return equal == (c.op == "=")
+		}
+		cmp = strings.Compare(field.value, c.value)
+	}
+
+	switch c.op {
+	case "=":
+		return cmp == 0
+	case "!=":
+		return cmp != 0
+	case ">":
+		return cmp > 0
+	case ">=":
+		return cmp >= 0
+	case "<":
+		return cmp < 0
+	default:
+		return cmp <= 0
+	}
+}
+
+// resolve returns the field the condition tests and whether it holds the
+// line level.
+func (c fieldCondition) resolve(s *structuredLog) (*logField, bool) {
+	switch c.key {
+	case "level":
+		if s.level != nil {
+			return s.level, true
+		}
+	case "message":
+		if s.message != nil {
+			return s.message, false
+		}
+	case "timestamp":
+		if s.timestamp != nil {
+			return s.timestamp, false
+		}
+	}
+	field := s.lookup(c.key)
+	return field, field != nil && field == s.level
+}
+
+func compareLevels(a, b string) (int, bool) {
+	la, okA := parseLogLevel(a)
+	lb, okB := parseLogLevel(b)
+	if !okA || !okB {
+		return 0, false
+	}
+	return int(la) - int(lb), true
+}
+
+func compareNumbers(a, b string) (int, bool) {
+	na, errA := strconv.ParseFloat(a, 64)
+	nb, errB := strconv.ParseFloat(b, 64)
+	if errA != nil || errB != nil {
+		return 0, false
+	}
+	switch {
+	case na < nb:
+		return -1, true
+	case na > nb:
+		return 1, true
+	default:
+		return 0, true
+	}
+}
+
+// logFields holds the structured log state of the logs panel: the field
+// filter, its input, whether lines are shown raw and the expanded line.
+type logFields struct {
+	mapping  config.LogFieldsConfig
+	raw      bool // show lines as received instead of columns
+	input    textinput.Model
+	typing   bool
+	err      error
+	filter   *fieldFilter
+	detail   viewport.Model
+	expanded bool
+}
+
+func newLogFields(mapping config.LogFieldsConfig) logFields {
+	ti := textinput.New()
+	ti.Prompt = "fields: "
+	ti.Placeholder = "level>=warn request_id=abc"
+	return logFields{mapping: mapping, input: ti, detail: viewport.New()}
+}
+
+// passes reports whether a line with the given parsed form passes the field
+// filter.
+func (f *logFields) passes(s *structuredLog) bool {
+	return f.filter == nil || f.filter.matches(s)
+}
+
+// handleFieldsKey handles the structured log keys. It reports false when the
+// key is not one of them so the caller can keep looking.
+func (l *logsPanel) handleFieldsKey(msg tea.KeyPressMsg) (bool, tea.Cmd) {
+	if l.fields.expanded {
+		if key.Matches(msg, keys.Keys.Esc, keys.Keys.LogExpand) {
+			l.fields.expanded = false
+			return true, nil
+		}
+		var cmd tea.Cmd
+		l.fields.detail, cmd = l.fields.detail.Update(msg)
+		return true, cmd
+	}
+	if l.fields.typing {
+		return true, l.updateFieldsInput(msg)
+	}
+	switch {
+	case key.Matches(msg, keys.Keys.LogFieldFilter):
+		l.fields.typing = true
+		l.fields.err = nil
+		l.resize()
+		return true, l.fields.input.Focus()
+	case key.Matches(msg, keys.Keys.LogStructured):
+		selected := l.selectedLine()
+		l.fields.raw = !l.fields.raw
+		log.Printf("[containers][logs-panel] raw view: %t", l.fields.raw)
+		l.refilter(selected)
+		return true, nil
+	case key.Matches(msg, keys.Keys.LogExpand):
+		l.expandSelected()
+		return true, nil
+	case key.Matches(msg, keys.Keys.Esc) && l.fields.filter != nil:
+		l.setFieldFilter(nil)
+		return true, nil
+	}
+	return false, nil
+}
+
+// updateFieldsInput handles a key while the field filter is being typed.
+func (l *logsPanel) updateFieldsInput(msg tea.KeyPressMsg) tea.Cmd {
+	switch {
+	case key.Matches(msg, keys.Keys.Esc):
+		l.fields.typing = false
+		l.fields.err = nil
+		l.fields.input.Blur()
+		if l.fields.filter != nil {
+			l.fields.input.SetValue(l.fields.filter.query)
+		} else {
+			l.fields.input.SetValue("")
+		}
+		l.resize()
+		return nil
+	case key.Matches(msg, keys.Keys.Enter):
+		filter, err := parseFieldFilter(l.fields.input.Value())
+		if err != nil {
+			l.fields.err = err
+			return nil
+		}
+		l.fields.typing = false
+		l.fields.err = nil
+		l.fields.input.Blur()
+		l.setFieldFilter(filter)
+		return nil
+	}
+	var cmd tea.Cmd
+	l.fields.input, cmd = l.fields.input.Update(msg)
+	return cmd
+}
+
+// setFieldFilter applies filter, or clears the field filter when nil.
+func (l *logsPanel) setFieldFilter(filter *fieldFilter) {
+	selected := l.selectedLine()
+	if filter != nil {
+		log.Printf("[containers][logs-panel] field filter: %q", filter.query)
+		l.fields.input.SetValue(filter.query)
+	} else {
+		l.fields.input.SetValue("")
+	}
+	l.fields.filter = filter
+	l.refilter(selected)
+}
+
+// expandSelected shows every field of the selected line when it is
+// structured.
+func (l *logsPanel) expandSelected() {
+	n := l.selectedLine()
+	if n < 0 {
+		return
+	}
+	structured := l.store.At(n).structured
+	if structured == nil {
+		return
+	}
+	l.fields.detail.SetContent(formatStructuredDetail(structured))
+	l.fields.detail.GotoTop()
+	l.fields.expanded = true
+}
+
+// fieldsStatus renders the field filter input while typing, or the applied
+// field filter.
+func (l *logsPanel) fieldsStatus() string {
+	if l.fields.typing {
+		if l.fields.err != nil {
+			return l.fields.input.View() + "  " + theme.StatusErrorStyle.Render(l.fields.err.Error())
+		}
+		return l.fields.input.View()
+	}
+	if l.fields.filter == nil {
+		return ""
+	}
+	return "[fields: " + l.fields.filter.query + "]"
+}
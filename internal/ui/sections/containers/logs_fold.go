@@ -0,0 +1,225 @@
+package containers
+
+import (
+	"log"
+	"sort"
+	"strings"
+)
+
+// logFolds groups continuation lines, such as the frames of a Java or Python
+// stack trace, under the line they continue so they can be folded away. Lines
+// are folded unless expanded; toggled holds the parents folded or unfolded
+// one by one.
+type logFolds struct {
+	expanded bool
+	toggled  map[int]bool
+	children []logFold // parents with continuation lines, ascending
+
+	// Appending state.
+	parents foldParents
+	blocks  map[string]foldBlock
+}
+
+// logFold is the number of continuation lines of the line numbered parent.
+type logFold struct {
+	parent int
+	count  int
+}
+
+// foldBlock is the state of the lines of a source while appending.
+type foldBlock struct {
+	continued bool // the last line was a continuation
+	traceback bool // inside a Python traceback
+}
+
+func newLogFolds() logFolds {
+	return logFolds{toggled: map[int]bool{}, parents: foldParents{}, blocks: map[string]foldBlock{}}
+}
+
+// add classifies entry, numbered n, as a continuation line or not and returns
+// the line it continues, or -1.
+func (f *logFolds) add(n int, entry *logEntry) int {
+	switch {
+	case entry.marker:
+		clear(f.blocks)
+	case entry.structured == nil:
+		_, hasParent := f.parents[entry.Source]
+		var cont bool
+		cont, f.blocks[entry.Source] = continues(entry.Content, f.blocks[entry.Source])
+		entry.continuation = cont && hasParent
+	default:
+		f.blocks[entry.Source] = foldBlock{}
+	}
+	parent := f.parents.track(n, *entry)
+	if parent < 0 {
+		return -1
+	}
+	i := sort.Search(len(f.children), func(i int) bool { return f.children[i].parent >= parent })
+	if i == len(f.children) || f.children[i].parent != parent {
+		f.children = append(f.children, logFold{})
+		copy(f.children[i+1:], f.children[i:])
+		f.children[i] = logFold{parent: parent}
+	}
+	f.children[i].count++
+	return parent
+}
+
+// continues reports whether content continues the previous line of its
+// source: an indented line, a stack frame, a Python traceback or the
+// exception ending it.
+func continues(content string, block foldBlock) (bool, foldBlock) {
+	content = plainContent(content)
+	trimmed := strings.TrimSpace(content)
+	switch {
+	case trimmed == "":
+		// Blank lines separate chained exceptions.
+		return block.continued, block
+	case content[0] == ' ' || content[0] == '\t':
+		return true, foldBlock{continued: true, traceback: block.traceback}
+	case strings.HasPrefix(trimmed, "Traceback (most recent call last)"),
+		strings.HasPrefix(trimmed, "During handling of the above exception"),
+		strings.HasPrefix(trimmed, "The above exception was the direct cause"):
+		return true, foldBlock{continued: true, traceback: true}
+	case strings.HasPrefix(trimmed, "at "), strings.HasPrefix(trimmed, "Caused by: "),
+		strings.HasPrefix(trimmed, "Suppressed: "), strings.HasPrefix(trimmed, "... "):
+		return true, foldBlock{continued: true}
+	case block.traceback:
+		// The exception ending a traceback, e.g. "ValueError: boom".
+		return true, foldBlock{continued: true}
+	}
+	return false, foldBlock{}
+}
+
+// active reports whether any line has continuation lines.
+func (f *logFolds) active() bool {
+	return len(f.children) > 0
+}
+
+// count returns the number of continuation lines of the line numbered n.
+func (f *logFolds) count(n int) int {
+	i := sort.Search(len(f.children), func(i int) bool { return f.children[i].parent >= n })
+	if i < len(f.children) && f.children[i].parent == n {
+		return f.children[i].count
+	}
+	return 0
+}
+
+// folded reports whether the continuation lines of the line numbered parent
+// are hidden.
+func (f *logFolds) folded(parent int) bool {
+	return f.count(parent) > 0 && f.expanded == f.toggled[parent]
+}
+
+// toggle folds or unfolds the continuation lines of the line numbered parent.
+func (f *logFolds) toggle(parent int) {
+	if f.toggled[parent] {
+		delete(f.toggled, parent)
+	} else {
+		f.toggled[parent] = true
+	}
+}
+
+// toggleAll folds or unfolds every line.
+func (f *logFolds) toggleAll() {
+	f.expanded = !f.expanded
+	clear(f.toggled)
+}
+
+// forget drops the folds of the lines numbered below first.
+func (f *logFolds) forget(first int) {
+	i := sort.Search(len(f.children), func(i int) bool { return f.children[i].parent >= first })
+	f.children = f.children[i:]
+	for n := range f.toggled {
+		if n < first {
+			delete(f.toggled, n)
+		}
+	}
+}
+
+// foldParents tracks the last line of each source that continuation lines
+// attach to. A marker line starts over.
+type foldParents map[string]int
+
+// track records entry, numbered n, and returns the line it continues, or -1.
+func (p foldParents) track(n int, entry logEntry) int {
+	switch {
+	case entry.marker:
+		clear(p)
+	case entry.continuation:
+		if parent, ok := p[entry.Source]; ok {
+			return parent
+		}
+	case entry.Content != "":
+		p[entry.Source] = n
+	}
+	return -1
+}
+
+// foldedAway reports whether a continuation line of parent is hidden in its
+// fold. Lines matching the search stay visible while only matching lines are
+// shown.
+func (l *logsPanel) foldedAway(parent int, matched bool) bool {
+	return parent >= 0 && l.folds.folded(parent) && (!l.search.filter || !matched)
+}
+
+// parentOf returns the line the line numbered n continues, or -1.
+func (l *logsPanel) parentOf(n int) int {
+	entry := l.store.At(n)
+	if !entry.continuation {
+		return -1
+	}
+	for i := n - 1; i >= l.store.First(); i-- {
+		prev := l.store.At(i)
+		switch {
+		case prev.marker:
+			return -1
+		case prev.Source == entry.Source && !prev.continuation && prev.Content != "":
+			return i
+		}
+	}
+	return -1
+}
+
+// toggleFold folds or unfolds the lines of the selected line, or of the line
+// the selected line continues.
+func (l *logsPanel) toggleFold() {
+	n := l.selectedLine()
+	if n < 0 {
+		return
+	}
+	parent := n
+	if p := l.parentOf(n); p >= 0 {
+		parent = p
+	}
+	if l.folds.count(parent) == 0 {
+		return
+	}
+	log.Printf("[containers][logs-panel] toggle fold: line=%d", parent)
+	l.folds.toggle(parent)
+	if l.folds.folded(parent) {
+		n = parent
+	}
+	l.refilter(n)
+}
+
+// toggleAllFolds folds or unfolds every line, keeping the selection on the
+// same line or on the line it continues.
+func (l *logsPanel) toggleAllFolds() {
+	n := l.selectedLine()
+	l.folds.toggleAll()
+	log.Printf("[containers][logs-panel] folds expanded=%v", l.folds.expanded)
+	if n >= 0 {
+		if p := l.parentOf(n); p >= 0 && l.folds.folded(p) {
+			n = p
+		}
+	}
+	l.refilter(n)
+}
+
+// unfoldLine shows the line numbered n when it is folded away.
+func (l *logsPanel) unfoldLine(n int) {
+	if p := l.parentOf(n); p >= 0 && l.folds.folded(p) {
+		l.folds.toggle(p)
+		l.rebuildList()
+	}
+}
@@ -0,0 +1,143 @@
+package containers
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/GustavoCaso/docker-dash/internal/client"
+)
+
+var javaTrace = []string{
+	"ERROR Request failed",
+	"java.lang.IllegalStateException: boom",
+	"\tat com.example.Service.run(Service.java:42)",
+	"\tat com.example.Main.main(Main.java:7)",
+	"Caused by: java.io.IOException: closed",
+	"\t... 2 more",
+	"INFO next request",
+}
+
+var pythonTrace = []string{
+	"ERROR:root:job failed",
+	"Traceback (most recent call last):",
+	`  File "job.py", line 3, in <module>`,
+	"    run()",
+	"ValueError: bad input",
+	"",
+	"During handling of the above exception, another exception occurred:",
+	"",
+	"Traceback (most recent call last):",
+	`  File "job.py", line 5, in <module>`,
+	"KeyError: 'x'",
+	"INFO:root:retrying",
+}
+
+func TestContinuesGroupsStackTraces(t *testing.T) {
+	tests := []struct {
+		name  string
+		lines []string
+		want  []bool
+	}{
+		{
+			name:  "java",
+			lines: javaTrace,
+			// The exception message is not indented and starts a new entry.
+			want: []bool{false, false, true, true, true, true, false},
+		},
+		{
+			name:  "python",
+			lines: pythonTrace,
+			want:  []bool{false, true, true, true, true, true, true, true, true, true, true, false},
+		},
+		{
+			name:  "colored indentation",
+			lines: []string{"\x1b[31mpanic\x1b[0m", "\x1b[2m    at handler\x1b[0m"},
+			want:  []bool{false, true},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var block foldBlock
+			for i, line := range tt.lines {
+				var got bool
+				got, block = continues(line, block)
+				if got != tt.want[i] {
+					t.Errorf("continues(%q) = %v, want %v", line, got, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestLogsPanelFoldsContinuationLines(t *testing.T) {
+	p := newTestLogsPanel()
+	p.SetSize(200, 50)
+	p.appendLines(stdoutLines(javaTrace...))
+
+	if got := p.list.Len(); got != 3 {
+		t.Fatalf("folded: %d rows, want the 3 entries", got)
+	}
+	view := p.View()
+	if !strings.Contains(view, "java.lang.IllegalStateException: boom  [+4 lines]") {
+		t.Errorf("View() should tell how many lines are folded:\n%s", view)
+	}
+	if strings.Contains(view, "Service.java") {
+		t.Errorf("View() should hide the folded frames:\n%s", view)
+	}
+
+	p.list.Select(1)
+	p.Update(tea.KeyPressMsg{Code: 'z', Text: "z"})
+	if got := p.list.Len(); got != len(javaTrace) {
+		t.Errorf("z: %d rows, want every line unfolded", got)
+	}
+	p.list.Select(3) // a frame
+	p.Update(tea.KeyPressMsg{Code: 'z', Text: "z"})
+	if got := p.list.Len(); got != 3 || p.selectedLine() != 1 {
+		t.Errorf("z on a frame: %d rows, selected line %d, want the fold closed on its parent", got,
+			p.selectedLine())
+	}
+
+	p.Update(tea.KeyPressMsg{Code: 'Z', Text: "Z"})
+	if got := p.list.Len(); got != len(javaTrace) {
+		t.Errorf("Z: %d rows, want every line unfolded", got)
+	}
+	p.Update(tea.KeyPressMsg{Code: 'Z', Text: "Z"})
+	if got := p.list.Len(); got != 3 {
+		t.Errorf("Z again: %d rows, want every fold closed", got)
+	}
+}
+
+func TestLogsPanelSearchUnfoldsMatch(t *testing.T) {
+	p := newTestLogsPanel()
+	p.SetSize(200, 50)
+	p.appendLines(stdoutLines(pythonTrace...))
+
+	p.applySearch(regexp.MustCompile(`KeyError`))
+	if p.selectedLine() != 10 {
+		t.Fatalf("selected line = %d, want the match", p.selectedLine())
+	}
+	if p.folds.folded(0) {
+		t.Error("jumping to a folded match should unfold it")
+	}
+}
+
+func TestLogsPanelFoldsEachSourceSeparately(t *testing.T) {
+	p := newTestLogsPanel()
+	p.SetSize(200, 50)
+	p.appendLines([]client.LogLine{
+		{Source: "api", Content: "panic: boom"},
+		{Source: "db", Content: "ready"},
+		{Source: "api", Content: "\tmain.go:12"},
+		{Source: "db", Content: "checkpoint"},
+	})
+
+	if p.folds.count(0) != 1 || p.folds.count(1) != 0 {
+		t.Errorf("counts = %d, %d, want the frame folded under the api line", p.folds.count(0), p.folds.count(1))
+	}
+	if got := p.list.Len(); got != 3 {
+		t.Errorf("%d rows, want the db lines and the api entry", got)
+	}
+}
@@ -0,0 +1,106 @@
+package containers
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"charm.land/lipgloss/v2"
+
+	"github.com/GustavoCaso/docker-dash/internal/client"
+	"github.com/GustavoCaso/docker-dash/internal/config"
+	"github.com/GustavoCaso/docker-dash/internal/ui/components/scrolllist"
+	"github.com/GustavoCaso/docker-dash/internal/ui/sections"
+	"github.com/GustavoCaso/docker-dash/internal/ui/theme"
+)
+
+// logTarget is a container whose logs the logs panel follows. name prefixes
+// its lines when several containers are followed at once. startedAt is when
+// its current run started, if known.
+type logTarget struct {
+	id        string
+	name      string
+	startedAt time.Time
+}
+
+// containerLogTarget follows the container of ci.
+func containerLogTarget(ci containerItem) logTarget {
+	return logTarget{id: ci.ID(), name: ci.container.Name, startedAt: ci.container.StartedAt}
+}
+
+// selectedLogTarget follows the container of the selected item.
+func selectedLogTarget(item sections.ListItem) []logTarget {
+	if ci, ok := item.(containerItem); ok {
+		return []logTarget{containerLogTarget(ci)}
+	}
+	return []logTarget{{id: item.ID(), name: item.Title()}}
+}
+
+// composeLogTargets follows every service of the selected compose project.
+func composeLogTargets(item sections.ListItem) []logTarget {
+	project, ok := item.InnerItem().(client.ComposeProject)
+	if !ok {
+		return nil
+	}
+	var targets []logTarget
+	for _, svc := range project.Services {
+		if svc.ContainerID == "" {
+			continue
+		}
+		targets = append(targets, logTarget{id: svc.ContainerID, name: svc.Name})
+	}
+	return targets
+}
+
+// NewComposeLogsPanel creates a logs panel that merges the logs of every
+// service of the selected compose project, like docker compose logs.
+func NewComposeLogsPanel(ctx context.Context, svc client.ContainerService, logsCfg config.LogsConfig) sections.Panel {
+	return newLogsPanel(ctx, svc, logsCfg, composeLogTargets)
+}
+
+// openMergedLogs opens a session per target and merges them. Timestamps are
+// always requested since the lines are interleaved by them.
+func openMergedLogs(
+	ctx context.Context,
+	svc client.ContainerService,
+	targets []logTarget,
+	optsFor logOptionsFunc,
+) (*client.LogsSession, error) {
+	sources := make([]client.LogSource, 0, len(targets))
+	for _, target := range targets {
+		opts, err := optsFor(ctx, svc, target)
+		var session *client.LogsSession
+		if err == nil {
+			opts.Timestamps = true
+			session, err = svc.Logs(ctx, target.id, opts)
+		}
+		if err != nil {
+			for _, src := range sources {
+				src.Session.Close()
+			}
+			return nil, fmt.Errorf("%s: %w", target.name, err)
+		}
+		sources = append(sources, client.LogSource{Name: target.name, Session: session})
+	}
+	log.Printf("[containers][logs-panel] merging logs: sources=%d", len(sources))
+	return client.MergeLogsSessions(sources), nil
+}
+
+// sourcePrefixes builds the colored "name | " prefix of every target, padded
+// to the longest name so the lines stay aligned.
+func sourcePrefixes(targets []logTarget) map[string]scrolllist.Prefix {
+	width := 0
+	for _, target := range targets {
+		width = max(width, lipgloss.Width(target.name))
+	}
+	prefixes := make(map[string]scrolllist.Prefix, len(targets))
+	for i, target := range targets {
+		color := theme.LogSourceColors[i%len(theme.LogSourceColors)]
+		prefixes[target.name] = scrolllist.Prefix{
+			Text:  padRight(target.name, width) + " | ",
+			Style: lipgloss.NewStyle().Foreground(color),
+		}
+	}
+	return prefixes
+}
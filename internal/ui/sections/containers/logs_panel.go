@@ -6,10 +6,13 @@ import (
 	"fmt"
 	"io"
 	"log"
-	"strings"
+	"slices"
+	"sort"
+	"time"
 
 	"charm.land/bubbles/v2/key"
 	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
 
 	"github.com/GustavoCaso/docker-dash/internal/client"
 	"github.com/GustavoCaso/docker-dash/internal/config"
@@ -17,53 +20,212 @@ import (
 	"github.com/GustavoCaso/docker-dash/internal/ui/keys"
 	"github.com/GustavoCaso/docker-dash/internal/ui/message"
 	"github.com/GustavoCaso/docker-dash/internal/ui/sections"
+	"github.com/GustavoCaso/docker-dash/internal/ui/theme"
 )
 
 type logsPanel struct {
 	ctx         context.Context
 	logsSession *client.LogsSession
-	list        scrolllist.Model
-	lineBuffer  string
+	list        scrolllist.Virtual
 	client      client.ContainerService
 	logsCfg     config.LogsConfig
+	// targets picks the containers to follow for the selected item.
+	targets func(sections.ListItem) []logTarget
+	// current holds the containers whose logs are shown.
+	current []logTarget
+	// prefixes holds the source prefix of each container while the logs of
+	// several containers are merged; it is nil when following a single one.
+	prefixes map[string]scrolllist.Prefix
+	restart  logRestartWatch
+	// timeRange is the time range picked for the logs; it is kept when
+	// another container is selected.
+	timeRange logRange
+	// tee is set while an export keeps appending the received lines.
+	tee *logTee
+	// requestID drops the messages of commands started for a previous
+	// container.
+	requestID int
+	// store holds the lines received, numbered in order; the list shows all
+	// of them, or only the lines listed in shown while a stream, field or
+	// search filter is on.
+	store    *logStore
+	shown    []int
+	streams  logStreamFilter
+	search   logSearch
+	fields   logFields
+	folds    logFolds
+	patterns logPatterns
+	width    int
+	height   int
 }
 
-// logsOutputMsg is sent when logs output is received from the background reader.
+// logEntry is a received log line along with its parsed form when it is a
+// JSON or logfmt line.
+type logEntry struct {
+	client.LogLine
+
+	structured *structuredLog
+	// marker is set for the lines the panel inserts itself, such as the one
+	// noting a container restart. Markers are never filtered out.
+	marker bool
+	// continuation is set for the lines that continue the previous line of
+	// their source, such as stack trace frames; they are folded under it.
+	continuation bool
+}
+
+// logStreamFilter selects which output streams the logs panel shows.
+type logStreamFilter int
+
+const (
+	streamsBoth logStreamFilter = iota
+	streamsStdout
+	streamsStderr
+	logStreamFilterCount
+)
+
+func (f logStreamFilter) String() string {
+	switch f {
+	case streamsStdout:
+		return "stdout only"
+	case streamsStderr:
+		return "stderr only"
+	default:
+		return "stdout+stderr"
+	}
+}
+
+// shows reports whether lines written to stream pass the filter.
+func (f logStreamFilter) shows(stream client.LogStream) bool {
+	switch f {
+	case streamsStdout:
+		return stream != client.LogStreamStderr
+	case streamsStderr:
+		return stream == client.LogStreamStderr
+	default:
+		return true
+	}
+}
+
+// logsOutputMsg is sent when log lines are received from the background reader.
+// session is the session read, nil when no session was opened.
 type logsOutputMsg struct {
-	output string
-	err    error
+	session *client.LogsSession
+	lines   []client.LogLine
+	err     error
 }
 
 type logsSessionStartedMsg struct {
-	session *client.LogsSession
+	requestID int
+	session   *client.LogsSession
 }
 
 func NewLogsPanel(ctx context.Context, client client.ContainerService, logsCfg config.LogsConfig) sections.Panel {
-	return &logsPanel{
-		ctx:     ctx,
-		client:  client,
-		list:    scrolllist.New(),
-		logsCfg: logsCfg,
+	return newLogsPanel(ctx, client, logsCfg, selectedLogTarget)
+}
+
+func newLogsPanel(
+	ctx context.Context,
+	client client.ContainerService,
+	logsCfg config.LogsConfig,
+	targets func(sections.ListItem) []logTarget,
+) *logsPanel {
+	l := &logsPanel{
+		ctx:      ctx,
+		client:   client,
+		list:     scrolllist.NewVirtual(),
+		logsCfg:  logsCfg,
+		targets:  targets,
+		store:    newLogStore(logsCfg.Retention, logsCfg.Fields),
+		search:   newLogSearch(),
+		fields:   newLogFields(logsCfg.Fields),
+		folds:    newLogFolds(),
+		patterns: newLogPatterns(),
+	}
+	l.list.SetSource(logRows{l})
+	return l
+}
+
+// logRows is the list source of the logs panel: every stored line, or only
+// the shown ones while filtering.
+type logRows struct {
+	l *logsPanel
+}
+
+func (r logRows) Len() int {
+	if r.l.filtered() {
+		return len(r.l.shown)
 	}
+	return r.l.store.Len()
 }
 
+func (r logRows) Line(row int) scrolllist.Line {
+	n := row + r.l.store.First()
+	if r.l.filtered() {
+		n = r.l.shown[row]
+	}
+	return r.l.rowLine(n, r.l.store.At(n))
+}
+
+// Init follows the logs of the containers item stands for. When they are
+// already followed, as after a reload of the list, the stream, its export and
+// its restart watch are kept; otherwise the previous stream is closed and its
+// lines dropped.
 func (l *logsPanel) Init(item sections.ListItem) tea.Cmd {
-	containerID := item.ID()
-	log.Printf("[containers][logs-panel] Init: containerID=%q", containerID)
-	return tea.Batch(l.init(containerID), l.extendHelpCmd())
+	targets := l.targets(item)
+	if l.follows(targets) {
+		return nil
+	}
+	log.Printf("[containers][logs-panel] Init: item=%q targets=%d", item.ID(), len(targets))
+	if l.logsSession != nil {
+		l.logsSession.Close()
+		l.logsSession = nil
+	}
+	l.prefixes = nil
+	l.current = targets
+	l.requestID++
+	l.restart = logRestartWatch{}
+	l.stopTee()
+	l.clearLines()
+	if len(targets) > 1 {
+		l.prefixes = sourcePrefixes(targets)
+	} else if len(targets) == 1 {
+		l.restart.target = targets[0]
+		l.restart.startedAt = targets[0].startedAt
+		if l.restart.startedAt.IsZero() {
+			l.restart.startedAt = time.Now()
+		}
+	}
+	return tea.Batch(l.openLogs(targets, l.rangeOptions()), l.extendHelpCmd())
 }
 
+// follows reports whether the panel already follows the logs of targets.
+func (l *logsPanel) follows(targets []logTarget) bool {
+	return len(l.current) > 0 && slices.EqualFunc(l.current, targets, func(a, b logTarget) bool {
+		return a.id == b.id
+	})
+}
+
+const logsPanelName = "Logs"
+
 func (l *logsPanel) Name() string {
-	return "Logs"
+	return logsPanelName
 }
 
 func (l *logsPanel) Update(msg tea.Msg) tea.Cmd {
 	switch msg := msg.(type) {
 	case logsSessionStartedMsg:
+		if msg.requestID != l.requestID {
+			// The logs were closed or reopened while the session started.
+			msg.session.Close()
+			return nil
+		}
 		log.Printf("[containers][logs-panel] session started")
 		l.logsSession = msg.session
 		return l.readLogsOutput()
 	case logsOutputMsg:
+		if msg.session != nil && msg.session != l.logsSession {
+			return nil
+		}
 		if msg.err != nil {
 			if l.logsSession == nil {
 				return nil
@@ -71,7 +233,7 @@ func (l *logsPanel) Update(msg tea.Msg) tea.Cmd {
 			if errors.Is(msg.err, io.EOF) {
 				l.logsSession.Close()
 				l.logsSession = nil
-				return nil
+				return l.watchRestart()
 			}
 			l.Close()
 			return func() tea.Msg {
@@ -81,26 +243,277 @@ func (l *logsPanel) Update(msg tea.Msg) tea.Cmd {
 				}
 			}
 		}
-		log.Printf("[containers][logs-panel] output chunk: bytes=%d", len(msg.output))
-		l.appendLines(msg.output)
-		return l.readLogsOutput()
+		log.Printf("[containers][logs-panel] output: lines=%d", len(msg.lines))
+		l.appendLines(msg.lines)
+		return tea.Batch(l.readLogsOutput(), l.flushTee())
+	case logsRestartCheckMsg:
+		return l.handleRestartCheck(msg)
+	case logsExportedMsg:
+		return l.handleExported(msg)
+	case logsRestartTickMsg:
+		if msg.requestID != l.requestID {
+			return nil
+		}
+		return l.checkRestartCmd()
+	case tea.KeyPressMsg:
+		if l.fields.expanded || l.fields.typing {
+			_, cmd := l.handleFieldsKey(msg)
+			return cmd
+		}
+		if l.patterns.open {
+			return l.handlePatternsKey(msg)
+		}
+		if handled, cmd := l.handleSearchKey(msg); handled {
+			return cmd
+		}
+		if handled, cmd := l.handleFieldsKey(msg); handled {
+			return cmd
+		}
+		switch {
+		case key.Matches(msg, keys.Keys.LogStreams):
+			l.cycleStreams()
+			return nil
+		case key.Matches(msg, keys.Keys.LogExport):
+			return l.exportFormCmd()
+		case key.Matches(msg, keys.Keys.LogRange):
+			return l.rangeFormCmd()
+		case key.Matches(msg, keys.Keys.LogFold):
+			l.toggleFold()
+			return nil
+		case key.Matches(msg, keys.Keys.LogFoldAll):
+			l.toggleAllFolds()
+			return nil
+		case key.Matches(msg, keys.Keys.LogPatterns):
+			l.openPatterns()
+			return nil
+		case key.Matches(msg, keys.Keys.Esc) && l.patterns.filter != nil:
+			l.setPatternFilter(nil)
+			return nil
+		}
 	}
 
+	if l.search.typing {
+		// Forward cursor blink ticks to the search input.
+		var cmd tea.Cmd
+		l.search.input, cmd = l.search.input.Update(msg)
+		return tea.Batch(cmd, l.list.Update(msg))
+	}
+	if l.fields.typing {
+		var cmd tea.Cmd
+		l.fields.input, cmd = l.fields.input.Update(msg)
+		return tea.Batch(cmd, l.list.Update(msg))
+	}
 	return l.list.Update(msg)
 }
 
-func (l *logsPanel) appendLines(chunk string) {
-	combined := l.lineBuffer + chunk
-	parts := strings.Split(combined, "\n")
-	l.lineBuffer = parts[len(parts)-1]
-	complete := parts[:len(parts)-1]
-	for _, line := range complete {
-		l.list.AppendLine(line)
+func (l *logsPanel) appendLines(lines []client.LogLine) {
+	dropped := 0
+	for _, line := range lines {
+		entry := logEntry{LogLine: line, structured: parseStructuredLog(line.Content, l.fields.mapping)}
+		n := l.store.Next()
+		wasFiltered := l.filtered()
+		parent := l.folds.add(n, &entry)
+		dropped += l.store.Append(entry)
+		l.patterns.add(n, entry)
+		matched := l.matches(entry)
+		if matched {
+			l.search.matches = append(l.search.matches, n)
+		}
+		switch {
+		case !wasFiltered && l.filtered():
+			// The first folded line: list the shown lines from now on.
+			l.rebuildList()
+		case l.filtered() && l.rowShown(entry, parent, matched):
+			l.shown = append(l.shown, n)
+		}
+	}
+	l.forgetDropped(dropped)
+	l.refreshPatterns()
+}
+
+// appendMarker adds a line noting an event in the container lifecycle.
+func (l *logsPanel) appendMarker(content string) {
+	n := l.store.Next()
+	entry := logEntry{LogLine: client.LogLine{Content: content}, marker: true}
+	l.folds.add(n, &entry)
+	dropped := l.store.Append(entry)
+	if l.filtered() {
+		l.shown = append(l.shown, n)
+	}
+	l.forgetDropped(dropped)
+}
+
+// forgetDropped removes the lines the store dropped from the shown lines and
+// the search matches, keeping the selection on the same line.
+func (l *logsPanel) forgetDropped(dropped int) {
+	if dropped == 0 {
+		return
+	}
+	first := l.store.First()
+	l.folds.forget(first)
+	l.patterns.forget(first)
+	rows := dropped
+	if l.filtered() {
+		rows = sort.SearchInts(l.shown, first)
+		l.shown = l.shown[rows:]
+	}
+	l.list.Shift(rows)
+	gone := sort.SearchInts(l.search.matches, first)
+	l.search.matches = l.search.matches[gone:]
+	if l.search.current >= 0 {
+		l.search.current -= gone
+		if l.search.current < 0 {
+			l.search.current = -1
+		}
+	}
+}
+
+// rowLine renders entry, numbered n, as a list line. Structured lines are
+// shown as columns and colored by level unless the raw view is on. A line
+// with folded continuation lines tells how many are hidden.
+func (l *logsPanel) rowLine(n int, entry logEntry) scrolllist.Line {
+	if entry.marker {
+		return scrolllist.Line{Content: entry.Content, Style: &theme.LogMarkerStyle}
+	}
+	line := entry.LogLine
+	if line.Source != "" && !l.logsCfg.Timestamps {
+		// Merged sessions always carry timestamps to order the lines.
+		line.Timestamp = time.Time{}
+	}
+	var style *lipgloss.Style
+	if line.Stream == client.LogStreamStderr {
+		style = &theme.LogStderrStyle
+	}
+	if entry.structured != nil && !l.fields.raw {
+		line.Content = formatStructured(entry.structured)
+		if levelStyle := levelStyle(entry.structured); levelStyle != nil {
+			style = levelStyle
+		}
+	}
+	row := scrolllist.Line{Content: formatLogLine(line), Style: style}
+	if l.folds.folded(n) {
+		row.Content += fmt.Sprintf("  [+%d lines]", l.folds.count(n))
+	}
+	if prefix, ok := l.prefixes[line.Source]; ok {
+		row.Prefix = &prefix
+	}
+	return row
+}
+
+// formatLogLine renders a log line, prefixed with its timestamp when known.
+func formatLogLine(line client.LogLine) string {
+	if line.Timestamp.IsZero() {
+		return line.Content
+	}
+	return line.Timestamp.Format(time.RFC3339Nano) + " " + line.Content
+}
+
+// filtered reports whether the list shows a subset of the lines.
+func (l *logsPanel) filtered() bool {
+	return l.search.filter || l.streams != streamsBoth || l.fields.filter != nil || l.folds.active() ||
+		l.patterns.filter != nil
+}
+
+// passesFilters reports whether entry passes the stream and field filters.
+func (l *logsPanel) passesFilters(entry logEntry) bool {
+	return entry.marker || l.streams.shows(entry.Stream) && l.fields.passes(entry.structured)
+}
+
+// rowShown reports whether entry, continuing the line numbered parent or -1,
+// is listed. matched tells whether it matches the search.
+func (l *logsPanel) rowShown(entry logEntry, parent int, matched bool) bool {
+	return l.rowVisible(entry, matched) && !l.foldedAway(parent, matched) && l.patternShows(entry, parent)
+}
+
+// rowVisible reports whether entry is shown by the current filters. matched
+// tells whether it matches the search.
+func (l *logsPanel) rowVisible(entry logEntry, matched bool) bool {
+	return l.passesFilters(entry) && (!l.search.filter || matched || entry.marker)
+}
+
+// cycleStreams switches between showing both streams, stdout only and stderr
+// only.
+func (l *logsPanel) cycleStreams() {
+	selected := l.selectedLine()
+	l.streams = (l.streams + 1) % logStreamFilterCount
+	log.Printf("[containers][logs-panel] streams: %s", l.streams)
+	l.refilter(selected)
+}
+
+// refilter rebuilds the list after a filter or the view changed, keeping the
+// selection on the line numbered selected when possible.
+func (l *logsPanel) refilter(selected int) {
+	if l.search.active() {
+		l.findMatches()
+		l.search.current = -1
+	}
+	l.rebuildList()
+	l.resize()
+	if selected >= 0 {
+		l.list.Select(l.rowOf(selected))
+	}
+}
+
+// rebuildList lists the lines that should be shown while filtering.
+func (l *logsPanel) rebuildList() {
+	l.shown = nil
+	if l.filtered() {
+		parents := foldParents{}
+		l.store.Each(l.store.First(), func(n int, entry logEntry) bool {
+			parent := parents.track(n, entry)
+			if l.rowShown(entry, parent, l.matches(entry)) {
+				l.shown = append(l.shown, n)
+			}
+			return true
+		})
+	}
+	l.list.SetSource(logRows{l})
+}
+
+// selectedLine returns the number of the line in the selected row, or -1
+// when the list is empty.
+func (l *logsPanel) selectedLine() int {
+	row := l.list.Index()
+	if l.filtered() {
+		if row < 0 || row >= len(l.shown) {
+			return -1
+		}
+		return l.shown[row]
 	}
+	if row < 0 || row >= l.store.Len() {
+		return -1
+	}
+	return row + l.store.First()
+}
+
+// rowOf returns the list row showing the line numbered n. When n is hidden by
+// a filter, it returns the row of the next shown line.
+func (l *logsPanel) rowOf(n int) int {
+	if !l.filtered() {
+		return max(n-l.store.First(), 0)
+	}
+	return min(sort.SearchInts(l.shown, n), max(len(l.shown)-1, 0))
 }
 
 func (l *logsPanel) View() string {
-	return l.list.View()
+	if l.fields.expanded {
+		return l.fields.detail.View()
+	}
+	list := l.list.View()
+	if l.patterns.open {
+		list = l.patterns.list.View()
+	}
+	if !l.statusBarVisible() {
+		return list
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, list, l.statusBarView())
+}
+
+// IsFilter reports whether the user is typing a search query or a field
+// filter, so the section routes every key to the panel instead of treating it
+// as a shortcut.
+func (l *logsPanel) IsFilter() bool {
+	return l.search.typing || l.fields.typing
 }
 
 func (l *logsPanel) Close() tea.Cmd {
@@ -110,12 +523,41 @@ func (l *logsPanel) Close() tea.Cmd {
 		l.logsSession = nil
 	}
 	l.list.Reset()
-	l.lineBuffer = ""
+	l.prefixes = nil
+	l.current = nil
+	l.requestID++
+	l.restart = logRestartWatch{}
+	l.stopTee()
+	l.store.Reset()
+	l.shown = nil
+	l.streams = streamsBoth
+	l.search = newLogSearch()
+	l.fields = newLogFields(l.logsCfg.Fields)
+	l.folds = newLogFolds()
+	l.patterns = newLogPatterns()
+	l.list.SetHighlight(nil)
+	l.resize()
 	return func() tea.Msg { return message.ClearContextualKeyBindingsMsg{} }
 }
 
 func (l *logsPanel) SetSize(width, height int) {
-	l.list.SetSize(width, height)
+	l.width = width
+	l.height = height
+	l.resize()
+}
+
+// resize sizes the list, leaving a line for the search bar when it is shown.
+func (l *logsPanel) resize() {
+	height := l.height
+	if l.statusBarVisible() {
+		height = max(height-1, 0)
+	}
+	l.list.SetSize(l.width, height)
+	l.patterns.list.SetSize(l.width, height)
+	l.search.input.SetWidth(max(l.width-len(l.search.input.Prompt), 0))
+	l.fields.input.SetWidth(max(l.width-len(l.fields.input.Prompt), 0))
+	l.fields.detail.SetWidth(l.width)
+	l.fields.detail.SetHeight(l.height)
 }
 
 func (l *logsPanel) readLogsOutput() tea.Cmd {
@@ -124,28 +566,47 @@ func (l *logsPanel) readLogsOutput() tea.Cmd {
 		return nil
 	}
 	return func() tea.Msg {
-		buf := make([]byte, readBufSize)
-		n, err := session.Reader.Read(buf)
+		lines, err := session.Read()
 		if err != nil {
-			return logsOutputMsg{err: err}
+			return logsOutputMsg{session: session, err: err}
 		}
 
-		return logsOutputMsg{output: string(buf[:n])}
+		return logsOutputMsg{session: session, lines: lines}
+	}
+}
+
+// logOptions returns the configured log options.
+func (l *logsPanel) logOptions() client.LogOptions {
+	return client.LogOptions{
+		Follow:     l.logsCfg.Follow,
+		Tail:       l.logsCfg.Tail,
+		Timestamps: l.logsCfg.Timestamps,
+		Since:      l.logsCfg.Since,
 	}
 }
 
-func (l *logsPanel) init(containerID string) tea.Cmd {
+// openLogs starts a logs session for targets, merging them when there are
+// several.
+func (l *logsPanel) openLogs(targets []logTarget, optsFor logOptionsFunc) tea.Cmd {
+	ctx, svc, requestID := l.ctx, l.client, l.requestID
 	return func() tea.Msg {
-		session, err := l.client.Logs(l.ctx, containerID, client.LogOptions{
-			Follow:     l.logsCfg.Follow,
-			Tail:       l.logsCfg.Tail,
-			Timestamps: l.logsCfg.Timestamps,
-			Since:      l.logsCfg.Since,
-		})
+		var session *client.LogsSession
+		var err error
+		switch len(targets) {
+		case 0:
+			return logsOutputMsg{err: errors.New("no containers to follow")}
+		case 1:
+			var opts client.LogOptions
+			if opts, err = optsFor(ctx, svc, targets[0]); err == nil {
+				session, err = svc.Logs(ctx, targets[0].id, opts)
+			}
+		default:
+			session, err = openMergedLogs(ctx, svc, targets, optsFor)
+		}
 		if err != nil {
 			return logsOutputMsg{err: err}
 		}
-		return logsSessionStartedMsg{session: session}
+		return logsSessionStartedMsg{requestID: requestID, session: session}
 	}
 }
 
@@ -156,6 +617,19 @@ func (l *logsPanel) extendHelpCmd() tea.Cmd {
 			keys.Keys.ScrollDown,
 			keys.Keys.LogScrollLeft,
 			keys.Keys.LogScrollRight,
+			keys.Keys.LogSearch,
+			keys.Keys.LogSearchNext,
+			keys.Keys.LogSearchPrev,
+			keys.Keys.LogSearchFilter,
+			keys.Keys.LogStreams,
+			keys.Keys.LogFieldFilter,
+			keys.Keys.LogStructured,
+			keys.Keys.LogExpand,
+			keys.Keys.LogExport,
+			keys.Keys.LogRange,
+			keys.Keys.LogFold,
+			keys.Keys.LogFoldAll,
+			keys.Keys.LogPatterns,
 		}}
 	}
 }
@@ -4,8 +4,11 @@ import (
 	"context"
 	"errors"
 	"io"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	tea "charm.land/bubbletea/v2"
 
@@ -90,8 +93,8 @@ func TestLogsPanelUpdateOnSessionStartedReadsOutput(t *testing.T) {
 	if !ok {
 		t.Fatalf("readLogsOutput returned %T, want logsOutputMsg", msg)
 	}
-	if !strings.Contains(outputMsg.output, "hello logs") {
-		t.Errorf("output = %q, want to contain 'hello logs'", outputMsg.output)
+	if len(outputMsg.lines) != 1 || outputMsg.lines[0].Content != "hello logs" {
+		t.Errorf("lines = %+v, want the 'hello logs' line", outputMsg.lines)
 	}
 }
 
@@ -99,12 +102,11 @@ func TestLogsPanelAccumulatesOutput(t *testing.T) {
 	p := newTestLogsPanel()
 	p.SetSize(100, 100)
 
-	p.Update(logsOutputMsg{output: "first line\n"})
-	p.Update(logsOutputMsg{output: "second line\n"})
+	p.Update(logsOutputMsg{lines: stdoutLines("first line")})
+	p.Update(logsOutputMsg{lines: stdoutLines("second line")})
 
-	items := p.list.Items()
-	if len(items) != 2 {
-		t.Fatalf("expected 2 items, got %d", len(items))
+	if got := p.list.Len(); got != 2 {
+		t.Fatalf("expected 2 items, got %d", got)
 	}
 }
 
@@ -113,18 +115,18 @@ func TestLogsPanelClose(t *testing.T) {
 	p.SetSize(100, 50)
 	pr, pw := io.Pipe()
 	p.logsSession = client.NewLogsSession(io.NopCloser(pr), func() { pr.Close(); pw.Close() })
-	p.appendLines("some output\n")
+	p.appendLines(stdoutLines("some output"))
 
 	p.Close()
 
 	if p.logsSession != nil {
 		t.Error("Close() should nil out logsSession")
 	}
-	if len(p.list.Items()) != 0 {
-		t.Errorf("Close() should clear list items, got %d", len(p.list.Items()))
+	if p.list.Len() != 0 {
+		t.Errorf("Close() should clear list items, got %d", p.list.Len())
 	}
-	if p.lineBuffer != "" {
-		t.Errorf("Close() should clear lineBuffer, got %q", p.lineBuffer)
+	if p.store.Len() != 0 {
+		t.Errorf("Close() should clear lines, got %d", p.store.Len())
 	}
 }
 
@@ -135,26 +137,71 @@ func TestLogsPanelCloseIsIdempotent(t *testing.T) {
 	p.Close()
 }
 
-func TestLogsPanelLineBuffering(t *testing.T) {
+func TestLogsPanelStreamFilter(t *testing.T) {
 	p := newTestLogsPanel()
 	p.SetSize(200, 50)
+	p.appendLines([]client.LogLine{
+		{Stream: client.LogStreamStdout, Content: "GET / 200"},
+		{Stream: client.LogStreamStderr, Content: "panic: boom"},
+		{Stream: client.LogStreamStdout, Content: "GET /a 200"},
+	})
 
-	// First chunk: incomplete line
-	p.Update(logsOutputMsg{output: "partial"})
-	if len(p.list.Items()) != 0 {
-		t.Errorf("incomplete line should not create item, got %d items", len(p.list.Items()))
+	visible := func() int { return p.list.Len() }
+	if visible() != 3 {
+		t.Fatalf("both streams: %d items, want 3", visible())
 	}
-	if p.lineBuffer != "partial" {
-		t.Errorf("lineBuffer = %q, want %q", p.lineBuffer, "partial")
+
+	p.Update(tea.KeyPressMsg{Code: 'e', Text: "e"})
+	if visible() != 2 || p.streams != streamsStdout {
+		t.Errorf("stdout only: %d items, want 2", visible())
+	}
+
+	p.Update(tea.KeyPressMsg{Code: 'e', Text: "e"})
+	if visible() != 1 || p.store.At(p.selectedLine()).Content != "panic: boom" {
+		t.Errorf("stderr only: %d items, want only the stderr line", visible())
+	}
+	if !strings.Contains(p.View(), "[stderr only]") {
+		t.Errorf("View() should show the stream filter, got:\n%s", p.View())
+	}
+
+	// New lines respect the filter while it is on.
+	p.Update(logsOutputMsg{lines: []client.LogLine{
+		{Stream: client.LogStreamStdout, Content: "GET /b 200"},
+		{Stream: client.LogStreamStderr, Content: "retrying"},
+	}})
+	if visible() != 2 {
+		t.Errorf("stderr only after streaming: %d items, want 2", visible())
+	}
+
+	p.Update(tea.KeyPressMsg{Code: 'e', Text: "e"})
+	if visible() != 5 || p.streams != streamsBoth {
+		t.Errorf("both streams again: %d items, want 5", visible())
+	}
+}
+
+func TestLogsPanelSearchIgnoresHiddenStream(t *testing.T) {
+	p := newTestLogsPanel()
+	p.SetSize(200, 50)
+	p.appendLines([]client.LogLine{
+		{Stream: client.LogStreamStdout, Content: "error in access log"},
+		{Stream: client.LogStreamStderr, Content: "error: boom"},
+	})
+	p.Update(tea.KeyPressMsg{Code: 'e', Text: "e"}) // stdout only
+	searchLogs(p, "error")
+
+	if len(p.search.matches) != 1 || p.store.At(p.search.matches[0]).Stream != client.LogStreamStdout {
+		t.Errorf("matches = %v, want only the stdout line", p.search.matches)
 	}
+}
 
-	// Second chunk: completes the line and starts another
-	p.Update(logsOutputMsg{output: " line\nsecond\n"})
-	if len(p.list.Items()) != 2 {
-		t.Errorf("expected 2 items, got %d", len(p.list.Items()))
+func TestFormatLogLine(t *testing.T) {
+	line := client.LogLine{Content: "hello"}
+	if got := formatLogLine(line); got != "hello" {
+		t.Errorf("formatLogLine() = %q, want %q", got, "hello")
 	}
-	if p.lineBuffer != "" {
-		t.Errorf("lineBuffer should be empty, got %q", p.lineBuffer)
+	line.Timestamp = time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC)
+	if got := formatLogLine(line); got != "2024-01-15T10:30:00Z hello" {
+		t.Errorf("formatLogLine() = %q, want timestamp prefix", got)
 	}
 }
 
@@ -206,3 +253,455 @@ func TestLogsPanelUsesLogsCfgOptions(t *testing.T) {
 		t.Error("logsCfg.Timestamps not stored")
 	}
 }
+
+// searchLogs types query into the logs search and submits it.
+func searchLogs(p *logsPanel, query string) {
+	p.Update(tea.KeyPressMsg{Code: '/', Text: "/"})
+	for _, r := range query {
+		p.Update(tea.KeyPressMsg{Code: r, Text: string(r)})
+	}
+	p.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+}
+
+func selectedLogLine(p *logsPanel) string {
+	return p.store.At(p.selectedLine()).Content
+}
+
+// stdoutLines returns lines written to stdout.
+func stdoutLines(contents ...string) []client.LogLine {
+	lines := make([]client.LogLine, len(contents))
+	for i, content := range contents {
+		lines[i] = client.LogLine{Stream: client.LogStreamStdout, Content: content}
+	}
+	return lines
+}
+
+func TestLogsPanelSearchJumpsBetweenMatches(t *testing.T) {
+	p := newTestLogsPanel()
+	p.SetSize(100, 20)
+	p.appendLines(stdoutLines("boot", "GET /a 200", "GET /b 500", "idle", "GET /c 503"))
+
+	searchLogs(p, `5\d\d`)
+	if p.IsFilter() {
+		t.Fatal("IsFilter() should be false after submitting the search")
+	}
+	if got := selectedLogLine(p); got != "GET /b 500" {
+		t.Errorf("selected after search = %q, want first match", got)
+	}
+
+	p.Update(tea.KeyPressMsg{Code: 'n', Text: "n"})
+	if got := selectedLogLine(p); got != "GET /c 503" {
+		t.Errorf("selected after n = %q, want second match", got)
+	}
+	p.Update(tea.KeyPressMsg{Code: 'n', Text: "n"})
+	if got := selectedLogLine(p); got != "GET /b 500" {
+		t.Errorf("selected after wrapping n = %q, want first match", got)
+	}
+	p.Update(tea.KeyPressMsg{Code: 'N', Text: "N"})
+	if got := selectedLogLine(p); got != "GET /c 503" {
+		t.Errorf("selected after N = %q, want last match", got)
+	}
+	if view := p.View(); !strings.Contains(view, "match 2/2") {
+		t.Errorf("View() should show the match position, got:\n%s", view)
+	}
+}
+
+func TestLogsPanelSearchKeepsPositionWhileStreaming(t *testing.T) {
+	p := newTestLogsPanel()
+	p.SetSize(100, 20)
+	p.appendLines(stdoutLines("error one", "ok", "error two"))
+	searchLogs(p, "error")
+	p.Update(tea.KeyPressMsg{Code: 'n', Text: "n"})
+
+	p.Update(logsOutputMsg{lines: stdoutLines("error three", "ok")})
+
+	if got := selectedLogLine(p); got != "error two" {
+		t.Errorf("selected after new lines = %q, want %q", got, "error two")
+	}
+	if len(p.search.matches) != 3 || p.search.current != 1 {
+		t.Errorf("matches=%v current=%d, want 3 matches and current 1", p.search.matches, p.search.current)
+	}
+	p.Update(tea.KeyPressMsg{Code: 'n', Text: "n"})
+	if got := selectedLogLine(p); got != "error three" {
+		t.Errorf("selected after n = %q, want streamed match", got)
+	}
+}
+
+func TestLogsPanelSearchFilterShowsOnlyMatches(t *testing.T) {
+	p := newTestLogsPanel()
+	p.SetSize(100, 20)
+	p.appendLines(stdoutLines("error one", "ok", "error two"))
+	searchLogs(p, "error")
+
+	p.Update(tea.KeyPressMsg{Code: 'f', Text: "f"})
+	if got := p.list.Len(); got != 2 {
+		t.Fatalf("filtered list has %d items, want 2", got)
+	}
+	p.Update(logsOutputMsg{lines: stdoutLines("ok again", "error three")})
+	if got := p.list.Len(); got != 3 {
+		t.Errorf("filtered list has %d items after streaming, want 3", got)
+	}
+
+	p.Update(tea.KeyPressMsg{Code: 'f', Text: "f"})
+	if got := p.list.Len(); got != 5 {
+		t.Errorf("unfiltered list has %d items, want 5", got)
+	}
+
+	p.Update(tea.KeyPressMsg{Code: 'f', Text: "f"})
+	p.Update(tea.KeyPressMsg{Code: tea.KeyEscape})
+	if p.search.active() || p.list.Len() != 5 {
+		t.Errorf("Esc should clear the search and show every line, got %d items", p.list.Len())
+	}
+}
+
+func TestLogsPanelSearchInvalidRegexKeepsTyping(t *testing.T) {
+	p := newTestLogsPanel()
+	p.SetSize(100, 20)
+	p.appendLines(stdoutLines("line"))
+
+	searchLogs(p, "(")
+	if !p.IsFilter() {
+		t.Error("invalid pattern should keep the search input open")
+	}
+	if p.search.err == nil {
+		t.Error("invalid pattern should set an error")
+	}
+
+	p.Update(tea.KeyPressMsg{Code: tea.KeyEscape})
+	if p.IsFilter() || p.search.active() {
+		t.Error("Esc should close the search input without applying a search")
+	}
+}
+
+// filterLogFields types query into the field filter and submits it.
+func filterLogFields(p *logsPanel, query string) {
+	p.Update(tea.KeyPressMsg{Code: 'F', Text: "F"})
+	for _, r := range query {
+		p.Update(tea.KeyPressMsg{Code: r, Text: string(r)})
+	}
+	p.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+}
+
+func TestLogsPanelRendersStructuredLines(t *testing.T) {
+	p := newTestLogsPanel()
+	p.SetSize(200, 50)
+	p.appendLines(stdoutLines(
+		`{"level":"error","msg":"db down","retry":true}`,
+		"plain line",
+	))
+
+	view := p.View()
+	if !strings.Contains(view, "ERROR db down retry=true") {
+		t.Errorf("View() should render the JSON line as columns:\n%s", view)
+	}
+
+	p.Update(tea.KeyPressMsg{Code: 'j', Text: "j"})
+	if !p.fields.raw || !strings.Contains(p.View(), `{"level":"error","msg":"db down","retry":true}`) {
+		t.Errorf("raw view should show the line as received:\n%s", p.View())
+	}
+}
+
+func TestLogsPanelFieldFilter(t *testing.T) {
+	p := newTestLogsPanel()
+	p.SetSize(200, 50)
+	p.appendLines(stdoutLines(
+		`{"level":"info","msg":"started","request_id":"abc"}`,
+		`{"level":"warn","msg":"slow","request_id":"abc"}`,
+		"plain line",
+		`level=error msg=failed request_id=def`,
+	))
+
+	filterLogFields(p, "level>=warn")
+	if p.IsFilter() {
+		t.Error("IsFilter() should be false once the field filter is applied")
+	}
+	if got := p.list.Len(); got != 2 {
+		t.Errorf("level>=warn: %d items, want 2", got)
+	}
+	if !strings.Contains(p.View(), "[fields: level>=warn]") {
+		t.Errorf("View() should show the field filter:\n%s", p.View())
+	}
+
+	// New lines respect the filter while it is on.
+	p.Update(logsOutputMsg{lines: stdoutLines(`{"level":"debug","msg":"tick"}`, `{"level":"fatal","msg":"bye"}`)})
+	if got := p.list.Len(); got != 3 {
+		t.Errorf("after streaming: %d items, want 3", got)
+	}
+
+	p.Update(tea.KeyPressMsg{Code: tea.KeyEscape})
+	if p.fields.filter != nil || p.list.Len() != 6 {
+		t.Errorf("Esc should clear the field filter, %d items", p.list.Len())
+	}
+
+	filterLogFields(p, "request_id=abc")
+	searchLogs(p, "slow|started|failed")
+	if len(p.search.matches) != 2 {
+		t.Errorf("search matches = %v, want only lines passing the field filter", p.search.matches)
+	}
+}
+
+func TestLogsPanelFieldFilterInvalidKeepsTyping(t *testing.T) {
+	p := newTestLogsPanel()
+	p.SetSize(200, 50)
+	filterLogFields(p, "level")
+	if !p.IsFilter() || p.fields.err == nil {
+		t.Fatal("an invalid field filter should keep the input open with an error")
+	}
+	p.Update(tea.KeyPressMsg{Code: tea.KeyEscape})
+	if p.IsFilter() || p.fields.filter != nil {
+		t.Error("Esc should close the field filter input")
+	}
+}
+
+func TestLogsPanelExpandsStructuredLine(t *testing.T) {
+	p := newTestLogsPanel()
+	p.SetSize(200, 50)
+	p.appendLines(stdoutLines(`{"level":"info","msg":"hi","user":{"id":7}}`, "plain line"))
+
+	p.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	if !p.fields.expanded || !strings.Contains(p.View(), "user.id: 7") {
+		t.Fatalf("Enter should expand the structured line:\n%s", p.View())
+	}
+	p.Update(tea.KeyPressMsg{Code: tea.KeyEscape})
+	if p.fields.expanded {
+		t.Error("Esc should close the expanded line")
+	}
+
+	p.list.Select(1)
+	p.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	if p.fields.expanded {
+		t.Error("Enter should not expand a plain line")
+	}
+}
+
+// composeProjectItem is a list item wrapping a compose project, as the
+// compose section lists them.
+type composeProjectItem struct {
+	project client.ComposeProject
+}
+
+func (c composeProjectItem) ID() string          { return c.project.Name }
+func (c composeProjectItem) InnerItem() any      { return c.project }
+func (c composeProjectItem) Title() string       { return c.project.Name }
+func (c composeProjectItem) Description() string { return "" }
+func (c composeProjectItem) FilterValue() string { return c.project.Name }
+
+func TestComposeLogsPanelMergesServices(t *testing.T) {
+	p := NewComposeLogsPanel(
+		context.Background(),
+		client.NewMockClient().Containers(),
+		config.DefaultLogsConfig(),
+	).(*logsPanel)
+	p.SetSize(200, 50)
+	item := composeProjectItem{project: client.ComposeProject{
+		Name: "web-app",
+		Services: []client.ComposeServiceInfo{
+			{Name: "api", ContainerID: "def456ghi789"},
+			{Name: "db", ContainerID: "ghi789jkl012"},
+			{Name: "gone"},
+		},
+	}}
+
+	var started *logsSessionStartedMsg
+	for _, cmd := range p.Init(item)().(tea.BatchMsg) {
+		if msg, ok := cmd().(logsSessionStartedMsg); ok {
+			started = &msg
+		}
+	}
+	if started == nil {
+		t.Fatal("Init() should start a merged session")
+	}
+	defer p.Close()
+	if len(p.prefixes) != 2 {
+		t.Fatalf("prefixes = %v, want the two services with a container", p.prefixes)
+	}
+
+	msg := p.Update(*started)()
+	p.Update(msg)
+	if p.store.Len() == 0 || p.store.At(0).Source == "" {
+		t.Fatalf("first line = %+v, want lines tagged with their service", p.store.At(0))
+	}
+	view := p.View()
+	if !strings.Contains(view, "api | ") && !strings.Contains(view, "db  | ") {
+		t.Errorf("View() should prefix lines with the service name:\n%s", view)
+	}
+	if strings.Contains(view, "2024-01-15T10:30:00Z") {
+		t.Errorf("View() should hide the merge timestamps when timestamps are off:\n%s", view)
+	}
+}
+
+func TestLogsPanelFollowsContainerRestart(t *testing.T) {
+	started := time.Date(2026, 3, 4, 5, 0, 0, 0, time.UTC)
+	p := newTestLogsPanel()
+	p.SetSize(200, 50)
+	p.Init(containerItem{container: client.Container{ID: "abc123def456", Name: "nginx-proxy", StartedAt: started}})
+	pr, pw := io.Pipe()
+	p.logsSession = client.NewLogsSession(io.NopCloser(pr), func() { pr.Close(); pw.Close() })
+	p.appendLines(stdoutLines("serving"))
+
+	cmd := p.Update(logsOutputMsg{err: io.EOF})
+	if cmd == nil || p.logsSession != nil {
+		t.Fatal("the end of a followed stream should close the session and watch for a restart")
+	}
+	if _, ok := cmd().(logsRestartCheckMsg); !ok {
+		t.Fatal("watching for a restart should inspect the container")
+	}
+
+	requestID := p.requestID
+	exited := client.Container{ID: "abc123def456", State: client.StateStopped, ExitCode: 137, StartedAt: started}
+	if cmd := p.Update(logsRestartCheckMsg{requestID: requestID, container: exited}); cmd == nil {
+		t.Fatal("a stopped container should be checked again later")
+	}
+
+	restarted := client.Container{ID: "abc123def456", State: client.StateRunning, StartedAt: started.Add(time.Minute)}
+	cmd = p.Update(logsRestartCheckMsg{requestID: requestID, container: restarted})
+	if cmd == nil {
+		t.Fatal("a restart should reattach the logs")
+	}
+	if msg, ok := cmd().(logsSessionStartedMsg); !ok {
+		t.Error("reattaching should start a new logs session")
+	} else {
+		msg.session.Close()
+	}
+	if !strings.Contains(p.View(), "— container restarted (exit 137) —") {
+		t.Errorf("View() should show the restart marker:\n%s", p.View())
+	}
+	if !p.restart.startedAt.Equal(restarted.StartedAt) {
+		t.Errorf("startedAt = %s, want the new run start", p.restart.startedAt)
+	}
+}
+
+func TestLogsPanelRestartWatchIgnoresStaleChecks(t *testing.T) {
+	p := newTestLogsPanel()
+	p.Init(containerItem{container: client.Container{ID: "abc123def456"}})
+	stale := p.requestID
+	p.Close()
+
+	running := client.Container{State: client.StateRunning, StartedAt: time.Now().Add(time.Hour)}
+	if cmd := p.Update(logsRestartCheckMsg{requestID: stale, container: running}); cmd != nil {
+		t.Error("a check from before Close should be ignored")
+	}
+	if cmd := p.Update(logsRestartTickMsg{requestID: stale}); cmd != nil {
+		t.Error("a tick from before Close should be ignored")
+	}
+}
+
+func TestLogsPanelDoesNotWatchRestartWithoutFollow(t *testing.T) {
+	cfg := config.DefaultLogsConfig()
+	cfg.Follow = false
+	p := NewLogsPanel(context.Background(), client.NewMockClient().Containers(), cfg).(*logsPanel)
+	p.Init(containerItem{container: client.Container{ID: "abc123def456"}})
+	pr, pw := io.Pipe()
+	p.logsSession = client.NewLogsSession(io.NopCloser(pr), func() { pr.Close(); pw.Close() })
+
+	if cmd := p.Update(logsOutputMsg{err: io.EOF}); cmd != nil {
+		t.Error("a snapshot ending should not watch for restarts")
+	}
+}
+
+func TestLogsPanelMarkerSurvivesFilters(t *testing.T) {
+	p := newTestLogsPanel()
+	p.SetSize(200, 50)
+	p.appendLines([]client.LogLine{{Stream: client.LogStreamStderr, Content: "boom"}})
+	p.Update(tea.KeyPressMsg{Code: 'e', Text: "e"}) // stdout only
+	p.appendMarker("— container restarted —")
+
+	if got := p.list.Len(); got != 1 {
+		t.Errorf("stdout only: %d items, want only the marker", got)
+	}
+	p.Update(tea.KeyPressMsg{Code: 'e', Text: "e"}) // stderr only
+	if got := p.list.Len(); got != 2 {
+		t.Errorf("stderr only: %d items, want the stderr line and the marker", got)
+	}
+}
+
+func TestLogsPanelRetentionDropsOldLines(t *testing.T) {
+	cfg := config.DefaultLogsConfig()
+	cfg.Retention = config.LogRetentionConfig{MaxLines: 3}
+	p := NewLogsPanel(context.Background(), client.NewMockClient().Containers(), cfg).(*logsPanel)
+	p.SetSize(200, 50)
+	p.appendLines(stdoutLines("error 1", "ok", "error 2"))
+	searchLogs(p, "error")
+	p.list.Select(2) // "error 2"
+
+	p.appendLines(stdoutLines("error 3", "ok"))
+
+	if got := p.list.Len(); got != 3 {
+		t.Errorf("list has %d lines, want the 3 kept", got)
+	}
+	if got := selectedLogLine(p); got != "error 2" {
+		t.Errorf("selected line = %q, want the selection to stay on %q", got, "error 2")
+	}
+	if len(p.search.matches) != 2 {
+		t.Errorf("matches = %v, want only the kept matching lines", p.search.matches)
+	}
+	if !strings.Contains(p.View(), "[2 older lines dropped]") {
+		t.Errorf("View() should show the dropped lines, got:\n%s", p.View())
+	}
+}
+
+func TestLogsPanelSpilledLinesCanBeScrolledBack(t *testing.T) {
+	cfg := config.DefaultLogsConfig()
+	cfg.Retention = config.LogRetentionConfig{MaxLines: 2, Spill: true}
+	p := NewLogsPanel(context.Background(), client.NewMockClient().Containers(), cfg).(*logsPanel)
+	defer p.Close()
+	p.SetSize(200, 50)
+	p.appendLines(stdoutLines("first", "second", "third", "fourth"))
+
+	if got := p.list.Len(); got != 4 {
+		t.Fatalf("list has %d lines, want every line while spilling", got)
+	}
+	p.list.Select(0)
+	if got := selectedLogLine(p); got != "first" {
+		t.Errorf("selected line = %q, want the spilled first line", got)
+	}
+	searchLogs(p, "ir")
+	if len(p.search.matches) != 2 {
+		t.Errorf("matches = %v, want the spilled and kept lines containing %q", p.search.matches, "ir")
+	}
+}
+
+func TestLogsPanelInitKeepsTheStreamOfTheSameContainer(t *testing.T) {
+	p := newTestLogsPanel()
+	p.SetSize(200, 50)
+	item := containerItem{container: client.Container{ID: "abc123def456", Name: "nginx-proxy"}}
+	p.Init(item)
+	pr, pw := io.Pipe()
+	closed := false
+	session := client.NewLogsSession(io.NopCloser(pr), func() { closed = true; pr.Close(); pw.Close() })
+	p.logsSession = session
+	p.appendLines(stdoutLines("first", "second"))
+	p.restart.exitCode = 137
+	file, err := os.Create(filepath.Join(t.TempDir(), "nginx-proxy.log"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tee := &logTee{file: file, path: file.Name()}
+	p.tee = tee
+	requestID := p.requestID
+
+	// A reload of the list initialises the panel again for the same container.
+	if cmd := p.Init(item); cmd != nil {
+		t.Error("Init() for the followed container should not reopen the logs")
+	}
+	if p.logsSession != session || p.requestID != requestID || p.restart.exitCode != 137 {
+		t.Error("Init() for the followed container should keep the stream and its restart watch")
+	}
+	if p.tee != tee {
+		t.Error("Init() for the followed container should keep appending to the export")
+	}
+	if got := p.store.Len(); got != 2 {
+		t.Errorf("store holds %d lines, want the 2 received once", got)
+	}
+
+	p.Init(containerItem{container: client.Container{ID: "def456ghi789", Name: "api-server"}})
+	if !closed || p.logsSession == session {
+		t.Error("Init() for another container should close the previous session")
+	}
+	if p.tee != nil {
+		t.Error("Init() for another container should stop the export")
+	}
+	if got := p.store.Len(); got != 0 {
+		t.Errorf("store holds %d lines of the previous container, want 0", got)
+	}
+}
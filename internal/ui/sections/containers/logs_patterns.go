@@ -0,0 +1,303 @@
+package containers
+
+import (
+	"fmt"
+	"log"
+	"regexp"
+	"sort"
+	"strings"
+
+	"charm.land/bubbles/v2/key"
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/GustavoCaso/docker-dash/internal/ui/components/scrolllist"
+	"github.com/GustavoCaso/docker-dash/internal/ui/components/sparkline"
+	"github.com/GustavoCaso/docker-dash/internal/ui/keys"
+)
+
+// sparklineWidth is the number of buckets of a pattern's sparkline.
+const sparklineWidth = 16
+
+// patternMask matches the variable parts of a log line. Each group is
+// replaced by the token of the same index in patternTokens.
+var patternMask = regexp.MustCompile(`("(?:[^"\\]|\\.)*"|'(?:[^'\\]|\\.)*')` +
+	`|(\b[0-9a-fA-F]{8}(?:-[0-9a-fA-F]{4}){3}-[0-9a-fA-F]{12}\b)` +
+	`|(\b\d{1,3}(?:\.\d{1,3}){3}(?::\d+)?\b` +
+	`|\b(?:[0-9a-fA-F]{1,4}:){4,7}[0-9a-fA-F]{1,4}\b` +
+	`|\b[0-9a-fA-F]{1,4}(?::[0-9a-fA-F]{1,4})*::(?:[0-9a-fA-F]{1,4}(?::[0-9a-fA-F]{1,4})*)?\b)` +
+	`|(\b(?:0x)?[0-9a-fA-F]{12,}\b)` +
+	`|(\b\d+(?:\.\d+)*)`)
+
+var patternTokens = []string{"<str>", "<uuid>", "<ip>", "<hex>", "<num>"}
+
+// logTemplate masks the quoted strings, UUIDs, IP addresses, hexadecimal IDs
+// and numbers of content, so lines logged by the same statement share it.
+func logTemplate(content string) string {
+	var b strings.Builder
+	last := 0
+	for _, m := range patternMask.FindAllStringSubmatchIndex(content, -1) {
+		b.WriteString(content[last:m[0]])
+		for group, token := range patternTokens {
+			if m[2+2*group] >= 0 {
+				b.WriteString(token)
+				break
+			}
+		}
+		last = m[1]
+	}
+	b.WriteString(content[last:])
+	return b.String()
+}
+
+// patternKey returns the source and template entry is clustered by. The
+// template of a structured line is built from its level and message only.
+func patternKey(entry logEntry) logPatternKey {
+	content := plainContent(entry.Content)
+	if s := entry.structured; s != nil && s.message != nil {
+		content = s.message.value
+		if s.level != nil {
+			content = s.level.value + " " + content
+		}
+	}
+	return logPatternKey{source: entry.Source, template: logTemplate(content)}
+}
+
+// logPatternKey identifies a pattern.
+type logPatternKey struct {
+	source   string
+	template string
+}
+
+func (k logPatternKey) String() string {
+	if k.source == "" {
+		return k.template
+	}
+	return k.source + " | " + k.template
+}
+
+// logPattern is a template along with the lines that follow it.
+type logPattern struct {
+	key   logPatternKey
+	lines []int // numbers of the lines, ascending
+}
+
+// patternOrder selects how the patterns view is sorted.
+type patternOrder int
+
+const (
+	patternsByCount patternOrder = iota
+	patternsByRecency
+)
+
+func (o patternOrder) String() string {
+	if o == patternsByRecency {
+		return "most recent"
+	}
+	return "most frequent"
+}
+
+// logPatterns clusters the log lines into templates. Patterns are only kept
+// up to date once the patterns view was opened. Markers and continuation
+// lines are left out; the latter go with the line they continue.
+type logPatterns struct {
+	open    bool // the patterns view is shown
+	built   bool
+	byKey   map[logPatternKey]*logPattern
+	sorted  []*logPattern // in view order
+	order   patternOrder
+	list    scrolllist.Virtual
+	filter  *logPatternKey // pattern the lines are filtered to
+	first   int            // number of the oldest line, for the sparklines
+	next    int            // number of the next line, for the sparklines
+	changed bool           // lines were added since sorting
+}
+
+func newLogPatterns() logPatterns {
+	return logPatterns{byKey: map[logPatternKey]*logPattern{}, list: scrolllist.NewVirtual()}
+}
+
+// add records entry, numbered n, when the patterns are kept.
+func (p *logPatterns) add(n int, entry logEntry) {
+	if !p.built || entry.marker || entry.continuation {
+		return
+	}
+	k := patternKey(entry)
+	pattern, ok := p.byKey[k]
+	if !ok {
+		pattern = &logPattern{key: k}
+		p.byKey[k] = pattern
+		p.sorted = append(p.sorted, pattern)
+	}
+	pattern.lines = append(pattern.lines, n)
+	p.changed = true
+}
+
+// forget drops the lines numbered below first, and the patterns left empty.
+func (p *logPatterns) forget(first int) {
+	if !p.built {
+		return
+	}
+	kept := p.sorted[:0]
+	for _, pattern := range p.sorted {
+		pattern.lines = pattern.lines[sort.SearchInts(pattern.lines, first):]
+		if len(pattern.lines) == 0 && (p.filter == nil || pattern.key != *p.filter) {
+			delete(p.byKey, pattern.key)
+			continue
+		}
+		kept = append(kept, pattern)
+	}
+	clear(p.sorted[len(kept):])
+	p.sorted = kept
+	p.changed = true
+}
+
+// resort sorts the patterns, keeping the selection on the same pattern.
+func (p *logPatterns) resort() {
+	selected := p.selected()
+	sort.SliceStable(p.sorted, func(i, j int) bool {
+		a, b := p.sorted[i], p.sorted[j]
+		if p.order == patternsByRecency {
+			return lastLine(a) > lastLine(b)
+		}
+		if len(a.lines) != len(b.lines) {
+			return len(a.lines) > len(b.lines)
+		}
+		return lastLine(a) > lastLine(b)
+	})
+	p.changed = false
+	for i, pattern := range p.sorted {
+		if pattern == selected {
+			p.list.Select(i)
+			return
+		}
+	}
+}
+
+// lastLine returns the number of the newest line of pattern, or -1.
+func lastLine(pattern *logPattern) int {
+	if len(pattern.lines) == 0 {
+		return -1
+	}
+	return pattern.lines[len(pattern.lines)-1]
+}
+
+// selected returns the selected pattern, or nil.
+func (p *logPatterns) selected() *logPattern {
+	if i := p.list.Index(); i < len(p.sorted) {
+		return p.sorted[i]
+	}
+	return nil
+}
+
+// sparkline draws when the lines of pattern were logged, from the oldest
+// line kept to the newest.
+func (p *logPatterns) sparkline(pattern *logPattern) string {
+	span := max(p.next-p.first, 1)
+	buckets := make([]float64, sparklineWidth)
+	for _, n := range pattern.lines {
+		buckets[min((n-p.first)*sparklineWidth/span, sparklineWidth-1)]++
+	}
+	return sparkline.Render(buckets)
+}
+
+// patternRows is the list source of the patterns view.
+type patternRows struct {
+	p *logPatterns
+}
+
+func (r patternRows) Len() int {
+	return len(r.p.sorted)
+}
+
+func (r patternRows) Line(row int) scrolllist.Line {
+	pattern := r.p.sorted[row]
+	return scrolllist.Line{Content: fmt.Sprintf("%7d  %s  %s",
+		len(pattern.lines), r.p.sparkline(pattern), pattern.key)}
+}
+
+// patternShows reports whether entry belongs to the pattern the lines are
+// filtered to. parent is the line entry continues, or -1.
+func (l *logsPanel) patternShows(entry logEntry, parent int) bool {
+	if l.patterns.filter == nil || entry.marker {
+		return true
+	}
+	if entry.continuation {
+		if parent < 0 {
+			return false
+		}
+		entry = l.store.At(parent)
+	}
+	return patternKey(entry) == *l.patterns.filter
+}
+
+// openPatterns shows the patterns view, clustering the lines received so far
+// the first time.
+func (l *logsPanel) openPatterns() {
+	if !l.patterns.built {
+		l.patterns.built = true
+		l.store.Each(l.store.First(), func(n int, entry logEntry) bool {
+			l.patterns.add(n, entry)
+			return true
+		})
+		log.Printf("[containers][logs-panel] patterns: %d", len(l.patterns.sorted))
+	}
+	l.patterns.open = true
+	l.patterns.list.SetSource(patternRows{&l.patterns})
+	l.refreshPatterns()
+	l.resize()
+}
+
+// refreshPatterns sorts the patterns again after lines were added or dropped.
+func (l *logsPanel) refreshPatterns() {
+	l.patterns.first, l.patterns.next = l.store.First(), l.store.Next()
+	if l.patterns.open && l.patterns.changed {
+		l.patterns.resort()
+	}
+}
+
+// handlePatternsKey handles a key while the patterns view is shown: enter
+// filters the lines to the selected pattern.
+func (l *logsPanel) handlePatternsKey(msg tea.KeyPressMsg) tea.Cmd {
+	switch {
+	case key.Matches(msg, keys.Keys.Esc, keys.Keys.LogPatterns):
+		l.patterns.open = false
+		l.resize()
+		return nil
+	case key.Matches(msg, keys.Keys.LogPatternSort):
+		l.patterns.order = (l.patterns.order + 1) % (patternsByRecency + 1)
+		l.patterns.resort()
+		return nil
+	case key.Matches(msg, keys.Keys.Enter):
+		if pattern := l.patterns.selected(); pattern != nil {
+			l.patterns.open = false
+			l.setPatternFilter(&pattern.key)
+		}
+		return nil
+	}
+	return l.patterns.list.Update(msg)
+}
+
+// setPatternFilter shows only the lines of the pattern k, or every line when
+// k is nil.
+func (l *logsPanel) setPatternFilter(k *logPatternKey) {
+	log.Printf("[containers][logs-panel] pattern filter: %v", k)
+	selected := l.selectedLine()
+	l.patterns.filter = k
+	l.refilter(selected)
+	if k != nil {
+		l.list.Select(max(l.list.Len()-1, 0))
+	}
+}
+
+// patternsStatus describes the patterns view or the pattern filter for the
+// status bar.
+func (l *logsPanel) patternsStatus() string {
+	if l.patterns.open {
+		return fmt.Sprintf("%d patterns by %s  enter: show lines  o: sort  esc: back",
+			len(l.patterns.sorted), l.patterns.order)
+	}
+	if l.patterns.filter != nil {
+		return "[pattern: " + l.patterns.filter.String() + "]"
+	}
+	return ""
+}
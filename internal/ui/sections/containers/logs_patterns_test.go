@@ -0,0 +1,114 @@
+package containers
+
+import (
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/GustavoCaso/docker-dash/internal/client"
+)
+
+func TestLogTemplate(t *testing.T) {
+	tests := []struct {
+		content string
+		want    string
+	}{
+		{
+			content: `GET /users/42 took 12.5ms from 10.0.0.7:5432`,
+			want:    `GET /users/<num> took <num>ms from <ip>`,
+		},
+		{
+			content: `request 3f2b1c4e-9a8d-4e7f-b6c5-1d2e3f4a5b6c failed: "connection reset"`,
+			want:    `request <uuid> failed: <str>`,
+		},
+		{
+			content: `container 4f66ad9a0b2e3c1d started on fe80::1c2b:3ff:fe4d`,
+			want:    `container <hex> started on <ip>`,
+		},
+		{
+			content: `worker http2 ready at 10:30:00`,
+			want:    `worker http2 ready at <num>:<num>:<num>`,
+		},
+	}
+	for _, tt := range tests {
+		if got := logTemplate(tt.content); got != tt.want {
+			t.Errorf("logTemplate(%q) = %q, want %q", tt.content, got, tt.want)
+		}
+	}
+}
+
+func TestLogsPanelPatternsView(t *testing.T) {
+	p := newTestLogsPanel()
+	p.SetSize(200, 50)
+	p.appendLines(stdoutLines(
+		"GET /health 200",
+		"GET /health 200",
+		"GET /health 503",
+		`cache miss for "user:1"`,
+		"GET /health 200",
+		"disk full on /dev/sda1",
+	))
+
+	p.Update(tea.KeyPressMsg{Code: 'c', Text: "c"})
+	if !p.patterns.open || len(p.patterns.sorted) != 3 {
+		t.Fatalf("c should open the patterns view with 3 patterns, got %d", len(p.patterns.sorted))
+	}
+	view := p.View()
+	first := strings.Split(view, "\n")[0]
+	if !strings.Contains(first, "4") || !strings.Contains(first, "GET /health <num>") {
+		t.Errorf("first pattern = %q, want the most frequent one with its count", first)
+	}
+
+	p.Update(tea.KeyPressMsg{Code: 'o', Text: "o"})
+	if got := p.patterns.selected().key.template; got != "GET /health <num>" {
+		t.Errorf("sorting should keep the selection, selected %q", got)
+	}
+	if got := p.patterns.sorted[0].key.template; got != "disk full on /dev/sda1" {
+		t.Errorf("by recency, first pattern = %q, want the newest one", got)
+	}
+
+	p.patterns.list.Select(2)
+	p.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	if p.patterns.open || p.list.Len() != 1 {
+		t.Fatalf("enter should show the %d lines of the pattern, got %d rows", 1, p.list.Len())
+	}
+	if !strings.Contains(p.View(), `[pattern: cache miss for <str>]`) {
+		t.Errorf("View() should show the pattern filter:\n%s", p.View())
+	}
+
+	p.appendLines(stdoutLines(`cache miss for "user:2"`, "GET /health 200"))
+	if p.list.Len() != 2 {
+		t.Errorf("%d rows, want the new line of the pattern only", p.list.Len())
+	}
+	if got := len(p.patterns.byKey[logPatternKey{template: "GET /health <num>"}].lines); got != 5 {
+		t.Errorf("GET pattern has %d lines, want the patterns kept up to date", got)
+	}
+
+	p.Update(tea.KeyPressMsg{Code: tea.KeyEscape})
+	if p.patterns.filter != nil || p.list.Len() != 8 {
+		t.Errorf("esc should clear the pattern filter, %d rows", p.list.Len())
+	}
+}
+
+func TestLogsPanelPatternFilterKeepsContinuationLines(t *testing.T) {
+	p := newTestLogsPanel()
+	p.SetSize(200, 50)
+	p.appendLines([]client.LogLine{
+		{Content: "ERROR job 1 failed"},
+		{Content: "\tat Job.run"},
+		{Content: "INFO job 2 done"},
+	})
+	p.openPatterns()
+	for i, pattern := range p.patterns.sorted {
+		if pattern.key.template == "ERROR job <num> failed" {
+			p.patterns.list.Select(i)
+		}
+	}
+	p.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	p.Update(tea.KeyPressMsg{Code: 'Z', Text: "Z"})
+
+	if p.list.Len() != 2 {
+		t.Errorf("%d rows, want the error and its frame", p.list.Len())
+	}
+}
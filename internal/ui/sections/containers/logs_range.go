@@ -0,0 +1,206 @@
+package containers
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/huh/v2"
+
+	"github.com/GustavoCaso/docker-dash/internal/client"
+	"github.com/GustavoCaso/docker-dash/internal/ui/components/form"
+	"github.com/GustavoCaso/docker-dash/internal/ui/message"
+)
+
+// logRangePreset selects the time range of the logs shown by the logs panel.
+type logRangePreset string
+
+const (
+	// rangeConfigured uses the since and tail values of the config.
+	rangeConfigured logRangePreset = "configured"
+	// rangeSinceStart shows the logs since the container last started.
+	rangeSinceStart logRangePreset = "start"
+	rangeLast15m    logRangePreset = "15m"
+	rangeLastHour   logRangePreset = "1h"
+	rangeLastDay    logRangePreset = "24h"
+	// rangeCustom uses the since and until values typed in the form.
+	rangeCustom logRangePreset = "custom"
+)
+
+// logRange is the time range of the logs shown by the logs panel.
+type logRange struct {
+	preset logRangePreset
+	since  string // for rangeCustom, as accepted by client.ParseLogTime
+	until  string // for rangeCustom, blank for now
+}
+
+// logOptionsFunc returns the options the logs of target are requested with.
+type logOptionsFunc func(ctx context.Context, svc client.ContainerService, target logTarget) (client.LogOptions, error)
+
+// fixedLogOptions requests the logs of every target with opts.
+func fixedLogOptions(opts client.LogOptions) logOptionsFunc {
+	return func(context.Context, client.ContainerService, logTarget) (client.LogOptions, error) {
+		return opts, nil
+	}
+}
+
+// options applies the range to opts, the configured options. Every line of a
+// range is requested; a range that ends in the past is not followed. The
+// "since last start" preset inspects the container to learn when it started.
+func (r logRange) options(
+	ctx context.Context,
+	svc client.ContainerService,
+	target logTarget,
+	opts client.LogOptions,
+) (client.LogOptions, error) {
+	switch r.preset {
+	case rangeConfigured, "":
+		return opts, nil
+	case rangeSinceStart:
+		c, err := svc.Get(ctx, target.id)
+		if err != nil {
+			return opts, fmt.Errorf("%s: %w", target.name, err)
+		}
+		opts.Since = ""
+		if !c.StartedAt.IsZero() {
+			opts.Since = c.StartedAt.Format(time.RFC3339Nano)
+		}
+	case rangeCustom:
+		opts.Since, opts.Until = r.since, r.until
+	default:
+		opts.Since = string(r.preset)
+	}
+	opts.Tail = "all"
+	opts.Follow = r.follows(opts.Follow)
+	return opts, nil
+}
+
+// follows reports whether the logs of the range are followed when following
+// is configured as follow.
+func (r logRange) follows(follow bool) bool {
+	return follow && (r.preset != rangeCustom || r.until == "")
+}
+
+// String describes the range for the status bar.
+func (r logRange) String() string {
+	switch r.preset {
+	case rangeConfigured, "":
+		return ""
+	case rangeSinceStart:
+		return "since last start"
+	case rangeCustom:
+		desc := "since " + r.since
+		if r.since == "" {
+			desc = "from the start"
+		}
+		if r.until != "" {
+			desc += " until " + r.until
+		}
+		return desc
+	default:
+		return "last " + string(r.preset)
+	}
+}
+
+// rangeForm asks for the time range of the logs. The since and until inputs
+// are only shown for a custom range.
+func rangeForm(r *logRange, configured string) *huh.Form {
+	return huh.NewForm(
+		huh.NewGroup(
+			huh.NewSelect[logRangePreset]().
+				Key("preset").
+				Title("Show logs").
+				Options(
+					huh.NewOption("As configured ("+configured+")", rangeConfigured),
+					huh.NewOption("Since the container last started", rangeSinceStart),
+					huh.NewOption("Last 15 minutes", rangeLast15m),
+					huh.NewOption("Last hour", rangeLastHour),
+					huh.NewOption("Last 24 hours", rangeLastDay),
+					huh.NewOption("Custom range…", rangeCustom),
+				).
+				Value(&r.preset),
+		),
+		huh.NewGroup(
+			huh.NewInput().
+				Key("since").
+				Title("Since").
+				Description("Duration ago (2h), RFC 3339 time or day and time (yesterday 14:00). "+
+					"Leave blank for the start of the logs.").
+				Value(&r.since).
+				Validate(validateLogTime),
+
+			huh.NewInput().
+				Key("until").
+				Title("Until").
+				Description("Same formats as since. Leave blank to keep following new lines.").
+				Value(&r.until).
+				Validate(validateLogTime),
+		).WithHideFunc(func() bool { return r.preset != rangeCustom }),
+	)
+}
+
+// configuredRange describes the configured since and tail values.
+func (l *logsPanel) configuredRange() string {
+	parts := []string{"tail " + l.logsCfg.Tail}
+	if l.logsCfg.Since != "" {
+		parts = append(parts, "since "+l.logsCfg.Since)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// rangeFormCmd shows the time range form for the shown logs.
+func (l *logsPanel) rangeFormCmd() tea.Cmd {
+	if len(l.current) == 0 {
+		return nil
+	}
+	r := l.timeRange
+	if r.preset == "" {
+		r.preset = rangeConfigured
+	}
+	rangeForm := form.New("Time Range", rangeForm(&r, l.configuredRange()), func(_ *huh.Form) tea.Cmd {
+		r.since, r.until = strings.TrimSpace(r.since), strings.TrimSpace(r.until)
+		return l.setRange(r)
+	})
+	return func() tea.Msg {
+		return message.ShowFormMsg{Form: rangeForm}
+	}
+}
+
+// setRange shows the logs of range r, reopening them from scratch.
+func (l *logsPanel) setRange(r logRange) tea.Cmd {
+	log.Printf("[containers][logs-panel] time range: %s %q %q", r.preset, r.since, r.until)
+	l.timeRange = r
+	if l.logsSession != nil {
+		l.logsSession.Close()
+		l.logsSession = nil
+	}
+	l.requestID++
+	l.restart.exited = false
+	l.stopTee()
+	l.clearLines()
+	return l.openLogs(l.current, l.rangeOptions())
+}
+
+// clearLines drops the lines received, and what refers to them, before the
+// logs are opened again.
+func (l *logsPanel) clearLines() {
+	l.store.Reset()
+	l.shown = nil
+	l.folds = newLogFolds()
+	l.patterns = newLogPatterns()
+	l.search.matches = nil
+	l.search.current = -1
+	l.list.Reset()
+	l.resize()
+}
+
+// rangeOptions returns the options for the selected time range.
+func (l *logsPanel) rangeOptions() logOptionsFunc {
+	r, opts := l.timeRange, l.logOptions()
+	return func(ctx context.Context, svc client.ContainerService, target logTarget) (client.LogOptions, error) {
+		return r.options(ctx, svc, target, opts)
+	}
+}
@@ -0,0 +1,135 @@
+package containers
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/GustavoCaso/docker-dash/internal/client"
+	"github.com/GustavoCaso/docker-dash/internal/ui/message"
+)
+
+func TestLogRangeOptions(t *testing.T) {
+	ctx := context.Background()
+	svc := client.NewMockClient().Containers()
+	target := logTarget{id: "abc123def456", name: "nginx-proxy"}
+	c, err := svc.Get(ctx, target.id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	configured := client.LogOptions{Follow: true, Tail: "100", Since: "2h"}
+
+	tests := []struct {
+		name string
+		r    logRange
+		want client.LogOptions
+	}{
+		{
+			name: "configured keeps the config",
+			r:    logRange{preset: rangeConfigured},
+			want: configured,
+		},
+		{
+			name: "since start uses the start time",
+			r:    logRange{preset: rangeSinceStart},
+			want: client.LogOptions{Follow: true, Tail: "all", Since: c.StartedAt.Format(time.RFC3339Nano)},
+		},
+		{
+			name: "last hour",
+			r:    logRange{preset: rangeLastHour},
+			want: client.LogOptions{Follow: true, Tail: "all", Since: "1h"},
+		},
+		{
+			name: "custom range ending now keeps following",
+			r:    logRange{preset: rangeCustom, since: "yesterday 14:00"},
+			want: client.LogOptions{Follow: true, Tail: "all", Since: "yesterday 14:00"},
+		},
+		{
+			name: "custom range ending in the past stops following",
+			r:    logRange{preset: rangeCustom, since: "3h", until: "1h"},
+			want: client.LogOptions{Tail: "all", Since: "3h", Until: "1h"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.r.options(ctx, svc, target, configured)
+			if err != nil {
+				t.Fatalf("options() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("options() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+
+	missing := logTarget{id: "missing"}
+	if _, err := (logRange{preset: rangeSinceStart}).options(ctx, svc, missing, configured); err == nil {
+		t.Error("since start should fail for a container that cannot be inspected")
+	}
+}
+
+func TestLogsPanelRangeKeyShowsForm(t *testing.T) {
+	p := newTestLogsPanel()
+	if cmd := p.Update(tea.KeyPressMsg{Code: 't', Text: "t"}); cmd != nil {
+		t.Error("the time range form needs logs to show")
+	}
+
+	p.Init(containerItem{container: client.Container{ID: "abc123def456"}})
+	defer p.Close()
+	cmd := p.Update(tea.KeyPressMsg{Code: 't', Text: "t"})
+	if cmd == nil {
+		t.Fatal("t should show the time range form")
+	}
+	if _, ok := cmd().(message.ShowFormMsg); !ok {
+		t.Error("t should show the time range form")
+	}
+}
+
+func TestLogsPanelSetRangeReopensLogs(t *testing.T) {
+	p := newTestLogsPanel()
+	p.SetSize(200, 50)
+	p.Init(containerItem{container: client.Container{ID: "abc123def456"}})
+	pr, pw := io.Pipe()
+	old := client.NewLogsSession(io.NopCloser(pr), func() { pr.Close(); pw.Close() })
+	p.logsSession = old
+	p.appendLines(stdoutLines("before"))
+	stale := p.requestID
+
+	cmd := p.setRange(logRange{preset: rangeLastHour})
+	if p.logsSession != nil || p.store.Len() != 0 {
+		t.Fatal("changing the range should close the session and clear the lines")
+	}
+	if !strings.Contains(p.View(), "[last 1h]") {
+		t.Errorf("View() should show the time range:\n%s", p.View())
+	}
+
+	if cmd := p.Update(logsOutputMsg{session: old, lines: stdoutLines("late")}); cmd != nil || p.store.Len() != 0 {
+		t.Error("lines of the previous session should be ignored")
+	}
+	pr2, pw2 := io.Pipe()
+	staleSession := client.NewLogsSession(io.NopCloser(pr2), func() { pr2.Close(); pw2.Close() })
+	if cmd := p.Update(logsSessionStartedMsg{requestID: stale, session: staleSession}); cmd != nil ||
+		p.logsSession != nil {
+		t.Error("a session started for the previous range should be ignored")
+	}
+
+	msg, ok := cmd().(logsSessionStartedMsg)
+	if !ok {
+		t.Fatal("changing the range should open the logs again")
+	}
+	p.Update(msg)()
+	if p.logsSession != msg.session {
+		t.Error("the session opened for the new range should be kept")
+	}
+
+	p.Close()
+	p.Init(containerItem{container: client.Container{ID: "def456ghi789"}})
+	defer p.Close()
+	if p.timeRange.preset != rangeLastHour {
+		t.Error("the time range should be kept when another container is selected")
+	}
+}
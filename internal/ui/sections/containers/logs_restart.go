@@ -0,0 +1,94 @@
+package containers
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/GustavoCaso/docker-dash/internal/client"
+)
+
+// restartPollInterval is how often a container whose log stream ended is
+// inspected to catch it restarting.
+const restartPollInterval = 2 * time.Second
+
+// logsRestartCheckMsg carries the state of the followed container after its
+// log stream ended.
+type logsRestartCheckMsg struct {
+	requestID int
+	container client.Container
+	err       error
+}
+
+// logsRestartTickMsg triggers the next restart check.
+type logsRestartTickMsg struct {
+	requestID int
+}
+
+// logRestartWatch follows a single container across restarts: once its log
+// stream ends, the container is inspected until it runs again and the logs
+// are reattached from the restart time.
+type logRestartWatch struct {
+	target    logTarget
+	startedAt time.Time // start of the run whose logs are shown
+	exitCode  int       // last exit code seen while waiting
+	exited    bool      // whether exitCode is known
+}
+
+// watchRestart starts watching for the container to restart after its log
+// stream ended. It returns nil when the logs are not followed or come from
+// several containers.
+func (l *logsPanel) watchRestart() tea.Cmd {
+	if !l.timeRange.follows(l.logsCfg.Follow) || l.restart.target.id == "" {
+		return nil
+	}
+	log.Printf("[containers][logs-panel] stream ended, watching %q for a restart", l.restart.target.id)
+	l.restart.exited = false
+	return l.checkRestartCmd()
+}
+
+func (l *logsPanel) checkRestartCmd() tea.Cmd {
+	ctx, svc := l.ctx, l.client
+	requestID, id := l.requestID, l.restart.target.id
+	return func() tea.Msg {
+		container, err := svc.Get(ctx, id)
+		return logsRestartCheckMsg{requestID: requestID, container: container, err: err}
+	}
+}
+
+// handleRestartCheck reattaches the logs when the container runs again since
+// the shown run, inserting a marker line, or checks again later.
+func (l *logsPanel) handleRestartCheck(msg logsRestartCheckMsg) tea.Cmd {
+	if msg.requestID != l.requestID {
+		return nil
+	}
+	if msg.err != nil {
+		log.Printf("[containers][logs-panel] restart watch stopped: %v", msg.err)
+		return nil
+	}
+	c := msg.container
+	if c.State != client.StateRunning || !c.StartedAt.After(l.restart.startedAt) {
+		if c.State != client.StateRunning {
+			l.restart.exitCode = c.ExitCode
+			l.restart.exited = true
+		}
+		requestID := l.requestID
+		return tea.Tick(restartPollInterval, func(time.Time) tea.Msg {
+			return logsRestartTickMsg{requestID: requestID}
+		})
+	}
+
+	log.Printf("[containers][logs-panel] %q restarted at %s, reattaching", c.ID, c.StartedAt)
+	marker := "— container restarted —"
+	if l.restart.exited {
+		marker = fmt.Sprintf("— container restarted (exit %d) —", l.restart.exitCode)
+	}
+	l.appendMarker(marker)
+	l.restart.startedAt = c.StartedAt
+	opts := l.logOptions()
+	opts.Since = c.StartedAt.Format(time.RFC3339Nano)
+	opts.Tail = "all"
+	return l.openLogs([]logTarget{l.restart.target}, fixedLogOptions(opts))
+}
@@ -0,0 +1,262 @@
+package containers
+
+import (
+	"fmt"
+	"log"
+	"regexp"
+	"sort"
+	"strings"
+
+	"charm.land/bubbles/v2/key"
+	"charm.land/bubbles/v2/textinput"
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/GustavoCaso/docker-dash/internal/ui/keys"
+	"github.com/GustavoCaso/docker-dash/internal/ui/theme"
+)
+
+var searchStatusStyle = lipgloss.NewStyle().Foreground(theme.TextMuted)
+
+// logSearch tracks a regex search over the lines of the logs panel. Matches
+// are stored as line numbers, so the current match stays put while new lines
+// stream in. Lines hidden by the stream or field filter never match.
+type logSearch struct {
+	input   textinput.Model
+	typing  bool
+	re      *regexp.Regexp
+	err     error
+	matches []int // numbers of the matching lines in logsPanel.store, ascending
+	current int   // index into matches, -1 until the first jump
+	filter  bool  // show only the matching lines
+}
+
+func newLogSearch() logSearch {
+	ti := textinput.New()
+	ti.Prompt = "/"
+	ti.Placeholder = "regex"
+	return logSearch{input: ti, current: -1}
+}
+
+// active reports whether a search pattern is applied.
+func (s *logSearch) active() bool {
+	return s.re != nil
+}
+
+// handleSearchKey handles the search keys. It reports false when the key is
+// not a search key so the caller can forward it to the list.
+func (l *logsPanel) handleSearchKey(msg tea.KeyPressMsg) (bool, tea.Cmd) {
+	if l.search.typing {
+		return true, l.updateSearchInput(msg)
+	}
+	switch {
+	case key.Matches(msg, keys.Keys.LogSearch):
+		l.search.typing = true
+		l.search.err = nil
+		l.resize()
+		return true, l.search.input.Focus()
+	case key.Matches(msg, keys.Keys.LogSearchNext) && l.search.active():
+		l.jumpToMatch(1)
+		return true, nil
+	case key.Matches(msg, keys.Keys.LogSearchPrev) && l.search.active():
+		l.jumpToMatch(-1)
+		return true, nil
+	case key.Matches(msg, keys.Keys.LogSearchFilter) && l.search.active():
+		l.toggleSearchFilter()
+		return true, nil
+	case key.Matches(msg, keys.Keys.Esc) && l.search.active():
+		l.clearSearch()
+		return true, nil
+	}
+	return false, nil
+}
+
+// updateSearchInput handles a key while the search query is being typed.
+func (l *logsPanel) updateSearchInput(msg tea.KeyPressMsg) tea.Cmd {
+	switch {
+	case key.Matches(msg, keys.Keys.Esc):
+		l.search.typing = false
+		l.search.err = nil
+		l.search.input.Blur()
+		if l.search.active() {
+			l.search.input.SetValue(l.search.re.String())
+		} else {
+			l.search.input.SetValue("")
+		}
+		l.resize()
+		return nil
+	case key.Matches(msg, keys.Keys.Enter):
+		query := l.search.input.Value()
+		if query == "" {
+			l.search.typing = false
+			l.search.input.Blur()
+			l.clearSearch()
+			return nil
+		}
+		re, err := regexp.Compile(query)
+		if err != nil {
+			l.search.err = err
+			return nil
+		}
+		log.Printf("[containers][logs-panel] search: pattern=%q", query)
+		l.search.typing = false
+		l.search.err = nil
+		l.search.input.Blur()
+		l.applySearch(re)
+		return nil
+	}
+	var cmd tea.Cmd
+	l.search.input, cmd = l.search.input.Update(msg)
+	return cmd
+}
+
+// matches reports whether entry matches the active search and passes the
+// stream and field filters.
+func (l *logsPanel) matches(entry logEntry) bool {
+	return l.search.active() && l.passesFilters(entry) && l.search.re.MatchString(plainContent(entry.Content))
+}
+
+// plainContent returns content without its ANSI escape sequences, as it
+// reads on screen.
+func plainContent(content string) string {
+	if !strings.ContainsRune(content, ansi.ESC) {
+		return content
+	}
+	return ansi.Strip(content)
+}
+
+// findMatches recomputes the matching lines.
+func (l *logsPanel) findMatches() {
+	l.search.matches = l.search.matches[:0]
+	l.store.Each(l.store.First(), func(n int, entry logEntry) bool {
+		if l.matches(entry) {
+			l.search.matches = append(l.search.matches, n)
+		}
+		return true
+	})
+}
+
+// applySearch finds the lines matching re and jumps to the first match at or
+// after the selected line.
+func (l *logsPanel) applySearch(re *regexp.Regexp) {
+	from := l.selectedLine()
+	l.search.re = re
+	l.findMatches()
+	l.list.SetHighlight(re)
+	if l.search.filter {
+		l.rebuildList()
+	}
+	l.resize()
+
+	l.search.current = -1
+	if len(l.search.matches) == 0 {
+		return
+	}
+	l.search.current = sort.SearchInts(l.search.matches, max(from, 0)) % len(l.search.matches)
+	l.unfoldLine(l.search.matches[l.search.current])
+	l.list.Select(l.rowOf(l.search.matches[l.search.current]))
+}
+
+// clearSearch drops the search pattern and shows every line again.
+func (l *logsPanel) clearSearch() {
+	selected := l.selectedLine()
+	wasFiltered := l.search.filter
+	l.search.re = nil
+	l.search.err = nil
+	l.search.matches = nil
+	l.search.current = -1
+	l.search.filter = false
+	l.search.input.SetValue("")
+	l.list.SetHighlight(nil)
+	if wasFiltered {
+		l.rebuildList()
+		if selected >= 0 {
+			l.list.Select(l.rowOf(selected))
+		}
+	}
+	l.resize()
+}
+
+// jumpToMatch moves the selection by delta matches, wrapping around.
+func (l *logsPanel) jumpToMatch(delta int) {
+	n := len(l.search.matches)
+	if n == 0 {
+		return
+	}
+	if l.search.current < 0 {
+		l.search.current = 0
+	} else {
+		l.search.current = ((l.search.current+delta)%n + n) % n
+	}
+	l.unfoldLine(l.search.matches[l.search.current])
+	l.list.Select(l.rowOf(l.search.matches[l.search.current]))
+}
+
+// toggleSearchFilter switches between showing every line and only the lines
+// matching the search, keeping the selection on the same line when possible.
+func (l *logsPanel) toggleSearchFilter() {
+	selected := l.selectedLine()
+	l.search.filter = !l.search.filter
+	l.rebuildList()
+	if selected >= 0 {
+		l.list.Select(l.rowOf(selected))
+	}
+}
+
+// statusBarVisible reports whether the status bar takes a line below the logs.
+func (l *logsPanel) statusBarVisible() bool {
+	return l.search.typing || l.search.active() || l.streams != streamsBoth ||
+		l.fields.typing || l.fields.filter != nil || l.store.Dropped() > 0 || l.timeRange.String() != "" ||
+		l.patternsStatus() != ""
+}
+
+// statusBarView renders the search or field filter input while typing, or the
+// search, stream and field filter status, the time range and the number of
+// dropped lines.
+func (l *logsPanel) statusBarView() string {
+	if l.fields.typing {
+		return l.fieldsStatus()
+	}
+	if l.patterns.open {
+		return searchStatusStyle.Render(l.patternsStatus())
+	}
+	if l.search.typing {
+		if l.search.err != nil {
+			return l.search.input.View() + "  " + theme.StatusErrorStyle.Render(l.search.err.Error())
+		}
+		return l.search.input.View()
+	}
+	var parts []string
+	if l.search.active() {
+		status := "/" + l.search.re.String() + "  "
+		switch {
+		case len(l.search.matches) == 0:
+			status += "no matches"
+		case l.search.current < 0:
+			status += fmt.Sprintf("%d matches", len(l.search.matches))
+		default:
+			status += fmt.Sprintf("match %d/%d", l.search.current+1, len(l.search.matches))
+		}
+		parts = append(parts, status)
+		if l.search.filter {
+			parts = append(parts, "[only matching]")
+		}
+	}
+	if l.streams != streamsBoth {
+		parts = append(parts, "["+l.streams.String()+"]")
+	}
+	if status := l.fieldsStatus(); status != "" {
+		parts = append(parts, status)
+	}
+	if status := l.patternsStatus(); status != "" {
+		parts = append(parts, status)
+	}
+	if r := l.timeRange.String(); r != "" {
+		parts = append(parts, "["+r+"]")
+	}
+	if dropped := l.store.Dropped(); dropped > 0 {
+		parts = append(parts, fmt.Sprintf("[%d older lines dropped]", dropped))
+	}
+	return searchStatusStyle.Render(strings.Join(parts, "  "))
+}
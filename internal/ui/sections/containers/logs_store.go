@@ -0,0 +1,365 @@
+package containers
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+	"log"
+	"os"
+	"time"
+
+	"github.com/GustavoCaso/docker-dash/internal/client"
+	"github.com/GustavoCaso/docker-dash/internal/config"
+)
+
+// minLogRingSize is the initial capacity of the log ring buffer. It doubles
+// as lines arrive, up to the configured maximum.
+const minLogRingSize = 1024
+
+// logStore keeps the lines received by the logs panel in a ring buffer bounded
+// by the retention limits. Lines are numbered in the order they were received.
+// Once a limit is reached the oldest line is dropped or, when spilling, moved
+// to a temporary file from which it is read back on demand.
+type logStore struct {
+	maxLines int
+	maxBytes int64
+	canSpill bool // spilling is configured
+	spill    bool // spilling is configured and has not failed
+	mapping  config.LogFieldsConfig
+
+	ring  []logEntry
+	head  int   // ring index of the oldest line in memory
+	size  int   // number of lines in memory
+	bytes int64 // size of the lines in memory
+
+	first   int // number of the oldest line still available
+	inMem   int // number of the oldest line in memory
+	next    int // number of the next line appended
+	dropped int // lines dropped since the store was reset
+
+	file    *os.File // spilled lines, as JSON lines
+	w       *bufio.Writer
+	offsets []int64 // file offset of each spilled line
+	end     int64   // size of the spilled lines
+}
+
+func newLogStore(retention config.LogRetentionConfig, mapping config.LogFieldsConfig) *logStore {
+	maxBytes, err := retention.Bytes()
+	if err != nil {
+		log.Printf("[containers][logs-panel] %v, keeping logs without a size limit", err)
+	}
+	return &logStore{
+		maxLines: retention.MaxLines,
+		maxBytes: maxBytes,
+		canSpill: retention.Spill,
+		spill:    retention.Spill,
+		mapping:  mapping,
+	}
+}
+
+// Len returns the number of lines available, in memory or spilled.
+func (s *logStore) Len() int {
+	return s.next - s.first
+}
+
+// First returns the number of the oldest line available.
+func (s *logStore) First() int {
+	return s.first
+}
+
+// Next returns the number the next appended line gets.
+func (s *logStore) Next() int {
+	return s.next
+}
+
+// Dropped returns the number of lines dropped to stay within the limits.
+func (s *logStore) Dropped() int {
+	return s.dropped
+}
+
+// Append adds entry and evicts the oldest lines over the limits. It returns
+// the number of lines that are no longer available.
+func (s *logStore) Append(entry logEntry) int {
+	first := s.first
+	if s.size == len(s.ring) {
+		s.grow()
+	}
+	if s.size == len(s.ring) {
+		s.evict()
+	}
+	s.ring[(s.head+s.size)%len(s.ring)] = entry
+	s.size++
+	s.bytes += entrySize(entry)
+	s.next++
+	for s.size > 1 && (s.maxLines > 0 && s.size > s.maxLines || s.maxBytes > 0 && s.bytes > s.maxBytes) {
+		s.evict()
+	}
+	return s.first - first
+}
+
+// grow doubles the ring capacity, up to maxLines.
+func (s *logStore) grow() {
+	capacity := max(len(s.ring)*2, minLogRingSize)
+	if s.maxLines > 0 {
+		capacity = min(capacity, s.maxLines)
+	}
+	if capacity <= len(s.ring) {
+		return
+	}
+	ring := make([]logEntry, capacity)
+	for i := range s.size {
+		ring[i] = s.ring[(s.head+i)%len(s.ring)]
+	}
+	s.ring = ring
+	s.head = 0
+}
+
+// evict removes the oldest line from memory, spilling it when enabled.
+func (s *logStore) evict() {
+	entry := s.ring[s.head]
+	s.ring[s.head] = logEntry{}
+	s.head = (s.head + 1) % len(s.ring)
+	s.size--
+	s.bytes -= entrySize(entry)
+	s.inMem++
+	if s.spill && s.spillEntry(entry) {
+		return
+	}
+	s.dropped += s.inMem - s.first
+	s.first = s.inMem
+}
+
+// spillEntry writes entry to the spill file. It reports false, giving up on
+// spilling, when the file cannot be written.
+func (s *logStore) spillEntry(entry logEntry) bool {
+	if s.file == nil {
+		file, err := os.CreateTemp("", "docker-dash-logs-*.jsonl")
+		if err != nil {
+			log.Printf("[containers][logs-panel] spill file: %v", err)
+			s.spill = false
+			return false
+		}
+		log.Printf("[containers][logs-panel] spilling old lines to %q", file.Name())
+		s.file = file
+		s.w = bufio.NewWriter(file)
+	}
+	data, err := encodeLogEntry(entry)
+	if err == nil {
+		_, err = s.w.Write(data)
+	}
+	if err != nil {
+		log.Printf("[containers][logs-panel] spill: %v, dropping old lines", err)
+		s.closeSpill()
+		s.spill = false
+		return false
+	}
+	s.offsets = append(s.offsets, s.end)
+	s.end += int64(len(data))
+	return true
+}
+
+// At returns the line numbered n, which must be available.
+func (s *logStore) At(n int) logEntry {
+	if n >= s.inMem {
+		return s.ring[(s.head+n-s.inMem)%len(s.ring)]
+	}
+	i := n - s.first
+	end := s.end
+	if i+1 < len(s.offsets) {
+		end = s.offsets[i+1]
+	}
+	data := make([]byte, end-s.offsets[i])
+	if err := s.flush(); err != nil {
+		return unavailableLogEntry(err)
+	}
+	if _, err := s.file.ReadAt(data, s.offsets[i]); err != nil {
+		return unavailableLogEntry(err)
+	}
+	entry, err := decodeLogEntry(data, s.mapping)
+	if err != nil {
+		return unavailableLogEntry(err)
+	}
+	return entry
+}
+
+// Each calls fn for every available line from the one numbered from, until
+// fn returns false.
+func (s *logStore) Each(from int, fn func(n int, entry logEntry) bool) {
+	from = max(from, s.first)
+	if from < s.inMem {
+		if err := s.flush(); err != nil {
+			log.Printf("[containers][logs-panel] spill: %v", err)
+			return
+		}
+		start := s.offsets[from-s.first]
+		r := io.NewSectionReader(s.file, start, s.end-start)
+		done, err := eachSpilled(r, s.mapping, func(i int, entry logEntry) bool {
+			return fn(from+i, entry)
+		})
+		if err != nil {
+			log.Printf("[containers][logs-panel] reading spilled lines: %v", err)
+		}
+		if !done {
+			return
+		}
+		from = s.inMem
+	}
+	for n := from; n < s.next; n++ {
+		if !fn(n, s.ring[(s.head+n-s.inMem)%len(s.ring)]) {
+			return
+		}
+	}
+}
+
+// snapshot returns the available lines as they are now, for reading outside
+// the UI goroutine.
+func (s *logStore) snapshot() logSnapshot {
+	snap := logSnapshot{mapping: s.mapping, lines: make([]logEntry, 0, s.size)}
+	for i := range s.size {
+		snap.lines = append(snap.lines, s.ring[(s.head+i)%len(s.ring)])
+	}
+	if s.inMem > s.first {
+		if err := s.flush(); err != nil {
+			snap.err = err
+		} else {
+			snap.spilled = io.NewSectionReader(s.file, s.offsets[0], s.end-s.offsets[0])
+		}
+	}
+	return snap
+}
+
+func (s *logStore) flush() error {
+	if s.w == nil {
+		return nil
+	}
+	return s.w.Flush()
+}
+
+// Reset removes every line and the spill file.
+func (s *logStore) Reset() {
+	s.closeSpill()
+	*s = logStore{
+		maxLines: s.maxLines,
+		maxBytes: s.maxBytes,
+		canSpill: s.canSpill,
+		spill:    s.canSpill,
+		mapping:  s.mapping,
+	}
+}
+
+func (s *logStore) closeSpill() {
+	if s.file == nil {
+		return
+	}
+	name := s.file.Name()
+	if err := s.file.Close(); err != nil {
+		log.Printf("[containers][logs-panel] closing spill file: %v", err)
+	}
+	if err := os.Remove(name); err != nil {
+		log.Printf("[containers][logs-panel] removing spill file: %v", err)
+	}
+	s.file = nil
+	s.w = nil
+	s.offsets = nil
+	s.end = 0
+	s.dropped += s.inMem - s.first
+	s.first = s.inMem
+}
+
+// logSnapshot holds the lines of a logStore at a point in time: the spilled
+// lines, which are never rewritten, followed by a copy of the lines in memory.
+type logSnapshot struct {
+	spilled *io.SectionReader
+	lines   []logEntry
+	mapping config.LogFieldsConfig
+	err     error
+}
+
+// Each calls fn for every line of the snapshot until fn returns false. It
+// fails when the spilled lines cannot be read, e.g. because the store was
+// reset.
+func (s logSnapshot) Each(fn func(entry logEntry) bool) error {
+	if s.err != nil {
+		return s.err
+	}
+	if s.spilled != nil {
+		done, err := eachSpilled(s.spilled, s.mapping, func(_ int, entry logEntry) bool {
+			return fn(entry)
+		})
+		if err != nil || !done {
+			return err
+		}
+	}
+	for _, entry := range s.lines {
+		if !fn(entry) {
+			return nil
+		}
+	}
+	return nil
+}
+
+// eachSpilled decodes the spilled lines of r, calling fn with their position
+// until fn returns false. It reports whether every line was visited.
+func eachSpilled(r io.Reader, mapping config.LogFieldsConfig, fn func(i int, entry logEntry) bool) (bool, error) {
+	sc := bufio.NewScanner(r)
+	sc.Buffer(nil, maxSpilledLineSize)
+	for i := 0; sc.Scan(); i++ {
+		entry, err := decodeLogEntry(sc.Bytes(), mapping)
+		if err != nil {
+			return false, err
+		}
+		if !fn(i, entry) {
+			return false, nil
+		}
+	}
+	return sc.Err() == nil, sc.Err()
+}
+
+// maxSpilledLineSize bounds the size of a spilled line read back.
+const maxSpilledLineSize = 64 * 1024 * 1024
+
+// entrySize approximates the memory held by entry.
+func entrySize(entry logEntry) int64 {
+	return int64(len(entry.Content) + len(entry.Source))
+}
+
+// encodeLogEntry encodes entry as a JSON line, in the same format as the
+// logs export.
+func encodeLogEntry(entry logEntry) ([]byte, error) {
+	var b bytes.Buffer
+	if err := writeLogEntry(&b, exportJSONLines, entry); err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
+
+// decodeLogEntry decodes a line written by encodeLogEntry.
+func decodeLogEntry(data []byte, mapping config.LogFieldsConfig) (logEntry, error) {
+	var line exportedLogLine
+	if err := json.Unmarshal(data, &line); err != nil {
+		return logEntry{}, err
+	}
+	entry := logEntry{
+		LogLine:      client.LogLine{Content: line.Message, Source: line.Source, Stream: client.LogStream(line.Stream)},
+		marker:       line.Marker,
+		continuation: line.Continuation,
+	}
+	if line.Time != "" {
+		t, err := time.Parse(time.RFC3339Nano, line.Time)
+		if err != nil {
+			return logEntry{}, err
+		}
+		entry.Timestamp = t
+	}
+	if !entry.marker {
+		entry.structured = parseStructuredLog(entry.Content, mapping)
+	}
+	return entry, nil
+}
+
+// unavailableLogEntry stands for a spilled line that cannot be read back.
+func unavailableLogEntry(err error) logEntry {
+	log.Printf("[containers][logs-panel] reading spilled line: %v", err)
+	return logEntry{LogLine: client.LogLine{Content: "— line unavailable: " + err.Error() + " —"}, marker: true}
+}
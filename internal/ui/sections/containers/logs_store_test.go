@@ -0,0 +1,156 @@
+package containers
+
+import (
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/GustavoCaso/docker-dash/internal/client"
+	"github.com/GustavoCaso/docker-dash/internal/config"
+)
+
+func newTestLogStore(retention config.LogRetentionConfig) *logStore {
+	return newLogStore(retention, config.DefaultLogsConfig().Fields)
+}
+
+func appendNumbered(store *logStore, from, to int) int {
+	dropped := 0
+	for i := from; i < to; i++ {
+		dropped += store.Append(logEntry{LogLine: client.LogLine{Content: "line " + strconv.Itoa(i)}})
+	}
+	return dropped
+}
+
+func storedContents(store *logStore) []string {
+	var contents []string
+	store.Each(store.First(), func(_ int, entry logEntry) bool {
+		contents = append(contents, entry.Content)
+		return true
+	})
+	return contents
+}
+
+func TestLogStoreDropsOldestLinesOverMaxLines(t *testing.T) {
+	store := newTestLogStore(config.LogRetentionConfig{MaxLines: 3})
+
+	if dropped := appendNumbered(store, 0, 5); dropped != 2 {
+		t.Errorf("Append() dropped %d lines, want 2", dropped)
+	}
+	if store.Len() != 3 || store.First() != 2 || store.Next() != 5 {
+		t.Errorf("Len, First, Next = %d, %d, %d, want 3, 2, 5", store.Len(), store.First(), store.Next())
+	}
+	if got := store.At(2).Content; got != "line 2" {
+		t.Errorf("At(2) = %q, want %q", got, "line 2")
+	}
+	if got := strings.Join(storedContents(store), ","); got != "line 2,line 3,line 4" {
+		t.Errorf("Each() = %s, want the 3 newest lines", got)
+	}
+	if store.Dropped() != 2 {
+		t.Errorf("Dropped() = %d, want 2", store.Dropped())
+	}
+}
+
+func TestLogStoreDropsOldestLinesOverMaxBytes(t *testing.T) {
+	store := newTestLogStore(config.LogRetentionConfig{MaxBytes: "20b"})
+
+	appendNumbered(store, 0, 10) // "line N" is 6 bytes
+	if store.Len() != 3 {
+		t.Errorf("Len() = %d, want the 3 lines that fit in 20 bytes", store.Len())
+	}
+	if got := store.At(store.Next() - 1).Content; got != "line 9" {
+		t.Errorf("newest line = %q, want %q", got, "line 9")
+	}
+}
+
+func TestLogStoreGrowsRingInOrder(t *testing.T) {
+	store := newTestLogStore(config.LogRetentionConfig{})
+
+	appendNumbered(store, 0, minLogRingSize*3)
+	if store.Len() != minLogRingSize*3 || store.Dropped() != 0 {
+		t.Fatalf("Len() = %d, Dropped() = %d, want every line kept", store.Len(), store.Dropped())
+	}
+	for _, n := range []int{0, minLogRingSize, minLogRingSize*3 - 1} {
+		if got, want := store.At(n).Content, "line "+strconv.Itoa(n); got != want {
+			t.Errorf("At(%d) = %q, want %q", n, got, want)
+		}
+	}
+}
+
+func TestLogStoreSpillsOldLinesToFile(t *testing.T) {
+	store := newTestLogStore(config.LogRetentionConfig{MaxLines: 2, Spill: true})
+	store.Append(logEntry{LogLine: client.LogLine{Content: `{"level":"error","msg":"boom"}`,
+		Stream: client.LogStreamStderr, Source: "api"}})
+	store.Append(logEntry{LogLine: client.LogLine{Content: "— restarted —"}, marker: true})
+
+	if dropped := appendNumbered(store, 2, 6); dropped != 0 {
+		t.Errorf("Append() dropped %d lines while spilling, want 0", dropped)
+	}
+	if store.Len() != 6 || store.First() != 0 {
+		t.Fatalf("Len() = %d, First() = %d, want every line available", store.Len(), store.First())
+	}
+
+	first := store.At(0)
+	if first.Content != `{"level":"error","msg":"boom"}` || first.Stream != client.LogStreamStderr ||
+		first.Source != "api" || first.structured == nil {
+		t.Errorf("At(0) = %+v, want the spilled stderr JSON line, parsed", first)
+	}
+	if !store.At(1).marker {
+		t.Error("At(1) should keep the marker flag through the spill file")
+	}
+	if got := store.At(3).Content; got != "line 3" {
+		t.Errorf("At(3) = %q, want %q", got, "line 3")
+	}
+	if got := storedContents(store); len(got) != 6 || got[5] != "line 5" {
+		t.Errorf("Each() = %v, want the spilled lines followed by the ones in memory", got)
+	}
+
+	var fromSpill []string
+	store.Each(3, func(_ int, entry logEntry) bool {
+		fromSpill = append(fromSpill, entry.Content)
+		return true
+	})
+	if strings.Join(fromSpill, ",") != "line 3,line 4,line 5" {
+		t.Errorf("Each(3) = %v, want lines 3 to 5", fromSpill)
+	}
+
+	name := store.file.Name()
+	store.Reset()
+	if _, err := os.Stat(name); !os.IsNotExist(err) {
+		t.Errorf("Reset() should remove the spill file, Stat() = %v", err)
+	}
+	if store.Len() != 0 || !store.spill {
+		t.Errorf("Reset() should empty the store and keep spilling, Len() = %d", store.Len())
+	}
+}
+
+func TestLogSnapshotKeepsLinesAppendedLater(t *testing.T) {
+	store := newTestLogStore(config.LogRetentionConfig{MaxLines: 2, Spill: true})
+	appendNumbered(store, 0, 4)
+
+	snap := store.snapshot()
+	appendNumbered(store, 4, 8)
+
+	var got []string
+	if err := snap.Each(func(entry logEntry) bool {
+		got = append(got, entry.Content)
+		return true
+	}); err != nil {
+		t.Fatalf("snapshot Each() error = %v", err)
+	}
+	if strings.Join(got, ",") != "line 0,line 1,line 2,line 3" {
+		t.Errorf("snapshot = %v, want the 4 lines stored when it was taken", got)
+	}
+	store.Reset()
+}
+
+func TestLogStoreSpillKeepsContinuationLines(t *testing.T) {
+	store := newTestLogStore(config.LogRetentionConfig{MaxLines: 1, Spill: true})
+	store.Append(logEntry{LogLine: client.LogLine{Content: "\tat Main.main"}, continuation: true})
+	appendNumbered(store, 1, 2)
+
+	if !store.At(0).continuation {
+		t.Error("At(0) should keep the continuation flag through the spill file")
+	}
+	store.Reset()
+}
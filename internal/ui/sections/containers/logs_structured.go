@@ -0,0 +1,368 @@
+package containers
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+
+	"charm.land/lipgloss/v2"
+
+	"github.com/GustavoCaso/docker-dash/internal/config"
+	"github.com/GustavoCaso/docker-dash/internal/ui/theme"
+)
+
+// levelColumnWidth is the width of the level column of structured lines.
+const levelColumnWidth = 5
+
+// minLogfmtPairs is the number of key=value pairs a line needs to be treated
+// as logfmt, so plain lines with a stray "=" are left alone.
+const minLogfmtPairs = 2
+
+// Numeric levels used by pino and bunyan: 10 trace, 20 debug, ... 60 fatal.
+const (
+	pinoLevelStep = 10
+	pinoMinLevel  = 10
+	pinoMaxLevel  = 60
+)
+
+type logFormat int
+
+const (
+	logFormatJSON logFormat = iota + 1
+	logFormatLogfmt
+)
+
+func (f logFormat) String() string {
+	if f == logFormatJSON {
+		return "JSON"
+	}
+	return "logfmt"
+}
+
+// logField is a key and its value in a structured log line. Nested JSON
+// objects are flattened into dotted keys.
+type logField struct {
+	key   string
+	value string
+}
+
+// structuredLog is a log line parsed as JSON or logfmt. level, message and
+// timestamp hold the fields picked by the configured field mapping.
+type structuredLog struct {
+	format    logFormat
+	fields    []logField
+	level     *logField
+	message   *logField
+	timestamp *logField
+}
+
+// parseStructuredLog parses content as a JSON object or a logfmt line and
+// picks its columns with mapping. It returns nil for any other line.
+func parseStructuredLog(content string, mapping config.LogFieldsConfig) *structuredLog {
+	content = strings.TrimSpace(content)
+	var s *structuredLog
+	if strings.HasPrefix(content, "{") {
+		fields, err := parseJSONFields(content)
+		if err != nil {
+			return nil
+		}
+		s = &structuredLog{format: logFormatJSON, fields: fields}
+	} else {
+		fields, ok := parseLogfmtFields(content)
+		if !ok {
+			return nil
+		}
+		s = &structuredLog{format: logFormatLogfmt, fields: fields}
+	}
+	s.level = s.lookupAny(mapping.Level)
+	s.message = s.lookupAny(mapping.Message)
+	s.timestamp = s.lookupAny(mapping.Timestamp)
+	return s
+}
+
+// lookup returns the field with key, or nil when the line does not have it.
+func (s *structuredLog) lookup(key string) *logField {
+	for i := range s.fields {
+		if s.fields[i].key == key {
+			return &s.fields[i]
+		}
+	}
+	return nil
+}
+
+// lookupAny returns the first of keys present in the line.
+func (s *structuredLog) lookupAny(keys []string) *logField {
+	for _, key := range keys {
+		if f := s.lookup(key); f != nil {
+			return f
+		}
+	}
+	return nil
+}
+
+// parseJSONFields decodes a JSON object keeping the order of its keys.
+func parseJSONFields(content string) ([]logField, error) {
+	dec := json.NewDecoder(strings.NewReader(content))
+	dec.UseNumber()
+	var fields []logField
+	if err := decodeJSONObject(dec, "", &fields); err != nil {
+		return nil, err
+	}
+	if _, err := dec.Token(); !errors.Is(err, io.EOF) {
+		return nil, errors.New("trailing data after JSON object")
+	}
+	return fields, nil
+}
+
+// decodeJSONObject appends the fields of the next object in dec to fields,
+// prefixing their keys with prefix.
+func decodeJSONObject(dec *json.Decoder, prefix string, fields *[]logField) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if delim, ok := tok.(json.Delim); !ok || delim != '{' {
+		return errors.New("not a JSON object")
+	}
+	for dec.More() {
+		tok, err = dec.Token()
+		if err != nil {
+			return err
+		}
+		key, _ := tok.(string)
+		var raw json.RawMessage
+		if err = dec.Decode(&raw); err != nil {
+			return err
+		}
+		if bytes.HasPrefix(raw, []byte("{")) && len(raw) > len("{}") {
+			nested := json.NewDecoder(bytes.NewReader(raw))
+			nested.UseNumber()
+			if err = decodeJSONObject(nested, prefix+key+".", fields); err != nil {
+				return err
+			}
+			continue
+		}
+		*fields = append(*fields, logField{key: prefix + key, value: jsonValueString(raw)})
+	}
+	_, err = dec.Token()
+	return err
+}
+
+// jsonValueString renders a JSON value: strings unquoted, anything else as
+// compact JSON.
+func jsonValueString(raw json.RawMessage) string {
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		return s
+	}
+	var b bytes.Buffer
+	if err := json.Compact(&b, raw); err != nil {
+		return string(raw)
+	}
+	return b.String()
+}
+
+// parseLogfmtFields parses a line made only of key=value pairs, where values
+// may be double quoted.
+func parseLogfmtFields(content string) ([]logField, bool) {
+	var fields []logField
+	rest := content
+	for {
+		rest = strings.TrimLeft(rest, " ")
+		if rest == "" {
+			break
+		}
+		eq := strings.IndexByte(rest, '=')
+		if eq <= 0 || strings.ContainsAny(rest[:eq], " \"") {
+			return nil, false
+		}
+		key := rest[:eq]
+		rest = rest[eq+1:]
+
+		var value string
+		if strings.HasPrefix(rest, `"`) {
+			end := closingQuote(rest)
+			if end < 0 {
+				return nil, false
+			}
+			unquoted, err := strconv.Unquote(rest[:end+1])
+			if err != nil {
+				return nil, false
+			}
+			value = unquoted
+			rest = rest[end+1:]
+			if rest != "" && rest[0] != ' ' {
+				return nil, false
+			}
+		} else {
+			end := strings.IndexByte(rest, ' ')
+			if end < 0 {
+				end = len(rest)
+			}
+			value = rest[:end]
+			rest = rest[end:]
+		}
+		fields = append(fields, logField{key: key, value: value})
+	}
+	if len(fields) < minLogfmtPairs {
+		return nil, false
+	}
+	return fields, true
+}
+
+// closingQuote returns the index of the quote closing the quoted string at
+// the start of s, or -1 when it is not closed.
+func closingQuote(s string) int {
+	for i := 1; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '"':
+			return i
+		}
+	}
+	return -1
+}
+
+// logLevel orders log levels by severity.
+type logLevel int
+
+const (
+	levelTrace logLevel = iota
+	levelDebug
+	levelInfo
+	levelWarn
+	levelError
+	levelFatal
+)
+
+var logLevelNames = map[string]logLevel{
+	"trace":       levelTrace,
+	"debug":       levelDebug,
+	"dbg":         levelDebug,
+	"info":        levelInfo,
+	"information": levelInfo,
+	"notice":      levelInfo,
+	"warn":        levelWarn,
+	"warning":     levelWarn,
+	"error":       levelError,
+	"err":         levelError,
+	"fatal":       levelFatal,
+	"panic":       levelFatal,
+	"critical":    levelFatal,
+	"crit":        levelFatal,
+	"alert":       levelFatal,
+	"emerg":       levelFatal,
+}
+
+// parseLogLevel parses a level name, case-insensitively, or a pino numeric
+// level.
+func parseLogLevel(s string) (logLevel, bool) {
+	if level, ok := logLevelNames[strings.ToLower(strings.TrimSpace(s))]; ok {
+		return level, true
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil || n < pinoMinLevel || n > pinoMaxLevel {
+		return 0, false
+	}
+	return logLevel(n/pinoLevelStep - 1), true
+}
+
+func (l logLevel) String() string {
+	switch l {
+	case levelTrace:
+		return "TRACE"
+	case levelDebug:
+		return "DEBUG"
+	case levelInfo:
+		return "INFO"
+	case levelWarn:
+		return "WARN"
+	case levelError:
+		return "ERROR"
+	default:
+		return "FATAL"
+	}
+}
+
+// levelStyle returns the style for a line of the given level, or nil when
+// the line keeps the default color.
+func levelStyle(s *structuredLog) *lipgloss.Style {
+	if s.level == nil {
+		return nil
+	}
+	level, ok := parseLogLevel(s.level.value)
+	if !ok {
+		return nil
+	}
+	switch {
+	case level >= levelError:
+		return &theme.LogErrorStyle
+	case level == levelWarn:
+		return &theme.LogWarnStyle
+	case level <= levelDebug:
+		return &theme.LogDebugStyle
+	default:
+		return nil
+	}
+}
+
+// formatStructured renders s as columns: timestamp, level and message, then
+// the remaining fields as key=value pairs.
+func formatStructured(s *structuredLog) string {
+	var parts []string
+	if s.timestamp != nil {
+		parts = append(parts, s.timestamp.value)
+	}
+	if s.level != nil {
+		name := strings.ToUpper(s.level.value)
+		if level, ok := parseLogLevel(s.level.value); ok {
+			name = level.String()
+		}
+		parts = append(parts, padRight(name, levelColumnWidth))
+	}
+	if s.message != nil {
+		parts = append(parts, s.message.value)
+	}
+	for i := range s.fields {
+		f := &s.fields[i]
+		if f == s.level || f == s.message || f == s.timestamp {
+			continue
+		}
+		parts = append(parts, f.key+"="+logfmtValue(f.value))
+	}
+	return strings.Join(parts, " ")
+}
+
+// formatStructuredDetail renders every field of s on its own line.
+func formatStructuredDetail(s *structuredLog) string {
+	width := 0
+	for _, f := range s.fields {
+		width = max(width, len(f.key))
+	}
+	var b strings.Builder
+	b.WriteString(s.format.String() + " log line (enter/esc to close)\n\n")
+	for _, f := range s.fields {
+		b.WriteString(padRight(f.key+":", width+1) + " " + f.value + "\n")
+	}
+	return b.String()
+}
+
+// logfmtValue quotes value when it is empty or has spaces, so each field
+// still reads as a single value.
+func logfmtValue(value string) string {
+	if value == "" || strings.ContainsAny(value, " \t") {
+		return strconv.Quote(value)
+	}
+	return value
+}
+
+func padRight(s string, width int) string {
+	if len(s) >= width {
+		return s
+	}
+	return s + strings.Repeat(" ", width-len(s))
+}
@@ -0,0 +1,150 @@
+package containers
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/GustavoCaso/docker-dash/internal/config"
+)
+
+func TestParseStructuredLogJSON(t *testing.T) {
+	mapping := config.DefaultLogsConfig().Fields
+	s := parseStructuredLog(
+		`{"ts":"2026-01-02T03:04:05Z","level":"warn","msg":"slow query","db":{"name":"app","ms":812},"tags":["a","b"]}`,
+		mapping,
+	)
+	if s == nil {
+		t.Fatal("parseStructuredLog() = nil, want a JSON line")
+	}
+	if s.format != logFormatJSON {
+		t.Errorf("format = %v, want JSON", s.format)
+	}
+	if s.level.value != "warn" || s.message.value != "slow query" || s.timestamp.value != "2026-01-02T03:04:05Z" {
+		t.Errorf("columns = %v %v %v, want mapped fields", s.level, s.message, s.timestamp)
+	}
+	if f := s.lookup("db.ms"); f == nil || f.value != "812" {
+		t.Errorf("db.ms = %v, want nested field flattened", f)
+	}
+	if f := s.lookup("tags"); f == nil || f.value != `["a","b"]` {
+		t.Errorf("tags = %v, want compact JSON", f)
+	}
+
+	want := `2026-01-02T03:04:05Z WARN  slow query db.name=app db.ms=812 tags=["a","b"]`
+	if got := formatStructured(s); got != want {
+		t.Errorf("formatStructured() = %q, want %q", got, want)
+	}
+}
+
+func TestParseStructuredLogLogfmt(t *testing.T) {
+	s := parseStructuredLog(`level=error msg="connection refused" attempt=3`, config.DefaultLogsConfig().Fields)
+	if s == nil || s.format != logFormatLogfmt {
+		t.Fatalf("parseStructuredLog() = %+v, want a logfmt line", s)
+	}
+	if s.message.value != "connection refused" {
+		t.Errorf("message = %q, want unquoted value", s.message.value)
+	}
+	if got := formatStructured(s); got != "ERROR connection refused attempt=3" {
+		t.Errorf("formatStructured() = %q", got)
+	}
+}
+
+func TestParseStructuredLogIgnoresPlainLines(t *testing.T) {
+	for _, line := range []string{
+		"GET / 200",
+		"retrying with timeout=5s",
+		"{not json",
+		`{"a":1} trailing`,
+		`a=1 b="unterminated`,
+		"[1,2,3]",
+	} {
+		if s := parseStructuredLog(line, config.DefaultLogsConfig().Fields); s != nil {
+			t.Errorf("parseStructuredLog(%q) = %+v, want nil", line, s)
+		}
+	}
+}
+
+func TestParseStructuredLogUsesMapping(t *testing.T) {
+	mapping := config.LogFieldsConfig{Level: []string{"sev"}, Message: []string{"text"}}
+	s := parseStructuredLog(`{"level":"info","sev":"error","text":"boom"}`, mapping)
+	if s.level.value != "error" || s.message.value != "boom" || s.timestamp != nil {
+		t.Errorf("columns = %v %v %v, want the configured keys", s.level, s.message, s.timestamp)
+	}
+}
+
+func TestParseLogLevel(t *testing.T) {
+	tests := []struct {
+		in   string
+		want logLevel
+		ok   bool
+	}{
+		{"WARNING", levelWarn, true},
+		{"err", levelError, true},
+		{"30", levelInfo, true},
+		{"60", levelFatal, true},
+		{"99", 0, false},
+		{"verbose", 0, false},
+	}
+	for _, tt := range tests {
+		got, ok := parseLogLevel(tt.in)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("parseLogLevel(%q) = %v, %t, want %v, %t", tt.in, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestFieldFilterMatches(t *testing.T) {
+	mapping := config.DefaultLogsConfig().Fields
+	warn := parseStructuredLog(`{"level":"warn","msg":"slow","request_id":"abc","ms":812}`, mapping)
+	info := parseStructuredLog(`level=info msg=ok request_id=def ms=20`, mapping)
+
+	tests := []struct {
+		query     string
+		warn, inf bool
+	}{
+		{"level>=warn", true, false},
+		{"level<warn", false, true},
+		{"request_id=abc", true, false},
+		{"request_id!=abc", false, true},
+		{"ms>100", true, false},
+		{"message~^sl", true, false},
+		{"level>=info request_id=def", false, true},
+		{"missing!=x", true, true},
+		{"missing=x", false, false},
+	}
+	for _, tt := range tests {
+		filter, err := parseFieldFilter(tt.query)
+		if err != nil {
+			t.Fatalf("parseFieldFilter(%q) error: %v", tt.query, err)
+		}
+		if got := filter.matches(warn); got != tt.warn {
+			t.Errorf("%q matches warn line = %t, want %t", tt.query, got, tt.warn)
+		}
+		if got := filter.matches(info); got != tt.inf {
+			t.Errorf("%q matches info line = %t, want %t", tt.query, got, tt.inf)
+		}
+		if filter.matches(nil) {
+			t.Errorf("%q should not match a plain line", tt.query)
+		}
+	}
+}
+
+func TestParseFieldFilterErrors(t *testing.T) {
+	for _, query := range []string{"level", "=warn", "level>=", "msg~(", "level!warn"} {
+		if _, err := parseFieldFilter(query); err == nil {
+			t.Errorf("parseFieldFilter(%q) should fail", query)
+		}
+	}
+	if filter, err := parseFieldFilter("  "); filter != nil || err != nil {
+		t.Errorf("parseFieldFilter(blank) = %v, %v, want no filter", filter, err)
+	}
+}
+
+func TestFormatStructuredDetail(t *testing.T) {
+	s := parseStructuredLog(`{"level":"info","msg":"hi","user":{"id":7}}`, config.DefaultLogsConfig().Fields)
+	got := formatStructuredDetail(s)
+	for _, want := range []string{"JSON", "level:   info", "user.id: 7"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("formatStructuredDetail() missing %q:\n%s", want, got)
+		}
+	}
+}
@@ -0,0 +1,271 @@
+package containers
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"log"
+	"os"
+	"time"
+
+	"github.com/GustavoCaso/docker-dash/internal/client"
+	"github.com/GustavoCaso/docker-dash/internal/config"
+)
+
+// compactFactor is how many times the points kept in memory the history file
+// may hold, counting the expired ones, before it is rewritten.
+const compactFactor = 2
+
+// metricPoint is the usage of a container at a point in time. The network and
+// block IO rates are in bytes per second.
+type metricPoint struct {
+	Time             time.Time `json:"time"`
+	CPUPercent       float64   `json:"cpu_percent"`
+	ThrottledPercent float64   `json:"throttled_percent"`
+	MemoryUsage      uint64    `json:"memory_usage"`
+	MemoryLimit      uint64    `json:"memory_limit"`
+	NetworkRx        float64   `json:"network_rx"`
+	NetworkTx        float64   `json:"network_tx"`
+	BlockRead        float64   `json:"block_read"`
+	BlockWrite       float64   `json:"block_write"`
+}
+
+func newMetricPoint(stats client.ContainerStats, rates client.StatsRates) metricPoint {
+	return metricPoint{
+		Time:             stats.Read,
+		CPUPercent:       rates.CPUPercent,
+		ThrottledPercent: rates.ThrottledPercent,
+		MemoryUsage:      stats.MemoryUsage,
+		MemoryLimit:      stats.MemoryLimit,
+		NetworkRx:        rates.NetworkRx,
+		NetworkTx:        rates.NetworkTx,
+		BlockRead:        rates.BlockRead,
+		BlockWrite:       rates.BlockWrite,
+	}
+}
+
+// memoryPercent returns the memory usage as a percentage of the limit.
+func (p metricPoint) memoryPercent() float64 {
+	return client.ContainerStats{MemoryUsage: p.MemoryUsage, MemoryLimit: p.MemoryLimit}.MemoryPercent()
+}
+
+// metricRecord is a line of the history file.
+type metricRecord struct {
+	ID string `json:"id"`
+	metricPoint
+}
+
+// metricsRecorder keeps the usage of each container over the configured
+// window, fed by the section's stats collector. The history of a container
+// that stopped stays until it expires. When a path is configured the points
+// are appended to it as JSON lines and loaded again on start.
+type metricsRecorder struct {
+	window time.Duration
+	path   string
+	file   *os.File
+	series map[string][]metricPoint // by container ID, oldest first
+	// written is the number of points in the history file.
+	written int
+}
+
+func newMetricsRecorder(cfg config.StatsHistoryConfig) *metricsRecorder {
+	window, err := cfg.Duration()
+	if err != nil {
+		log.Printf("[containers][stats] history: %v", err)
+		window, _ = config.StatsHistoryConfig{}.Duration()
+	}
+	r := &metricsRecorder{window: window, path: cfg.Path, series: map[string][]metricPoint{}}
+	if r.path != "" {
+		r.load(time.Now())
+	}
+	return r
+}
+
+// record adds points, by container ID, and drops the points that fell out of
+// the window.
+func (r *metricsRecorder) record(points map[string]metricPoint) {
+	if r == nil || len(points) == 0 {
+		return
+	}
+	var latest time.Time
+	for id, p := range points {
+		r.series[id] = append(r.series[id], p)
+		if p.Time.After(latest) {
+			latest = p.Time
+		}
+	}
+	r.expire(latest.Add(-r.window))
+	r.persist(points)
+}
+
+// points returns the points of the container with id taken after since,
+// oldest first.
+func (r *metricsRecorder) points(id string, since time.Time) []metricPoint {
+	if r == nil {
+		return nil
+	}
+	series := r.series[id]
+	for i, p := range series {
+		if p.Time.After(since) {
+			return series[i:]
+		}
+	}
+	return nil
+}
+
+// expire drops the points taken before cutoff, and the containers left
+// without any.
+func (r *metricsRecorder) expire(cutoff time.Time) {
+	for id, series := range r.series {
+		i := 0
+		for i < len(series) && series[i].Time.Before(cutoff) {
+			i++
+		}
+		if i == len(series) {
+			delete(r.series, id)
+			continue
+		}
+		r.series[id] = series[i:]
+	}
+}
+
+// kept returns the number of points in memory.
+func (r *metricsRecorder) kept() int {
+	n := 0
+	for _, series := range r.series {
+		n += len(series)
+	}
+	return n
+}
+
+// load reads the history file, keeping the points within the window before
+// now, and rewrites it without the expired ones. A missing file starts an
+// empty history.
+func (r *metricsRecorder) load(now time.Time) {
+	file, err := os.Open(r.path)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		log.Printf("[containers][stats] history: %v, not persisting", err)
+		r.path = ""
+		return
+	}
+	if err == nil {
+		cutoff := now.Add(-r.window)
+		scanner := bufio.NewScanner(file)
+		skipped := 0
+		for scanner.Scan() {
+			var rec metricRecord
+			if json.Unmarshal(scanner.Bytes(), &rec) != nil || rec.ID == "" {
+				skipped++
+				continue
+			}
+			if rec.Time.After(cutoff) {
+				r.series[rec.ID] = append(r.series[rec.ID], rec.metricPoint)
+			}
+		}
+		if err := scanner.Err(); err != nil {
+			log.Printf("[containers][stats] history: reading %q: %v", r.path, err)
+		}
+		_ = file.Close()
+		log.Printf("[containers][stats] history: loaded %d points from %q, skipped %d lines",
+			r.kept(), r.path, skipped)
+	}
+	r.compact()
+}
+
+// persist appends points to the history file, rewriting it once it holds
+// compactFactor times the points kept. A failed write stops persisting.
+func (r *metricsRecorder) persist(points map[string]metricPoint) {
+	if r.file == nil {
+		return
+	}
+	if r.written+len(points) > compactFactor*r.kept() {
+		r.compact()
+		return
+	}
+	w := bufio.NewWriter(r.file)
+	var err error
+	for id, p := range points {
+		if err = writeMetricRecord(w, id, p); err != nil {
+			break
+		}
+	}
+	if err == nil {
+		err = w.Flush()
+	}
+	if err != nil {
+		log.Printf("[containers][stats] history: %v, not persisting", err)
+		r.stopPersisting()
+		return
+	}
+	r.written += len(points)
+}
+
+// compact replaces the history file with the points kept in memory and opens
+// it to append the next ones.
+func (r *metricsRecorder) compact() {
+	if r.path == "" {
+		return
+	}
+	if r.file != nil {
+		_ = r.file.Close()
+		r.file = nil
+	}
+	tmp := r.path + ".tmp"
+	err := writeFile(tmp, func(w *bufio.Writer) error {
+		for id, series := range r.series {
+			for _, p := range series {
+				if err := writeMetricRecord(w, id, p); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+	if err == nil {
+		err = os.Rename(tmp, r.path)
+	}
+	if err == nil {
+		r.file, err = os.OpenFile(r.path, os.O_WRONLY|os.O_APPEND, exportFileMode)
+	}
+	if err != nil {
+		log.Printf("[containers][stats] history: %v, not persisting", err)
+		r.stopPersisting()
+		return
+	}
+	r.written = r.kept()
+}
+
+func (r *metricsRecorder) stopPersisting() {
+	if r.file != nil {
+		_ = r.file.Close()
+	}
+	r.file = nil
+	r.path = ""
+}
+
+func writeMetricRecord(w *bufio.Writer, id string, p metricPoint) error {
+	b, err := json.Marshal(metricRecord{ID: id, metricPoint: p})
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(append(b, '\n'))
+	return err
+}
+
+// writeFile creates the file at path and fills it with write.
+func writeFile(path string, write func(w *bufio.Writer) error) error {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, exportFileMode)
+	if err != nil {
+		return err
+	}
+	w := bufio.NewWriter(file)
+	err = write(w)
+	if err == nil {
+		err = w.Flush()
+	}
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
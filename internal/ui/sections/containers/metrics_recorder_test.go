@@ -0,0 +1,68 @@
+package containers
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/GustavoCaso/docker-dash/internal/config"
+)
+
+func countLines(t *testing.T, path string) int {
+	t.Helper()
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	n := 0
+	for scanner := bufio.NewScanner(file); scanner.Scan(); {
+		n++
+	}
+	return n
+}
+
+func TestMetricsRecorderExpiresOldPoints(t *testing.T) {
+	r := newMetricsRecorder(config.StatsHistoryConfig{Window: "1m"})
+	start := time.Unix(1000, 0)
+	r.record(map[string]metricPoint{"a": {Time: start}, "b": {Time: start}})
+	r.record(map[string]metricPoint{"a": {Time: start.Add(30 * time.Second), CPUPercent: 5}})
+	r.record(map[string]metricPoint{"a": {Time: start.Add(70 * time.Second), CPUPercent: 7}})
+
+	if got := r.points("b", time.Time{}); got != nil {
+		t.Errorf("points of b = %v, want them expired", got)
+	}
+	got := r.points("a", time.Time{})
+	if len(got) != 2 || got[0].CPUPercent != 5 {
+		t.Errorf("points of a = %v, want the two in the window", got)
+	}
+	if got := r.points("a", start.Add(40*time.Second)); len(got) != 1 || got[0].CPUPercent != 7 {
+		t.Errorf("points since 40s = %v, want the last one", got)
+	}
+}
+
+func TestMetricsRecorderPersistsHistory(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "stats.jsonl")
+	cfg := config.StatsHistoryConfig{Window: "1h", Path: path}
+	now := time.Now()
+
+	r := newMetricsRecorder(cfg)
+	r.record(map[string]metricPoint{"a": {Time: now.Add(-2 * time.Hour)}})
+	for i := range 4 {
+		r.record(map[string]metricPoint{"a": {Time: now.Add(time.Duration(i) * time.Second), CPUPercent: float64(i)}})
+	}
+	if got := countLines(t, path); got > compactFactor*r.kept() {
+		t.Errorf("history file has %d lines, want it compacted to at most %d", got, compactFactor*r.kept())
+	}
+
+	loaded := newMetricsRecorder(cfg)
+	got := loaded.points("a", time.Time{})
+	if len(got) != 4 || got[3].CPUPercent != 3 {
+		t.Errorf("loaded points = %v, want the four within the window", got)
+	}
+	if got := countLines(t, path); got != 4 {
+		t.Errorf("history file has %d lines after loading, want the expired points dropped", got)
+	}
+}
@@ -0,0 +1,367 @@
+package containers
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"slices"
+	"strconv"
+	"time"
+
+	"charm.land/bubbles/v2/key"
+	"charm.land/bubbles/v2/list"
+	tea "charm.land/bubbletea/v2"
+	"charm.land/huh/v2"
+	"charm.land/lipgloss/v2"
+
+	"github.com/GustavoCaso/docker-dash/internal/client"
+	"github.com/GustavoCaso/docker-dash/internal/ui/components/form"
+	"github.com/GustavoCaso/docker-dash/internal/ui/keys"
+	"github.com/GustavoCaso/docker-dash/internal/ui/message"
+	"github.com/GustavoCaso/docker-dash/internal/ui/sections"
+	"github.com/GustavoCaso/docker-dash/internal/ui/theme"
+)
+
+const processesRefreshInterval = 2 * time.Second
+
+var processHeaderStyle = lipgloss.NewStyle().Bold(true).Foreground(theme.TextMuted)
+
+// processesLoadedMsg is sent when the process list has been fetched.
+type processesLoadedMsg struct {
+	requestID int
+	processes []client.Process
+	err       error
+}
+
+// processesTickMsg triggers the next periodic refresh of the process list.
+type processesTickMsg struct {
+	requestID int
+}
+
+// processSignalledMsg is sent when a signal has been delivered to a process.
+type processSignalledMsg struct {
+	pid    int
+	signal string
+	err    error
+}
+
+// processSort is the column the process list is ordered by.
+type processSort int
+
+const (
+	sortByCPU processSort = iota
+	sortByMemory
+	sortByPID
+	processSortCount
+)
+
+func (s processSort) String() string {
+	switch s {
+	case sortByMemory:
+		return "MEM%"
+	case sortByPID:
+		return "PID"
+	default:
+		return "CPU%"
+	}
+}
+
+// processItem implements list.Item interface.
+type processItem struct {
+	process client.Process
+}
+
+func (p processItem) Title() string       { return p.process.Command }
+func (p processItem) Description() string { return "" }
+func (p processItem) FilterValue() string {
+	return strconv.Itoa(p.process.PID) + " " + p.process.User + " " + p.process.Command
+}
+
+const (
+	pidColumnWidth          = 7
+	userColumnWidth         = 10
+	pctColumnWidth          = 6
+	ellipsisWidth           = 1
+	signalTitleCommandWidth = 24
+)
+
+func formatProcessRow(pid, user, cpu, mem, command string) string {
+	return fmt.Sprintf("%*s %-*s %*s %*s %s",
+		pidColumnWidth, pid,
+		userColumnWidth, truncate(user, userColumnWidth),
+		pctColumnWidth, cpu,
+		pctColumnWidth, mem,
+		command,
+	)
+}
+
+func truncate(s string, width int) string {
+	runes := []rune(s)
+	if len(runes) <= width {
+		return s
+	}
+	if width <= ellipsisWidth {
+		return string(runes[:width])
+	}
+	return string(runes[:width-ellipsisWidth]) + "…"
+}
+
+// processDelegate renders a process as a single table row.
+type processDelegate struct{}
+
+func (d processDelegate) Height() int                             { return 1 }
+func (d processDelegate) Spacing() int                            { return 0 }
+func (d processDelegate) Update(_ tea.Msg, _ *list.Model) tea.Cmd { return nil }
+
+func (d processDelegate) Render(w io.Writer, m list.Model, index int, item list.Item) {
+	pi, ok := item.(processItem)
+	if !ok {
+		return
+	}
+	row := truncate(formatProcessRow(
+		strconv.Itoa(pi.process.PID),
+		pi.process.User,
+		strconv.FormatFloat(pi.process.CPU, 'f', 1, 64),
+		strconv.FormatFloat(pi.process.Memory, 'f', 1, 64),
+		pi.process.Command,
+	), m.Width())
+	if index == m.Index() {
+		row = theme.SelectedLogLine.Render(row)
+	}
+	fmt.Fprint(w, row)
+}
+
+type processesPanel struct {
+	ctx         context.Context
+	service     client.ContainerService
+	containerID string
+	list        list.Model
+	sortBy      processSort
+	processes   []client.Process
+	err         error
+	requestID   int
+	width       int
+}
+
+func NewProcessesPanel(ctx context.Context, svc client.ContainerService) sections.Panel {
+	l := list.New([]list.Item{}, processDelegate{}, 0, 0)
+	l.SetShowTitle(false)
+	l.SetShowHelp(false)
+	l.SetShowStatusBar(true)
+	l.DisableQuitKeybindings()
+	return &processesPanel{ctx: ctx, service: svc, list: l}
+}
+
+func (p *processesPanel) Name() string {
+	return "Processes"
+}
+
+func (p *processesPanel) Init(item sections.ListItem) tea.Cmd {
+	p.containerID = item.ID()
+	log.Printf("[containers][processes-panel] Init: containerID=%q", p.containerID)
+	p.requestID++
+	p.err = nil
+	p.processes = nil
+	p.list.ResetFilter()
+	p.list.SetItems([]list.Item{})
+	return tea.Batch(p.fetchCmd(p.requestID), p.extendHelpCmd())
+}
+
+func (p *processesPanel) Update(msg tea.Msg) tea.Cmd {
+	switch msg := msg.(type) {
+	case processesLoadedMsg:
+		if msg.requestID != p.requestID {
+			return nil
+		}
+		if msg.err != nil {
+			log.Printf("[containers][processes-panel] processesLoadedMsg: err=%v", msg.err)
+			p.err = msg.err
+			p.processes = nil
+			// Keep polling: the error may be transient, such as the
+			// container restarting.
+			return tea.Batch(p.list.SetItems([]list.Item{}), p.tickCmd(msg.requestID))
+		}
+		p.err = nil
+		p.processes = msg.processes
+		return tea.Batch(p.setItems(), p.tickCmd(msg.requestID))
+
+	case processesTickMsg:
+		if msg.requestID != p.requestID {
+			return nil
+		}
+		return p.fetchCmd(msg.requestID)
+
+	case processSignalledMsg:
+		log.Printf(
+			"[containers][processes-panel] processSignalledMsg: pid=%d signal=%q err=%v",
+			msg.pid,
+			msg.signal,
+			msg.err,
+		)
+		if msg.err != nil {
+			return func() tea.Msg {
+				return message.ShowBannerMsg{
+					Message: fmt.Sprintf("Error sending %s to process %d: %s", msg.signal, msg.pid, msg.err.Error()),
+					IsError: true,
+				}
+			}
+		}
+		// Restart the refresh chain so the list reflects the signal right away.
+		p.requestID++
+		return tea.Batch(p.fetchCmd(p.requestID), func() tea.Msg {
+			return message.ShowBannerMsg{
+				Message: fmt.Sprintf("Sent %s to process %d", msg.signal, msg.pid),
+				IsError: false,
+			}
+		})
+
+	case tea.KeyPressMsg:
+		if p.list.SettingFilter() {
+			var cmd tea.Cmd
+			p.list, cmd = p.list.Update(msg)
+			return cmd
+		}
+		switch {
+		case key.Matches(msg, keys.Keys.ProcessSort):
+			p.sortBy = (p.sortBy + 1) % processSortCount
+			log.Printf("[containers][processes-panel] sort by %s", p.sortBy)
+			return p.setItems()
+		case key.Matches(msg, keys.Keys.ProcessSignal):
+			return p.signalFormCmd()
+		}
+	}
+
+	var cmd tea.Cmd
+	p.list, cmd = p.list.Update(msg)
+	return cmd
+}
+
+func (p *processesPanel) View() string {
+	if p.err != nil {
+		return theme.StatusErrorStyle.Render(p.err.Error())
+	}
+	label := func(column processSort) string {
+		if column == p.sortBy {
+			return column.String() + "▼"
+		}
+		return column.String()
+	}
+	header := formatProcessRow(label(sortByPID), "USER", label(sortByCPU), label(sortByMemory), "COMMAND")
+	return lipgloss.JoinVertical(
+		lipgloss.Left,
+		processHeaderStyle.Render(truncate(header, p.width)),
+		p.list.View(),
+	)
+}
+
+func (p *processesPanel) Close() tea.Cmd {
+	log.Printf("[containers][processes-panel] Close")
+	p.requestID++
+	p.err = nil
+	p.processes = nil
+	p.list.ResetFilter()
+	p.list.SetItems([]list.Item{})
+	return func() tea.Msg { return message.ClearContextualKeyBindingsMsg{} }
+}
+
+func (p *processesPanel) SetSize(width, height int) {
+	p.width = width
+	// Reserve one line for the column header.
+	p.list.SetSize(width, max(height-1, 0))
+}
+
+// IsFilter reports whether the user is typing a filter, so the section routes
+// every key to the panel instead of treating it as a shortcut.
+func (p *processesPanel) IsFilter() bool {
+	return p.list.SettingFilter()
+}
+
+// setItems sorts the current processes and replaces the list items, keeping
+// the cursor on the previously selected PID.
+func (p *processesPanel) setItems() tea.Cmd {
+	selectedPID := -1
+	if pi, ok := p.list.SelectedItem().(processItem); ok {
+		selectedPID = pi.process.PID
+	}
+
+	sorted := slices.Clone(p.processes)
+	slices.SortStableFunc(sorted, func(a, b client.Process) int {
+		switch p.sortBy {
+		case sortByMemory:
+			return cmp.Compare(b.Memory, a.Memory)
+		case sortByPID:
+			return cmp.Compare(a.PID, b.PID)
+		default:
+			return cmp.Compare(b.CPU, a.CPU)
+		}
+	})
+
+	items := make([]list.Item, len(sorted))
+	for i, process := range sorted {
+		items[i] = processItem{process: process}
+	}
+	cmd := p.list.SetItems(items)
+
+	for i, item := range p.list.VisibleItems() {
+		if pi, ok := item.(processItem); ok && pi.process.PID == selectedPID {
+			p.list.Select(i)
+			break
+		}
+	}
+	return cmd
+}
+
+func (p *processesPanel) fetchCmd(requestID int) tea.Cmd {
+	ctx := p.ctx
+	svc := p.service
+	containerID := p.containerID
+	return func() tea.Msg {
+		processes, err := svc.Top(ctx, containerID)
+		return processesLoadedMsg{requestID: requestID, processes: processes, err: err}
+	}
+}
+
+func (p *processesPanel) tickCmd(requestID int) tea.Cmd {
+	return tea.Tick(processesRefreshInterval, func(_ time.Time) tea.Msg {
+		return processesTickMsg{requestID: requestID}
+	})
+}
+
+func (p *processesPanel) signalFormCmd() tea.Cmd {
+	pi, ok := p.list.SelectedItem().(processItem)
+	if !ok {
+		return nil
+	}
+	ctx := p.ctx
+	svc := p.service
+	containerID := p.containerID
+	pid := pi.process.PID
+	title := fmt.Sprintf("Signal process %d (%s)", pid, truncate(pi.process.Command, signalTitleCommandWidth))
+
+	signal, custom := "SIGTERM", ""
+	f := newSignalForm(title, &signal, &custom)
+	signalForm := form.New("Signal Process", f, func(_ *huh.Form) tea.Cmd {
+		signal := resolveSignal(signal, custom)
+		return func() tea.Msg {
+			err := svc.SignalProcess(ctx, containerID, pid, signal)
+			return processSignalledMsg{pid: pid, signal: signal, err: err}
+		}
+	})
+	return func() tea.Msg {
+		return message.ShowFormMsg{Form: signalForm}
+	}
+}
+
+func (p *processesPanel) extendHelpCmd() tea.Cmd {
+	return func() tea.Msg {
+		return message.AddContextualKeyBindingsMsg{Bindings: []key.Binding{
+			keys.Keys.ScrollUp,
+			keys.Keys.ScrollDown,
+			keys.Keys.Filter,
+			keys.Keys.ProcessSort,
+			keys.Keys.ProcessSignal,
+		}}
+	}
+}
@@ -0,0 +1,260 @@
+package containers
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/GustavoCaso/docker-dash/internal/client"
+	"github.com/GustavoCaso/docker-dash/internal/ui/message"
+)
+
+func newTestProcessesPanel() *processesPanel {
+	p := NewProcessesPanel(context.Background(), client.NewMockClient().Containers()).(*processesPanel)
+	p.SetSize(120, 20)
+	return p
+}
+
+var testProcesses = []client.Process{
+	{PID: 10, User: "root", CPU: 0.5, Memory: 3.0, Command: "init"},
+	{PID: 20, User: "app", CPU: 9.0, Memory: 1.0, Command: "worker --queue high"},
+	{PID: 30, User: "app", CPU: 2.0, Memory: 7.0, Command: "server"},
+}
+
+func visiblePIDs(p *processesPanel) []int {
+	var pids []int
+	for _, item := range p.list.VisibleItems() {
+		pids = append(pids, item.(processItem).process.PID)
+	}
+	return pids
+}
+
+func TestProcessesPanelInitFetchesProcesses(t *testing.T) {
+	p := newTestProcessesPanel()
+	cmd := p.Init(containerItem{container: client.Container{ID: "abc123def456"}})
+	msg := cmd()
+	batch, ok := msg.(tea.BatchMsg)
+	if !ok {
+		t.Fatalf("Init() cmd returned %T, want tea.BatchMsg", msg)
+	}
+
+	var loaded *processesLoadedMsg
+	extendCmd := false
+	for _, cmd := range batch {
+		switch msg := cmd().(type) {
+		case processesLoadedMsg:
+			loaded = &msg
+		case message.AddContextualKeyBindingsMsg:
+			extendCmd = true
+		}
+	}
+
+	if loaded == nil {
+		t.Fatal("Init() not returned processesLoadedMsg msg")
+	}
+	if loaded.err != nil || len(loaded.processes) == 0 {
+		t.Errorf("processesLoadedMsg = %+v, want mock processes", *loaded)
+	}
+	if !extendCmd {
+		t.Fatal("Init() not returned AddContextualKeyBindingsMsg msg")
+	}
+}
+
+func TestProcessesPanelSortsAndCyclesSortOrder(t *testing.T) {
+	p := newTestProcessesPanel()
+	p.Init(containerItem{container: client.Container{ID: "abc123def456"}})
+	p.Update(processesLoadedMsg{requestID: p.requestID, processes: testProcesses})
+
+	if got := visiblePIDs(p); !slices.Equal(got, []int{20, 30, 10}) {
+		t.Errorf("default order = %v, want by CPU [20 30 10]", got)
+	}
+
+	p.Update(tea.KeyPressMsg{Code: 'o', Text: "o"})
+	if got := visiblePIDs(p); !slices.Equal(got, []int{30, 10, 20}) {
+		t.Errorf("order after one sort cycle = %v, want by memory [30 10 20]", got)
+	}
+
+	p.Update(tea.KeyPressMsg{Code: 'o', Text: "o"})
+	if got := visiblePIDs(p); !slices.Equal(got, []int{10, 20, 30}) {
+		t.Errorf("order after two sort cycles = %v, want by PID [10 20 30]", got)
+	}
+	if !strings.Contains(p.View(), "PID▼") {
+		t.Error("View() should mark the PID column as the sort column")
+	}
+}
+
+func TestProcessesPanelKeepsSelectionAcrossRefresh(t *testing.T) {
+	p := newTestProcessesPanel()
+	p.Init(containerItem{container: client.Container{ID: "abc123def456"}})
+	p.Update(processesLoadedMsg{requestID: p.requestID, processes: testProcesses})
+	p.Update(tea.KeyPressMsg{Code: tea.KeyDown})
+
+	refreshed := []client.Process{
+		{PID: 10, User: "root", CPU: 50.0, Memory: 3.0, Command: "init"},
+		testProcesses[1],
+		testProcesses[2],
+	}
+	p.Update(processesLoadedMsg{requestID: p.requestID, processes: refreshed})
+
+	selected, ok := p.list.SelectedItem().(processItem)
+	if !ok {
+		t.Fatal("expected a selected process")
+	}
+	if selected.process.PID != 30 {
+		t.Errorf("selected PID after refresh = %d, want 30", selected.process.PID)
+	}
+}
+
+func TestProcessesPanelIgnoresStaleMessages(t *testing.T) {
+	p := newTestProcessesPanel()
+	p.Init(containerItem{container: client.Container{ID: "abc123def456"}})
+	stale := p.requestID - 1
+
+	if cmd := p.Update(processesLoadedMsg{requestID: stale, processes: testProcesses}); cmd != nil {
+		t.Error("stale processesLoadedMsg should return nil cmd")
+	}
+	if len(p.list.Items()) != 0 {
+		t.Errorf("stale processesLoadedMsg should not set items, got %d", len(p.list.Items()))
+	}
+	if cmd := p.Update(processesTickMsg{requestID: stale}); cmd != nil {
+		t.Error("stale processesTickMsg should return nil cmd")
+	}
+}
+
+func TestProcessesPanelTickRefetches(t *testing.T) {
+	p := newTestProcessesPanel()
+	p.Init(containerItem{container: client.Container{ID: "abc123def456"}})
+
+	cmd := p.Update(processesTickMsg{requestID: p.requestID})
+	if cmd == nil {
+		t.Fatal("processesTickMsg should return a fetch cmd")
+	}
+	if _, ok := cmd().(processesLoadedMsg); !ok {
+		t.Error("processesTickMsg cmd should produce processesLoadedMsg")
+	}
+}
+
+func TestProcessesPanelShowsError(t *testing.T) {
+	p := newTestProcessesPanel()
+	p.Init(containerItem{container: client.Container{ID: "jkl012mno345"}})
+	cmd := p.Update(processesLoadedMsg{requestID: p.requestID, err: errors.New("container is not running")})
+
+	if !strings.Contains(p.View(), "container is not running") {
+		t.Errorf("View() = %q, want to contain the error", p.View())
+	}
+	if !slices.ContainsFunc(runBatch(cmd), func(msg tea.Msg) bool {
+		tick, ok := msg.(processesTickMsg)
+		return ok && tick.requestID == p.requestID
+	}) {
+		t.Error("an error should keep the refresh ticking")
+	}
+	if p.Update(processesLoadedMsg{requestID: p.requestID - 1, err: errors.New("stale")}) != nil {
+		t.Error("a stale error should not start another tick")
+	}
+}
+
+func TestProcessesPanelFilter(t *testing.T) {
+	p := newTestProcessesPanel()
+	p.Init(containerItem{container: client.Container{ID: "abc123def456"}})
+	p.Update(processesLoadedMsg{requestID: p.requestID, processes: testProcesses})
+
+	p.Update(tea.KeyPressMsg{Code: '/', Text: "/"})
+	if !p.IsFilter() {
+		t.Fatal("IsFilter() should be true after pressing the filter key")
+	}
+	for _, r := range "worker" {
+		drainProcessesCmd(p, p.Update(tea.KeyPressMsg{Code: r, Text: string(r)}))
+	}
+	drainProcessesCmd(p, p.Update(tea.KeyPressMsg{Code: tea.KeyEnter}))
+
+	if p.IsFilter() {
+		t.Error("IsFilter() should be false once the filter is applied")
+	}
+	if got := visiblePIDs(p); !slices.Equal(got, []int{20}) {
+		t.Errorf("visible PIDs = %v, want [20]", got)
+	}
+}
+
+func TestProcessesPanelSignalKeyShowsForm(t *testing.T) {
+	p := newTestProcessesPanel()
+	p.Init(containerItem{container: client.Container{ID: "abc123def456"}})
+	p.Update(processesLoadedMsg{requestID: p.requestID, processes: testProcesses})
+
+	cmd := p.Update(tea.KeyPressMsg{Code: 'x', Text: "x"})
+	if cmd == nil {
+		t.Fatal("signal key should return a cmd")
+	}
+	if _, ok := cmd().(message.ShowFormMsg); !ok {
+		t.Error("signal key should show the signal form")
+	}
+}
+
+func TestProcessesPanelSignalledRefreshes(t *testing.T) {
+	p := newTestProcessesPanel()
+	p.Init(containerItem{container: client.Container{ID: "abc123def456"}})
+	before := p.requestID
+
+	cmd := p.Update(processSignalledMsg{pid: 20, signal: "SIGHUP"})
+	if p.requestID == before {
+		t.Error("processSignalledMsg should restart the refresh chain")
+	}
+	batch, ok := cmd().(tea.BatchMsg)
+	if !ok {
+		t.Fatalf("processSignalledMsg cmd returned %T, want tea.BatchMsg", cmd())
+	}
+	refreshed, banner := false, false
+	for _, cmd := range batch {
+		switch msg := cmd().(type) {
+		case processesLoadedMsg:
+			refreshed = msg.requestID == p.requestID
+		case message.ShowBannerMsg:
+			banner = !msg.IsError
+		}
+	}
+	if !refreshed || !banner {
+		t.Errorf("processSignalledMsg refreshed=%v banner=%v, want both", refreshed, banner)
+	}
+
+	cmd = p.Update(processSignalledMsg{pid: 20, signal: "SIGHUP", err: errors.New("no such process")})
+	bannerMsg, ok := cmd().(message.ShowBannerMsg)
+	if !ok || !bannerMsg.IsError {
+		t.Errorf("failed signal should show an error banner, got %#v", cmd())
+	}
+}
+
+func TestProcessesPanelClose(t *testing.T) {
+	p := newTestProcessesPanel()
+	p.Init(containerItem{container: client.Container{ID: "abc123def456"}})
+	p.Update(processesLoadedMsg{requestID: p.requestID, processes: testProcesses})
+	requestID := p.requestID
+
+	p.Close()
+
+	if p.requestID == requestID {
+		t.Error("Close() should invalidate in-flight refreshes")
+	}
+	if len(p.list.Items()) != 0 {
+		t.Errorf("Close() should clear list items, got %d", len(p.list.Items()))
+	}
+}
+
+// drainProcessesCmd runs cmd and feeds the resulting messages back into the
+// panel, so the list's asynchronous filtering settles.
+func drainProcessesCmd(p *processesPanel, cmd tea.Cmd) {
+	if cmd == nil {
+		return
+	}
+	switch msg := cmd().(type) {
+	case tea.BatchMsg:
+		for _, c := range msg {
+			drainProcessesCmd(p, c)
+		}
+	case nil:
+	default:
+		p.Update(msg)
+	}
+}
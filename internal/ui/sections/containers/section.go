@@ -3,14 +3,21 @@ package containers
 import (
 	"context"
 	"fmt"
+	"image/color"
 	"log"
+	"strings"
+	"time"
 
 	"charm.land/bubbles/v2/key"
 	"charm.land/bubbles/v2/list"
 	tea "charm.land/bubbletea/v2"
+	"charm.land/huh/v2"
 
 	"github.com/GustavoCaso/docker-dash/internal/client"
 	"github.com/GustavoCaso/docker-dash/internal/config"
+	"github.com/GustavoCaso/docker-dash/internal/ui/components/form"
+	"github.com/GustavoCaso/docker-dash/internal/ui/components/prune"
+	"github.com/GustavoCaso/docker-dash/internal/ui/components/table"
 	"github.com/GustavoCaso/docker-dash/internal/ui/helper"
 	"github.com/GustavoCaso/docker-dash/internal/ui/keys"
 	"github.com/GustavoCaso/docker-dash/internal/ui/message"
@@ -25,6 +32,13 @@ type containersLoadedMsg struct {
 	items []list.Item
 }
 
+// containersPrunePreviewMsg is sent when the dry-run of a container prune completes.
+type containersPrunePreviewMsg struct {
+	opts       client.PruneOptions
+	candidates []client.PruneCandidate
+	err        error
+}
+
 // containersPrunedMsg is sent when a container prune completes.
 type containersPrunedMsg struct {
 	report client.PruneReport
@@ -39,9 +53,37 @@ type containerActionMsg struct {
 	Error  error
 }
 
+// containersSignalledMsg is sent when a signal has been sent to one or more
+// containers. results holds one entry per target container.
+type containersSignalledMsg struct {
+	signal  string
+	results []containerActionMsg
+}
+
+// containerEventMsg is sent when the daemon reports a container event. It
+// carries the stream to keep listening on.
+type containerEventMsg struct {
+	event  client.ContainerEvent
+	events <-chan client.ContainerEvent
+	errs   <-chan error
+}
+
+// containerEventsEndedMsg is sent when the container events stream ends.
+type containerEventsEndedMsg struct {
+	err error
+}
+
+// eventsReloadMsg reloads the list once a burst of container events settled.
+type eventsReloadMsg struct{}
+
+// eventsRetryMsg subscribes to the container events again after the stream
+// ended.
+type eventsRetryMsg struct{}
+
 // containerItem implements list.Item interface.
 type containerItem struct {
 	container client.Container
+	usage     *listUsage
 }
 
 func (c containerItem) ID() string     { return c.container.ID }
@@ -56,8 +98,37 @@ func (c containerItem) Description() string {
 	}
 	stateIcon := theme.GetContainerStatusIcon(string(c.container.State))
 	stateStyle := theme.GetContainerStatusStyle(string(c.container.State))
-	state := stateStyle.Render(stateIcon + " " + string(c.container.State))
-	return state + " " + healthStatus + " " + c.container.Image + " " + helper.ShortID(c.ID())
+	if c.container.State == client.StateStopped && c.container.ExitCode != 0 {
+		stateStyle = theme.StatusErrorStyle
+	}
+	state := stateStyle.Render(stateIcon + " " + stateLabel(c.container))
+	if usage := c.usage.describe(c.ID()); usage != "" {
+		state += " " + usage
+	}
+	return state + " " + lifecycleBadges(c.container) + healthStatus + " " + c.container.Image + " " +
+		helper.ShortID(c.ID())
+}
+
+// stateLabel returns the state of container, with the exit code of crashed
+// containers to tell them apart from ones that exited cleanly.
+func stateLabel(container client.Container) string {
+	if container.State == client.StateStopped && container.ExitCode != 0 {
+		return fmt.Sprintf("%s (%d)", container.State, container.ExitCode)
+	}
+	return string(container.State)
+}
+
+// lifecycleBadges returns the OOM-killed and crash-loop icons that apply to
+// container, each followed by a space, or an empty string.
+func lifecycleBadges(container client.Container) string {
+	var badges string
+	if container.OOMKilled {
+		badges += theme.OOMKilledStyle.Render(theme.IconOOMKilled) + " "
+	}
+	if container.CrashLooping() {
+		badges += theme.CrashLoopStyle.Render(fmt.Sprintf("%s %d", theme.IconCrashLoop, container.RestartCount)) + " "
+	}
+	return badges
 }
 func (c containerItem) FilterValue() string { return c.container.Name }
 
@@ -65,34 +136,87 @@ var _ sections.ListItem = containerItem{}
 
 const (
 	readBufSize = 4096 // buffer size for reading container output
+	// eventsReloadDelay coalesces the events of a single change, such as the
+	// die, stop and start of a restart, into one reload of the list.
+	eventsReloadDelay = 500 * time.Millisecond
+	// eventsRetryDelay is how long to wait before listening to the container
+	// events again after the stream ended.
+	eventsRetryDelay = 5 * time.Second
 )
 
+// containerPruneOptions prunes every stopped container.
+var containerPruneOptions = client.PruneOptions{}
+
 // Section wraps bubbles/list for displaying containers.
 type Section struct {
 	*base.Section
-	ctx     context.Context
-	service client.ContainerService
+	ctx       context.Context
+	service   client.ContainerService
+	stopCfg   config.StopConfig
+	collector *statsCollector
+	usage     *listUsage
+	stats     *statsPanel
+	top       *topPanel
+	alerts    *alertEngine
+	notifier  *alertNotifier
+	alertsTab *alertsPanel
+	// mergeLogs is set with M to follow the merged logs of the marked
+	// containers instead of the selected one.
+	mergeLogs bool
+	// listening is set once the section subscribed to the container events.
+	listening bool
+	// reloadPending is set while a reload triggered by events is scheduled.
+	reloadPending bool
 }
 
 // New creates a new container list.
-func New(ctx context.Context, svc client.ContainerService, logsCfg config.LogsConfig) *Section {
+func New(
+	ctx context.Context,
+	svc client.ContainerService,
+	logsCfg config.LogsConfig,
+	stopCfg config.StopConfig,
+	statsCfg config.StatsConfig,
+	alertsCfg config.AlertsConfig,
+	tableCfg config.TableConfig,
+) *Section {
 	cl := &Section{
 		ctx:     ctx,
 		service: svc,
-		Section: base.New(sections.ContainersSection, []sections.Panel{
-			NewDetailsPanel(ctx, svc),
-			NewLogsPanel(ctx, svc, logsCfg),
-			NewStatsPanel(ctx, svc),
-			newFilesPanel(ctx, svc),
-			NewExecPanel(ctx, svc),
-		}),
-	}
+		stopCfg: stopCfg,
+	}
+	cl.collector = newStatsCollector(ctx, svc, cl.listedContainers, newMetricsRecorder(statsCfg.History))
+	cl.usage = &listUsage{collector: cl.collector, cfg: statsCfg}
+	cl.stats = newStatsPanel(cl.collector)
+	cl.top = newTopPanel(cl.collector)
+	cl.alerts = newAlertEngine(alertsCfg)
+	cl.notifier = newAlertNotifier(ctx, alertsCfg)
+	cl.alertsTab = newAlertsPanel(cl.alerts)
+	cl.Section = base.New(sections.ContainersSection, []sections.Panel{
+		NewDetailsPanel(ctx, svc),
+		newLogsPanel(ctx, svc, logsCfg, cl.logTargets),
+		cl.stats,
+		cl.top,
+		cl.alertsTab,
+		NewHealthPanel(ctx, svc),
+		NewProcessesPanel(ctx, svc),
+		newFilesPanel(ctx, svc),
+		NewExecPanel(ctx, svc),
+	})
 
 	cl.LoadingText = "Loading..."
 	cl.RefreshCmd = cl.updateContainersCmd
-	cl.PruneCmd = cl.confirmContainerPrune
+	cl.PruneCmd = func() tea.Cmd { return cl.WithSpinner(cl.previewContainerPruneCmd(containerPruneOptions)) }
+	cl.PruneFilteredCmd = func() tea.Cmd {
+		return prune.ShowForm("Prune Containers", containerPruneOptions, func(opts client.PruneOptions) tea.Cmd {
+			return cl.WithSpinner(cl.previewContainerPruneCmd(opts))
+		})
+	}
 	cl.HandleMsg = cl.handleMsg
 	cl.HandleKey = cl.handleKey
+	cl.SetTable(table.New(cl.tableColumns(), tableCfg))
+	cl.Tint = func(item sections.ListItem) (color.Color, bool) {
+		return cl.usage.tint(item.ID())
+	}
 
 	return cl
 }
@@ -114,8 +238,41 @@ func (s *Section) handleMsg(msg tea.Msg) base.UpdateResult {
 			}
 		}
 
+		cmds := s.UpdateItems(msg.items)
+		cmds = append(cmds,
+			s.collector.want(statsForList, s.usage.cfg.Enabled()),
+			s.collector.want(statsForHistory, s.usage.cfg.History.Enabled()),
+			s.collector.want(statsForAlerts, s.alerts.watchesUsage()),
+			s.alertsRaised(s.alerts.observeContainers(time.Now(), s.listedContainers())),
+		)
+		if !s.listening {
+			s.listening = true
+			cmds = append(cmds, s.listenEventsCmd())
+		}
 		return base.UpdateResult{
-			Cmd:         tea.Batch(s.UpdateItems(msg.items)...),
+			Cmd:         tea.Batch(cmds...),
+			Handled:     true,
+			StopSpinner: true,
+		}
+	case containersPrunePreviewMsg:
+		log.Printf("[containers] containersPrunePreviewMsg: candidates=%d err=%v", len(msg.candidates), msg.err)
+		if msg.err != nil {
+			return base.UpdateResult{
+				Cmd: func() tea.Msg {
+					return message.ShowBannerMsg{
+						Message: "Error previewing container prune: " + msg.err.Error(),
+						IsError: true,
+					}
+				},
+				Handled:     true,
+				StopSpinner: true,
+			}
+		}
+		if len(msg.candidates) == 0 {
+			return base.UpdateResult{Cmd: prune.NothingToPrune("container"), Handled: true, StopSpinner: true}
+		}
+		return base.UpdateResult{
+			Cmd:         s.confirmContainerPrune(msg.opts, msg.candidates),
 			Handled:     true,
 			StopSpinner: true,
 		}
@@ -185,6 +342,22 @@ func (s *Section) handleMsg(msg tea.Msg) base.UpdateResult {
 			StopSpinner:        false,
 			RefreshAllSections: true,
 		}
+	case containersSignalledMsg:
+		return s.handleContainersSignalled(msg)
+	case statsSampledMsg, statsTickMsg:
+		cmd, _ := s.collector.update(msg)
+		if _, sampled := msg.(statsSampledMsg); sampled {
+			cmd = tea.Batch(cmd, s.alertsRaised(s.alerts.observeUsage(time.Now(), s.collector.usage)))
+			switch s.ActivePanel() {
+			case sections.Panel(s.top):
+				cmd = tea.Batch(cmd, s.top.refresh())
+			case sections.Panel(s.stats):
+				s.stats.refresh()
+			}
+		}
+		return base.UpdateResult{Cmd: cmd, Handled: true}
+	case containerEventMsg, containerEventsEndedMsg, eventsReloadMsg, eventsRetryMsg:
+		return base.UpdateResult{Cmd: s.handleEvents(msg), Handled: true}
 	case execCloseMsg:
 		log.Printf("[containers] execCloseMsg")
 		s.ActivePanel().Close()
@@ -198,6 +371,128 @@ func (s *Section) handleMsg(msg tea.Msg) base.UpdateResult {
 	return base.UpdateResult{}
 }
 
+// handleEvents reloads the list after container events, which also evaluates
+// the unhealthy and restarted alert rules, and evaluates the exited ones.
+func (s *Section) handleEvents(msg tea.Msg) tea.Cmd {
+	switch msg := msg.(type) {
+	case containerEventMsg:
+		log.Printf("[containers] containerEventMsg: action=%q containerID=%q", msg.event.Action, msg.event.ID)
+		cmds := []tea.Cmd{
+			waitForEventCmd(msg.events, msg.errs),
+			s.alertsRaised(s.alerts.observeEvent(msg.event)),
+		}
+		if !s.reloadPending {
+			s.reloadPending = true
+			cmds = append(cmds, tea.Tick(eventsReloadDelay, func(_ time.Time) tea.Msg {
+				return eventsReloadMsg{}
+			}))
+		}
+		return tea.Batch(cmds...)
+	case eventsReloadMsg:
+		s.reloadPending = false
+		return s.updateContainersCmd()
+	case containerEventsEndedMsg:
+		log.Printf("[containers] containerEventsEndedMsg: err=%v", msg.err)
+		if s.ctx.Err() != nil {
+			return nil
+		}
+		return tea.Tick(eventsRetryDelay, func(_ time.Time) tea.Msg { return eventsRetryMsg{} })
+	case eventsRetryMsg:
+		return s.listenEventsCmd()
+	}
+	return nil
+}
+
+// listenEventsCmd subscribes to the container events.
+func (s *Section) listenEventsCmd() tea.Cmd {
+	events, errs := s.service.Events(s.ctx)
+	return waitForEventCmd(events, errs)
+}
+
+// waitForEventCmd waits for the next container event of the stream.
+func waitForEventCmd(events <-chan client.ContainerEvent, errs <-chan error) tea.Cmd {
+	return func() tea.Msg {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				return containerEventsEndedMsg{}
+			}
+			return containerEventMsg{event: event, events: events, errs: errs}
+		case err := <-errs:
+			return containerEventsEndedMsg{err: err}
+		}
+	}
+}
+
+// alertsRaised notifies the alerts just raised with a banner, on the desktop
+// and to the webhook, and refreshes the Alerts panel and the header.
+func (s *Section) alertsRaised(fired []*alert) tea.Cmd {
+	var cmds []tea.Cmd
+	if s.ActivePanel() == sections.Panel(s.alertsTab) {
+		cmds = append(cmds, s.alertsTab.refresh())
+	}
+	if len(fired) == 0 {
+		return tea.Batch(cmds...)
+	}
+	for _, a := range fired {
+		cmds = append(cmds, s.notifier.notifyCmd(a))
+	}
+	banner := fmt.Sprintf("Alert: %s — %s: %s", fired[0].rule, fired[0].container, fired[0].detail)
+	if len(fired) > 1 {
+		banner = fmt.Sprintf("%d alerts raised, see the Alerts panel", len(fired))
+	}
+	cmds = append(cmds, alertsChangedCmd(s.alerts), func() tea.Msg {
+		return message.ShowBannerMsg{Message: banner, IsError: true}
+	})
+	return tea.Batch(cmds...)
+}
+
+func (s *Section) handleContainersSignalled(msg containersSignalledMsg) base.UpdateResult {
+	var failed []string
+	for _, result := range msg.results {
+		log.Printf(
+			"[containers] containersSignalledMsg: signal=%q containerID=%q err=%v",
+			msg.signal,
+			result.ID,
+			result.Error,
+		)
+		if result.Error != nil {
+			failed = append(failed, fmt.Sprintf("%s (%s)", helper.ShortID(result.ID), result.Error.Error()))
+		}
+	}
+	s.ClearMarks()
+
+	sent := len(msg.results) - len(failed)
+	var banner message.ShowBannerMsg
+	switch {
+	case len(msg.results) == 1 && sent == 1:
+		banner.Message = fmt.Sprintf("Sent %s to container %s", msg.signal, helper.ShortID(msg.results[0].ID))
+	case len(msg.results) == 1:
+		banner.Message = fmt.Sprintf("Error sending %s to container %s", msg.signal, failed[0])
+		banner.IsError = true
+	case len(failed) == 0:
+		banner.Message = fmt.Sprintf("Sent %s to %d containers", msg.signal, sent)
+	default:
+		banner.Message = fmt.Sprintf(
+			"Sent %s to %d of %d containers, failed: %s",
+			msg.signal,
+			sent,
+			len(msg.results),
+			strings.Join(failed, ", "),
+		)
+		banner.IsError = true
+	}
+
+	return base.UpdateResult{
+		Cmd:     func() tea.Msg { return banner },
+		Handled: true,
+		// When at least one signal was delivered the broadcast refresh reloads
+		// this section too, and its containersLoadedMsg stops the spinner.
+		StopSpinner:        sent == 0,
+		RefreshAllSections: sent > 0,
+	}
+}
+
 func (s *Section) handleKey(msg tea.KeyPressMsg) base.UpdateResult {
 	// When exec panel is active, route ALL keys directly to it.
 	if s.IsPanelFocused() {
@@ -206,21 +501,67 @@ func (s *Section) handleKey(msg tea.KeyPressMsg) base.UpdateResult {
 			return base.UpdateResult{Cmd: ep.Update(msg), Handled: true}
 		}
 	}
+	if cmd, ok := s.handleBulkKey(msg); ok {
+		return base.UpdateResult{Cmd: cmd, Handled: true}
+	}
 	switch {
 	case key.Matches(msg, keys.Keys.ContainerDelete):
 		return base.UpdateResult{Cmd: s.confirmContainerDelete(), Handled: true}
 	case key.Matches(msg, keys.Keys.ContainerStartStop):
 		return base.UpdateResult{Cmd: s.confirmContainerToggle(), Handled: true}
 	case key.Matches(msg, keys.Keys.ContainerRestart):
-		return base.UpdateResult{Cmd: s.confirmContainerRestart(), Handled: true}
+		return base.UpdateResult{Cmd: s.showRestartForm(), Handled: true}
 	case key.Matches(msg, keys.Keys.ContainerPauseUnpause):
 		return base.UpdateResult{Cmd: s.confirmContainerPauseUnpause(), Handled: true}
 	case key.Matches(msg, keys.Keys.ContainerKill):
-		return base.UpdateResult{Cmd: s.confirmContainerKill(), Handled: true}
+		return base.UpdateResult{Cmd: s.killFormCmd(), Handled: true}
+	case key.Matches(msg, keys.Keys.ContainerMergeLogs):
+		return base.UpdateResult{Cmd: s.showMergedLogs(), Handled: true}
 	}
 	return base.UpdateResult{}
 }
 
+// logTargets follows the marked containers, merging their logs, once M
+// switched merging on, or the selected one otherwise. Unmarking every
+// container switches merging off.
+func (s *Section) logTargets(item sections.ListItem) []logTarget {
+	if len(s.MarkedItems()) == 0 {
+		s.mergeLogs = false
+	}
+	if !s.mergeLogs {
+		return selectedLogTarget(item)
+	}
+	var targets []logTarget
+	for _, ci := range s.targetContainers() {
+		targets = append(targets, containerLogTarget(ci))
+	}
+	return targets
+}
+
+// listedContainers returns every container in the list.
+func (s *Section) listedContainers() []client.Container {
+	var containers []client.Container
+	for _, item := range s.List.Items() {
+		if ci, ok := item.(containerItem); ok {
+			containers = append(containers, ci.container)
+		}
+	}
+	return containers
+}
+
+// showMergedLogs switches merging the logs of the marked containers on or
+// off, and opens the Logs panel on the result.
+func (s *Section) showMergedLogs() tea.Cmd {
+	if !s.mergeLogs && len(s.MarkedItems()) == 0 {
+		return func() tea.Msg {
+			return message.ShowBannerMsg{Message: "Mark containers with space to merge their logs", IsError: true}
+		}
+	}
+	s.mergeLogs = !s.mergeLogs
+	log.Printf("[containers] merge logs of marked: %t", s.mergeLogs)
+	return s.ShowPanel(logsPanelName)
+}
+
 func (s *Section) deleteContainerCmd() tea.Cmd {
 	ctx := s.ctx
 	svc := s.service
@@ -241,76 +582,53 @@ func (s *Section) deleteContainerCmd() tea.Cmd {
 	}
 }
 
-func (s *Section) toggleContainerCmd() tea.Cmd {
+// toggleContainerCmd starts ci, or stops it with opts when it is running. ci
+// is captured when the action is chosen, as the list may reload before a form
+// asking for opts is submitted.
+func (s *Section) toggleContainerCmd(ci containerItem, opts client.ContainerStopOptions) tea.Cmd {
 	ctx := s.ctx
 	svc := s.service
-	items := s.List.Items()
-	idx := s.List.Index()
-	if idx < 0 || idx >= len(items) {
-		return nil
-	}
-
-	ci, ok := items[idx].(containerItem)
-	if !ok {
-		return nil
-	}
-
 	container := ci.container
 	return func() tea.Msg {
 		var err error
 		var action string
 		if container.State == client.StateRunning {
 			action = "stopping"
-			err = svc.Stop(ctx, container.ID)
+			err = svc.Stop(ctx, container.ID, opts)
 		} else {
 			action = "starting"
 			err = svc.Start(ctx, container.ID)
 		}
-		return containerActionMsg{ID: container.ID, Action: action, Idx: idx, Error: err}
+		return containerActionMsg{ID: container.ID, Action: action, Error: err}
 	}
 }
 
-func (s *Section) restartContainerCmd() tea.Cmd {
+// restartContainerCmd restarts ci, stopping it with opts.
+func (s *Section) restartContainerCmd(ci containerItem, opts client.ContainerStopOptions) tea.Cmd {
+	ctx := s.ctx
 	svc := s.service
-	items := s.List.Items()
-	idx := s.List.Index()
-	if idx < 0 || idx >= len(items) {
-		return nil
-	}
-
-	ci, ok := items[idx].(containerItem)
-	if !ok {
-		return nil
-	}
-
 	return func() tea.Msg {
-		err := svc.Restart(s.ctx, ci.ID())
-		return containerActionMsg{ID: ci.ID(), Action: "restarting", Idx: idx, Error: err}
+		err := svc.Restart(ctx, ci.ID(), opts)
+		return containerActionMsg{ID: ci.ID(), Action: "restarting", Error: err}
 	}
 }
 
-func (s *Section) killContainerCmd() tea.Cmd {
+func (s *Section) killContainersCmd(targets []containerItem, signal string) tea.Cmd {
 	ctx := s.ctx
 	svc := s.service
-	items := s.List.Items()
-	idx := s.List.Index()
-	if idx < 0 || idx >= len(items) {
-		return nil
-	}
-
-	ci, ok := items[idx].(containerItem)
-	if !ok {
-		return nil
-	}
-
 	return func() tea.Msg {
-		err := svc.Kill(ctx, ci.ID(), "SIGKILL")
-		return containerActionMsg{ID: ci.ID(), Action: "terminating", Idx: idx, Error: err}
+		results := make([]containerActionMsg, 0, len(targets))
+		for _, ci := range targets {
+			err := svc.Kill(ctx, ci.ID(), signal)
+			results = append(results, containerActionMsg{ID: ci.ID(), Action: "signalling", Error: err})
+		}
+		return containersSignalledMsg{signal: signal, results: results}
 	}
 }
 
 func (s *Section) updateContainersCmd() tea.Cmd {
 	svc := s.service
+	usage := s.usage
 	return func() tea.Msg {
 		containers, err := svc.List(s.ctx)
 		if err != nil {
@@ -318,16 +636,25 @@ func (s *Section) updateContainersCmd() tea.Cmd {
 		}
 		items := make([]list.Item, len(containers))
 		for idx, container := range containers {
-			items[idx] = containerItem{container: container}
+			items[idx] = containerItem{container: container, usage: usage}
 		}
 		return containersLoadedMsg{items: items}
 	}
 }
 
-func (s *Section) pruneContainersCmd() tea.Cmd {
+// previewContainerPruneCmd lists the containers a prune with opts would remove.
+func (s *Section) previewContainerPruneCmd(opts client.PruneOptions) tea.Cmd {
 	ctx, svc := s.ctx, s.service
 	return func() tea.Msg {
-		report, err := svc.Prune(ctx, client.PruneOptions{})
+		candidates, err := svc.PrunePreview(ctx, opts)
+		return containersPrunePreviewMsg{opts: opts, candidates: candidates, err: err}
+	}
+}
+
+func (s *Section) pruneContainersCmd(opts client.PruneOptions) tea.Cmd {
+	ctx, svc := s.ctx, s.service
+	return func() tea.Msg {
+		report, err := svc.Prune(ctx, opts)
 		return containersPrunedMsg{report: report, err: err}
 	}
 }
@@ -361,12 +688,13 @@ func (s *Section) pauseUnpauseContainerCmd() tea.Cmd {
 	}
 }
 
-func (s *Section) confirmContainerPrune() tea.Cmd {
-	pruneCmd := s.pruneContainersCmd()
+// confirmContainerPrune shows the containers of the dry-run before pruning them.
+func (s *Section) confirmContainerPrune(opts client.PruneOptions, candidates []client.PruneCandidate) tea.Cmd {
+	pruneCmd := s.pruneContainersCmd(opts)
 	return func() tea.Msg {
 		return message.ShowConfirmationMsg{
 			Title:     "Prune Containers",
-			Body:      "Remove all stopped containers?",
+			Body:      prune.Body("container", candidates),
 			OnConfirm: s.WithSpinner(pruneCmd),
 		}
 	}
@@ -392,21 +720,40 @@ func (s *Section) confirmContainerDelete() tea.Cmd {
 	}
 }
 
-func (s *Section) confirmContainerKill() tea.Cmd {
-	items := s.List.Items()
-	idx := s.List.Index()
-	if idx < 0 || idx >= len(items) {
+// killFormCmd asks which signal to send to the marked containers, or to the
+// selected one when nothing is marked. SIGKILL is preselected.
+func (s *Section) killFormCmd() tea.Cmd {
+	targets := s.targetContainers()
+	if len(targets) == 0 {
 		return nil
 	}
 
-	killCmd := s.killContainerCmd()
+	formTitle := "Kill Container"
+	title := "Signal to send to container " + helper.ShortID(targets[0].ID())
+	if len(targets) > 1 {
+		formTitle = "Kill Containers"
+		title = fmt.Sprintf("Signal to send to %d containers", len(targets))
+	}
+	signal, custom := "SIGKILL", ""
+	f := newSignalForm(title, &signal, &custom)
+	killForm := form.New(formTitle, f, func(_ *huh.Form) tea.Cmd {
+		return s.WithSpinner(s.killContainersCmd(targets, resolveSignal(signal, custom)))
+	})
 	return func() tea.Msg {
-		return message.ShowConfirmationMsg{
-			Title:     "Kill Container",
-			Body:      "Force kill container ? This sends SIGKILL",
-			OnConfirm: s.WithSpinner(killCmd),
-		}
+		return message.ShowFormMsg{Form: killForm}
+	}
+}
+
+// targetContainers returns the marked containers, or the selected one when
+// nothing is marked.
+func (s *Section) targetContainers() []containerItem {
+	if marked := s.markedContainers(); len(marked) > 0 {
+		return marked
+	}
+	if ci, ok := s.List.SelectedItem().(containerItem); ok {
+		return []containerItem{ci}
 	}
+	return nil
 }
 
 func (s *Section) confirmContainerToggle() tea.Cmd {
@@ -419,37 +766,54 @@ func (s *Section) confirmContainerToggle() tea.Cmd {
 	if !ok {
 		return nil
 	}
-	action := "Stop"
-	if ci.container.State != client.StateRunning {
-		action = "Start"
+	if ci.container.State == client.StateRunning {
+		return s.showStopForm(ci)
 	}
-	toggleCmd := s.toggleContainerCmd()
+	toggleCmd := s.toggleContainerCmd(ci, client.ContainerStopOptions{})
 	return func() tea.Msg {
 		return message.ShowConfirmationMsg{
-			Title:     fmt.Sprintf("%s Container", action),
-			Body:      fmt.Sprintf("%s container %s?", action, helper.ShortID(ci.ID())),
+			Title:     "Start Container",
+			Body:      fmt.Sprintf("Start container %s?", helper.ShortID(ci.ID())),
 			OnConfirm: s.WithSpinner(toggleCmd),
 		}
 	}
 }
 
-func (s *Section) confirmContainerRestart() tea.Cmd {
-	items := s.List.Items()
-	idx := s.List.Index()
-	if idx < 0 || idx >= len(items) {
-		return nil
+// showStopForm asks for the stop timeout and signal, prefilled with the
+// configured defaults for the container.
+func (s *Section) showStopForm(ci containerItem) tea.Cmd {
+	timeout, signal := s.stopCfg.Defaults(ci.container.Name, ci.container.Labels)
+	f := stopForm(&timeout, &signal)
+	stopContainerForm := form.New(
+		fmt.Sprintf("Stop — %s", ci.container.Name),
+		f,
+		func(_ *huh.Form) tea.Cmd {
+			return s.WithSpinner(s.toggleContainerCmd(ci, buildStopOptions(timeout, signal)))
+		},
+	)
+	return func() tea.Msg {
+		return message.ShowFormMsg{Form: stopContainerForm}
 	}
-	ci, ok := items[idx].(containerItem)
+}
+
+// showRestartForm asks for the timeout and signal used to stop the container
+// before it starts again, prefilled with the configured defaults.
+func (s *Section) showRestartForm() tea.Cmd {
+	ci, ok := s.List.SelectedItem().(containerItem)
 	if !ok {
 		return nil
 	}
-	restartCmd := s.restartContainerCmd()
+	timeout, signal := s.stopCfg.Defaults(ci.container.Name, ci.container.Labels)
+	f := stopForm(&timeout, &signal)
+	restartForm := form.New(
+		fmt.Sprintf("Restart — %s", ci.container.Name),
+		f,
+		func(_ *huh.Form) tea.Cmd {
+			return s.WithSpinner(s.restartContainerCmd(ci, buildStopOptions(timeout, signal)))
+		},
+	)
 	return func() tea.Msg {
-		return message.ShowConfirmationMsg{
-			Title:     "Restart Container",
-			Body:      fmt.Sprintf("Restart container %s?", helper.ShortID(ci.ID())),
-			OnConfirm: s.WithSpinner(restartCmd),
-		}
+		return message.ShowFormMsg{Form: restartForm}
 	}
 }
 
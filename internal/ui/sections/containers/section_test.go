@@ -4,29 +4,96 @@ import (
 	"context"
 	"errors"
 	"io"
+	"slices"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
 	"charm.land/bubbles/v2/list"
 	tea "charm.land/bubbletea/v2"
+	"charm.land/huh/v2"
 
 	"github.com/charmbracelet/x/exp/teatest/v2"
 
 	"github.com/GustavoCaso/docker-dash/internal/client"
 	"github.com/GustavoCaso/docker-dash/internal/config"
+	"github.com/GustavoCaso/docker-dash/internal/ui/components/form"
 	"github.com/GustavoCaso/docker-dash/internal/ui/message"
+	"github.com/GustavoCaso/docker-dash/internal/ui/theme"
 )
 
 type containerSectionModel struct {
 	section *Section
+	form    *activeForm
+	// banner is the last banner the section showed.
+	banner *message.ShowBannerMsg
+}
+
+// activeForm holds the form shown by the section, if any. It is a pointer so
+// the value-receiver model can replace it.
+type activeForm struct {
+	model *form.Model
 }
 
 func newContainerSectionModel() containerSectionModel {
-	client := client.NewMockClient()
-	section := New(context.Background(), client.Containers(), config.DefaultLogsConfig())
+	return newContainerSectionModelWith(client.NewMockClient().Containers(), config.StopConfig{})
+}
+
+func newContainerSectionModelWith(svc client.ContainerService, stopCfg config.StopConfig) containerSectionModel {
+	section := New(
+		context.Background(),
+		svc,
+		config.DefaultLogsConfig(),
+		stopCfg,
+		config.StatsConfig{},
+		config.AlertsConfig{},
+		config.TableConfig{},
+	)
 	section.SetSize(120, 40)
-	return containerSectionModel{section: section}
+	return containerSectionModel{section: section, form: &activeForm{}, banner: &message.ShowBannerMsg{}}
+}
+
+// stopRecorder records the options passed to Stop and Restart, and the
+// containers they were passed for.
+type stopRecorder struct {
+	client.ContainerService
+	mu   sync.Mutex
+	ids  []string
+	opts []client.ContainerStopOptions
+}
+
+func (r *stopRecorder) Stop(ctx context.Context, id string, opts client.ContainerStopOptions) error {
+	r.record(id, opts)
+	return r.ContainerService.Stop(ctx, id, opts)
+}
+
+func (r *stopRecorder) Restart(ctx context.Context, id string, opts client.ContainerStopOptions) error {
+	r.record(id, opts)
+	return r.ContainerService.Restart(ctx, id, opts)
+}
+
+func (r *stopRecorder) record(id string, opts client.ContainerStopOptions) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.ids = append(r.ids, id)
+	r.opts = append(r.opts, opts)
+}
+
+func (r *stopRecorder) recorded() []client.ContainerStopOptions {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return slices.Clone(r.opts)
+}
+
+// recordedFor returns the options recorded for the container with id.
+func (r *stopRecorder) recordedFor(id string) (client.ContainerStopOptions, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if i := slices.Index(r.ids, id); i >= 0 {
+		return r.opts[i], true
+	}
+	return client.ContainerStopOptions{}, false
 }
 
 func (m containerSectionModel) Init() tea.Cmd { return m.section.Init() }
@@ -38,6 +105,26 @@ func (m containerSectionModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	if confirmMsg, ok := msg.(message.ShowConfirmationMsg); ok {
 		return m, confirmMsg.OnConfirm
 	}
+	if bannerMsg, ok := msg.(message.ShowBannerMsg); ok {
+		*m.banner = bannerMsg
+		return m, nil
+	}
+	// Mirror the app's form handling: the form owns the keyboard while shown.
+	if formMsg, ok := msg.(message.ShowFormMsg); ok {
+		m.form.model = formMsg.Form
+		return m, formMsg.Form.Init()
+	}
+	if m.form.model != nil {
+		var formCmd tea.Cmd
+		m.form.model, formCmd = m.form.model.Update(msg)
+		if m.form.model.State() == huh.StateCompleted {
+			m.form.model = nil
+		}
+		if _, isKey := msg.(tea.KeyPressMsg); isKey {
+			return m, formCmd
+		}
+		return m, tea.Batch(formCmd, m.section.Update(msg))
+	}
 	// Mirror the app's BubbleUpMsg handling: forward the key to the section.
 	if bubbleMsg, ok := msg.(message.BubbleUpMsg); ok {
 		return m, m.section.Update(bubbleMsg.KeyMsg)
@@ -83,6 +170,10 @@ func TestContainerListStartStop(t *testing.T) {
 		tea.KeyPressMsg{Code: 's', Text: "s"},
 	) // We stop the container with id "abc123def456" or the first container
 	time.Sleep(500 * time.Millisecond)
+	// Accept the default timeout and signal in the stop form.
+	tm.Send(tea.KeyPressMsg{Code: tea.KeyEnter})
+	tm.Send(tea.KeyPressMsg{Code: tea.KeyEnter})
+	time.Sleep(500 * time.Millisecond)
 	tm.Send(tea.KeyPressMsg{Code: 'q', Text: "q"})
 	fm := tm.FinalModel(t, teatest.WithFinalTimeout(time.Second))
 
@@ -138,13 +229,21 @@ func TestContainerListDelete(t *testing.T) {
 
 func TestContainerListExecMouseScroll(t *testing.T) {
 	dockerClient := client.NewMockClient()
-	section := New(context.Background(), dockerClient.Containers(), config.DefaultLogsConfig())
+	section := New(
+		context.Background(),
+		dockerClient.Containers(),
+		config.DefaultLogsConfig(),
+		config.StopConfig{},
+		config.StatsConfig{},
+		config.AlertsConfig{},
+		config.TableConfig{},
+	)
 	section.SetSize(120, 40)
 
 	// Set focus on panels
 	section.Update(tea.KeyPressMsg{Code: tea.KeyTab})
-	// Navigate to exec panel (details=0, logs=1, stats=2, files=3 exec=4)
-	// Moving one to the left wraps to exec (index 4)
+	// Navigate to exec panel (details=0, logs=1, stats=2, health=3, processes=4, files=5, exec=6)
+	// Moving one to the left wraps to exec (index 6)
 	section.Update(tea.KeyPressMsg{Code: tea.KeyLeft, Mod: tea.ModShift})
 	ep := section.ActivePanel().(*execPanel)
 
@@ -164,24 +263,32 @@ func TestContainerListExecMouseScroll(t *testing.T) {
 
 func TestActivePanelClosedOnLogsSessionClose(t *testing.T) {
 	dockerClient := client.NewMockClient()
-	section := New(context.Background(), dockerClient.Containers(), config.DefaultLogsConfig())
+	section := New(
+		context.Background(),
+		dockerClient.Containers(),
+		config.DefaultLogsConfig(),
+		config.StopConfig{},
+		config.StatsConfig{},
+		config.AlertsConfig{},
+		config.TableConfig{},
+	)
 	section.SetSize(120, 40)
 
 	// Set focus on panels
 	section.Update(tea.KeyPressMsg{Code: tea.KeyTab})
-	// Navigate to stats panel (details=0, logs=1, stats=2, files=3 exec=4)
+	// Navigate to logs panel (details=0, logs=1, stats=2, health=3, processes=4, files=5, exec=6)
 	section.Update(tea.KeyPressMsg{Code: tea.KeyRight, Mod: tea.ModShift})
 	lp := section.ActivePanel().(*logsPanel)
 
 	pr, pw := io.Pipe()
 	lp.logsSession = client.NewLogsSession(io.NopCloser(pr), func() { pr.Close(); pw.Close() })
-	lp.appendLines("some logs\n")
+	lp.appendLines(stdoutLines("some logs"))
 
 	// Simulate exec close which calls activePanel().Close()
 	section.ActivePanel().Close()
 
-	if len(lp.list.Items()) != 0 {
-		t.Errorf("Close() should clear list items, got %d", len(lp.list.Items()))
+	if lp.list.Len() != 0 {
+		t.Errorf("Close() should clear list items, got %d", lp.list.Len())
 	}
 }
 
@@ -225,6 +332,9 @@ func TestContainerKill(t *testing.T) {
 		tea.KeyPressMsg{Code: 'K', Text: "K"},
 	) // We kill the container with id "abc123def456" or the first container
 	time.Sleep(500 * time.Millisecond)
+	// Accept the preselected SIGKILL in the signal picker.
+	tm.Send(tea.KeyPressMsg{Code: tea.KeyEnter})
+	time.Sleep(500 * time.Millisecond)
 	tm.Send(tea.KeyPressMsg{Code: 'q', Text: "q"})
 	fm := tm.FinalModel(t, teatest.WithFinalTimeout(time.Second))
 	m, ok := fm.(containerSectionModel)
@@ -247,9 +357,40 @@ func TestContainerKill(t *testing.T) {
 	t.Fatal("not found nginx:latest container")
 }
 
+func TestContainerItemDescriptionShowsLifecycle(t *testing.T) {
+	crashed := containerItem{container: client.Container{
+		ID:           "pqr678stu901",
+		State:        client.StateStopped,
+		ExitCode:     137,
+		OOMKilled:    true,
+		RestartCount: 5,
+	}}
+	desc := crashed.Description()
+	for _, want := range []string{"stopped (137)", theme.IconOOMKilled, theme.IconCrashLoop + " 5"} {
+		if !strings.Contains(desc, want) {
+			t.Errorf("Description() = %q, want it to contain %q", desc, want)
+		}
+	}
+
+	clean := containerItem{container: client.Container{ID: "jkl012mno345", State: client.StateStopped, RestartCount: 5}}
+	desc = clean.Description()
+	if strings.Contains(desc, "(0)") || strings.Contains(desc, theme.IconOOMKilled) ||
+		strings.Contains(desc, theme.IconCrashLoop) {
+		t.Errorf("Description() of a cleanly stopped container = %q, want no lifecycle badges", desc)
+	}
+}
+
 func TestContainersLoadedMsgCallsUpdateItems(t *testing.T) {
 	c := client.NewMockClient()
-	section := New(context.Background(), c.Containers(), config.DefaultLogsConfig())
+	section := New(
+		context.Background(),
+		c.Containers(),
+		config.DefaultLogsConfig(),
+		config.StopConfig{},
+		config.StatsConfig{},
+		config.AlertsConfig{},
+		config.TableConfig{},
+	)
 	section.SetSize(120, 40)
 
 	if len(section.List.Items()) != 0 {
@@ -267,9 +408,42 @@ func TestContainersLoadedMsgCallsUpdateItems(t *testing.T) {
 	}
 }
 
+func TestContainersListShowsUsage(t *testing.T) {
+	c := client.NewMockClient()
+	statsCfg := config.StatsConfig{ListColumns: config.StatsColumnsValues}
+	section := New(
+		context.Background(),
+		c.Containers(),
+		config.DefaultLogsConfig(),
+		config.StopConfig{},
+		statsCfg,
+		config.AlertsConfig{},
+		config.TableConfig{},
+	)
+	section.SetSize(200, 40)
+	section.Update(section.RefreshCmd()())
+	if !section.collector.running {
+		t.Fatal("loading the containers should start the stats collector")
+	}
+
+	section.Update(section.collector.sampleCmd(section.collector.requestID)())
+	desc := section.List.Items()[0].(containerItem).Description()
+	if !strings.Contains(desc, "CPU - MEM 64.0 MB") {
+		t.Errorf("Description() = %q, want the usage of the running container", desc)
+	}
+}
+
 func TestContainersLoadedMsgEmptyCallsUpdateItemsReset(t *testing.T) {
 	c := client.NewMockClient()
-	section := New(context.Background(), c.Containers(), config.DefaultLogsConfig())
+	section := New(
+		context.Background(),
+		c.Containers(),
+		config.DefaultLogsConfig(),
+		config.StopConfig{},
+		config.StatsConfig{},
+		config.AlertsConfig{},
+		config.TableConfig{},
+	)
 	section.SetSize(120, 40)
 
 	section.Update(section.RefreshCmd()())
@@ -295,6 +469,10 @@ func TestContainerRestart(t *testing.T) {
 
 	tm.Send(tea.KeyPressMsg{Code: 'R', Text: "R"})
 	time.Sleep(500 * time.Millisecond)
+	// Accept the default timeout and signal in the restart form.
+	tm.Send(tea.KeyPressMsg{Code: tea.KeyEnter})
+	tm.Send(tea.KeyPressMsg{Code: tea.KeyEnter})
+	time.Sleep(500 * time.Millisecond)
 	tm.Send(tea.KeyPressMsg{Code: 'q', Text: "q"})
 	fm := tm.FinalModel(t, teatest.WithFinalTimeout(time.Second))
 
@@ -320,7 +498,15 @@ func TestContainerRestart(t *testing.T) {
 
 func TestContainerDeleteConfirmationMsg(t *testing.T) {
 	c := client.NewMockClient()
-	section := New(context.Background(), c.Containers(), config.DefaultLogsConfig())
+	section := New(
+		context.Background(),
+		c.Containers(),
+		config.DefaultLogsConfig(),
+		config.StopConfig{},
+		config.StatsConfig{},
+		config.AlertsConfig{},
+		config.TableConfig{},
+	)
 	section.SetSize(120, 40)
 	section.Update(section.RefreshCmd()())
 
@@ -341,29 +527,228 @@ func TestContainerDeleteConfirmationMsg(t *testing.T) {
 	}
 }
 
-func TestContainerRestartConfirmationMsg(t *testing.T) {
+func TestContainerRestartShowsStopForm(t *testing.T) {
 	c := client.NewMockClient()
-	section := New(context.Background(), c.Containers(), config.DefaultLogsConfig())
+	section := New(
+		context.Background(),
+		c.Containers(),
+		config.DefaultLogsConfig(),
+		config.StopConfig{},
+		config.StatsConfig{},
+		config.AlertsConfig{},
+		config.TableConfig{},
+	)
 	section.SetSize(120, 40)
 	section.Update(section.RefreshCmd()())
 
-	cmd := section.confirmContainerRestart()
+	cmd := section.Update(tea.KeyPressMsg{Code: 'R', Text: "R"})
 	if cmd == nil {
-		t.Fatal("confirmContainerRestart should return non-nil cmd")
+		t.Fatal("restart key should return a cmd")
 	}
-	msg := cmd()
-	confirm, ok := msg.(message.ShowConfirmationMsg)
+	if _, ok := cmd().(message.ShowFormMsg); !ok {
+		t.Error("restart key should show the stop options form")
+	}
+}
+
+func TestContainerStopUsesConfiguredDefaults(t *testing.T) {
+	recorder := &stopRecorder{ContainerService: client.NewMockClient().Containers()}
+	stopCfg := config.StopConfig{
+		Timeout: "10s",
+		Rules: []config.StopRule{
+			{Container: "nginx-proxy", Timeout: "2m", Signal: "SIGQUIT"},
+		},
+	}
+	tm := teatest.NewTestModel(
+		t,
+		newContainerSectionModelWith(recorder, stopCfg),
+		teatest.WithInitialTermSize(120, 40),
+	)
+	time.Sleep(500 * time.Millisecond)
+
+	tm.Send(tea.KeyPressMsg{Code: 's', Text: "s"}) // nginx-proxy is the first container
+	time.Sleep(500 * time.Millisecond)
+	tm.Send(tea.KeyPressMsg{Code: tea.KeyEnter})
+	tm.Send(tea.KeyPressMsg{Code: tea.KeyEnter})
+	time.Sleep(500 * time.Millisecond)
+	tm.Send(tea.KeyPressMsg{Code: 'q', Text: "q"})
+	tm.WaitFinished(t, teatest.WithFinalTimeout(time.Second))
+
+	recorded := recorder.recorded()
+	if len(recorded) != 1 {
+		t.Fatalf("Stop called %d times, want 1", len(recorded))
+	}
+	opts := recorded[0]
+	if opts.Timeout == nil || *opts.Timeout != 2*time.Minute || opts.Signal != "SIGQUIT" {
+		t.Errorf("Stop options = timeout %v signal %q, want 2m0s SIGQUIT", opts.Timeout, opts.Signal)
+	}
+}
+
+func TestContainerStopFormKeepsTheContainerAcrossReloads(t *testing.T) {
+	c := client.NewMockClient()
+	model := newContainerSectionModelWith(c.Containers(), config.StopConfig{})
+	tm := teatest.NewTestModel(t, model, teatest.WithInitialTermSize(120, 40))
+	time.Sleep(500 * time.Millisecond)
+
+	tm.Send(tea.KeyPressMsg{Code: 's', Text: "s"}) // nginx-proxy is the first container
+	time.Sleep(500 * time.Millisecond)
+	// A reload while the form is open puts the stopped old-container first.
+	loaded, ok := model.section.RefreshCmd()().(containersLoadedMsg)
 	if !ok {
-		t.Fatalf("expected ShowConfirmationMsg, got %T", msg)
+		t.Fatal("RefreshCmd() should load the containers")
 	}
-	if confirm.Title != "Restart Container" {
-		t.Errorf("unexpected title: %q", confirm.Title)
+	slices.SortStableFunc(loaded.items, func(a, _ list.Item) int {
+		if a.(containerItem).container.Name == "old-container" {
+			return -1
+		}
+		return 0
+	})
+	tm.Send(loaded)
+	time.Sleep(200 * time.Millisecond)
+	tm.Send(tea.KeyPressMsg{Code: tea.KeyEnter})
+	tm.Send(tea.KeyPressMsg{Code: tea.KeyEnter})
+	time.Sleep(500 * time.Millisecond)
+	tm.Send(tea.KeyPressMsg{Code: 'q', Text: "q"})
+	tm.WaitFinished(t, teatest.WithFinalTimeout(time.Second))
+
+	containers, err := c.Containers().List(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, container := range containers {
+		switch container.Name {
+		case "nginx-proxy", "old-container":
+			if container.State != client.StateStopped {
+				t.Errorf("%s state = %s, want the form to stop nginx-proxy only", container.Name, container.State)
+			}
+		}
+	}
+}
+
+func TestContainerKillShowsSignalForm(t *testing.T) {
+	c := client.NewMockClient()
+	section := New(
+		context.Background(),
+		c.Containers(),
+		config.DefaultLogsConfig(),
+		config.StopConfig{},
+		config.StatsConfig{},
+		config.AlertsConfig{},
+		config.TableConfig{},
+	)
+	section.SetSize(120, 40)
+	section.Update(section.RefreshCmd()())
+
+	cmd := section.Update(tea.KeyPressMsg{Code: 'K', Text: "K"})
+	if cmd == nil {
+		t.Fatal("kill key should return a cmd")
+	}
+	if _, ok := cmd().(message.ShowFormMsg); !ok {
+		t.Error("kill key should show the signal form")
+	}
+}
+
+func TestContainerKillMarkedContainers(t *testing.T) {
+	c := client.NewMockClient()
+	section := New(
+		context.Background(),
+		c.Containers(),
+		config.DefaultLogsConfig(),
+		config.StopConfig{},
+		config.StatsConfig{},
+		config.AlertsConfig{},
+		config.TableConfig{},
+	)
+	section.SetSize(120, 40)
+	section.Update(section.RefreshCmd()())
+
+	// Mark abc123def456 (running) and jkl012mno345 (stopped).
+	section.Update(tea.KeyPressMsg{Code: tea.KeySpace, Text: " "})
+	for range 3 {
+		section.Update(tea.KeyPressMsg{Code: tea.KeyDown})
+	}
+	section.Update(tea.KeyPressMsg{Code: tea.KeySpace, Text: " "})
+
+	targets := section.targetContainers()
+	if len(targets) != 2 || targets[0].ID() != "abc123def456" || targets[1].ID() != "jkl012mno345" {
+		t.Fatalf("targetContainers() = %v, want the two marked containers", targets)
+	}
+
+	result := section.handleMsg(section.killContainersCmd(targets, "SIGKILL")())
+	if !result.Handled {
+		t.Fatal("expected containersSignalledMsg to be handled")
+	}
+	if !result.RefreshAllSections {
+		t.Error("expected RefreshAllSections when a signal was delivered")
+	}
+	banner, ok := result.Cmd().(message.ShowBannerMsg)
+	if !ok {
+		t.Fatalf("expected ShowBannerMsg, got %T", result.Cmd())
+	}
+	if !banner.IsError || !strings.Contains(banner.Message, "Sent SIGKILL to 1 of 2 containers") ||
+		!strings.Contains(banner.Message, "jkl012mno345") {
+		t.Errorf("unexpected summary banner: %#v", banner)
+	}
+	if len(section.MarkedItems()) != 0 {
+		t.Error("marks should be cleared after the kill")
+	}
+
+	containers, err := c.Containers().List(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, container := range containers {
+		if container.ID == "abc123def456" && container.State != client.StateStopped {
+			t.Errorf("abc123def456 state = %s, want stopped", container.State)
+		}
+	}
+}
+
+func TestContainersSignalledMsgSingle(t *testing.T) {
+	c := client.NewMockClient()
+	section := New(
+		context.Background(),
+		c.Containers(),
+		config.DefaultLogsConfig(),
+		config.StopConfig{},
+		config.StatsConfig{},
+		config.AlertsConfig{},
+		config.TableConfig{},
+	)
+	section.SetSize(120, 40)
+
+	result := section.handleMsg(containersSignalledMsg{
+		signal:  "SIGHUP",
+		results: []containerActionMsg{{ID: "abc123def456"}},
+	})
+	banner, ok := result.Cmd().(message.ShowBannerMsg)
+	if !ok || banner.IsError || banner.Message != "Sent SIGHUP to container abc123def456" {
+		t.Errorf("unexpected banner: %#v", result.Cmd())
+	}
+
+	result = section.handleMsg(containersSignalledMsg{
+		signal:  "SIGHUP",
+		results: []containerActionMsg{{ID: "abc123def456", Error: errors.New("permission denied")}},
+	})
+	if !result.StopSpinner || result.RefreshAllSections {
+		t.Error("a failed signal should stop the spinner without refreshing")
+	}
+	banner, ok = result.Cmd().(message.ShowBannerMsg)
+	if !ok || !banner.IsError || !strings.Contains(banner.Message, "permission denied") {
+		t.Errorf("unexpected banner: %#v", result.Cmd())
 	}
 }
 
 func TestContainerActionMsgError(t *testing.T) {
 	c := client.NewMockClient()
-	section := New(context.Background(), c.Containers(), config.DefaultLogsConfig())
+	section := New(
+		context.Background(),
+		c.Containers(),
+		config.DefaultLogsConfig(),
+		config.StopConfig{},
+		config.StatsConfig{},
+		config.AlertsConfig{},
+		config.TableConfig{},
+	)
 	section.SetSize(120, 40)
 
 	result := section.handleMsg(containerActionMsg{
@@ -390,7 +775,15 @@ func TestContainerActionMsgError(t *testing.T) {
 
 func TestContainersPrunedMsgError(t *testing.T) {
 	c := client.NewMockClient()
-	section := New(context.Background(), c.Containers(), config.DefaultLogsConfig())
+	section := New(
+		context.Background(),
+		c.Containers(),
+		config.DefaultLogsConfig(),
+		config.StopConfig{},
+		config.StatsConfig{},
+		config.AlertsConfig{},
+		config.TableConfig{},
+	)
 	section.SetSize(120, 40)
 
 	result := section.handleMsg(containersPrunedMsg{err: errors.New("prune failed")})
@@ -412,7 +805,15 @@ func TestContainersPrunedMsgError(t *testing.T) {
 
 func TestContainersLoadedMsgError(t *testing.T) {
 	c := client.NewMockClient()
-	section := New(context.Background(), c.Containers(), config.DefaultLogsConfig())
+	section := New(
+		context.Background(),
+		c.Containers(),
+		config.DefaultLogsConfig(),
+		config.StopConfig{},
+		config.StatsConfig{},
+		config.AlertsConfig{},
+		config.TableConfig{},
+	)
 	section.SetSize(120, 40)
 
 	result := section.handleMsg(containersLoadedMsg{error: errors.New("connection refused")})
@@ -455,3 +856,81 @@ func TestFormatBytes(t *testing.T) {
 		})
 	}
 }
+
+func TestContainerMergeLogsShowsMarkedContainers(t *testing.T) {
+	section := New(
+		context.Background(),
+		client.NewMockClient().Containers(),
+		config.DefaultLogsConfig(),
+		config.StopConfig{},
+		config.StatsConfig{},
+		config.AlertsConfig{},
+		config.TableConfig{},
+	)
+	section.SetSize(120, 40)
+	section.Update(section.RefreshCmd()())
+
+	cmd := section.Update(tea.KeyPressMsg{Code: 'M', Text: "M"})
+	banner, ok := cmd().(message.ShowBannerMsg)
+	if !ok || !banner.IsError {
+		t.Fatalf("M without marks should show an error banner, got %T", cmd())
+	}
+
+	// Mark abc123def456 and def456ghi789.
+	section.Update(tea.KeyPressMsg{Code: tea.KeySpace, Text: " "})
+	section.Update(tea.KeyPressMsg{Code: tea.KeyDown})
+	section.Update(tea.KeyPressMsg{Code: tea.KeySpace, Text: " "})
+
+	if targets := section.logTargets(section.List.SelectedItem().(containerItem)); len(targets) != 1 {
+		t.Fatalf("marks alone should not merge the logs, targets = %+v", targets)
+	}
+
+	section.Update(tea.KeyPressMsg{Code: 'M', Text: "M"})
+	if section.ActivePanelName() != "Logs" || !section.IsPanelFocused() {
+		t.Fatalf("M should focus the Logs panel, active=%q", section.ActivePanelName())
+	}
+	logs, ok := section.ActivePanel().(*logsPanel)
+	if !ok {
+		t.Fatalf("active panel is %T, want *logsPanel", section.ActivePanel())
+	}
+	if len(logs.prefixes) != 2 {
+		t.Fatalf("prefixes = %v, want one per marked container", logs.prefixes)
+	}
+	if prefix := logs.prefixes["api-server"].Text; prefix != "api-server  | " {
+		t.Errorf("api-server prefix = %q, want it padded to the longest name", prefix)
+	}
+
+	section.Update(tea.KeyPressMsg{Code: 'M', Text: "M"})
+	if logs, ok = section.ActivePanel().(*logsPanel); !ok || len(logs.prefixes) != 0 {
+		t.Errorf("M again should follow the selected container, prefixes = %v", logs.prefixes)
+	}
+}
+
+func TestContainerEventsReloadTheListOnce(t *testing.T) {
+	m := newContainerSectionModel()
+	section := m.section
+	section.Update(section.RefreshCmd()())
+	if !section.listening {
+		t.Fatal("the first load should subscribe to the container events")
+	}
+
+	events := make(chan client.ContainerEvent)
+	msg := containerEventMsg{event: client.ContainerEvent{ID: "abc123def456", Action: "start"}, events: events}
+	if section.Update(msg) == nil || !section.reloadPending {
+		t.Fatal("an event should schedule a reload of the list")
+	}
+	section.Update(msg)
+
+	loaded, ok := section.Update(eventsReloadMsg{})().(containersLoadedMsg)
+	if !ok || loaded.error != nil || len(loaded.items) == 0 {
+		t.Errorf("the scheduled reload should load the containers, got %+v", loaded)
+	}
+	if section.reloadPending {
+		t.Error("the reload should allow the next event to schedule another")
+	}
+
+	close(events)
+	if _, ok := waitForEventCmd(events, nil)().(containerEventsEndedMsg); !ok {
+		t.Error("a closed stream should end the events")
+	}
+}
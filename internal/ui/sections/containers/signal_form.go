@@ -0,0 +1,84 @@
+package containers
+
+import (
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"charm.land/huh/v2"
+)
+
+// signalOptions are the signals offered by the signal picker, in menu order.
+var signalOptions = []string{"SIGTERM", "SIGHUP", "SIGUSR1", "SIGUSR2", "SIGINT", "SIGKILL"}
+
+// customSignalOption is the picker value that reveals the free-form signal
+// input.
+const customSignalOption = "other"
+
+// signalNamePattern matches a normalized signal name such as SIGWINCH or
+// SIGRTMIN+3.
+var signalNamePattern = regexp.MustCompile(`^SIG[A-Z][A-Z0-9]*([+-][0-9]+)?$`)
+
+// newSignalForm builds a picker for the signals in signalOptions plus an
+// "Other…" entry that asks for a signal name or number. The choice is bound
+// to signal and custom; use resolveSignal to turn them into the signal to send.
+func newSignalForm(title string, signal, custom *string) *huh.Form {
+	options := huh.NewOptions(signalOptions...)
+	options = append(options, huh.NewOption("Other…", customSignalOption))
+
+	return huh.NewForm(
+		huh.NewGroup(
+			huh.NewSelect[string]().
+				Key("signal").
+				Title(title).
+				Options(options...).
+				Value(signal),
+		),
+		huh.NewGroup(
+			huh.NewInput().
+				Key("custom").
+				Title("Signal").
+				Placeholder("SIGWINCH or 28").
+				Validate(validateSignal).
+				Value(custom),
+		).WithHideFunc(func() bool { return *signal != customSignalOption }),
+	)
+}
+
+// resolveSignal returns the signal picked in a form built by newSignalForm.
+func resolveSignal(signal, custom string) string {
+	if signal == customSignalOption {
+		return normalizeSignal(custom)
+	}
+	return signal
+}
+
+// normalizeSignal upper-cases a signal name and adds the SIG prefix, so
+// "hup" becomes "SIGHUP". Signal numbers are returned unchanged.
+func normalizeSignal(signal string) string {
+	signal = strings.ToUpper(strings.TrimSpace(signal))
+	if _, err := strconv.Atoi(signal); err == nil {
+		return signal
+	}
+	if !strings.HasPrefix(signal, "SIG") {
+		signal = "SIG" + signal
+	}
+	return signal
+}
+
+// validateSignal accepts a signal name, with or without the SIG prefix, or a
+// positive signal number.
+func validateSignal(signal string) error {
+	signal = normalizeSignal(signal)
+	if n, err := strconv.Atoi(signal); err == nil {
+		if n <= 0 {
+			return errors.New("signal number must be positive")
+		}
+		return nil
+	}
+	if !signalNamePattern.MatchString(signal) {
+		return errors.New("enter a signal name like SIGWINCH or a number")
+	}
+	return nil
+}
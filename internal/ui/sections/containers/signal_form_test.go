@@ -0,0 +1,49 @@
+package containers
+
+import (
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+func TestResolveSignal(t *testing.T) {
+	tests := []struct {
+		signal, custom, want string
+	}{
+		{signal: "SIGHUP", custom: "ignored", want: "SIGHUP"},
+		{signal: customSignalOption, custom: "winch", want: "SIGWINCH"},
+		{signal: customSignalOption, custom: " sigrtmin+3 ", want: "SIGRTMIN+3"},
+		{signal: customSignalOption, custom: "28", want: "28"},
+	}
+	for _, tt := range tests {
+		if got := resolveSignal(tt.signal, tt.custom); got != tt.want {
+			t.Errorf("resolveSignal(%q, %q) = %q, want %q", tt.signal, tt.custom, got, tt.want)
+		}
+	}
+}
+
+func TestValidateSignal(t *testing.T) {
+	for _, valid := range []string{"SIGWINCH", "hup", "SIGRTMIN+3", "28"} {
+		if err := validateSignal(valid); err != nil {
+			t.Errorf("validateSignal(%q) = %v, want nil", valid, err)
+		}
+	}
+	for _, invalid := range []string{"", "0", "-9", "SIG HUP", "kill;rm"} {
+		if err := validateSignal(invalid); err == nil {
+			t.Errorf("validateSignal(%q) = nil, want an error", invalid)
+		}
+	}
+}
+
+func TestSignalFormStartsOnPreselectedSignal(t *testing.T) {
+	signal, custom := "SIGHUP", ""
+	f := newSignalForm("Signal", &signal, &custom)
+	f.Init()
+
+	f.Update(tea.KeyPressMsg{Code: tea.KeyDown})
+
+	// SIGUSR1 follows SIGHUP in signalOptions.
+	if got := resolveSignal(signal, custom); got != "SIGUSR1" {
+		t.Errorf("resolveSignal() after moving down from SIGHUP = %q, want SIGUSR1", got)
+	}
+}
@@ -0,0 +1,254 @@
+package containers
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/GustavoCaso/docker-dash/internal/client"
+)
+
+const (
+	statsInterval = 2 * time.Second
+	// statsStallTimeout is how long the collector waits for the tick after a
+	// sample before it takes the sampling chain for lost.
+	statsStallTimeout = 2 * statsInterval
+	// statsLimit caps the stats requests sent to the daemon at once.
+	statsLimit = 8
+	// usageHistoryLength is the number of samples kept for the sparklines.
+	usageHistoryLength = 8
+)
+
+// statsUser names what the usage of running containers is collected for.
+type statsUser string
+
+const (
+	statsForList    statsUser = "list"
+	statsForTop     statsUser = "top"
+	statsForStats   statsUser = "stats"
+	statsForHistory statsUser = "history"
+	statsForAlerts  statsUser = "alerts"
+)
+
+// statsSampledMsg is sent when a stats sample of every running container has
+// been taken. Containers whose sample failed, such as one that just stopped,
+// are left out.
+type statsSampledMsg struct {
+	requestID  int
+	containers []client.Container
+	samples    map[string]client.ContainerStats
+}
+
+// statsTickMsg triggers the next sample of the stats collector.
+type statsTickMsg struct {
+	requestID int
+}
+
+// containerUsage is the latest sample of a running container. The rates are
+// only known from the second sample on.
+type containerUsage struct {
+	container client.Container
+	stats     client.ContainerStats
+	rates     client.StatsRates
+	hasRates  bool
+	// cpuHistory and memHistory hold the CPU and memory percentages of the
+	// last samples, oldest first.
+	cpuHistory []float64
+	memHistory []float64
+}
+
+// statsCollector samples the usage of every running container every
+// statsInterval while it has a user, so the container list columns, the Top
+// and Stats panels, the usage alerts and the usage history share the same
+// samples. The history is only a user when it is configured. Every sample
+// with rates goes to the recorder. The section routes its messages to update.
+type statsCollector struct {
+	ctx        context.Context
+	service    client.ContainerService
+	containers func() []client.Container
+	recorder   *metricsRecorder
+	usage      map[string]*containerUsage // by container ID
+	users      map[statsUser]bool
+	sampled    bool // a sample was taken since the collector started
+	running    bool
+	// sampling is set while a sample command is in flight. Sampling many
+	// containers can take longer than statsStallTimeout.
+	sampling  bool
+	requestID int
+	// progressAt is when the sampling chain last moved on: when it started,
+	// or when a sample or a tick arrived.
+	progressAt time.Time
+}
+
+func newStatsCollector(
+	ctx context.Context,
+	svc client.ContainerService,
+	containers func() []client.Container,
+	recorder *metricsRecorder,
+) *statsCollector {
+	return &statsCollector{
+		ctx:        ctx,
+		service:    svc,
+		containers: containers,
+		recorder:   recorder,
+		usage:      map[string]*containerUsage{},
+		users:      map[statsUser]bool{},
+	}
+}
+
+// want starts or stops collecting for user. Sampling stops once no user is
+// left; it starts right away when the first one comes. A chain whose tick
+// was lost is started again, keeping the samples taken so far; a sample
+// still in flight is never given up on.
+func (c *statsCollector) want(user statsUser, on bool) tea.Cmd {
+	if !on {
+		delete(c.users, user)
+		return nil
+	}
+	c.users[user] = true
+	if c.running {
+		if c.sampling || time.Since(c.progressAt) <= statsStallTimeout {
+			return nil
+		}
+		log.Printf("[containers][stats] sampling stalled since %s, restarting", c.progressAt.Format(time.TimeOnly))
+	} else {
+		log.Printf("[containers][stats] start collecting for %s", user)
+		c.running = true
+		c.sampled = false
+		clear(c.usage)
+	}
+	c.requestID++
+	c.progressAt = time.Now()
+	c.sampling = true
+	return c.sampleCmd(c.requestID)
+}
+
+// usageOf returns the latest usage of the container with id, or nil.
+func (c *statsCollector) usageOf(id string) *containerUsage {
+	if c == nil {
+		return nil
+	}
+	return c.usage[id]
+}
+
+// update handles the collector messages and reports whether msg was one.
+func (c *statsCollector) update(msg tea.Msg) (tea.Cmd, bool) {
+	switch msg := msg.(type) {
+	case statsSampledMsg:
+		if msg.requestID != c.requestID || !c.running {
+			return nil, true
+		}
+		c.progressAt = time.Now()
+		c.sampling = false
+		c.record(msg.containers, msg.samples)
+		if len(c.users) == 0 {
+			log.Printf("[containers][stats] stop collecting")
+			c.running = false
+			return nil, true
+		}
+		requestID := msg.requestID
+		return tea.Tick(statsInterval, func(_ time.Time) tea.Msg {
+			return statsTickMsg{requestID: requestID}
+		}), true
+	case statsTickMsg:
+		if msg.requestID != c.requestID || !c.running {
+			return nil, true
+		}
+		c.progressAt = time.Now()
+		if len(c.users) == 0 {
+			log.Printf("[containers][stats] stop collecting")
+			c.running = false
+			return nil, true
+		}
+		c.sampling = true
+		return c.sampleCmd(msg.requestID), true
+	}
+	return nil, false
+}
+
+// record keeps the new samples, computing the rates from the previous sample
+// of each container, and passes them to the recorder. Containers left out of
+// samples are forgotten.
+func (c *statsCollector) record(containers []client.Container, samples map[string]client.ContainerStats) {
+	c.sampled = true
+	usage := make(map[string]*containerUsage, len(samples))
+	points := make(map[string]metricPoint, len(samples))
+	for _, container := range containers {
+		stats, ok := samples[container.ID]
+		if !ok {
+			continue
+		}
+		u := c.usage[container.ID]
+		if u == nil {
+			u = &containerUsage{}
+		} else {
+			u.rates = stats.RatesSince(u.stats)
+			u.hasRates = true
+			u.cpuHistory = appendHistory(u.cpuHistory, u.rates.CPUPercent)
+			points[container.ID] = newMetricPoint(stats, u.rates)
+		}
+		u.container = container
+		u.stats = stats
+		u.memHistory = appendHistory(u.memHistory, stats.MemoryPercent())
+		usage[container.ID] = u
+	}
+	c.usage = usage
+	c.recorder.record(points)
+}
+
+// appendHistory appends v to history, keeping the last usageHistoryLength
+// values.
+func appendHistory(history []float64, v float64) []float64 {
+	history = append(history, v)
+	if len(history) > usageHistoryLength {
+		history = history[len(history)-usageHistoryLength:]
+	}
+	return history
+}
+
+// sampleCmd samples the running containers, at most statsLimit at a time.
+func (c *statsCollector) sampleCmd(requestID int) tea.Cmd {
+	ctx := c.ctx
+	svc := c.service
+	var running []client.Container
+	for _, container := range c.containers() {
+		if container.State == client.StateRunning {
+			running = append(running, container)
+		}
+	}
+	return func() tea.Msg {
+		return statsSampledMsg{requestID: requestID, containers: running, samples: sampleStats(ctx, svc, running)}
+	}
+}
+
+// sampleStats takes a stats sample of each container, at most statsLimit at
+// a time. Containers whose sample fails are left out.
+func sampleStats(
+	ctx context.Context,
+	svc client.ContainerService,
+	containers []client.Container,
+) map[string]client.ContainerStats {
+	var mu sync.Mutex
+	samples := make(map[string]client.ContainerStats, len(containers))
+	var group errgroup.Group
+	group.SetLimit(statsLimit)
+	for _, container := range containers {
+		group.Go(func() error {
+			stats, err := svc.StatsOnce(ctx, container.ID)
+			if err != nil {
+				log.Printf("[containers][stats] sample: containerID=%q err=%v", container.ID, err)
+				return nil
+			}
+			mu.Lock()
+			samples[container.ID] = stats
+			mu.Unlock()
+			return nil
+		})
+	}
+	_ = group.Wait()
+	return samples
+}
@@ -0,0 +1,142 @@
+package containers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/GustavoCaso/docker-dash/internal/client"
+	"github.com/GustavoCaso/docker-dash/internal/config"
+	"github.com/GustavoCaso/docker-dash/internal/ui/theme"
+)
+
+func TestStatsCollectorSamplesRunningContainers(t *testing.T) {
+	c := newTestCollector([]client.Container{
+		{ID: "abc123def456", Name: "nginx-proxy", State: client.StateRunning},
+		{ID: "missing", Name: "gone", State: client.StateRunning},
+		{ID: "c", Name: "old", State: client.StateStopped},
+	})
+	cmd := c.want(statsForList, true)
+	if cmd == nil {
+		t.Fatal("want() should start sampling")
+	}
+	msg, ok := cmd().(statsSampledMsg)
+	if !ok {
+		t.Fatal("the sample cmd should return a statsSampledMsg")
+	}
+	if len(msg.containers) != 2 {
+		t.Errorf("sampled %d containers, want the running ones only", len(msg.containers))
+	}
+	if _, ok := msg.samples["abc123def456"]; !ok || len(msg.samples) != 1 {
+		t.Errorf("samples = %v, want the containers whose sample succeeded", msg.samples)
+	}
+	if c.want(statsForTop, true) != nil {
+		t.Error("want() while sampling should not start another sample")
+	}
+
+	next, handled := c.update(msg)
+	if !handled || next == nil || c.usageOf("abc123def456") == nil {
+		t.Error("update() should record the samples and schedule the next one")
+	}
+}
+
+func TestStatsCollectorStopsWithoutUsers(t *testing.T) {
+	c := newTestCollector(topContainers)
+	c.want(statsForTop, true)
+	c.want(statsForTop, false)
+
+	next, _ := c.update(statsSampledMsg{requestID: c.requestID})
+	if next != nil || c.running {
+		t.Error("the collector should stop once no user is left")
+	}
+	if c.want(statsForList, true) == nil {
+		t.Error("want() should start sampling again")
+	}
+	if _, _ = c.update(statsSampledMsg{requestID: c.requestID - 1}); c.sampled {
+		t.Error("samples of a previous run should be dropped")
+	}
+}
+
+func TestStatsCollectorRestartsAStalledChain(t *testing.T) {
+	c := newTestCollector(topContainers)
+	c.want(statsForList, true)
+	c.update(statsSampledMsg{
+		requestID:  c.requestID,
+		containers: topContainers,
+		samples:    map[string]client.ContainerStats{"a": topSample(10, 1, 100)},
+	})
+	requestID := c.requestID
+
+	// The tick that would carry the chain on was lost.
+	c.progressAt = time.Now().Add(-statsStallTimeout - time.Second)
+	cmd := c.want(statsForTop, true)
+	if cmd == nil || c.requestID == requestID {
+		t.Fatal("want() should restart a stalled chain")
+	}
+	if c.usageOf("a") == nil {
+		t.Error("restarting a stalled chain should keep the samples taken so far")
+	}
+	if next, _ := c.update(statsTickMsg{requestID: requestID}); next != nil {
+		t.Error("messages of the stalled chain should be dropped")
+	}
+	if _, ok := cmd().(statsSampledMsg); !ok {
+		t.Error("the restarted chain should take a sample")
+	}
+}
+
+func TestStatsCollectorWaitsForASlowSample(t *testing.T) {
+	c := newTestCollector(topContainers)
+	c.want(statsForList, true)
+	requestID := c.requestID
+
+	// Sampling many containers can outlast the stall timeout.
+	c.progressAt = time.Now().Add(-statsStallTimeout - time.Second)
+	if c.want(statsForHistory, true) != nil || c.requestID != requestID {
+		t.Fatal("want() should not give up on a sample still in flight")
+	}
+	if next, _ := c.update(statsSampledMsg{requestID: requestID}); next == nil {
+		t.Error("the slow sample should carry the chain on")
+	}
+}
+
+func TestStatsCollectorForgetsStoppedContainers(t *testing.T) {
+	c := newTestCollector(topContainers)
+	c.record(topContainers, map[string]client.ContainerStats{"a": topSample(10, 1, 100), "b": topSample(10, 1, 1)})
+	c.record(topContainers, map[string]client.ContainerStats{"a": topSample(20, 6, 200)})
+
+	if c.usageOf("b") != nil {
+		t.Error("a container left out of a sample should be forgotten")
+	}
+	a := c.usageOf("a")
+	if a == nil || !a.hasRates || a.rates.CPUPercent != 50 || len(a.cpuHistory) != 1 || len(a.memHistory) != 2 {
+		t.Errorf("usage of a = %+v, want the rates and history of both samples", a)
+	}
+}
+
+func TestListUsage(t *testing.T) {
+	c := newTestCollector(topContainers)
+	c.record(topContainers, map[string]client.ContainerStats{"a": topSample(10, 1, 100), "b": topSample(10, 1, 950)})
+	c.record(topContainers, map[string]client.ContainerStats{"a": topSample(20, 9, 100), "b": topSample(20, 1, 950)})
+
+	values := &listUsage{collector: c, cfg: config.StatsConfig{ListColumns: config.StatsColumnsValues}}
+	if got := values.describe("a"); got != "CPU 80.0% MEM 100 B" {
+		t.Errorf("describe() = %q, want the CPU and memory values", got)
+	}
+	sparklines := &listUsage{collector: c, cfg: config.StatsConfig{ListColumns: config.StatsColumnsSparklines}}
+	if got := sparklines.describe("a"); got != "CPU ▆ MEM ▁▁" {
+		t.Errorf("describe() = %q, want sparklines", got)
+	}
+	if got := (&listUsage{collector: c}).describe("a"); got != "" {
+		t.Errorf("describe() = %q, want nothing when the columns are off", got)
+	}
+
+	thresholds := &listUsage{collector: c, cfg: config.StatsConfig{CPUThreshold: 50, MemoryThreshold: 90}}
+	for id, want := range map[string]bool{"a": true, "b": true, "c": false} {
+		color, tinted := thresholds.tint(id)
+		if tinted != want || (tinted && color != theme.StatusError) {
+			t.Errorf("tint(%q) = %v, %v, want tinted %v", id, color, tinted, want)
+		}
+	}
+	if _, tinted := (&listUsage{collector: c}).tint("a"); tinted {
+		t.Error("tint() without thresholds should not tint")
+	}
+}
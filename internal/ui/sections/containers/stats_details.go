@@ -0,0 +1,77 @@
+package containers
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	lipgloss "charm.land/lipgloss/v2"
+
+	"github.com/GustavoCaso/docker-dash/internal/client"
+)
+
+// details breaks down the latest sample of the container: PIDs against their
+// limit, CPU throttling, memory by kind, and the rates of each network
+// interface and block device. It is empty when the container is not sampled.
+func (s *statsPanel) details() string {
+	usage := s.collector.usageOf(s.containerID)
+	if usage == nil {
+		return ""
+	}
+	stats := usage.stats
+
+	pids := "PIDs " + strconv.FormatUint(stats.PIDs, 10)
+	if stats.PIDsLimit > 0 {
+		pids += " / " + strconv.FormatUint(stats.PIDsLimit, 10)
+	} else {
+		pids += " (no limit)"
+	}
+	throttling := "CPU throttling: no quota"
+	if stats.CPUPeriods > 0 {
+		throttled := "-"
+		if usage.hasRates {
+			throttled = formatPercent(usage.rates.ThrottledPercent)
+		}
+		throttling = fmt.Sprintf("CPU throttled %s of periods (%d of %d, %s in total)",
+			throttled, stats.ThrottledPeriods, stats.CPUPeriods,
+			time.Duration(stats.ThrottledTime).Round(time.Millisecond)) //nolint:gosec // a duration
+	}
+	memory := fmt.Sprintf("Memory rss %s  cache %s  swap %s",
+		formatBytes(stats.MemoryRSS), formatBytes(stats.MemoryCache), formatBytes(stats.MemorySwap))
+
+	lines := []string{
+		pids + "   " + throttling,
+		memory,
+		"Network " + formatIORates(stats.Networks, usage.rates.Networks, usage.hasRates, "rx", "tx"),
+		"Block I/O " + formatIORates(stats.BlockDevices, usage.rates.BlockDevices, usage.hasRates, "r", "w"),
+	}
+	for i, line := range lines {
+		lines[i] = truncate(line, s.width)
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, lines...)
+}
+
+// formatIORates lists the rates of each network interface or block device of
+// counters, by name, or "-" for each until the rates are known.
+func formatIORates(
+	counters map[string]client.IOCounters,
+	rates map[string]client.IORates,
+	hasRates bool,
+	in, out string,
+) string {
+	if len(counters) == 0 {
+		return "none"
+	}
+	parts := make([]string, 0, len(counters))
+	for _, name := range slices.Sorted(maps.Keys(counters)) {
+		inRate, outRate := "-", "-"
+		if hasRates {
+			inRate, outRate = formatRate(rates[name].In), formatRate(rates[name].Out)
+		}
+		parts = append(parts, fmt.Sprintf("%s %s %s %s %s", name, in, inRate, out, outRate))
+	}
+	return strings.Join(parts, "  ")
+}
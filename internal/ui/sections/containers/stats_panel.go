@@ -1,28 +1,37 @@
 package containers
 
 import (
-	"context"
-	"encoding/json"
+	"encoding/csv"
 	"fmt"
+	"image/color"
+	"io"
 	"log"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
 
+	"charm.land/bubbles/v2/key"
 	tea "charm.land/bubbletea/v2"
+	"charm.land/huh/v2"
 	lipgloss "charm.land/lipgloss/v2"
 	"github.com/NimbleMarkets/ntcharts/canvas/runes"
 	"github.com/NimbleMarkets/ntcharts/linechart/streamlinechart"
 	oldlipgloss "github.com/charmbracelet/lipgloss"
-	"github.com/docker/docker/api/types/container"
 
-	"github.com/GustavoCaso/docker-dash/internal/client"
+	"github.com/GustavoCaso/docker-dash/internal/ui/components/form"
+	"github.com/GustavoCaso/docker-dash/internal/ui/keys"
 	"github.com/GustavoCaso/docker-dash/internal/ui/message"
 	"github.com/GustavoCaso/docker-dash/internal/ui/sections"
 	"github.com/GustavoCaso/docker-dash/internal/ui/theme"
 )
 
 const (
-	chartHalves      = 2     // divisor to split chart space in half
-	netIOChartLines  = 2     // lines reserved for net/io chart label and legend
-	cpuPercentFactor = 100.0 // multiplier to convert CPU ratio to percentage
+	chartHalves     = 2 // divisor to split chart space in half
+	netIOChartLines = 3 // lines reserved for net/io chart label and legends
+	statsHeaderRows = 1 // line reserved for the window selector
+	statsDetailRows = 4 // lines reserved for the details of the latest sample
 )
 
 // Chart color hex values mirroring theme constants, for use with ntcharts (old lipgloss).
@@ -33,6 +42,28 @@ const (
 	chartColorRed    = "#E74C3C" // theme.StatusError
 )
 
+// writeDataSet is the chart data set of the network and block IO writes.
+const writeDataSet = "write"
+
+// The time ranges the Stats panel charts.
+const (
+	statsWindowShort  = time.Minute
+	statsWindowMedium = 15 * time.Minute
+	statsWindowLong   = time.Hour
+)
+
+// statsWindow is a time range charted by the Stats panel.
+type statsWindow struct {
+	label    string
+	duration time.Duration
+}
+
+var statsWindows = []statsWindow{
+	{label: "1m", duration: statsWindowShort},
+	{label: "15m", duration: statsWindowMedium},
+	{label: "1h", duration: statsWindowLong},
+}
+
 func formatBytes(b uint64) string {
 	const unit = 1024
 	if b < unit {
@@ -46,32 +77,33 @@ func formatBytes(b uint64) string {
 	return fmt.Sprintf("%.1f %ciB", float64(b)/float64(div), "KMGTPE"[exp])
 }
 
+func formatPercent(v float64) string {
+	return fmt.Sprintf("%.2f%%", v)
+}
+
 // chartStyle builds an old lipgloss.Style for use with ntcharts given a hex color string.
 func chartStyle(hex string) oldlipgloss.Style {
 	return oldlipgloss.NewStyle().Foreground(oldlipgloss.Color(hex))
 }
 
-// statsOutputMsg is sent when stats output is received.
-type statsOutputMsg struct {
-	cpuPercetange    float64
-	memoryPercentage float64
-	memoryUsage      float64
-	memoryLimit      float64
-	networkRead      float64
-	networkWrite     float64
-	ioRead           float64
-	ioWrite          float64
-	err              error
-}
-
-type statsSessionStartedMsg struct {
-	session *client.StatsSession
+// statsExportedMsg reports the result of a CSV export.
+type statsExportedMsg struct {
+	path string
+	rows int
+	err  error
 }
 
+// statsPanel charts the usage of the selected container over the last
+// minute, 15 minutes or hour. The points come from the section's metrics
+// recorder, so the charts keep their history when the panel is reopened.
+// Below the charts, the latest sample of a running container is broken down
+// by network interface, block device and kind of memory.
 type statsPanel struct {
-	ctx          context.Context
-	service      client.ContainerService
-	session      *client.StatsSession
+	collector    *statsCollector
+	recorder     *metricsRecorder
+	containerID  string
+	name         string
+	window       int // index in statsWindows
 	cpuChart     streamlinechart.Model
 	memChart     streamlinechart.Model
 	networkChart streamlinechart.Model
@@ -81,10 +113,10 @@ type statsPanel struct {
 	height       int
 }
 
-func NewStatsPanel(ctx context.Context, svc client.ContainerService) sections.Panel {
+func newStatsPanel(collector *statsCollector) *statsPanel {
 	return &statsPanel{
-		ctx:     ctx,
-		service: svc,
+		collector: collector,
+		recorder:  collector.recorder,
 		cpuChart: streamlinechart.New(
 			1, 1,
 			streamlinechart.WithStyles(runes.ArcLineStyle, chartStyle(chartColorBlue)),
@@ -97,7 +129,7 @@ func NewStatsPanel(ctx context.Context, svc client.ContainerService) sections.Pa
 			1, 1,
 			streamlinechart.WithStyles(runes.ArcLineStyle, chartStyle(chartColorGreen)),
 			streamlinechart.WithDataSetStyles(
-				"write",
+				writeDataSet,
 				runes.ArcLineStyle,
 				chartStyle(chartColorOrange),
 			),
@@ -106,7 +138,7 @@ func NewStatsPanel(ctx context.Context, svc client.ContainerService) sections.Pa
 			1, 1,
 			streamlinechart.WithStyles(runes.ArcLineStyle, chartStyle(chartColorBlue)),
 			streamlinechart.WithDataSetStyles(
-				"write",
+				writeDataSet,
 				runes.ArcLineStyle,
 				chartStyle(chartColorRed),
 			),
@@ -118,77 +150,33 @@ func (s *statsPanel) Name() string {
 	return "Stats"
 }
 
+// Init charts the history recorded for the container and keeps the collector
+// sampling while the panel is open.
 func (s *statsPanel) Init(item sections.ListItem) tea.Cmd {
-	containerID := item.ID()
-	log.Printf("[containers][stats-panel] Init: containerID=%q", containerID)
-	return s.startSession(containerID)
+	s.containerID = item.ID()
+	s.name = s.containerID
+	if ci, ok := item.(containerItem); ok {
+		s.name = strings.TrimPrefix(ci.container.Name, "/")
+	}
+	log.Printf("[containers][stats-panel] Init: containerID=%q", s.containerID)
+	cmd := s.collector.want(statsForStats, true)
+	s.refresh()
+	return tea.Batch(cmd, s.extendHelpCmd())
 }
 
 func (s *statsPanel) Update(msg tea.Msg) tea.Cmd {
 	switch msg := msg.(type) {
-	case statsSessionStartedMsg:
-		log.Printf("[containers][stats-panel] session started")
-		s.session = msg.session
-		return s.readOutput()
-	case statsOutputMsg:
-		if msg.err != nil {
-			if s.session == nil {
-				return nil
-			}
-			s.Close()
-			return func() tea.Msg {
-				return message.ShowBannerMsg{
-					Message: fmt.Sprintf("Stats session error. Err: %s", msg.err),
-					IsError: true,
-				}
-			}
+	case statsExportedMsg:
+		return s.handleExported(msg)
+	case tea.KeyPressMsg:
+		switch {
+		case key.Matches(msg, keys.Keys.StatsWindow):
+			s.window = (s.window + 1) % len(statsWindows)
+			log.Printf("[containers][stats-panel] window %s", statsWindows[s.window].label)
+			s.refresh()
+		case key.Matches(msg, keys.Keys.StatsExport):
+			return s.exportFormCmd()
 		}
-
-		log.Printf("[containers][stats-panel] stats update received")
-		s.cpuChart.Push(msg.cpuPercetange)
-		s.cpuChart.Draw()
-		s.memChart.Push(msg.memoryPercentage)
-		s.memChart.Draw()
-		s.networkChart.Push(msg.networkRead)
-		s.networkChart.PushDataSet("write", msg.networkWrite)
-		s.networkChart.DrawAll()
-		s.ioChart.Push(msg.ioRead)
-		s.ioChart.PushDataSet("write", msg.ioWrite)
-		s.ioChart.DrawAll()
-
-		cpuLabel := fmt.Sprintf("CPU %.2f%%", msg.cpuPercetange)
-		memLabel := fmt.Sprintf("MEM %.2f%% (%s / %s)",
-			msg.memoryPercentage,
-			formatBytes(uint64(msg.memoryUsage)),
-			formatBytes(uint64(msg.memoryLimit)),
-		)
-		netLabel := fmt.Sprintf("NET  rx:%s tx:%s",
-			formatBytes(uint64(msg.networkRead)),
-			formatBytes(uint64(msg.networkWrite)),
-		)
-		ioLabel := fmt.Sprintf("I/O  r:%s w:%s",
-			formatBytes(uint64(msg.ioRead)),
-			formatBytes(uint64(msg.ioWrite)),
-		)
-
-		netReadLegend := noStyle.Foreground(theme.StatusRunning).Render("● read")
-		netWriteLegend := noStyle.Foreground(theme.StatusPaused).Render("● write")
-		netLegend := netReadLegend + "  " + netWriteLegend
-
-		ioReadLegend := noStyle.Foreground(theme.DockerBlue).Render("● read")
-		ioWriteLegend := noStyle.Foreground(theme.StatusError).Render("● write")
-		ioLegend := ioReadLegend + "  " + ioWriteLegend
-
-		row1 := lipgloss.JoinHorizontal(lipgloss.Top,
-			lipgloss.JoinVertical(lipgloss.Left, cpuLabel, s.cpuChart.View()),
-			lipgloss.JoinVertical(lipgloss.Left, memLabel, s.memChart.View()),
-		)
-		row2 := lipgloss.JoinHorizontal(lipgloss.Top,
-			lipgloss.JoinVertical(lipgloss.Left, netLabel, netLegend, s.networkChart.View()),
-			lipgloss.JoinVertical(lipgloss.Left, ioLabel, ioLegend, s.ioChart.View()),
-		)
-		s.lastView = lipgloss.JoinVertical(lipgloss.Left, row1, row2)
-		return s.readOutput()
 	}
 	return nil
 }
@@ -199,100 +187,278 @@ func (s *statsPanel) View() string {
 
 func (s *statsPanel) Close() tea.Cmd {
 	log.Printf("[containers][stats-panel] Close")
-	if s.session != nil {
-		s.session.Close()
-		s.session = nil
-	}
+	s.collector.want(statsForStats, false)
+	s.containerID = ""
 	s.cpuChart.ClearAllData()
 	s.memChart.ClearAllData()
 	s.ioChart.ClearAllData()
 	s.networkChart.ClearAllData()
 	s.lastView = ""
 
-	return nil
+	return func() tea.Msg { return message.ClearContextualKeyBindingsMsg{} }
 }
 
 func (s *statsPanel) SetSize(width, height int) {
 	s.width = width
 	s.height = height
 	chartWidth := width / chartHalves
-	cpuMemChartHeight := height/chartHalves - 1
-	netIOChartHeight := height/chartHalves - netIOChartLines
+	chartsHeight := height - statsHeaderRows - statsDetailRows
+	cpuMemChartHeight := chartsHeight/chartHalves - 1
+	netIOChartHeight := chartsHeight/chartHalves - netIOChartLines
 	s.cpuChart.Resize(chartWidth, cpuMemChartHeight)
 	s.memChart.Resize(chartWidth, cpuMemChartHeight)
 	s.networkChart.Resize(chartWidth, netIOChartHeight)
 	s.ioChart.Resize(chartWidth, netIOChartHeight)
+	if s.containerID != "" {
+		s.refresh()
+	}
 }
 
-func (s *statsPanel) startSession(containerID string) tea.Cmd {
-	ctx := s.ctx
-	svc := s.service
-	return func() tea.Msg {
-		session, err := svc.Stats(ctx, containerID)
-		if err != nil {
-			return statsOutputMsg{err: err}
+// refresh charts the points of the container recorded within the window.
+func (s *statsPanel) refresh() {
+	window := statsWindows[s.window]
+	now := time.Now()
+	points := s.recorder.points(s.containerID, now.Add(-window.duration))
+	header := s.windowSelector()
+	if len(points) == 0 {
+		s.lastView = lipgloss.JoinVertical(lipgloss.Left, header,
+			processHeaderStyle.Render("No usage recorded in the last "+window.label), s.details())
+		return
+	}
+
+	buckets := max(s.width/chartHalves, 1)
+	series := func(value func(metricPoint) float64) []float64 {
+		return bucketValues(points, now, window.duration, buckets, value)
+	}
+	cpu := func(p metricPoint) float64 { return p.CPUPercent }
+	mem := metricPoint.memoryPercent
+	netRx := func(p metricPoint) float64 { return p.NetworkRx }
+	netTx := func(p metricPoint) float64 { return p.NetworkTx }
+	ioRead := func(p metricPoint) float64 { return p.BlockRead }
+	ioWrite := func(p metricPoint) float64 { return p.BlockWrite }
+	plot(&s.cpuChart, series(cpu), nil)
+	plot(&s.memChart, series(mem), nil)
+	plot(&s.networkChart, series(netRx), series(netTx))
+	plot(&s.ioChart, series(ioRead), series(ioWrite))
+
+	last := points[len(points)-1]
+	cpuLabel := "CPU " + formatPercent(last.CPUPercent)
+	if last.ThrottledPercent > 0 {
+		cpuLabel += fmt.Sprintf(" (throttled %s)", formatPercent(last.ThrottledPercent))
+	}
+	cpuLabel += "  " + summarize(points, cpu).format(formatPercent)
+	memLabel := fmt.Sprintf("MEM %s (%s / %s)  %s",
+		formatPercent(last.memoryPercent()),
+		formatBytes(last.MemoryUsage),
+		formatBytes(last.MemoryLimit),
+		summarize(points, mem).format(formatPercent),
+	)
+	netLabel := fmt.Sprintf("NET  rx:%s tx:%s", formatRate(last.NetworkRx), formatRate(last.NetworkTx))
+	ioLabel := fmt.Sprintf("I/O  r:%s w:%s", formatRate(last.BlockRead), formatRate(last.BlockWrite))
+
+	legend := func(c color.Color, name string, value func(metricPoint) float64) string {
+		return noStyle.Foreground(c).Render("● "+name) + "  " +
+			summarize(points, value).format(formatRate)
+	}
+
+	row1 := lipgloss.JoinHorizontal(lipgloss.Top,
+		lipgloss.JoinVertical(lipgloss.Left, cpuLabel, s.cpuChart.View()),
+		lipgloss.JoinVertical(lipgloss.Left, memLabel, s.memChart.View()),
+	)
+	row2 := lipgloss.JoinHorizontal(lipgloss.Top,
+		lipgloss.JoinVertical(lipgloss.Left,
+			netLabel,
+			legend(theme.StatusRunning, "read", netRx),
+			legend(theme.StatusPaused, "write", netTx),
+			s.networkChart.View(),
+		),
+		lipgloss.JoinVertical(lipgloss.Left,
+			ioLabel,
+			legend(theme.DockerBlue, "read", ioRead),
+			legend(theme.StatusError, "write", ioWrite),
+			s.ioChart.View(),
+		),
+	)
+	s.lastView = lipgloss.JoinVertical(lipgloss.Left, header, row1, row2, s.details())
+}
+
+// windowSelector shows the windows the panel can chart, the current one
+// highlighted.
+func (s *statsPanel) windowSelector() string {
+	labels := make([]string, len(statsWindows))
+	for i, w := range statsWindows {
+		labels[i] = " " + w.label + " "
+		if i == s.window {
+			labels[i] = theme.SelectedLogLine.Render(labels[i])
 		}
-		return statsSessionStartedMsg{session: session}
 	}
+	return processHeaderStyle.Render("Window ") + strings.Join(labels, " ")
+}
+
+// plot replaces the data of chart with reads, and writes for the charts that
+// have a second data set, and draws it.
+func plot(chart *streamlinechart.Model, reads, writes []float64) {
+	chart.ClearAllData()
+	chart.ClearDataSet(writeDataSet)
+	chart.SetYRange(0, 1)
+	chart.SetViewYRange(0, 1)
+	for _, v := range reads {
+		chart.Push(v)
+	}
+	for _, v := range writes {
+		chart.PushDataSet(writeDataSet, v)
+	}
+	chart.DrawAll()
 }
 
-func (s *statsPanel) readOutput() tea.Cmd {
-	session := s.session
-	if session == nil {
+// bucketValues splits the window ending at now into buckets and returns the
+// average value of the points in each, from the first bucket with a point.
+// Buckets without points repeat the previous value.
+func bucketValues(
+	points []metricPoint,
+	now time.Time,
+	window time.Duration,
+	buckets int,
+	value func(metricPoint) float64,
+) []float64 {
+	if len(points) == 0 {
 		return nil
 	}
-	return func() tea.Msg {
-		buf := make([]byte, readBufSize)
-		n, err := session.Reader.Read(buf)
-		if err != nil {
-			return statsOutputMsg{err: err}
-		}
-		var stats container.StatsResponse
-		err = json.Unmarshal(buf[:n], &stats)
-		if err != nil {
-			return statsOutputMsg{err: err}
+	start := now.Add(-window)
+	sums := make([]float64, buckets)
+	counts := make([]int, buckets)
+	for _, p := range points {
+		i := int(int64(p.Time.Sub(start)) * int64(buckets) / int64(window))
+		i = min(max(i, 0), buckets-1)
+		sums[i] += value(p)
+		counts[i]++
+	}
+	var values []float64
+	for i := range buckets {
+		switch {
+		case counts[i] > 0:
+			values = append(values, sums[i]/float64(counts[i]))
+		case len(values) > 0:
+			values = append(values, values[len(values)-1])
 		}
+	}
+	return values
+}
 
-		cpuDelta := stats.CPUStats.CPUUsage.TotalUsage - stats.PreCPUStats.CPUUsage.TotalUsage
-		systemCPUDelta := stats.CPUStats.SystemUsage - stats.PreCPUStats.SystemUsage
-		numberCpus := stats.CPUStats.OnlineCPUs
+// metricSummary is the minimum, maximum and average of a metric.
+type metricSummary struct {
+	min, max, avg float64
+}
 
-		percentageCPUUsage := float64(cpuDelta) / float64(systemCPUDelta) * float64(numberCpus) * cpuPercentFactor
+func summarize(points []metricPoint, value func(metricPoint) float64) metricSummary {
+	if len(points) == 0 {
+		return metricSummary{}
+	}
+	summary := metricSummary{min: value(points[0]), max: value(points[0])}
+	var sum float64
+	for _, p := range points {
+		v := value(p)
+		summary.min = min(summary.min, v)
+		summary.max = max(summary.max, v)
+		sum += v
+	}
+	summary.avg = sum / float64(len(points))
+	return summary
+}
 
-		memUsage := float64(stats.MemoryStats.Usage) - float64(stats.MemoryStats.Stats["cache"])
-		memLimit := float64(stats.MemoryStats.Limit)
-		var memPercentage float64
-		if memLimit > 0 {
-			memPercentage = memUsage / memLimit * cpuPercentFactor
-		}
+func (m metricSummary) format(value func(float64) string) string {
+	return fmt.Sprintf("min %s avg %s max %s", value(m.min), value(m.avg), value(m.max))
+}
 
-		networkRead := float64(0)
-		networkWrite := float64(0)
-		for _, stat := range stats.Networks {
-			networkRead += float64(stat.RxBytes)
-			networkWrite += float64(stat.TxBytes)
-		}
+func (s *statsPanel) extendHelpCmd() tea.Cmd {
+	return func() tea.Msg {
+		return message.AddContextualKeyBindingsMsg{Bindings: []key.Binding{
+			keys.Keys.StatsWindow,
+			keys.Keys.StatsExport,
+		}}
+	}
+}
 
-		ioRead := float64(0)
-		ioWrite := float64(0)
-		for _, stat := range stats.BlkioStats.IoServiceBytesRecursive {
-			if stat.Op == "read" {
-				ioRead += float64(stat.Value)
-			}
-			if stat.Op == "write" {
-				ioWrite += float64(stat.Value)
-			}
+// exportFormCmd asks where to save the points of the current window as CSV.
+func (s *statsPanel) exportFormCmd() tea.Cmd {
+	if s.containerID == "" {
+		return nil
+	}
+	id := s.containerID
+	window := statsWindows[s.window]
+	path := s.name + "-stats-" + time.Now().Format("20060102-150405") + ".csv"
+	f := huh.NewForm(huh.NewGroup(
+		huh.NewInput().
+			Key("path").
+			Title("File").
+			Description("Path on this host. Relative paths are resolved from the current directory.").
+			Value(&path).
+			Validate(validateExportPath),
+	))
+	exportForm := form.New("Save Stats — "+window.label, f, func(_ *huh.Form) tea.Cmd {
+		points := slices.Clone(s.recorder.points(id, time.Now().Add(-window.duration)))
+		return exportStatsCmd(strings.TrimSpace(path), points)
+	})
+	return func() tea.Msg {
+		return message.ShowFormMsg{Form: exportForm}
+	}
+}
+
+func exportStatsCmd(path string, points []metricPoint) tea.Cmd {
+	log.Printf("[containers][stats-panel] export: path=%q points=%d", path, len(points))
+	return func() tea.Msg {
+		file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, exportFileMode)
+		if err != nil {
+			return statsExportedMsg{path: path, err: err}
 		}
+		err = writeStatsCSV(file, points)
+		if closeErr := file.Close(); err == nil {
+			err = closeErr
+		}
+		return statsExportedMsg{path: path, rows: len(points), err: err}
+	}
+}
 
-		return statsOutputMsg{
-			cpuPercetange:    percentageCPUUsage,
-			memoryPercentage: memPercentage,
-			memoryUsage:      memUsage,
-			memoryLimit:      memLimit,
-			networkRead:      networkRead,
-			networkWrite:     networkWrite,
-			ioRead:           ioRead,
-			ioWrite:          ioWrite,
+// writeStatsCSV writes points as CSV with a header row. Rates are in bytes per
+// second.
+func writeStatsCSV(w io.Writer, points []metricPoint) error {
+	cw := csv.NewWriter(w)
+	_ = cw.Write([]string{
+		"time", "cpu_percent", "throttled_percent", "memory_usage", "memory_limit", "memory_percent",
+		"network_rx", "network_tx", "block_read", "block_write",
+	})
+	number := func(v float64) string { return strconv.FormatFloat(v, 'f', 2, 64) }
+	for _, p := range points {
+		_ = cw.Write([]string{
+			p.Time.Format(time.RFC3339),
+			number(p.CPUPercent),
+			number(p.ThrottledPercent),
+			strconv.FormatUint(p.MemoryUsage, 10),
+			strconv.FormatUint(p.MemoryLimit, 10),
+			number(p.memoryPercent()),
+			number(p.NetworkRx),
+			number(p.NetworkTx),
+			number(p.BlockRead),
+			number(p.BlockWrite),
+		})
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func (s *statsPanel) handleExported(msg statsExportedMsg) tea.Cmd {
+	if msg.err != nil {
+		log.Printf("[containers][stats-panel] export failed: %v", msg.err)
+		return func() tea.Msg {
+			return message.ShowBannerMsg{
+				Message: fmt.Sprintf("Failed to save stats to %s: %s", msg.path, msg.err),
+				IsError: true,
+			}
 		}
 	}
+	text := fmt.Sprintf("Saved %d samples to %s", msg.rows, msg.path)
+	log.Printf("[containers][stats-panel] %s", text)
+	return func() tea.Msg {
+		return message.ShowBannerMsg{Message: text}
+	}
 }
@@ -1,139 +1,119 @@
 package containers
 
 import (
-	"context"
-	"errors"
-	"io"
+	"bytes"
 	"strings"
 	"testing"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
 
 	"github.com/GustavoCaso/docker-dash/internal/client"
 	"github.com/GustavoCaso/docker-dash/internal/ui/message"
 )
 
 func newTestStatsPanel() *statsPanel {
-	return NewStatsPanel(context.Background(), client.NewMockClient().Containers()).(*statsPanel)
+	p := newStatsPanel(newTestCollector(topContainers))
+	p.SetSize(160, 40)
+	return p
 }
 
-func TestStatsPanelInitStartsSession(t *testing.T) {
-	p := newTestStatsPanel()
-	cmd := p.Init(containerItem{container: client.Container{ID: "abc123def456"}}) // running container in mock
-	if cmd == nil {
-		t.Fatal("Init() returned nil cmd")
-	}
-	msg := cmd()
-	sessionMsg, ok := msg.(statsSessionStartedMsg)
-	if !ok {
-		t.Fatalf("Init() cmd returned %T, want statsSessionStartedMsg", msg)
-	}
-	if sessionMsg.session == nil {
-		t.Error("statsSessionStartedMsg.session is nil")
-	}
-	sessionMsg.session.Close()
+// recordPoint records a point of the container a taken ago with cpu percent.
+func recordPoint(p *statsPanel, ago time.Duration, cpu float64) {
+	p.recorder.record(map[string]metricPoint{"a": {
+		Time:        time.Now().Add(-ago),
+		CPUPercent:  cpu,
+		MemoryUsage: 100,
+		MemoryLimit: 1000,
+		NetworkRx:   2048,
+	}})
 }
 
-func TestStatsPanelUpdateOnSessionStartedStoresSession(t *testing.T) {
+func TestStatsPanelChartsRecordedHistory(t *testing.T) {
 	p := newTestStatsPanel()
-	pr, pw := io.Pipe()
-	session := client.NewStatsSession(io.NopCloser(pr), func() { pr.Close(); pw.Close() })
+	recordPoint(p, 30*time.Second, 10)
+	recordPoint(p, 10*time.Second, 30)
 
-	go func() {
-		pw.Write([]byte("{}"))
-		pw.Close()
-	}()
-
-	cmd := p.Update(statsSessionStartedMsg{session: session})
-	if cmd == nil {
-		t.Fatal("Update(statsSessionStartedMsg) returned nil cmd")
+	if cmd := p.Init(containerItem{container: client.Container{ID: "a", Name: "api"}}); cmd == nil {
+		t.Fatal("Init() should start the collector and show the key bindings")
 	}
-	if p.session != session {
-		t.Error("Update should store the session")
+	view := p.View()
+	for _, want := range []string{
+		"CPU 30.00%", "min 10.00% avg 20.00% max 30.00%", "MEM 10.00%", "rx:" + formatRate(2048),
+	} {
+		if !strings.Contains(view, want) {
+			t.Errorf("View() should contain %q:\n%s", want, view)
+		}
 	}
-
-	cmd() // drain
 }
 
-func TestStatsPanelErrorEmitsBanner(t *testing.T) {
+func TestStatsPanelSwitchesWindows(t *testing.T) {
 	p := newTestStatsPanel()
-	pr, pw := io.Pipe()
-	p.session = client.NewStatsSession(io.NopCloser(pr), func() { pr.Close(); pw.Close() })
-
-	cmd := p.Update(statsOutputMsg{err: errors.New("stats broken")})
-
-	if p.session != nil {
-		t.Error("Update with error should close session")
+	recordPoint(p, 10*time.Minute, 50)
+	p.Init(containerItem{container: client.Container{ID: "a"}})
+	if !strings.Contains(p.View(), "No usage recorded in the last 1m") {
+		t.Errorf("View() should show nothing in the last minute:\n%s", p.View())
 	}
-	if cmd == nil {
-		t.Fatal("Update with error should return banner cmd")
-	}
-	result := cmd()
-	bannerMsg, ok := result.(message.ShowBannerMsg)
-	if !ok {
-		t.Fatalf("expected ShowBannerMsg, got %T", result)
-	}
-	if !bannerMsg.IsError {
-		t.Error("ShowBannerMsg.IsError should be true")
+
+	p.Update(tea.KeyPressMsg{Code: 'w', Text: "w"})
+	if !strings.Contains(p.View(), "CPU 50.00%") {
+		t.Errorf("View() should show the point in the last 15 minutes:\n%s", p.View())
 	}
 }
 
-func TestStatsPanelCloseNilsSession(t *testing.T) {
+func TestStatsPanelKeepsHistoryWhenReopened(t *testing.T) {
 	p := newTestStatsPanel()
-	pr, pw := io.Pipe()
-	p.session = client.NewStatsSession(io.NopCloser(pr), func() { pr.Close(); pw.Close() })
-	p.lastView = "some chart"
+	recordPoint(p, 10*time.Second, 25)
+	item := containerItem{container: client.Container{ID: "a"}}
+	p.Init(item)
 
-	p.Close()
-
-	if p.session != nil {
-		t.Error("Close() should nil out session")
+	cmd := p.Close()
+	if p.View() != "" {
+		t.Errorf("Close() should clear the view, got %q", p.View())
 	}
-	if p.lastView != "" {
-		t.Errorf("Close() should clear lastView, got %q", p.lastView)
+	if _, ok := cmd().(message.ClearContextualKeyBindingsMsg); !ok {
+		t.Error("Close() should clear the contextual key bindings")
 	}
-}
-
-func TestStatsPanelCloseIsIdempotent(t *testing.T) {
-	p := newTestStatsPanel()
-	p.Close()
 	p.Close() // must not panic
-}
-
-func TestStatsPanelCloseClearsChartData(t *testing.T) {
-	p := newTestStatsPanel()
-	pr, pw := io.Pipe()
-	p.session = client.NewStatsSession(io.NopCloser(pr), func() { pr.Close(); pw.Close() })
-	p.lastView = "some chart data"
-
-	// Initialize charts with some size so they have internal buffers
-	p.SetSize(100, 40)
 
-	// Close should clear all charts without panicking
-	p.Close()
-
-	// Verify session is closed
-	if p.session != nil {
-		t.Error("Close() should nil out session")
-	}
-	// Verify lastView is cleared
-	if p.lastView != "" {
-		t.Errorf("Close() should clear lastView, got %q", p.lastView)
+	p.Init(item)
+	if !strings.Contains(p.View(), "CPU 25.00%") {
+		t.Errorf("View() should chart the history recorded before:\n%s", p.View())
 	}
+}
 
-	if strings.TrimSpace(p.cpuChart.View()) != "" {
-		t.Errorf("Close() should clear cpuChart view, got %q", strings.TrimSpace(p.cpuChart.View()))
-	}
-	if strings.TrimSpace(p.networkChart.View()) != "" {
-		t.Errorf("Close() should clear networkChart view, got %q", strings.TrimSpace(p.networkChart.View()))
-	}
-	if strings.TrimSpace(p.ioChart.View()) != "" {
-		t.Errorf("Close() should clear ioChart view, got %q", strings.TrimSpace(p.ioChart.View()))
-	}
-	if strings.TrimSpace(p.memChart.View()) != "" {
-		t.Errorf("Close() should clear memChart view, got %q", strings.TrimSpace(p.memChart.View()))
+func TestStatsPanelShowsDetailsOfTheLatestSample(t *testing.T) {
+	p := newTestStatsPanel()
+	sample := func(seconds int, rx, throttled uint64) client.ContainerStats {
+		stats := topSample(seconds, 1, 100)
+		stats.PIDs, stats.PIDsLimit = 7, 100
+		stats.CPUPeriods, stats.ThrottledPeriods = uint64(seconds)*10, throttled
+		stats.MemoryRSS, stats.MemoryCache, stats.MemorySwap = 300, 200, 0
+		stats.Networks = map[string]client.IOCounters{"eth0": {In: rx}}
+		stats.BlockDevices = map[string]client.IOCounters{"8:0": {Out: rx}}
+		return stats
+	}
+	p.Init(containerItem{container: client.Container{ID: "a"}})
+	p.collector.record(topContainers, map[string]client.ContainerStats{"a": sample(10, 0, 0)})
+	p.refresh()
+	if view := p.View(); !strings.Contains(view, "eth0 rx - tx -") {
+		t.Errorf("View() should show no rates before the second sample:\n%s", view)
+	}
+
+	p.collector.record(topContainers, map[string]client.ContainerStats{"a": sample(20, 2048, 25)})
+	p.refresh()
+	view := p.View()
+	for _, want := range []string{
+		"PIDs 7 / 100",
+		"CPU throttled 25.00% of periods (25 of 200",
+		"Memory rss 300 B  cache 200 B  swap 0 B",
+		"eth0 rx " + formatRate(204.8),
+		"8:0 r " + formatRate(0) + " w " + formatRate(204.8),
+	} {
+		if !strings.Contains(view, want) {
+			t.Errorf("View() should contain %q:\n%s", want, view)
+		}
 	}
-
-	// Verify Close() can be called again (charts are properly cleared)
-	p.Close() // Should not panic even after charts were cleared
 }
 
 func TestStatsPanelSetSizeResizesCharts(t *testing.T) {
@@ -148,11 +128,51 @@ func TestStatsPanelSetSizeResizesCharts(t *testing.T) {
 	}
 }
 
-func TestStatsPanelViewReturnsLastView(t *testing.T) {
+func TestStatsPanelExportErrorEmitsBanner(t *testing.T) {
 	p := newTestStatsPanel()
-	p.lastView = "CPU chart"
+	cmd := exportStatsCmd(t.TempDir()+"/missing/stats.csv", nil)
+	banner, ok := p.Update(cmd())().(message.ShowBannerMsg)
+	if !ok || !banner.IsError {
+		t.Errorf("a failed export should show an error banner, got %+v", banner)
+	}
+}
+
+func TestBucketValues(t *testing.T) {
+	now := time.Unix(1000, 0)
+	points := []metricPoint{
+		{Time: now.Add(-50 * time.Second), CPUPercent: 10},
+		{Time: now.Add(-45 * time.Second), CPUPercent: 20},
+		{Time: now.Add(-5 * time.Second), CPUPercent: 40},
+	}
+	cpu := func(p metricPoint) float64 { return p.CPUPercent }
+	got := bucketValues(points, now, time.Minute, 6, cpu)
+	want := []float64{15, 15, 15, 15, 40}
+	if len(got) != len(want) {
+		t.Fatalf("bucketValues() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("bucketValues() = %v, want %v", got, want)
+		}
+	}
+}
 
-	if p.View() != "CPU chart" {
-		t.Errorf("View() = %q, want 'CPU chart'", p.View())
+func TestWriteStatsCSV(t *testing.T) {
+	var buf bytes.Buffer
+	points := []metricPoint{{
+		Time:        time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		CPUPercent:  12.5,
+		MemoryUsage: 250,
+		MemoryLimit: 1000,
+		NetworkRx:   1024,
+	}}
+	if err := writeStatsCSV(&buf, points); err != nil {
+		t.Fatal(err)
+	}
+	want := "time,cpu_percent,throttled_percent,memory_usage,memory_limit,memory_percent," +
+		"network_rx,network_tx,block_read,block_write\n" +
+		"2024-01-02T03:04:05Z,12.50,0.00,250,1000,25.00,1024.00,0.00,0.00,0.00\n"
+	if buf.String() != want {
+		t.Errorf("writeStatsCSV() =\n%s\nwant\n%s", buf.String(), want)
 	}
 }
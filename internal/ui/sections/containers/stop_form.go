@@ -0,0 +1,70 @@
+package containers
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"charm.land/huh/v2"
+
+	"github.com/GustavoCaso/docker-dash/internal/client"
+)
+
+// stopForm asks for the timeout and signal used to stop a container. Blank
+// values leave the choice to the container's own configuration.
+func stopForm(timeout, signal *string) *huh.Form {
+	return huh.NewForm(
+		huh.NewGroup(
+			huh.NewInput().
+				Key("timeout").
+				Title("Timeout").
+				Description("Grace period before the container is killed (e.g. 30s, 0s to kill at once). "+
+					"Leave blank for the container default.").
+				Value(timeout).
+				Validate(validateOptionalTimeout),
+
+			huh.NewInput().
+				Key("signal").
+				Title("Signal").
+				Description("Signal sent to stop the container (e.g. SIGINT). Leave blank for the container default.").
+				Value(signal).
+				Validate(validateOptionalSignal),
+		),
+	)
+}
+
+func buildStopOptions(timeoutStr, signalStr string) client.ContainerStopOptions {
+	var opts client.ContainerStopOptions
+	if t := strings.TrimSpace(timeoutStr); t != "" {
+		if d, err := time.ParseDuration(t); err == nil {
+			opts.Timeout = &d
+		}
+	}
+	if sig := strings.TrimSpace(signalStr); sig != "" {
+		opts.Signal = normalizeSignal(sig)
+	}
+	return opts
+}
+
+func validateOptionalTimeout(s string) error {
+	t := strings.TrimSpace(s)
+	if t == "" {
+		return nil
+	}
+	d, err := time.ParseDuration(t)
+	if err != nil {
+		return fmt.Errorf("invalid duration %q: use Go format e.g. 10s, 1m30s", s)
+	}
+	if d < 0 {
+		return errors.New("timeout must not be negative")
+	}
+	return nil
+}
+
+func validateOptionalSignal(s string) error {
+	if strings.TrimSpace(s) == "" {
+		return nil
+	}
+	return validateSignal(s)
+}
@@ -0,0 +1,57 @@
+package containers
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBuildStopOptions(t *testing.T) {
+	opts := buildStopOptions("", "")
+	if opts.Timeout != nil || opts.Signal != "" {
+		t.Errorf("buildStopOptions(blank) = %+v, want container defaults", opts)
+	}
+
+	opts = buildStopOptions(" 0s ", "int")
+	if opts.Timeout == nil || *opts.Timeout != 0 {
+		t.Errorf("buildStopOptions(0s) timeout = %v, want 0s", opts.Timeout)
+	}
+	if opts.Signal != "SIGINT" {
+		t.Errorf("buildStopOptions(int) signal = %q, want SIGINT", opts.Signal)
+	}
+
+	opts = buildStopOptions("1m30s", "SIGTERM")
+	if opts.Timeout == nil || *opts.Timeout != 90*time.Second || opts.Signal != "SIGTERM" {
+		t.Errorf("buildStopOptions(1m30s, SIGTERM) = %+v", opts)
+	}
+}
+
+func TestValidateOptionalTimeout(t *testing.T) {
+	tests := []struct {
+		input   string
+		wantErr bool
+	}{
+		{input: "", wantErr: false},
+		{input: "0s", wantErr: false},
+		{input: "2m", wantErr: false},
+		{input: "-5s", wantErr: true},
+		{input: "soon", wantErr: true},
+	}
+	for _, tt := range tests {
+		err := validateOptionalTimeout(tt.input)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("validateOptionalTimeout(%q): wantErr=%v, got %v", tt.input, tt.wantErr, err)
+		}
+	}
+}
+
+func TestValidateOptionalSignal(t *testing.T) {
+	if err := validateOptionalSignal(" "); err != nil {
+		t.Errorf("validateOptionalSignal(blank) = %v, want nil", err)
+	}
+	if err := validateOptionalSignal("SIGQUIT"); err != nil {
+		t.Errorf("validateOptionalSignal(SIGQUIT) = %v, want nil", err)
+	}
+	if err := validateOptionalSignal("not a signal"); err == nil {
+		t.Error("validateOptionalSignal(not a signal) = nil, want an error")
+	}
+}
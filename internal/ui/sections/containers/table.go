@@ -0,0 +1,131 @@
+package containers
+
+import (
+	"cmp"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/docker/go-units"
+
+	"github.com/GustavoCaso/docker-dash/internal/client"
+	"github.com/GustavoCaso/docker-dash/internal/ui/components/table"
+	"github.com/GustavoCaso/docker-dash/internal/ui/helper"
+	"github.com/GustavoCaso/docker-dash/internal/ui/sections"
+	"github.com/GustavoCaso/docker-dash/internal/ui/theme"
+)
+
+const (
+	statusColumnWidth  = 22
+	createdColumnWidth = 16
+	cpuColumnWidth     = 7
+	sizeColumnWidth    = 9
+)
+
+// tableColumns are the columns of the container table. CPU and memory come
+// from the samples of the stats collector, and are blank until the first
+// sample of a running container.
+func (s *Section) tableColumns() []table.Column {
+	return []table.Column{
+		{Key: "name", Title: "NAME", Value: func(item sections.ListItem) string { return containerOf(item).Name }},
+		{Key: "image", Title: "IMAGE", Value: func(item sections.ListItem) string { return containerOf(item).Image }},
+		{
+			Key:   "status",
+			Title: "STATUS",
+			Width: statusColumnWidth,
+			Value: func(item sections.ListItem) string {
+				c := containerOf(item)
+				status := theme.GetContainerStatusIcon(string(c.State)) + " " + stateLabel(c)
+				if c.Health != nil {
+					status += " " + string(c.Health.Status)
+				}
+				return status
+			},
+		},
+		{Key: "ports", Title: "PORTS", Value: func(item sections.ListItem) string { return ports(containerOf(item)) }},
+		{
+			Key:   "created",
+			Title: "CREATED",
+			Width: createdColumnWidth,
+			Value: func(item sections.ListItem) string {
+				return units.HumanDuration(time.Since(containerOf(item).Created)) + " ago"
+			},
+			Compare: func(a, b sections.ListItem) int {
+				return containerOf(a).Created.Compare(containerOf(b).Created)
+			},
+		},
+		{
+			Key:   "cpu",
+			Title: "CPU",
+			Width: cpuColumnWidth,
+			Right: true,
+			Value: func(item sections.ListItem) string {
+				if usage := s.collector.usageOf(item.ID()); usage != nil && usage.hasRates {
+					return fmt.Sprintf("%.1f%%", usage.rates.CPUPercent)
+				}
+				return ""
+			},
+			Compare: func(a, b sections.ListItem) int { return cmp.Compare(s.cpuOf(a.ID()), s.cpuOf(b.ID())) },
+		},
+		{
+			Key:   "memory",
+			Title: "MEM",
+			Width: sizeColumnWidth,
+			Right: true,
+			Value: func(item sections.ListItem) string {
+				if usage := s.collector.usageOf(item.ID()); usage != nil {
+					return helper.FormatSize(usage.stats.MemoryUsage)
+				}
+				return ""
+			},
+			Compare: func(a, b sections.ListItem) int { return cmp.Compare(s.memoryOf(a.ID()), s.memoryOf(b.ID())) },
+		},
+		{
+			Key:   "size",
+			Title: "SIZE",
+			Width: sizeColumnWidth,
+			Right: true,
+			Value: func(item sections.ListItem) string { return helper.FormatSize(containerOf(item).Size) },
+			Compare: func(a, b sections.ListItem) int {
+				return cmp.Compare(containerOf(a).Size, containerOf(b).Size)
+			},
+		},
+	}
+}
+
+// cpuOf returns the CPU usage of the container with id, or -1 when it is not
+// known so it sorts below idle containers.
+func (s *Section) cpuOf(id string) float64 {
+	if usage := s.collector.usageOf(id); usage != nil && usage.hasRates {
+		return usage.rates.CPUPercent
+	}
+	return -1
+}
+
+// memoryOf returns the memory usage of the container with id, or 0 when it
+// is not known.
+func (s *Section) memoryOf(id string) uint64 {
+	if usage := s.collector.usageOf(id); usage != nil {
+		return usage.stats.MemoryUsage
+	}
+	return 0
+}
+
+func containerOf(item sections.ListItem) client.Container {
+	ci, _ := item.(containerItem)
+	return ci.container
+}
+
+// ports lists the published ports of container as host→container/protocol,
+// and the exposed ones as container/protocol.
+func ports(container client.Container) string {
+	mappings := make([]string, 0, len(container.Ports))
+	for _, port := range container.Ports {
+		if port.HostPort == 0 {
+			mappings = append(mappings, fmt.Sprintf("%d/%s", port.ContainerPort, port.Protocol))
+			continue
+		}
+		mappings = append(mappings, fmt.Sprintf("%d→%d/%s", port.HostPort, port.ContainerPort, port.Protocol))
+	}
+	return strings.Join(mappings, ", ")
+}
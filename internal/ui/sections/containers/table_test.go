@@ -0,0 +1,54 @@
+package containers
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/GustavoCaso/docker-dash/internal/client"
+	"github.com/GustavoCaso/docker-dash/internal/config"
+)
+
+func TestTableColumnsMatchConfig(t *testing.T) {
+	section := newLoadedSection(t, client.NewMockClient())
+	available := section.Table().Available()
+	if len(available) != len(config.ContainerTableColumns) {
+		t.Fatalf("table has %d columns, want %d", len(available), len(config.ContainerTableColumns))
+	}
+	for i, column := range available {
+		if column.Key != config.ContainerTableColumns[i] {
+			t.Errorf("column %d = %q, want %q", i, column.Key, config.ContainerTableColumns[i])
+		}
+	}
+}
+
+func TestTableLayoutSortsBySize(t *testing.T) {
+	c := client.NewMockClient()
+	section := New(
+		context.Background(),
+		c.Containers(),
+		config.DefaultLogsConfig(),
+		config.StopConfig{},
+		config.StatsConfig{},
+		config.AlertsConfig{},
+		config.TableConfig{Enabled: true, Columns: []string{"name", "ports", "size"}, Sort: "size", Descending: true},
+	)
+	section.SetSize(160, 40)
+	section.Update(section.RefreshCmd()())
+
+	items := section.List.Items()
+	for i := 1; i < len(items); i++ {
+		if items[i-1].(containerItem).container.Size < items[i].(containerItem).container.Size {
+			t.Fatalf("rows should be sorted by size, largest first")
+		}
+	}
+	view := section.View()
+	for _, want := range []string{"NAME", "PORTS", "SIZE▼", "6379→6379/tcp"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("View() should contain %q:\n%s", want, view)
+		}
+	}
+	if strings.Contains(view, "IMAGE") {
+		t.Errorf("View() should only show the configured columns:\n%s", view)
+	}
+}
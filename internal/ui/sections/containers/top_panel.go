@@ -0,0 +1,290 @@
+package containers
+
+import (
+	"cmp"
+	"fmt"
+	"io"
+	"log"
+	"slices"
+	"strconv"
+	"strings"
+
+	"charm.land/bubbles/v2/key"
+	"charm.land/bubbles/v2/list"
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+
+	"github.com/GustavoCaso/docker-dash/internal/ui/helper"
+	"github.com/GustavoCaso/docker-dash/internal/ui/keys"
+	"github.com/GustavoCaso/docker-dash/internal/ui/message"
+	"github.com/GustavoCaso/docker-dash/internal/ui/sections"
+	"github.com/GustavoCaso/docker-dash/internal/ui/theme"
+)
+
+const topPanelName = "Top"
+
+const (
+	topNameColumnWidth = 20
+	topCPUColumnWidth  = 6
+	topMemColumnWidth  = 9
+	topRateColumnWidth = 10
+	topPIDsColumnWidth = 5
+)
+
+// topSort is the column the Top panel is ordered by.
+type topSort int
+
+const (
+	topByCPU topSort = iota
+	topByMemory
+	topByNetwork
+	topByBlockIO
+	topByPIDs
+	topByName
+	topSortCount
+)
+
+func (s topSort) String() string {
+	switch s {
+	case topByMemory:
+		return "MEM"
+	case topByNetwork:
+		return "NET"
+	case topByBlockIO:
+		return "BLOCK I/O"
+	case topByPIDs:
+		return "PIDS"
+	case topByName:
+		return "NAME"
+	default:
+		return "CPU%"
+	}
+}
+
+// topRow is the usage of a container as a list item.
+type topRow struct {
+	containerUsage
+}
+
+func (r topRow) Title() string       { return r.container.Name }
+func (r topRow) Description() string { return "" }
+func (r topRow) FilterValue() string { return r.container.Name }
+
+func formatTopRow(name, cpu, mem, memPercent, netRx, netTx, blockRead, blockWrite, pids string) string {
+	return fmt.Sprintf("%-*s %*s %*s %*s %*s %*s %*s %*s %*s",
+		topNameColumnWidth, truncate(name, topNameColumnWidth),
+		topCPUColumnWidth, cpu,
+		topMemColumnWidth, mem,
+		topCPUColumnWidth, memPercent,
+		topRateColumnWidth, netRx,
+		topRateColumnWidth, netTx,
+		topRateColumnWidth, blockRead,
+		topRateColumnWidth, blockWrite,
+		topPIDsColumnWidth, pids,
+	)
+}
+
+func formatRate(bytesPerSecond float64) string {
+	return helper.FormatSize(uint64(bytesPerSecond)) + "/s"
+}
+
+// topDelegate renders a container as a single table row.
+type topDelegate struct{}
+
+func (d topDelegate) Height() int                             { return 1 }
+func (d topDelegate) Spacing() int                            { return 0 }
+func (d topDelegate) Update(_ tea.Msg, _ *list.Model) tea.Cmd { return nil }
+
+func (d topDelegate) Render(w io.Writer, m list.Model, index int, item list.Item) {
+	r, ok := item.(topRow)
+	if !ok {
+		return
+	}
+	cpu, netRx, netTx, blockRead, blockWrite := "-", "-", "-", "-", "-"
+	if r.hasRates {
+		cpu = strconv.FormatFloat(r.rates.CPUPercent, 'f', 1, 64)
+		netRx, netTx = formatRate(r.rates.NetworkRx), formatRate(r.rates.NetworkTx)
+		blockRead, blockWrite = formatRate(r.rates.BlockRead), formatRate(r.rates.BlockWrite)
+	}
+	row := truncate(formatTopRow(
+		r.container.Name,
+		cpu,
+		helper.FormatSize(r.stats.MemoryUsage),
+		strconv.FormatFloat(r.stats.MemoryPercent(), 'f', 1, 64),
+		netRx, netTx, blockRead, blockWrite,
+		strconv.FormatUint(r.stats.PIDs, 10),
+	), m.Width())
+	if index == m.Index() {
+		row = theme.SelectedLogLine.Render(row)
+	}
+	fmt.Fprint(w, row)
+}
+
+// topPanel shows the usage of every running container, like docker stats,
+// whichever container is selected. The samples come from the section's stats
+// collector, which runs while the panel is open.
+type topPanel struct {
+	collector *statsCollector
+	list      list.Model
+	sortBy    topSort
+	rows      []topRow
+	width     int
+}
+
+func newTopPanel(collector *statsCollector) *topPanel {
+	l := list.New([]list.Item{}, topDelegate{}, 0, 0)
+	l.SetShowTitle(false)
+	l.SetShowHelp(false)
+	l.SetShowStatusBar(false)
+	l.DisableQuitKeybindings()
+	return &topPanel{collector: collector, list: l}
+}
+
+func (p *topPanel) Name() string {
+	return topPanelName
+}
+
+// SectionWide keeps the panel open while the selection moves, as it lists
+// every running container.
+func (p *topPanel) SectionWide() bool {
+	return true
+}
+
+// Init starts collecting, showing the samples taken so far.
+func (p *topPanel) Init(_ sections.ListItem) tea.Cmd {
+	log.Printf("[containers][top-panel] Init")
+	cmd := p.collector.want(statsForTop, true)
+	return tea.Batch(cmd, p.refresh(), p.extendHelpCmd())
+}
+
+func (p *topPanel) Update(msg tea.Msg) tea.Cmd {
+	if msg, ok := msg.(tea.KeyPressMsg); ok && !p.list.SettingFilter() &&
+		key.Matches(msg, keys.Keys.ProcessSort) {
+		p.sortBy = (p.sortBy + 1) % topSortCount
+		log.Printf("[containers][top-panel] sort by %s", p.sortBy)
+		return p.setItems()
+	}
+
+	var cmd tea.Cmd
+	p.list, cmd = p.list.Update(msg)
+	return cmd
+}
+
+func (p *topPanel) View() string {
+	if !p.collector.sampled {
+		return ""
+	}
+	if len(p.rows) == 0 {
+		return processHeaderStyle.Render("No running containers")
+	}
+	label := func(column topSort, title string) string {
+		if column == p.sortBy {
+			return title + "▼"
+		}
+		return title
+	}
+	header := formatTopRow(
+		label(topByName, "NAME"),
+		label(topByCPU, "CPU%"),
+		label(topByMemory, "MEM"),
+		"MEM%",
+		label(topByNetwork, "NET RX/s"),
+		"NET TX/s",
+		label(topByBlockIO, "BLK R/s"),
+		"BLK W/s",
+		label(topByPIDs, "PIDS"),
+	)
+	return lipgloss.JoinVertical(
+		lipgloss.Left,
+		processHeaderStyle.Render(truncate(header, p.width)),
+		p.list.View(),
+	)
+}
+
+func (p *topPanel) Close() tea.Cmd {
+	log.Printf("[containers][top-panel] Close")
+	p.collector.want(statsForTop, false)
+	p.rows = nil
+	p.list.ResetFilter()
+	p.list.SetItems([]list.Item{})
+	return func() tea.Msg { return message.ClearContextualKeyBindingsMsg{} }
+}
+
+func (p *topPanel) SetSize(width, height int) {
+	p.width = width
+	// Reserve one line for the column header.
+	p.list.SetSize(width, max(height-1, 0))
+}
+
+// IsFilter reports whether the user is typing a filter, so the section routes
+// every key to the panel instead of treating it as a shortcut.
+func (p *topPanel) IsFilter() bool {
+	return p.list.SettingFilter()
+}
+
+// refresh shows the latest samples of the collector.
+func (p *topPanel) refresh() tea.Cmd {
+	p.rows = p.rows[:0]
+	for _, u := range p.collector.usage {
+		p.rows = append(p.rows, topRow{*u})
+	}
+	return p.setItems()
+}
+
+// setItems sorts the rows, heaviest first, and replaces the list items,
+// keeping the cursor on the previously selected container.
+func (p *topPanel) setItems() tea.Cmd {
+	var selectedID string
+	if r, ok := p.list.SelectedItem().(topRow); ok {
+		selectedID = r.container.ID
+	}
+
+	sorted := slices.Clone(p.rows)
+	slices.SortFunc(sorted, func(a, b topRow) int {
+		return cmp.Or(p.compare(a, b), strings.Compare(a.container.Name, b.container.Name))
+	})
+
+	items := make([]list.Item, len(sorted))
+	for i, row := range sorted {
+		items[i] = row
+	}
+	cmd := p.list.SetItems(items)
+
+	for i, item := range p.list.VisibleItems() {
+		if r, ok := item.(topRow); ok && r.container.ID == selectedID {
+			p.list.Select(i)
+			break
+		}
+	}
+	return cmd
+}
+
+func (p *topPanel) extendHelpCmd() tea.Cmd {
+	return func() tea.Msg {
+		return message.AddContextualKeyBindingsMsg{Bindings: []key.Binding{
+			keys.Keys.ScrollUp,
+			keys.Keys.ScrollDown,
+			keys.Keys.Filter,
+			keys.Keys.ProcessSort,
+		}}
+	}
+}
+
+// compare orders a before b when it is the heavier consumer by the sort
+// column, or comes first by name.
+func (p *topPanel) compare(a, b topRow) int {
+	switch p.sortBy {
+	case topByMemory:
+		return cmp.Compare(b.stats.MemoryUsage, a.stats.MemoryUsage)
+	case topByNetwork:
+		return cmp.Compare(b.rates.NetworkRx+b.rates.NetworkTx, a.rates.NetworkRx+a.rates.NetworkTx)
+	case topByBlockIO:
+		return cmp.Compare(b.rates.BlockRead+b.rates.BlockWrite, a.rates.BlockRead+a.rates.BlockWrite)
+	case topByPIDs:
+		return cmp.Compare(b.stats.PIDs, a.stats.PIDs)
+	case topByName:
+		return strings.Compare(a.container.Name, b.container.Name)
+	default:
+		return cmp.Compare(b.rates.CPUPercent, a.rates.CPUPercent)
+	}
+}
@@ -0,0 +1,129 @@
+package containers
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/GustavoCaso/docker-dash/internal/client"
+	"github.com/GustavoCaso/docker-dash/internal/config"
+)
+
+var topContainers = []client.Container{
+	{ID: "a", Name: "api", State: client.StateRunning},
+	{ID: "b", Name: "db", State: client.StateRunning},
+	{ID: "c", Name: "old", State: client.StateStopped},
+}
+
+func newTestCollector(containers []client.Container) *statsCollector {
+	return newStatsCollector(context.Background(), client.NewMockClient().Containers(),
+		func() []client.Container { return containers }, newMetricsRecorder(config.StatsHistoryConfig{}))
+}
+
+func newTestTopPanel() *topPanel {
+	p := newTopPanel(newTestCollector(topContainers))
+	p.SetSize(120, 20)
+	return p
+}
+
+func visibleTopNames(p *topPanel) []string {
+	var names []string
+	for _, item := range p.list.VisibleItems() {
+		names = append(names, item.(topRow).container.Name)
+	}
+	return names
+}
+
+// topSample returns a sample taken at seconds with the given CPU time, in
+// seconds, and memory usage.
+func topSample(seconds, cpu int, memory uint64) client.ContainerStats {
+	return client.ContainerStats{
+		Read:        time.Unix(int64(seconds), 0),
+		CPUTotal:    uint64(cpu) * uint64(time.Second),
+		SystemCPU:   uint64(seconds) * uint64(time.Second),
+		OnlineCPUs:  1,
+		MemoryUsage: memory,
+		MemoryLimit: 1000,
+	}
+}
+
+// sampleTop records the samples of api and db in the collector of p, as the
+// section does, and refreshes the panel.
+func sampleTop(p *topPanel, api, db client.ContainerStats) {
+	p.collector.update(statsSampledMsg{
+		requestID:  p.collector.requestID,
+		containers: topContainers[:2],
+		samples:    map[string]client.ContainerStats{"a": api, "b": db},
+	})
+	p.refresh()
+}
+
+func TestTopPanelRatesAndSorting(t *testing.T) {
+	p := newTestTopPanel()
+	p.Init(containerItem{})
+	sampleTop(p, topSample(10, 1, 100), topSample(10, 1, 800))
+	if strings.Contains(p.View(), "0.0 B/s") {
+		t.Errorf("View() should show no rates before the second sample:\n%s", p.View())
+	}
+
+	sampleTop(p, topSample(20, 9, 100), topSample(20, 2, 800)) // 80% and 10% CPU
+	if got := visibleTopNames(p); strings.Join(got, ",") != "api,db" {
+		t.Errorf("by CPU: %v, want the heaviest first", got)
+	}
+	view := p.View()
+	if !strings.Contains(view, "CPU%▼") || !strings.Contains(view, "80.0") {
+		t.Errorf("View() should show the CPU rate and the sort column:\n%s", view)
+	}
+
+	p.Update(tea.KeyPressMsg{Code: 'o', Text: "o"})
+	if got := visibleTopNames(p); strings.Join(got, ",") != "db,api" {
+		t.Errorf("by memory: %v, want the heaviest first", got)
+	}
+	if !strings.Contains(p.View(), "MEM▼") {
+		t.Errorf("View() should mark the memory column:\n%s", p.View())
+	}
+}
+
+func TestTopPanelKeepsSelectionAcrossUpdates(t *testing.T) {
+	p := newTestTopPanel()
+	p.Init(containerItem{})
+	sampleTop(p, topSample(10, 1, 100), topSample(10, 1, 800))
+	p.list.Select(1)
+	selected := p.list.SelectedItem().(topRow).container.ID
+
+	sampleTop(p, topSample(20, 1, 100), topSample(20, 9, 800))
+	if got := p.list.SelectedItem().(topRow).container.ID; got != selected {
+		t.Errorf("selected %q, want %q kept after resorting", got, selected)
+	}
+}
+
+func TestTopPanelKeepsSamplesWhenReopened(t *testing.T) {
+	p := newTestTopPanel()
+	p.Init(containerItem{})
+	sampleTop(p, topSample(10, 1, 100), topSample(10, 1, 800))
+
+	// Switching to another panel and back closes and opens it again.
+	p.Close()
+	if cmd := p.collector.want(statsForTop, true); cmd != nil {
+		t.Error("the collector should keep going while the panel is reopened")
+	}
+	p.Init(containerItem{})
+	if got := len(p.list.Items()); got != 2 {
+		t.Errorf("%d rows after reopening, want the samples taken so far", got)
+	}
+}
+
+func TestTopPanelStaysOpenWhileTheSelectionMoves(t *testing.T) {
+	section := newContainerSectionModel().section
+	section.Update(section.RefreshCmd()())
+	section.ShowPanel(topPanelName)
+	section.Update(tea.KeyPressMsg{Code: tea.KeyTab})
+
+	section.Update(tea.KeyPressMsg{Code: tea.KeyDown})
+	if section.ActivePanelName() != topPanelName {
+		t.Errorf("active panel = %q after moving the selection, want %q", section.ActivePanelName(), topPanelName)
+	}
+}
@@ -0,0 +1,81 @@
+package disk
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"charm.land/huh/v2"
+	"github.com/docker/go-units"
+
+	"github.com/GustavoCaso/docker-dash/internal/client"
+)
+
+// buildCachePruneForm asks which build cache records a prune removes. Blank
+// values leave the daemon defaults.
+func buildCachePruneForm(all *bool, until, keepStorage *string) *huh.Form {
+	return huh.NewForm(
+		huh.NewGroup(
+			huh.NewConfirm().
+				Key("all").
+				Title("Remove all unused records").
+				Description("Otherwise only dangling records are removed.").
+				Value(all),
+
+			huh.NewInput().
+				Key("until").
+				Title("Until").
+				Description("Only remove records unused for longer than this (e.g. 24h). Leave blank for any age.").
+				Value(until).
+				Validate(validateOptionalUntil),
+
+			huh.NewInput().
+				Key("keepStorage").
+				Title("Keep storage").
+				Description("Amount of cache to keep (e.g. 5GB). Leave blank to remove every matching record.").
+				Value(keepStorage).
+				Validate(validateOptionalKeepStorage),
+		),
+	)
+}
+
+func buildCachePruneOptions(all bool, until, keepStorage string) client.BuildCachePruneOptions {
+	opts := client.BuildCachePruneOptions{All: all, Until: strings.TrimSpace(until)}
+	if k := strings.TrimSpace(keepStorage); k != "" {
+		if size, err := units.RAMInBytes(k); err == nil {
+			opts.KeepStorage = size
+		}
+	}
+	return opts
+}
+
+func validateOptionalUntil(s string) error {
+	t := strings.TrimSpace(s)
+	if t == "" {
+		return nil
+	}
+	d, err := time.ParseDuration(t)
+	if err != nil {
+		return fmt.Errorf("invalid duration %q: use Go format e.g. 24h, 30m", s)
+	}
+	if d < 0 {
+		return errors.New("until must not be negative")
+	}
+	return nil
+}
+
+func validateOptionalKeepStorage(s string) error {
+	k := strings.TrimSpace(s)
+	if k == "" {
+		return nil
+	}
+	size, err := units.RAMInBytes(k)
+	if err != nil {
+		return fmt.Errorf("invalid size %q: use e.g. 512MB, 5GB", s)
+	}
+	if size < 0 {
+		return errors.New("keep storage must not be negative")
+	}
+	return nil
+}
@@ -0,0 +1,479 @@
+// Package disk implements the Disk section, which breaks down the disk space
+// used by images, containers, volumes and the build cache.
+package disk
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"slices"
+	"strings"
+
+	"charm.land/bubbles/v2/key"
+	"charm.land/bubbles/v2/list"
+	tea "charm.land/bubbletea/v2"
+	"charm.land/huh/v2"
+	"charm.land/lipgloss/v2"
+
+	"github.com/GustavoCaso/docker-dash/internal/client"
+	"github.com/GustavoCaso/docker-dash/internal/ui/components/form"
+	"github.com/GustavoCaso/docker-dash/internal/ui/helper"
+	"github.com/GustavoCaso/docker-dash/internal/ui/keys"
+	"github.com/GustavoCaso/docker-dash/internal/ui/message"
+	"github.com/GustavoCaso/docker-dash/internal/ui/sections"
+	"github.com/GustavoCaso/docker-dash/internal/ui/sections/base"
+	"github.com/GustavoCaso/docker-dash/internal/ui/theme"
+)
+
+const (
+	kindColumnWidth  = 12
+	sizeColumnWidth  = 10
+	reclaimColWidth  = 12
+	inUseColumnWidth = 6
+	minNameWidth     = 10
+	// headerLines is the summary line and the column header above the list.
+	headerLines   = 2
+	ellipsisWidth = 1
+)
+
+var (
+	summaryStyle = lipgloss.NewStyle().Foreground(theme.TextPrimary)
+	headerStyle  = lipgloss.NewStyle().Bold(true).Foreground(theme.TextMuted)
+)
+
+// diskSort is the column the entries are ordered by.
+type diskSort int
+
+const (
+	bySize diskSort = iota
+	byReclaimable
+	byKind
+	byName
+	diskSortCount
+)
+
+func (s diskSort) String() string {
+	switch s {
+	case byReclaimable:
+		return "RECLAIMABLE"
+	case byKind:
+		return "KIND"
+	case byName:
+		return "NAME"
+	default:
+		return "SIZE"
+	}
+}
+
+// diskUsageLoadedMsg is sent when the disk usage report has been loaded.
+type diskUsageLoadedMsg struct {
+	report client.DiskUsageReport
+	err    error
+}
+
+// entryRemovedMsg is sent when an entry removal completes.
+type entryRemovedMsg struct {
+	entry client.DiskUsageEntry
+	err   error
+}
+
+// buildCachePrunedMsg is sent when a build cache prune completes.
+type buildCachePrunedMsg struct {
+	report client.PruneReport
+	err    error
+}
+
+// entryItem implements list.Item interface.
+type entryItem struct {
+	entry client.DiskUsageEntry
+}
+
+func (e entryItem) ID() string    { return e.entry.ID }
+func (e entryItem) Title() string { return e.entry.Name }
+func (e entryItem) Description() string {
+	parts := []string{string(e.entry.Kind), helper.FormatSize(e.entry.Size)}
+	if e.entry.Reclaimable > 0 {
+		parts = append(parts, helper.FormatSize(e.entry.Reclaimable)+" reclaimable")
+	}
+	if e.entry.InUse {
+		parts = append(parts, "in use")
+	}
+	return strings.Join(parts, " · ")
+}
+func (e entryItem) FilterValue() string { return string(e.entry.Kind) + " " + e.entry.Name }
+func (e entryItem) InnerItem() any      { return e.entry }
+
+var _ sections.ListItem = entryItem{}
+
+func truncate(s string, width int) string {
+	runes := []rune(s)
+	if len(runes) <= width {
+		return s
+	}
+	if width <= ellipsisWidth {
+		return string(runes[:max(width, 0)])
+	}
+	return string(runes[:width-ellipsisWidth]) + "…"
+}
+
+func formatRow(kind, name, size, reclaimable, inUse string, nameWidth int) string {
+	return fmt.Sprintf("%-*s %-*s %*s %*s %*s",
+		kindColumnWidth, truncate(kind, kindColumnWidth),
+		nameWidth, truncate(name, nameWidth),
+		sizeColumnWidth, size,
+		reclaimColWidth, reclaimable,
+		inUseColumnWidth, inUse,
+	)
+}
+
+// nameWidth is the width left for the name column in a row of width.
+func nameWidth(width int) int {
+	fixed := kindColumnWidth + sizeColumnWidth + reclaimColWidth + inUseColumnWidth + 4 //nolint:mnd // separators
+	return max(width-fixed, minNameWidth)
+}
+
+// entryDelegate renders an entry as a single table row.
+type entryDelegate struct {
+	isMarked func(id string) bool
+}
+
+func (d entryDelegate) Height() int                             { return 1 }
+func (d entryDelegate) Spacing() int                            { return 0 }
+func (d entryDelegate) Update(_ tea.Msg, _ *list.Model) tea.Cmd { return nil }
+
+func (d entryDelegate) Render(w io.Writer, m list.Model, index int, item list.Item) {
+	e, ok := item.(entryItem)
+	if !ok {
+		return
+	}
+	inUse := ""
+	if e.entry.InUse {
+		inUse = "yes"
+	}
+	reclaimable := "-"
+	if e.entry.Reclaimable > 0 {
+		reclaimable = helper.FormatSize(e.entry.Reclaimable)
+	}
+	prefix := "  "
+	if d.isMarked(e.ID()) {
+		prefix = theme.MarkedStyle.Render(theme.IconMarked) + " "
+	}
+	row := formatRow(string(e.entry.Kind), e.entry.Name, helper.FormatSize(e.entry.Size), reclaimable, inUse,
+		nameWidth(m.Width()-2)) //nolint:mnd // prefix
+	if index == m.Index() {
+		row = theme.SelectedLogLine.Render(row)
+	}
+	fmt.Fprint(w, prefix+row)
+}
+
+// Section lists every image, container, volume and build cache record with
+// the space it uses and how much of it a prune would reclaim.
+type Section struct {
+	*base.Section
+	ctx     context.Context
+	client  client.Client
+	report  client.DiskUsageReport
+	sortBy  diskSort
+	width   int
+	summary string
+}
+
+// New creates a new disk usage section.
+func New(ctx context.Context, c client.Client) *Section {
+	s := &Section{
+		ctx:     ctx,
+		client:  c,
+		Section: base.New(sections.DiskSection, []sections.Panel{}),
+	}
+	s.List.SetDelegate(entryDelegate{isMarked: s.IsMarked})
+
+	s.LoadingText = "Loading..."
+	s.RefreshCmd = s.loadDiskUsageCmd
+	s.PruneCmd = s.showBuildCachePruneForm
+	s.HandleMsg = s.handleMsg
+	s.HandleKey = s.handleKey
+
+	return s
+}
+
+// SetSize reserves room for the summary and the column header.
+func (s *Section) SetSize(width, height int) {
+	s.width = width
+	s.Section.SetSize(width, max(height-headerLines, 0))
+}
+
+// View renders the totals per kind and the column header above the list.
+func (s *Section) View() string {
+	label := func(column diskSort, title string) string {
+		if column == s.sortBy {
+			return title + "▼"
+		}
+		return title
+	}
+	listX, _ := theme.ListStyle.GetFrameSize()
+	// Line the header up with the rows inside the list border and padding.
+	header := strings.Repeat(" ", listX/2) + "  " + formatRow(
+		label(byKind, "KIND"),
+		label(byName, "NAME"),
+		label(bySize, "SIZE"),
+		label(byReclaimable, "RECLAIMABLE"),
+		"IN USE",
+		nameWidth(s.width-listX-2), //nolint:mnd // prefix
+	)
+	return lipgloss.JoinVertical(
+		lipgloss.Left,
+		summaryStyle.Render(truncate(s.summary, s.width)),
+		headerStyle.Render(truncate(header, s.width)),
+		s.Section.View(),
+	)
+}
+
+func (s *Section) handleMsg(msg tea.Msg) base.UpdateResult {
+	switch msg := msg.(type) {
+	case diskUsageLoadedMsg:
+		log.Printf("[disk] diskUsageLoadedMsg: entries=%d err=%v", len(msg.report.Entries), msg.err)
+		if msg.err != nil {
+			return base.UpdateResult{
+				Cmd: func() tea.Msg {
+					return message.ShowBannerMsg{
+						Message: fmt.Sprintf("Error loading disk usage: %s", msg.err.Error()),
+						IsError: true,
+					}
+				},
+				Handled:     true,
+				StopSpinner: true,
+			}
+		}
+		s.report = msg.report
+		s.summary = summarize(msg.report)
+		return base.UpdateResult{
+			Cmd:         tea.Batch(s.setItems()...),
+			Handled:     true,
+			StopSpinner: true,
+		}
+	case entryRemovedMsg:
+		log.Printf("[disk] entryRemovedMsg: kind=%q id=%q err=%v", msg.entry.Kind, msg.entry.ID, msg.err)
+		if msg.err != nil {
+			return base.UpdateResult{
+				Cmd: func() tea.Msg {
+					return message.ShowBannerMsg{
+						Message: fmt.Sprintf("Error removing %s: %s", msg.entry.Kind, msg.err.Error()),
+						IsError: true,
+					}
+				},
+				Handled:     true,
+				StopSpinner: true,
+			}
+		}
+		banner := fmt.Sprintf("Removed %s %s, reclaimed %s",
+			msg.entry.Kind, msg.entry.Name, helper.FormatSize(msg.entry.Size))
+		return base.UpdateResult{
+			Cmd: tea.Batch(s.loadDiskUsageCmd(), func() tea.Msg {
+				return message.ShowBannerMsg{Message: banner, IsError: false}
+			}),
+			Handled:            true,
+			RefreshAllSections: msg.entry.Kind != client.DiskUsageBuildCache,
+		}
+	case buildCachePrunedMsg:
+		log.Printf("[disk] buildCachePrunedMsg: deleted=%d spaceReclaimed=%d err=%v",
+			msg.report.ItemsDeleted, msg.report.SpaceReclaimed, msg.err)
+		if msg.err != nil {
+			return base.UpdateResult{
+				Cmd: func() tea.Msg {
+					return message.ShowBannerMsg{
+						Message: "Error pruning build cache: " + msg.err.Error(),
+						IsError: true,
+					}
+				},
+				Handled:     true,
+				StopSpinner: true,
+			}
+		}
+		summary := fmt.Sprintf(
+			"Pruned %d build cache records, reclaimed %s",
+			msg.report.ItemsDeleted,
+			helper.FormatSize(msg.report.SpaceReclaimed),
+		)
+		return base.UpdateResult{
+			Cmd: tea.Batch(s.loadDiskUsageCmd(), func() tea.Msg {
+				return message.ShowBannerMsg{Message: summary, IsError: false}
+			}),
+			Handled: true,
+		}
+	}
+	return base.UpdateResult{}
+}
+
+func (s *Section) handleKey(msg tea.KeyPressMsg) base.UpdateResult {
+	switch {
+	case key.Matches(msg, keys.Keys.DiskSort):
+		s.sortBy = (s.sortBy + 1) % diskSortCount
+		log.Printf("[disk] sort by %s", s.sortBy)
+		return base.UpdateResult{Cmd: tea.Batch(s.setItems()...), Handled: true}
+	case key.Matches(msg, keys.Keys.Delete):
+		if marked := s.MarkedItems(); len(marked) > 0 {
+			return base.UpdateResult{Cmd: s.ConfirmBulk(s.removeEntriesAction(), marked), Handled: true}
+		}
+		return base.UpdateResult{Cmd: s.confirmEntryRemove(), Handled: true}
+	}
+	return base.UpdateResult{}
+}
+
+// setItems sorts the entries of the report and replaces the list items,
+// keeping the cursor on the previously selected entry.
+func (s *Section) setItems() []tea.Cmd {
+	var selectedID string
+	if e, ok := s.List.SelectedItem().(entryItem); ok {
+		selectedID = e.entry.ID
+	}
+
+	entries := slices.Clone(s.report.Entries)
+	slices.SortStableFunc(entries, func(a, b client.DiskUsageEntry) int {
+		return cmp.Or(s.compare(a, b), strings.Compare(a.Name, b.Name))
+	})
+	items := make([]list.Item, len(entries))
+	for i, entry := range entries {
+		items[i] = entryItem{entry: entry}
+	}
+	cmds := s.UpdateItems(items)
+
+	for i, item := range s.List.VisibleItems() {
+		if e, ok := item.(entryItem); ok && e.entry.ID == selectedID {
+			s.List.Select(i)
+			break
+		}
+	}
+	return cmds
+}
+
+// compare orders a before b when it is the larger offender by the sort
+// column, or comes first by kind or name.
+func (s *Section) compare(a, b client.DiskUsageEntry) int {
+	switch s.sortBy {
+	case byReclaimable:
+		return cmp.Or(cmp.Compare(b.Reclaimable, a.Reclaimable), cmp.Compare(b.Size, a.Size))
+	case byKind:
+		return cmp.Or(strings.Compare(string(a.Kind), string(b.Kind)), cmp.Compare(b.Size, a.Size))
+	case byName:
+		return 0
+	default:
+		return cmp.Compare(b.Size, a.Size)
+	}
+}
+
+// summarize totals the size and reclaimable space of every kind.
+func summarize(report client.DiskUsageReport) string {
+	kinds := []struct {
+		kind  client.DiskUsageKind
+		label string
+	}{
+		{client.DiskUsageImage, "Images"},
+		{client.DiskUsageContainer, "Containers"},
+		{client.DiskUsageVolume, "Volumes"},
+		{client.DiskUsageBuildCache, "Build cache"},
+	}
+	var total, reclaimable int64
+	parts := make([]string, 0, len(kinds))
+	for _, k := range kinds {
+		var size, free int64
+		for _, entry := range report.Entries {
+			if entry.Kind == k.kind {
+				size += entry.Size
+				free += entry.Reclaimable
+			}
+		}
+		total += size
+		reclaimable += free
+		parts = append(parts, fmt.Sprintf("%s %s (%s)", k.label, helper.FormatSize(size), helper.FormatSize(free)))
+	}
+	return fmt.Sprintf("Total %s, %s reclaimable · %s",
+		helper.FormatSize(total), helper.FormatSize(reclaimable), strings.Join(parts, " · "))
+}
+
+func (s *Section) loadDiskUsageCmd() tea.Cmd {
+	ctx, c := s.ctx, s.client
+	return func() tea.Msg {
+		report, err := c.DiskUsage(ctx)
+		return diskUsageLoadedMsg{report: report, err: err}
+	}
+}
+
+// removeEntryCmd removes the object behind entry with the service of its kind.
+// Objects still in use are not forced, so the daemon refuses to remove them.
+func (s *Section) removeEntryCmd(entry client.DiskUsageEntry) tea.Cmd {
+	ctx, c := s.ctx, s.client
+	return func() tea.Msg {
+		return entryRemovedMsg{entry: entry, err: removeEntry(ctx, c, entry)}
+	}
+}
+
+// removeEntriesAction removes the objects behind the marked entries.
+func (s *Section) removeEntriesAction() base.BulkAction {
+	ctx, c := s.ctx, s.client
+	return base.BulkAction{
+		Verb: "Remove",
+		Done: "Removed",
+		Noun: "object",
+		Run: func(item sections.ListItem) error {
+			e, ok := item.(entryItem)
+			if !ok {
+				return errors.New("not a disk usage entry")
+			}
+			return removeEntry(ctx, c, e.entry)
+		},
+	}
+}
+
+func removeEntry(ctx context.Context, c client.Client, entry client.DiskUsageEntry) error {
+	switch entry.Kind {
+	case client.DiskUsageImage:
+		return c.Images().Remove(ctx, entry.ID, false)
+	case client.DiskUsageContainer:
+		return c.Containers().Remove(ctx, entry.ID, false)
+	case client.DiskUsageVolume:
+		return c.Volumes().Remove(ctx, entry.ID, false)
+	case client.DiskUsageBuildCache:
+		return c.BuildCache().Remove(ctx, entry.ID)
+	}
+	return fmt.Errorf("unknown disk usage kind %q", entry.Kind)
+}
+
+func (s *Section) confirmEntryRemove() tea.Cmd {
+	e, ok := s.List.SelectedItem().(entryItem)
+	if !ok {
+		return nil
+	}
+	removeCmd := s.removeEntryCmd(e.entry)
+	return func() tea.Msg {
+		return message.ShowConfirmationMsg{
+			Title: "Remove " + string(e.entry.Kind),
+			Body: fmt.Sprintf("Remove %s %s and reclaim %s?",
+				e.entry.Kind, e.entry.Name, helper.FormatSize(e.entry.Size)),
+			OnConfirm: s.WithSpinner(removeCmd),
+		}
+	}
+}
+
+func (s *Section) pruneBuildCacheCmd(opts client.BuildCachePruneOptions) tea.Cmd {
+	ctx, c := s.ctx, s.client
+	return func() tea.Msg {
+		report, err := c.BuildCache().Prune(ctx, opts)
+		return buildCachePrunedMsg{report: report, err: err}
+	}
+}
+
+// showBuildCachePruneForm asks which build cache records to prune.
+func (s *Section) showBuildCachePruneForm() tea.Cmd {
+	var all bool
+	var until, keepStorage string
+	f := buildCachePruneForm(&all, &until, &keepStorage)
+	pruneForm := form.New("Prune Build Cache", f, func(_ *huh.Form) tea.Cmd {
+		return s.WithSpinner(s.pruneBuildCacheCmd(buildCachePruneOptions(all, until, keepStorage)))
+	})
+	return func() tea.Msg {
+		return message.ShowFormMsg{Form: pruneForm}
+	}
+}
@@ -0,0 +1,122 @@
+package disk
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/GustavoCaso/docker-dash/internal/client"
+	"github.com/GustavoCaso/docker-dash/internal/ui/message"
+)
+
+func newLoadedSection(t *testing.T, c client.Client) *Section {
+	t.Helper()
+	s := New(context.Background(), c)
+	s.SetSize(160, 40)
+	s.Update(s.loadDiskUsageCmd()())
+	if len(s.List.Items()) == 0 {
+		t.Fatal("the disk usage report should list entries")
+	}
+	return s
+}
+
+func entries(s *Section) []client.DiskUsageEntry {
+	var out []client.DiskUsageEntry
+	for _, item := range s.List.Items() {
+		out = append(out, item.(entryItem).entry)
+	}
+	return out
+}
+
+func TestDiskSectionSortsLargestFirst(t *testing.T) {
+	s := newLoadedSection(t, client.NewMockClient())
+
+	got := entries(s)
+	for i := 1; i < len(got); i++ {
+		if got[i-1].Size < got[i].Size {
+			t.Fatalf("entries not sorted by size: %s (%d) before %s (%d)",
+				got[i-1].Name, got[i-1].Size, got[i].Name, got[i].Size)
+		}
+	}
+
+	s.Update(tea.KeyPressMsg{Code: 'o', Text: "o"})
+	if s.sortBy != byReclaimable {
+		t.Fatalf("sortBy = %s, want RECLAIMABLE after o", s.sortBy)
+	}
+	got = entries(s)
+	for i := 1; i < len(got); i++ {
+		if got[i-1].Reclaimable < got[i].Reclaimable {
+			t.Fatalf("entries not sorted by reclaimable space: %s before %s", got[i-1].Name, got[i].Name)
+		}
+	}
+	if view := s.View(); !strings.Contains(view, "RECLAIMABLE▼") || !strings.Contains(view, "Build cache") {
+		t.Errorf("View() should show the sort column and the build cache total:\n%s", view)
+	}
+}
+
+func TestDiskSectionRemovesSelectedEntry(t *testing.T) {
+	c := client.NewMockClient()
+	s := newLoadedSection(t, c)
+	s.sortBy = byKind
+	s.setItems()
+
+	var target client.DiskUsageEntry
+	for i, entry := range entries(s) {
+		if entry.Kind == client.DiskUsageBuildCache && !entry.InUse {
+			s.List.Select(i)
+			target = entry
+			break
+		}
+	}
+	if target.ID == "" {
+		t.Fatal("the mock should have an unused build cache record")
+	}
+
+	confirm, ok := s.Update(tea.KeyPressMsg{Code: 'd', Text: "d"})().(message.ShowConfirmationMsg)
+	if !ok {
+		t.Fatal("d should ask for confirmation")
+	}
+	if !strings.Contains(confirm.Body, target.Name) {
+		t.Errorf("confirmation body = %q, want the entry name", confirm.Body)
+	}
+	s.Update(s.removeEntryCmd(target)())
+	s.Update(s.loadDiskUsageCmd()())
+
+	for _, entry := range entries(s) {
+		if entry.ID == target.ID {
+			t.Fatalf("entry %s should be removed", target.ID)
+		}
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	got := summarize(client.DiskUsageReport{Entries: []client.DiskUsageEntry{
+		{Kind: client.DiskUsageImage, Size: 2048, Reclaimable: 1024},
+		{Kind: client.DiskUsageBuildCache, Size: 1024, Reclaimable: 1024},
+	}})
+	for _, want := range []string{
+		"Total 3.0 KB, 2.0 KB reclaimable",
+		"Images 2.0 KB (1.0 KB)",
+		"Build cache 1.0 KB (1.0 KB)",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("summarize() = %q, want it to contain %q", got, want)
+		}
+	}
+}
+
+func TestBuildCachePruneOptions(t *testing.T) {
+	got := buildCachePruneOptions(true, " 24h ", "1GB")
+	want := client.BuildCachePruneOptions{All: true, Until: "24h", KeepStorage: 1024 * 1024 * 1024}
+	if got != want {
+		t.Errorf("buildCachePruneOptions() = %+v, want %+v", got, want)
+	}
+	if err := validateOptionalUntil("yesterday"); err == nil {
+		t.Error("validateOptionalUntil() should reject a non-duration")
+	}
+	if err := validateOptionalKeepStorage("lots"); err == nil {
+		t.Error("validateOptionalKeepStorage() should reject a non-size")
+	}
+}
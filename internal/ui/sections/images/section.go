@@ -18,6 +18,8 @@ import (
 	"github.com/GustavoCaso/docker-dash/internal/client"
 	"github.com/GustavoCaso/docker-dash/internal/config"
 	"github.com/GustavoCaso/docker-dash/internal/ui/components/form"
+	"github.com/GustavoCaso/docker-dash/internal/ui/components/prune"
+	"github.com/GustavoCaso/docker-dash/internal/ui/components/table"
 	"github.com/GustavoCaso/docker-dash/internal/ui/helper"
 	"github.com/GustavoCaso/docker-dash/internal/ui/keys"
 	"github.com/GustavoCaso/docker-dash/internal/ui/message"
@@ -38,6 +40,13 @@ type containerRunMsg struct {
 	error       error
 }
 
+// imagesPrunePreviewMsg is sent when the dry-run of an image prune completes.
+type imagesPrunePreviewMsg struct {
+	opts       client.PruneOptions
+	candidates []client.PruneCandidate
+	err        error
+}
+
 // imagesPrunedMsg is sent when an image prune completes.
 type imagesPrunedMsg struct {
 	report client.PruneReport
@@ -79,6 +88,9 @@ func (i imageItem) FilterValue() string { return i.image.Repo + ":" + i.image.Ta
 
 var _ sections.ListItem = imageItem{}
 
+// imagePruneOptions prunes every unused image, tagged ones included.
+var imagePruneOptions = client.PruneOptions{All: true}
+
 // Section wraps bubbles/list.
 type Section struct {
 	*base.Section
@@ -92,25 +104,31 @@ type Section struct {
 }
 
 // New creates a new image section.
-func New(ctx context.Context, client client.Client, cfg config.UpdateCheckConfig) *Section {
+func New(ctx context.Context, c client.Client, cfg config.UpdateCheckConfig, tableCfg config.TableConfig) *Section {
 	il := &Section{
 		ctx:              ctx,
 		cfg:              cfg,
-		imageService:     client.Images(),
-		containerService: client.Containers(),
+		imageService:     c.Images(),
+		containerService: c.Containers(),
 		currentImages:    nil,
 		imageUpdates:     make(map[string]bool),
 		Section: base.New(
 			sections.ImagesSection,
-			[]sections.Panel{NewLayersPanel(ctx, client.Images())},
+			[]sections.Panel{NewLayersPanel(ctx, c.Images())},
 		),
 	}
 
 	il.LoadingText = "Loading..."
 	il.RefreshCmd = il.updateImagesCmd
-	il.PruneCmd = il.confirmImagePrune
+	il.PruneCmd = func() tea.Cmd { return il.WithSpinner(il.previewImagePruneCmd(imagePruneOptions)) }
+	il.PruneFilteredCmd = func() tea.Cmd {
+		return prune.ShowForm("Prune Images", imagePruneOptions, func(opts client.PruneOptions) tea.Cmd {
+			return il.WithSpinner(il.previewImagePruneCmd(opts))
+		})
+	}
 	il.HandleMsg = il.handleMsg
 	il.HandleKey = il.handleKey
+	il.SetTable(table.New(tableColumns, tableCfg))
 
 	return il
 }
@@ -204,6 +222,28 @@ func (s *Section) handleMsg(msg tea.Msg) base.UpdateResult {
 			Handled:     true,
 			StopSpinner: true,
 		}
+	case imagesPrunePreviewMsg:
+		log.Printf("[images] imagesPrunePreviewMsg: candidates=%d err=%v", len(msg.candidates), msg.err)
+		if msg.err != nil {
+			return base.UpdateResult{
+				Cmd: func() tea.Msg {
+					return message.ShowBannerMsg{
+						Message: "Error previewing image prune: " + msg.err.Error(),
+						IsError: true,
+					}
+				},
+				Handled:     true,
+				StopSpinner: true,
+			}
+		}
+		if len(msg.candidates) == 0 {
+			return base.UpdateResult{Cmd: prune.NothingToPrune("image"), Handled: true, StopSpinner: true}
+		}
+		return base.UpdateResult{
+			Cmd:         s.confirmImagePrune(msg.opts, msg.candidates),
+			Handled:     true,
+			StopSpinner: true,
+		}
 	case imagesPrunedMsg:
 		log.Printf(
 			"[images] imagesPrunedMsg: deleted=%d spaceReclaimed=%d",
@@ -326,6 +366,9 @@ func (s *Section) handleKey(msg tea.KeyPressMsg) base.UpdateResult {
 	case key.Matches(msg, keys.Keys.PullImageUpdate):
 		return s.pullUpdateCmd()
 	case key.Matches(msg, keys.Keys.Delete):
+		if marked := s.MarkedItems(); len(marked) > 0 {
+			return base.UpdateResult{Cmd: s.ConfirmBulk(s.deleteImagesAction(), marked), Handled: true}
+		}
 		return base.UpdateResult{Cmd: s.confirmImageDelete(), Handled: true}
 	case key.Matches(msg, keys.Keys.CreateAndRunContainer):
 		return base.UpdateResult{Cmd: s.showRunContainerForm(), Handled: true}
@@ -551,25 +594,48 @@ func exposedPortsList(img client.Image) string {
 	return strings.Join(ports, ", ")
 }
 
-func (s *Section) pruneImagesCmd() tea.Cmd {
+// previewImagePruneCmd lists the images a prune with opts would remove.
+func (s *Section) previewImagePruneCmd(opts client.PruneOptions) tea.Cmd {
+	ctx, svc := s.ctx, s.imageService
+	return func() tea.Msg {
+		candidates, err := svc.PrunePreview(ctx, opts)
+		return imagesPrunePreviewMsg{opts: opts, candidates: candidates, err: err}
+	}
+}
+
+func (s *Section) pruneImagesCmd(opts client.PruneOptions) tea.Cmd {
 	ctx, svc := s.ctx, s.imageService
 	return func() tea.Msg {
-		report, err := svc.Prune(ctx, client.PruneOptions{All: true})
+		report, err := svc.Prune(ctx, opts)
 		return imagesPrunedMsg{report: report, err: err}
 	}
 }
 
-func (s *Section) confirmImagePrune() tea.Cmd {
-	pruneCmd := s.pruneImagesCmd()
+// confirmImagePrune shows the images of the dry-run before pruning them.
+func (s *Section) confirmImagePrune(opts client.PruneOptions, candidates []client.PruneCandidate) tea.Cmd {
+	pruneCmd := s.pruneImagesCmd(opts)
 	return func() tea.Msg {
 		return message.ShowConfirmationMsg{
 			Title:     "Prune Images",
-			Body:      "Remove all unused images (including non-dangling)?",
+			Body:      prune.Body("image", candidates),
 			OnConfirm: s.WithSpinner(pruneCmd),
 		}
 	}
 }
 
+// deleteImagesAction deletes the marked images.
+func (s *Section) deleteImagesAction() base.BulkAction {
+	ctx, svc := s.ctx, s.imageService
+	return base.BulkAction{
+		Verb: "Delete",
+		Done: "Deleted",
+		Noun: "image",
+		Run: func(item sections.ListItem) error {
+			return svc.Remove(ctx, item.ID(), true)
+		},
+	}
+}
+
 func (s *Section) confirmImageDelete() tea.Cmd {
 	items := s.List.Items()
 	idx := s.List.Index()
@@ -43,7 +43,7 @@ type imageSectionModel struct {
 
 func newModel() imageSectionModel {
 	client := client.NewMockClient()
-	section := New(context.Background(), client, config.UpdateCheckConfig{})
+	section := New(context.Background(), client, config.UpdateCheckConfig{}, config.TableConfig{})
 	section.SetSize(120, 40)
 	return imageSectionModel{section: section}
 }
@@ -76,7 +76,7 @@ func TestInitShowsBannerForInvalidUpdateCheckInterval(t *testing.T) {
 	section := New(context.Background(), c, config.UpdateCheckConfig{
 		Enabled:  true,
 		Interval: "not-a-duration",
-	})
+	}, config.TableConfig{})
 	section.SetSize(120, 40)
 
 	msgs := runBatch(section.Init())
@@ -108,7 +108,7 @@ func TestInitShowsBannerForNonPositiveUpdateCheckInterval(t *testing.T) {
 	section := New(context.Background(), c, config.UpdateCheckConfig{
 		Enabled:  true,
 		Interval: "0s",
-	})
+	}, config.TableConfig{})
 	section.SetSize(120, 40)
 
 	msgs := runBatch(section.Init())
@@ -174,7 +174,7 @@ func TestImageListDelete(t *testing.T) {
 
 func TestRunContainerKeyShowsForm(t *testing.T) {
 	c := client.NewMockClient()
-	section := New(context.Background(), c, config.UpdateCheckConfig{})
+	section := New(context.Background(), c, config.UpdateCheckConfig{}, config.TableConfig{})
 	section.SetSize(120, 40)
 	section.Update(section.RefreshCmd()())
 
@@ -225,7 +225,7 @@ func TestImageListPrune(t *testing.T) {
 
 func TestPullImageKeyShowsForm(t *testing.T) {
 	c := client.NewMockClient()
-	section := New(context.Background(), c, config.UpdateCheckConfig{})
+	section := New(context.Background(), c, config.UpdateCheckConfig{}, config.TableConfig{})
 	section.SetSize(120, 40)
 
 	cmd := section.Update(tea.KeyPressMsg{Code: '+', Text: "+"})
@@ -245,7 +245,7 @@ func TestPullImageKeyShowsForm(t *testing.T) {
 
 func TestPullImageCmdSuccess(t *testing.T) {
 	c := client.NewMockClient()
-	section := New(context.Background(), c, config.UpdateCheckConfig{})
+	section := New(context.Background(), c, config.UpdateCheckConfig{}, config.TableConfig{})
 	section.SetSize(120, 40)
 
 	cmd := section.pullImageCmd("nginx:latest", "")
@@ -272,7 +272,7 @@ func TestPullImageCmdError(t *testing.T) {
 		ImageService: mc.Images(),
 		pullErr:      errors.New("pull failed"),
 	}
-	section := New(context.Background(), mc, config.UpdateCheckConfig{})
+	section := New(context.Background(), mc, config.UpdateCheckConfig{}, config.TableConfig{})
 	section.imageService = errImageSvc
 	section.SetSize(120, 40)
 
@@ -290,7 +290,7 @@ func TestPullImageCmdError(t *testing.T) {
 
 func TestPullImageMsgSuccess_ShowsBanner(t *testing.T) {
 	c := client.NewMockClient()
-	section := New(context.Background(), c, config.UpdateCheckConfig{})
+	section := New(context.Background(), c, config.UpdateCheckConfig{}, config.TableConfig{})
 	section.SetSize(120, 40)
 
 	cmd := section.Update(imagePullMsg{image: "nginx:latest", err: nil})
@@ -319,7 +319,7 @@ func TestPullImageMsgSuccess_ShowsBanner(t *testing.T) {
 
 func TestPullImageMsgError_ShowsErrorBanner(t *testing.T) {
 	c := client.NewMockClient()
-	section := New(context.Background(), c, config.UpdateCheckConfig{})
+	section := New(context.Background(), c, config.UpdateCheckConfig{}, config.TableConfig{})
 	section.SetSize(120, 40)
 
 	pullErr := errors.New("image not found")
@@ -435,7 +435,7 @@ func TestImageItemDescriptionShowsUpdateIcon(t *testing.T) {
 
 func TestImageUpdatesMsg_UpdatesListItems(t *testing.T) {
 	c := client.NewMockClient()
-	section := New(context.Background(), c, config.UpdateCheckConfig{})
+	section := New(context.Background(), c, config.UpdateCheckConfig{}, config.TableConfig{})
 	section.SetSize(120, 40)
 
 	// Load images by running the RefreshCmd synchronously
@@ -480,7 +480,7 @@ func TestImageUpdatesMsg_UpdatesListItems(t *testing.T) {
 
 func TestPullUpdateCmd_NoUpdateShowsBanner(t *testing.T) {
 	c := client.NewMockClient()
-	section := New(context.Background(), c, config.UpdateCheckConfig{})
+	section := New(context.Background(), c, config.UpdateCheckConfig{}, config.TableConfig{})
 	section.SetSize(120, 40)
 	section.Update(section.RefreshCmd()())
 
@@ -504,7 +504,7 @@ func TestPullUpdateCmd_NoUpdateShowsBanner(t *testing.T) {
 
 func TestPullUpdateCmd_WithUpdate_FiresPull(t *testing.T) {
 	c := client.NewMockClient()
-	section := New(context.Background(), c, config.UpdateCheckConfig{})
+	section := New(context.Background(), c, config.UpdateCheckConfig{}, config.TableConfig{})
 	section.SetSize(120, 40)
 
 	// Load images synchronously first
@@ -533,7 +533,7 @@ func TestPullUpdateCmd_WithUpdate_FiresPull(t *testing.T) {
 
 func TestImagesLoadedMsgError(t *testing.T) {
 	c := client.NewMockClient()
-	section := New(context.Background(), c, config.UpdateCheckConfig{})
+	section := New(context.Background(), c, config.UpdateCheckConfig{}, config.TableConfig{})
 	section.SetSize(120, 40)
 
 	// imagesLoadedMsg has an `error` field — check the field name in section.go
@@ -560,7 +560,7 @@ func TestImagesLoadedMsgError(t *testing.T) {
 
 func TestImagesLoadedMsgCallsUpdateItems(t *testing.T) {
 	c := client.NewMockClient()
-	section := New(context.Background(), c, config.UpdateCheckConfig{})
+	section := New(context.Background(), c, config.UpdateCheckConfig{}, config.TableConfig{})
 	section.SetSize(120, 40)
 
 	if len(section.List.Items()) != 0 {
@@ -580,7 +580,7 @@ func TestImagesLoadedMsgCallsUpdateItems(t *testing.T) {
 
 func TestImagesLoadedMsgEmptyCallsUpdateItemsReset(t *testing.T) {
 	c := client.NewMockClient()
-	section := New(context.Background(), c, config.UpdateCheckConfig{})
+	section := New(context.Background(), c, config.UpdateCheckConfig{}, config.TableConfig{})
 	section.SetSize(120, 40)
 
 	section.Update(section.RefreshCmd()())
@@ -601,7 +601,7 @@ func TestImagesLoadedMsgEmptyCallsUpdateItemsReset(t *testing.T) {
 
 func TestPanelClosedOnUpNavigation(t *testing.T) {
 	c := client.NewMockClient()
-	section := New(context.Background(), c, config.UpdateCheckConfig{})
+	section := New(context.Background(), c, config.UpdateCheckConfig{}, config.TableConfig{})
 	section.SetSize(120, 40)
 
 	// Navigate to second image
@@ -618,3 +618,31 @@ func TestPanelClosedOnUpNavigation(t *testing.T) {
 		t.Error("Panel should be able to reinitialize after navigation")
 	}
 }
+
+func TestTableLayoutSortsByUsedBy(t *testing.T) {
+	c := client.NewMockClient()
+	section := New(context.Background(), c, config.UpdateCheckConfig{}, config.TableConfig{Enabled: true})
+	section.SetSize(200, 40)
+	section.Update(section.RefreshCmd()())
+
+	for i, column := range section.Table().Available() {
+		if column.Key != config.ImageTableColumns[i] {
+			t.Errorf("column %d = %q, want %q", i, column.Key, config.ImageTableColumns[i])
+		}
+	}
+
+	// Sort by the fifth column, used by, from the most used down.
+	for range len(config.ImageTableColumns) {
+		section.Update(tea.KeyPressMsg{Code: 'o', Text: "o"})
+	}
+	section.Update(tea.KeyPressMsg{Code: 'O', Text: "O"})
+
+	items := section.List.Items()
+	first, last := items[0].(imageItem), items[len(items)-1].(imageItem)
+	if usedBy(first.image) == 0 || usedBy(last.image) != 0 {
+		t.Errorf("rows should be sorted by used by, most used first: first=%s last=%s", first.Title(), last.Title())
+	}
+	if view := section.View(); !strings.Contains(view, "USED BY▼") || !strings.Contains(view, "REPOSITORY") {
+		t.Errorf("View() should show the image columns:\n%s", view)
+	}
+}
@@ -0,0 +1,69 @@
+package images
+
+import (
+	"cmp"
+	"strconv"
+	"time"
+
+	"github.com/docker/go-units"
+
+	"github.com/GustavoCaso/docker-dash/internal/client"
+	"github.com/GustavoCaso/docker-dash/internal/ui/components/table"
+	"github.com/GustavoCaso/docker-dash/internal/ui/helper"
+	"github.com/GustavoCaso/docker-dash/internal/ui/sections"
+)
+
+const (
+	sizeColumnWidth    = 9
+	createdColumnWidth = 16
+	usedByColumnWidth  = 8
+)
+
+// tableColumns are the columns of the image table.
+var tableColumns = []table.Column{
+	{Key: "repo", Title: "REPOSITORY", Value: func(item sections.ListItem) string { return imageOf(item).Repo }},
+	{Key: "tag", Title: "TAG", Value: func(item sections.ListItem) string { return imageOf(item).Tag }},
+	{
+		Key:   "size",
+		Title: "SIZE",
+		Width: sizeColumnWidth,
+		Right: true,
+		Value: func(item sections.ListItem) string { return helper.FormatSize(imageOf(item).Size) },
+		Compare: func(a, b sections.ListItem) int {
+			return cmp.Compare(imageOf(a).Size, imageOf(b).Size)
+		},
+	},
+	{
+		Key:   "created",
+		Title: "CREATED",
+		Width: createdColumnWidth,
+		Value: func(item sections.ListItem) string {
+			return units.HumanDuration(time.Since(imageOf(item).Created)) + " ago"
+		},
+		Compare: func(a, b sections.ListItem) int {
+			return imageOf(a).Created.Compare(imageOf(b).Created)
+		},
+	},
+	{
+		Key:   "used_by",
+		Title: "USED BY",
+		Width: usedByColumnWidth,
+		Right: true,
+		Value: func(item sections.ListItem) string {
+			return strconv.FormatInt(usedBy(imageOf(item)), 10)
+		},
+		Compare: func(a, b sections.ListItem) int {
+			return cmp.Compare(usedBy(imageOf(a)), usedBy(imageOf(b)))
+		},
+	},
+}
+
+func imageOf(item sections.ListItem) client.Image {
+	ii, _ := item.(imageItem)
+	return ii.image
+}
+
+// usedBy is the number of containers using img.
+func usedBy(img client.Image) int64 {
+	return max(img.Containers, int64(len(img.UsedBy)))
+}
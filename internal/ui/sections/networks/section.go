@@ -11,6 +11,7 @@ import (
 	tea "charm.land/bubbletea/v2"
 
 	"github.com/GustavoCaso/docker-dash/internal/client"
+	"github.com/GustavoCaso/docker-dash/internal/ui/components/prune"
 	"github.com/GustavoCaso/docker-dash/internal/ui/keys"
 	"github.com/GustavoCaso/docker-dash/internal/ui/message"
 	"github.com/GustavoCaso/docker-dash/internal/ui/sections"
@@ -24,6 +25,13 @@ type networksLoadedMsg struct {
 	items []list.Item
 }
 
+// networksPrunePreviewMsg is sent when the dry-run of a network prune completes.
+type networksPrunePreviewMsg struct {
+	opts       client.PruneOptions
+	candidates []client.PruneCandidate
+	err        error
+}
+
 // networksPrunedMsg is sent when a network prune completes.
 type networksPrunedMsg struct {
 	report client.PruneReport
@@ -62,6 +70,9 @@ func (n networkItem) FilterValue() string { return n.network.Name }
 
 var _ sections.ListItem = networkItem{}
 
+// networkPruneOptions prunes every unused network.
+var networkPruneOptions = client.PruneOptions{}
+
 // Section wraps bubbles/list for displaying networks.
 type Section struct {
 	*base.Section
@@ -79,7 +90,12 @@ func New(ctx context.Context, svc client.NetworkService) *Section {
 
 	s.LoadingText = "Loading..."
 	s.RefreshCmd = s.updateNetworksCmd
-	s.PruneCmd = s.confirmNetworkPrune
+	s.PruneCmd = func() tea.Cmd { return s.WithSpinner(s.previewNetworkPruneCmd(networkPruneOptions)) }
+	s.PruneFilteredCmd = func() tea.Cmd {
+		return prune.ShowForm("Prune Networks", networkPruneOptions, func(opts client.PruneOptions) tea.Cmd {
+			return s.WithSpinner(s.previewNetworkPruneCmd(opts))
+		})
+	}
 	s.HandleMsg = s.handleMsg
 	s.HandleKey = s.handleKey
 
@@ -107,6 +123,28 @@ func (s *Section) handleMsg(msg tea.Msg) base.UpdateResult {
 			Handled:     true,
 			StopSpinner: true,
 		}
+	case networksPrunePreviewMsg:
+		log.Printf("[networks] networksPrunePreviewMsg: candidates=%d err=%v", len(msg.candidates), msg.err)
+		if msg.err != nil {
+			return base.UpdateResult{
+				Cmd: func() tea.Msg {
+					return message.ShowBannerMsg{
+						Message: "Error previewing network prune: " + msg.err.Error(),
+						IsError: true,
+					}
+				},
+				Handled:     true,
+				StopSpinner: true,
+			}
+		}
+		if len(msg.candidates) == 0 {
+			return base.UpdateResult{Cmd: prune.NothingToPrune("network"), Handled: true, StopSpinner: true}
+		}
+		return base.UpdateResult{
+			Cmd:         s.confirmNetworkPrune(msg.opts, msg.candidates),
+			Handled:     true,
+			StopSpinner: true,
+		}
 	case networksPrunedMsg:
 		log.Printf(
 			"[networks] networksPrunedMsg: deleted=%d spaceReclaimed=%d",
@@ -162,6 +200,9 @@ func (s *Section) handleMsg(msg tea.Msg) base.UpdateResult {
 
 func (s *Section) handleKey(msg tea.KeyPressMsg) base.UpdateResult {
 	if key.Matches(msg, keys.Keys.NetworkDelete) {
+		if marked := s.MarkedItems(); len(marked) > 0 {
+			return base.UpdateResult{Cmd: s.ConfirmBulk(s.deleteNetworksAction(), marked), Handled: true}
+		}
 		return base.UpdateResult{Cmd: s.confirmNetworkDelete(), Handled: true}
 	}
 	return base.UpdateResult{}
@@ -203,25 +244,48 @@ func (s *Section) deleteNetworkCmd() tea.Cmd {
 	}
 }
 
-func (s *Section) pruneNetworksCmd() tea.Cmd {
+// previewNetworkPruneCmd lists the networks a prune with opts would remove.
+func (s *Section) previewNetworkPruneCmd(opts client.PruneOptions) tea.Cmd {
 	ctx, svc := s.ctx, s.networkService
 	return func() tea.Msg {
-		report, err := svc.Prune(ctx, client.PruneOptions{})
+		candidates, err := svc.PrunePreview(ctx, opts)
+		return networksPrunePreviewMsg{opts: opts, candidates: candidates, err: err}
+	}
+}
+
+func (s *Section) pruneNetworksCmd(opts client.PruneOptions) tea.Cmd {
+	ctx, svc := s.ctx, s.networkService
+	return func() tea.Msg {
+		report, err := svc.Prune(ctx, opts)
 		return networksPrunedMsg{report: report, err: err}
 	}
 }
 
-func (s *Section) confirmNetworkPrune() tea.Cmd {
-	pruneCmd := s.pruneNetworksCmd()
+// confirmNetworkPrune shows the networks of the dry-run before pruning them.
+func (s *Section) confirmNetworkPrune(opts client.PruneOptions, candidates []client.PruneCandidate) tea.Cmd {
+	pruneCmd := s.pruneNetworksCmd(opts)
 	return func() tea.Msg {
 		return message.ShowConfirmationMsg{
 			Title:     "Prune Networks",
-			Body:      "Remove all unused networks?",
+			Body:      prune.Body("network", candidates),
 			OnConfirm: s.WithSpinner(pruneCmd),
 		}
 	}
 }
 
+// deleteNetworksAction deletes the marked networks.
+func (s *Section) deleteNetworksAction() base.BulkAction {
+	ctx, svc := s.ctx, s.networkService
+	return base.BulkAction{
+		Verb: "Delete",
+		Done: "Deleted",
+		Noun: "network",
+		Run: func(item sections.ListItem) error {
+			return svc.Remove(ctx, item.ID())
+		},
+	}
+}
+
 func (s *Section) confirmNetworkDelete() tea.Cmd {
 	items := s.List.Items()
 	idx := s.List.Index()
@@ -12,6 +12,7 @@ var ContainersSection SectionName = "containers"
 var VolumesSection SectionName = "volumes"
 var NetworksSection SectionName = "networks"
 var ComposeSection SectionName = "compose"
+var DiskSection SectionName = "disk"
 
 type ListItem interface {
 	ID() string
@@ -30,6 +31,22 @@ type Panel interface {
 	SetSize(width, height int)
 }
 
+// FilterablePanel is implemented by panels that own a filter input. While the
+// panel is focused the section forwards the filter key to it, and while
+// IsFilter reports true every message is routed to the panel.
+type FilterablePanel interface {
+	Panel
+	IsFilter() bool
+}
+
+// SectionPanel is implemented by panels that show the whole section rather
+// than the selected item, such as the container Top. The section keeps them
+// open while the selection moves.
+type SectionPanel interface {
+	Panel
+	SectionWide() bool
+}
+
 type Section interface {
 	// Initialize Section
 	Init() tea.Cmd
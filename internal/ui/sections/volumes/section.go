@@ -11,6 +11,7 @@ import (
 	tea "charm.land/bubbletea/v2"
 
 	"github.com/GustavoCaso/docker-dash/internal/client"
+	"github.com/GustavoCaso/docker-dash/internal/ui/components/prune"
 	"github.com/GustavoCaso/docker-dash/internal/ui/helper"
 	"github.com/GustavoCaso/docker-dash/internal/ui/keys"
 	"github.com/GustavoCaso/docker-dash/internal/ui/message"
@@ -25,6 +26,13 @@ type volumesLoadedMsg struct {
 	items []list.Item
 }
 
+// volumesPrunePreviewMsg is sent when the dry-run of a volume prune completes.
+type volumesPrunePreviewMsg struct {
+	opts       client.PruneOptions
+	candidates []client.PruneCandidate
+	err        error
+}
+
 // volumesPrunedMsg is sent when a volume prune completes.
 type volumesPrunedMsg struct {
 	report client.PruneReport
@@ -62,6 +70,9 @@ func (v volumeItem) InnerItem() any      { return v.volume }
 
 var _ sections.ListItem = volumeItem{}
 
+// volumePruneOptions prunes every unused volume, named ones included.
+var volumePruneOptions = client.PruneOptions{All: true}
+
 // Section wraps bubbles/list for displaying volumes.
 type Section struct {
 	*base.Section
@@ -79,7 +90,12 @@ func New(ctx context.Context, svc client.VolumeService) *Section {
 
 	s.LoadingText = "Loading..."
 	s.RefreshCmd = s.updateVolumesCmd
-	s.PruneCmd = s.confirmVolumePrune
+	s.PruneCmd = func() tea.Cmd { return s.WithSpinner(s.previewVolumePruneCmd(volumePruneOptions)) }
+	s.PruneFilteredCmd = func() tea.Cmd {
+		return prune.ShowForm("Prune Volumes", volumePruneOptions, func(opts client.PruneOptions) tea.Cmd {
+			return s.WithSpinner(s.previewVolumePruneCmd(opts))
+		})
+	}
 	s.HandleMsg = s.handleMsg
 	s.HandleKey = s.handleKey
 
@@ -107,6 +123,28 @@ func (s *Section) handleMsg(msg tea.Msg) base.UpdateResult {
 			Handled:     true,
 			StopSpinner: true,
 		}
+	case volumesPrunePreviewMsg:
+		log.Printf("[volumes] volumesPrunePreviewMsg: candidates=%d err=%v", len(msg.candidates), msg.err)
+		if msg.err != nil {
+			return base.UpdateResult{
+				Cmd: func() tea.Msg {
+					return message.ShowBannerMsg{
+						Message: "Error previewing volume prune: " + msg.err.Error(),
+						IsError: true,
+					}
+				},
+				Handled:     true,
+				StopSpinner: true,
+			}
+		}
+		if len(msg.candidates) == 0 {
+			return base.UpdateResult{Cmd: prune.NothingToPrune("volume"), Handled: true, StopSpinner: true}
+		}
+		return base.UpdateResult{
+			Cmd:         s.confirmVolumePrune(msg.opts, msg.candidates),
+			Handled:     true,
+			StopSpinner: true,
+		}
 	case volumesPrunedMsg:
 		log.Printf("[volumes] volumesPrunedMsg: deleted=%d spaceReclaimed=%d",
 			msg.report.ItemsDeleted, msg.report.SpaceReclaimed)
@@ -164,6 +202,9 @@ func (s *Section) handleMsg(msg tea.Msg) base.UpdateResult {
 
 func (s *Section) handleKey(msg tea.KeyPressMsg) base.UpdateResult {
 	if key.Matches(msg, keys.Keys.Delete) {
+		if marked := s.MarkedItems(); len(marked) > 0 {
+			return base.UpdateResult{Cmd: s.ConfirmBulk(s.deleteVolumesAction(), marked), Handled: true}
+		}
 		return base.UpdateResult{Cmd: s.confirmVolumeDelete(), Handled: true}
 	}
 	return base.UpdateResult{}
@@ -206,25 +247,48 @@ func (s *Section) deleteVolumeCmd() tea.Cmd {
 	}
 }
 
-func (s *Section) pruneVolumesCmd() tea.Cmd {
+// previewVolumePruneCmd lists the volumes a prune with opts would remove.
+func (s *Section) previewVolumePruneCmd(opts client.PruneOptions) tea.Cmd {
 	ctx, svc := s.ctx, s.volumeService
 	return func() tea.Msg {
-		report, err := svc.Prune(ctx, client.PruneOptions{All: true})
+		candidates, err := svc.PrunePreview(ctx, opts)
+		return volumesPrunePreviewMsg{opts: opts, candidates: candidates, err: err}
+	}
+}
+
+func (s *Section) pruneVolumesCmd(opts client.PruneOptions) tea.Cmd {
+	ctx, svc := s.ctx, s.volumeService
+	return func() tea.Msg {
+		report, err := svc.Prune(ctx, opts)
 		return volumesPrunedMsg{report: report, err: err}
 	}
 }
 
-func (s *Section) confirmVolumePrune() tea.Cmd {
-	pruneCmd := s.pruneVolumesCmd()
+// confirmVolumePrune shows the volumes of the dry-run before pruning them.
+func (s *Section) confirmVolumePrune(opts client.PruneOptions, candidates []client.PruneCandidate) tea.Cmd {
+	pruneCmd := s.pruneVolumesCmd(opts)
 	return func() tea.Msg {
 		return message.ShowConfirmationMsg{
 			Title:     "Prune Volumes",
-			Body:      "Remove all unused volumes (including named)?",
+			Body:      prune.Body("volume", candidates),
 			OnConfirm: s.WithSpinner(pruneCmd),
 		}
 	}
 }
 
+// deleteVolumesAction deletes the marked volumes.
+func (s *Section) deleteVolumesAction() base.BulkAction {
+	ctx, svc := s.ctx, s.volumeService
+	return base.BulkAction{
+		Verb: "Delete",
+		Done: "Deleted",
+		Noun: "volume",
+		Run: func(item sections.ListItem) error {
+			return svc.Remove(ctx, item.ID(), true)
+		},
+	}
+}
+
 func (s *Section) confirmVolumeDelete() tea.Cmd {
 	items := s.List.Items()
 	idx := s.List.Index()
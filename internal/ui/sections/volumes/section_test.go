@@ -3,6 +3,7 @@ package volumes
 import (
 	"context"
 	"errors"
+	"strings"
 	"testing"
 	"time"
 
@@ -130,6 +131,41 @@ func TestVolumeDelete(t *testing.T) {
 	}
 }
 
+func TestVolumeBulkDelete(t *testing.T) {
+	c := client.NewMockClient()
+	section := New(context.Background(), c.Volumes())
+	section.SetSize(120, 40)
+	section.Update(section.RefreshCmd()())
+
+	// Mark the first two volumes.
+	section.Update(tea.KeyPressMsg{Code: tea.KeySpace, Text: " "})
+	section.Update(tea.KeyPressMsg{Code: tea.KeyDown})
+	section.Update(tea.KeyPressMsg{Code: tea.KeySpace, Text: " "})
+	marked := section.MarkedItems()
+
+	confirm, ok := section.Update(tea.KeyPressMsg{Code: 'd', Text: "d"})().(message.ShowConfirmationMsg)
+	if !ok {
+		t.Fatal("d should ask once for every marked volume")
+	}
+	if confirm.Title != "Delete 2 volumes" {
+		t.Errorf("confirmation title = %q, want Delete 2 volumes", confirm.Title)
+	}
+	section.Update(section.BulkCmd(section.deleteVolumesAction(), marked)())
+
+	volumes, err := c.Volumes().List(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, v := range volumes {
+		if v.Name == marked[0].ID() || v.Name == marked[1].ID() {
+			t.Errorf("volume %s should be deleted", v.Name)
+		}
+	}
+	if len(section.MarkedItems()) != 0 {
+		t.Error("marks should be cleared after the bulk delete")
+	}
+}
+
 func TestVolumesLoadedMsgError(t *testing.T) {
 	c := client.NewMockClient()
 	section := New(context.Background(), c.Volumes())
@@ -174,6 +210,35 @@ func TestVolumesPrunedMsgError(t *testing.T) {
 	}
 }
 
+func TestVolumePrunePreviewFilters(t *testing.T) {
+	c := client.NewMockClient()
+	section := New(context.Background(), c.Volumes())
+	section.SetSize(120, 40)
+
+	preview := section.previewVolumePruneCmd(client.PruneOptions{All: true, Labels: []string{"missing"}})()
+	result := section.handleMsg(preview)
+
+	if !result.Handled || !result.StopSpinner {
+		t.Fatal("expected the preview to be handled and stop the spinner")
+	}
+	banner, ok := result.Cmd().(message.ShowBannerMsg)
+	if !ok {
+		t.Fatalf("expected ShowBannerMsg when nothing matches, got %T", result.Cmd())
+	}
+	if banner.IsError || banner.Message != "No volumes to prune" {
+		t.Errorf("banner = %+v, want the nothing to prune notice", banner)
+	}
+
+	preview = section.previewVolumePruneCmd(volumePruneOptions)()
+	confirm, ok := section.handleMsg(preview).Cmd().(message.ShowConfirmationMsg)
+	if !ok {
+		t.Fatal("expected a confirmation listing the unused volumes")
+	}
+	if !strings.Contains(confirm.Body, "app_data") {
+		t.Errorf("confirmation body = %q, want the unused volumes listed", confirm.Body)
+	}
+}
+
 func TestVolumeRemovedMsgError(t *testing.T) {
 	c := client.NewMockClient()
 	section := New(context.Background(), c.Volumes())
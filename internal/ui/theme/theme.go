@@ -3,6 +3,8 @@
 package theme
 
 import (
+	"image/color"
+
 	"charm.land/lipgloss/v2"
 )
 
@@ -46,6 +48,7 @@ const (
 	IconVolume    = "\uf0a0" // Hard drive/volume icon
 	IconNetwork   = "\uef09" // Network icon
 	IconCompose   = "\uf51e" // Code/compose icon
+	IconDisk      = "\uf200" // Pie chart/disk usage icon
 
 	// Container Status icons.
 	IconRunning = "\uf04b" // Play icon (running)
@@ -75,6 +78,13 @@ const (
 	IconInfo    = "\uf05a" // Info circle
 	IconSuccess = "\uf00c" // Checkmark
 
+	// Lifecycle icons.
+	IconOOMKilled = "\uf1e2" // Bomb (killed by the OOM killer)
+	IconCrashLoop = "\uf021" // Circular arrows (crash looping)
+
+	// Selection icons.
+	IconMarked = "\uf14a" // Checked square
+
 	// Update available icon.
 	UpdateAvailableIcon = "⬆"
 )
@@ -112,6 +122,12 @@ var (
 				Foreground(DockerBlue).
 				Bold(true).
 				PaddingRight(dockerPadding)
+
+	// HeaderAlertStyle renders the number of alerts next to the logo.
+	HeaderAlertStyle = lipgloss.NewStyle().
+				Foreground(StatusError).
+				Bold(true).
+				PaddingRight(dockerPadding)
 )
 
 // List item styles.
@@ -163,6 +179,15 @@ var (
 
 	StatusStartingStyle = lipgloss.NewStyle().
 				Foreground(StatusStarting)
+
+		// Lifecycle Styles.
+	OOMKilledStyle = lipgloss.NewStyle().
+			Foreground(StatusError).
+			Bold(true)
+
+	CrashLoopStyle = lipgloss.NewStyle().
+			Foreground(StatusPaused).
+			Bold(true)
 )
 
 // Status bar styles.
@@ -173,6 +198,39 @@ var (
 // UpdateAvailableStyle is the style used to render the update-available icon.
 var UpdateAvailableStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#FFD700"))
 
+// MarkedStyle is the style used to render list items marked for a bulk action.
+var MarkedStyle = lipgloss.NewStyle().Foreground(DockerBlue).Bold(true)
+
+// SearchMatch is the style used to highlight search matches in log lines.
+var SearchMatch = lipgloss.NewStyle().
+	Background(lipgloss.Color("#FFD700")).
+	Foreground(lipgloss.Color("#000000"))
+
+// LogStderrStyle is the style used to render log lines written to stderr.
+var LogStderrStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#FF8A80"))
+
+// Styles used to color structured log lines by their level.
+var (
+	LogErrorStyle = lipgloss.NewStyle().Foreground(StatusError)
+	LogWarnStyle  = lipgloss.NewStyle().Foreground(StatusPaused)
+	LogDebugStyle = lipgloss.NewStyle().Foreground(TextMuted)
+)
+
+// LogMarkerStyle is the style used to render the lines noting container
+// lifecycle events in the logs, such as a restart.
+var LogMarkerStyle = lipgloss.NewStyle().Foreground(DockerBlue).Bold(true)
+
+// LogSourceColors are the colors given, in turn, to the container names that
+// prefix the lines of merged logs.
+var LogSourceColors = []color.Color{
+	lipgloss.Color("#5FAFFF"),
+	lipgloss.Color("#87D787"),
+	lipgloss.Color("#D7AFFF"),
+	lipgloss.Color("#FFD75F"),
+	lipgloss.Color("#5FD7D7"),
+	lipgloss.Color("#FF87AF"),
+}
+
 // SelectedLogLine is the style used to render the currently selected log line.
 var SelectedLogLine = lipgloss.NewStyle().
 	Background(lipgloss.Color("#1e3a5f")).